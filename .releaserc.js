@@ -6,6 +6,10 @@ module.exports = {
     plugins: [
         "@semantic-release/commit-analyzer",
         "@semantic-release/release-notes-generator",
+        // the root module is tagged before the publish step, so the nested modules can require the released version
+        ["@semantic-release/exec", {
+            publishCmd: "./build/release-modules.sh ${nextRelease.version}",
+        }],
         "@semantic-release/github"
     ]
 };
@@ -0,0 +1,24 @@
+#!/bin/sh
+# Releases the nested modules (e.g. the framework adapters) after the root module was released (and tagged) by the
+# semantic release: Their required root version is set to the released one in a release commit, which is tagged as
+# `pkg/http/<module>/v1.<minor>.<patch>` (e.g. `pkg/http/zitadelgin/v1.2.3` for `v3.2.3`).
+# The module paths (e.g. `github.com/zitadel/zitadel-go/pkg/http/zitadelgin`) have no major version suffix,
+# so they can only be released as v1. The release commit is only referenced by the tags and not pushed to any branch.
+#
+# Usage: ./build/release-modules.sh 3.2.3
+set -eu
+
+version="$1"
+module_version="v1.${version#*.}"
+modules="pkg/http/zitadelgin pkg/http/zitadelecho pkg/http/zitadelfiber pkg/http/zitadelgqlgen"
+
+for module in $modules; do
+  (cd "$module" && go mod edit -require="github.com/zitadel/zitadel-go/v3@v${version}")
+  git add "$module/go.mod"
+done
+git -c user.name="${GIT_COMMITTER_NAME:-semantic-release-bot}" -c user.email="${GIT_COMMITTER_EMAIL:-semantic-release-bot@martynus.net}" \
+  commit --message "chore(release): nested modules ${module_version} [skip ci]"
+for module in $modules; do
+  git tag "${module}/${module_version}"
+  git push origin "${module}/${module_version}"
+done
@@ -70,8 +70,12 @@ func main() {
 	// Register the authentication handler on your desired path.
 	// It will register the following handlers on it:
 	// - /login (starts the authentication process to the Login UI)
+	// - /silent (starts a silent authentication, which falls back to the Login UI if there's no SSO session)
 	// - /callback (handles the redirect back from the Login UI)
 	// - /logout (handles the logout process)
+	// - /logout/done (handles the redirect back from the Login UI after the logout)
+	// - /backchannel-logout (handles the backchannel logout requests of ZITADEL)
+	// - /frontchannel-logout (handles the front-channel logout requests of ZITADEL)
 	router.Handle("/auth/", authN)
 	// This endpoint is only accessible with a valid authentication. If there is none, it will directly redirect the user
 	// to the Login UI for authentication. If successful (or already authenticated), the user will be presented the profile page.
@@ -0,0 +1,145 @@
+package actions
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Request is the payload of a `request` execution, sent before the API call (FullMethod) is executed by ZITADEL.
+type Request struct {
+	FullMethod string          `json:"fullMethod,omitempty"`
+	InstanceID string          `json:"instanceID,omitempty"`
+	OrgID      string          `json:"orgID,omitempty"`
+	ProjectID  string          `json:"projectID,omitempty"`
+	UserID     string          `json:"userID,omitempty"`
+	Request    json.RawMessage `json:"request,omitempty"`
+}
+
+// DecodeRequest decodes the request of the API call, e.g. into the AddHumanUserRequest of the user/v2 client package
+// for the `/zitadel.user.v2.UserService/AddHumanUser` method.
+func (r *Request) DecodeRequest(v any) error {
+	return decode(r.Request, v)
+}
+
+// Response is the payload of a `response` execution, sent after the API call (FullMethod) was executed by ZITADEL.
+type Response struct {
+	FullMethod string          `json:"fullMethod,omitempty"`
+	InstanceID string          `json:"instanceID,omitempty"`
+	OrgID      string          `json:"orgID,omitempty"`
+	ProjectID  string          `json:"projectID,omitempty"`
+	UserID     string          `json:"userID,omitempty"`
+	Request    json.RawMessage `json:"request,omitempty"`
+	Response   json.RawMessage `json:"response,omitempty"`
+}
+
+// DecodeRequest decodes the request of the API call.
+func (r *Response) DecodeRequest(v any) error {
+	return decode(r.Request, v)
+}
+
+// DecodeResponse decodes the response of the API call.
+func (r *Response) DecodeResponse(v any) error {
+	return decode(r.Response, v)
+}
+
+// Function is the payload of a `function` execution, e.g. `preuserinfo` or `preaccesstoken`,
+// which allows manipulating the claims using the [FunctionResponse].
+type Function struct {
+	Function     string          `json:"function,omitempty"`
+	UserInfo     *oidc.UserInfo  `json:"userinfo,omitempty"`
+	User         json.RawMessage `json:"user,omitempty"`
+	UserMetadata []*Metadata     `json:"user_metadata,omitempty"`
+	Org          *Org            `json:"org,omitempty"`
+	UserGrants   json.RawMessage `json:"user_grants,omitempty"`
+}
+
+// Org is the organization of the user of a [Function] execution.
+type Org struct {
+	ID            string `json:"id,omitempty"`
+	Name          string `json:"name,omitempty"`
+	PrimaryDomain string `json:"primary_domain,omitempty"`
+}
+
+// Metadata is a key value pair of the metadata of a user.
+type Metadata struct {
+	Key   string `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// Event is the payload of an `event` execution, sent after the event was stored by ZITADEL.
+type Event struct {
+	AggregateID   string          `json:"aggregateID,omitempty"`
+	AggregateType string          `json:"aggregateType,omitempty"`
+	ResourceOwner string          `json:"resourceOwner,omitempty"`
+	InstanceID    string          `json:"instanceID,omitempty"`
+	Version       string          `json:"version,omitempty"`
+	Sequence      uint64          `json:"sequence,omitempty"`
+	EventType     string          `json:"event_type,omitempty"`
+	CreatedAt     time.Time       `json:"created_at,omitempty"`
+	UserID        string          `json:"userID,omitempty"`
+	EventPayload  json.RawMessage `json:"event_payload,omitempty"`
+}
+
+// DecodePayload decodes the payload of the event.
+func (e *Event) DecodePayload(v any) error {
+	return decode(e.EventPayload, v)
+}
+
+// FunctionResponse allows manipulating the claims and the metadata of the user in a [Function] execution:
+//
+//	return actions.NewFunctionResponse().
+//		AppendClaim("urn:example:tenant", tenantID).
+//		AppendLogClaim("tenant added"), nil
+type FunctionResponse struct {
+	UserMetadata []*Metadata    `json:"set_user_metadata,omitempty"`
+	Claims       []*AppendClaim `json:"append_claims,omitempty"`
+	LogClaims    []string       `json:"append_log_claims,omitempty"`
+}
+
+// AppendClaim is a claim added to the token or userinfo by a [FunctionResponse].
+type AppendClaim struct {
+	Key   string `json:"key"`
+	Value any    `json:"value"`
+}
+
+// NewFunctionResponse creates an empty [FunctionResponse], which leaves the claims unchanged.
+func NewFunctionResponse() *FunctionResponse {
+	return &FunctionResponse{}
+}
+
+// AppendClaim adds the claim to the token, resp. userinfo. Existing claims cannot be overwritten.
+func (r *FunctionResponse) AppendClaim(key string, value any) *FunctionResponse {
+	r.Claims = append(r.Claims, &AppendClaim{Key: key, Value: value})
+	return r
+}
+
+// AppendLogClaim adds the entry to the `urn:zitadel:iam:action:{action}:log` claim.
+func (r *FunctionResponse) AppendLogClaim(entry string) *FunctionResponse {
+	r.LogClaims = append(r.LogClaims, entry)
+	return r
+}
+
+// SetUserMetadata sets the metadata of the user.
+func (r *FunctionResponse) SetUserMetadata(key string, value []byte) *FunctionResponse {
+	r.UserMetadata = append(r.UserMetadata, &Metadata{Key: key, Value: value})
+	return r
+}
+
+// decode uses protojson for [proto.Message], so the API types of the client packages can be used.
+func decode(data []byte, v any) error {
+	if m, ok := v.(proto.Message); ok {
+		return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, m)
+	}
+	return json.Unmarshal(data, v)
+}
+
+func encode(v any) ([]byte, error) {
+	if m, ok := v.(proto.Message); ok {
+		return protojson.Marshal(m)
+	}
+	return json.Marshal(v)
+}
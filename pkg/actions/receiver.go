@@ -0,0 +1,102 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// EventHandler handles the [Event] of an `event` execution.
+type EventHandler func(ctx context.Context, e *Event) error
+
+// Receiver receives the events of `event` executions on a single endpoint and dispatches them
+// by their type to the registered handlers, e.g. with the typed payloads of the helper/events package:
+//
+//	receiver, err := actions.NewReceiver(signingKey)
+//	actions.OnEvent(receiver, events.TypeUserHumanAdded, func(ctx context.Context, e *actions.Event, p *events.UserHumanAdded) error {
+//		return welcome(ctx, e.AggregateID, p.Email)
+//	})
+//	receiver.On("user.grant.*", syncGrants)
+//	http.Handle("/actions/events", receiver)
+//
+// Handlers must be registered before the receiver serves any request.
+type Receiver struct {
+	handler  http.Handler
+	handlers []eventRoute
+	fallback EventHandler
+}
+
+type eventRoute struct {
+	pattern string
+	handle  EventHandler
+}
+
+// NewReceiver creates a [Receiver] verifying the calls with the signing key of the target (see [NewTarget]).
+func NewReceiver(signingKey string, opts ...Option) (*Receiver, error) {
+	target, err := NewTarget(signingKey, opts...)
+	if err != nil {
+		return nil, err
+	}
+	r := new(Receiver)
+	r.handler = target.Event(r.dispatch)
+	return r, nil
+}
+
+// On registers the handler for the event type, e.g. `user.human.added`.
+// A trailing `*` matches all event types with the prefix, e.g. `user.*`.
+// All handlers matching an event are called in the order of their registration, until one returns an error.
+func (r *Receiver) On(eventType string, handle EventHandler) *Receiver {
+	r.handlers = append(r.handlers, eventRoute{pattern: eventType, handle: handle})
+	return r
+}
+
+// Fallback registers the handler for events not matching any registered event type.
+// By default, these events are acknowledged without further handling.
+func (r *Receiver) Fallback(handle EventHandler) *Receiver {
+	r.fallback = handle
+	return r
+}
+
+// OnEvent registers the handler for the event type (see [Receiver.On]),
+// which is called with the payload of the event decoded into P.
+func OnEvent[P any](r *Receiver, eventType string, handle func(ctx context.Context, e *Event, payload *P) error) *Receiver {
+	return r.On(eventType, func(ctx context.Context, e *Event) error {
+		payload := new(P)
+		if len(e.EventPayload) > 0 {
+			if err := e.DecodePayload(payload); err != nil {
+				return fmt.Errorf("decode payload of event `%s`: %w", e.EventType, err)
+			}
+		}
+		return handle(ctx, e, payload)
+	})
+}
+
+// ServeHTTP implements [http.Handler].
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.handler.ServeHTTP(w, req)
+}
+
+func (r *Receiver) dispatch(ctx context.Context, e *Event) error {
+	handled := false
+	for _, route := range r.handlers {
+		if !matchEventType(route.pattern, e.EventType) {
+			continue
+		}
+		handled = true
+		if err := route.handle(ctx, e); err != nil {
+			return err
+		}
+	}
+	if !handled && r.fallback != nil {
+		return r.fallback(ctx, e)
+	}
+	return nil
+}
+
+func matchEventType(pattern, eventType string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(eventType, prefix)
+	}
+	return pattern == eventType
+}
@@ -0,0 +1,93 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/events"
+)
+
+func TestReceiver(t *testing.T) {
+	var calls []string
+	record := func(name string) EventHandler {
+		return func(_ context.Context, e *Event) error {
+			calls = append(calls, name+":"+e.EventType)
+			return nil
+		}
+	}
+	receiver, err := NewReceiver("key")
+	require.NoError(t, err)
+	OnEvent(receiver, events.TypeUserHumanAdded, func(_ context.Context, e *Event, p *events.UserHumanAdded) error {
+		calls = append(calls, "typed:"+p.UserName)
+		return nil
+	})
+	receiver.On("user.*", record("prefix")).
+		On(events.TypeOrgRemoved, func(context.Context, *Event) error { return errors.New("failed") }).
+		Fallback(record("fallback"))
+
+	tests := []struct {
+		name      string
+		payload   string
+		key       string
+		wantCode  int
+		wantCalls []string
+	}{
+		{
+			name:      "typed and prefix",
+			payload:   `{"event_type":"user.human.added","event_payload":{"userName":"alice"}}`,
+			key:       "key",
+			wantCode:  http.StatusOK,
+			wantCalls: []string{"typed:alice", "prefix:user.human.added"},
+		},
+		{
+			name:      "prefix",
+			payload:   `{"event_type":"user.removed"}`,
+			key:       "key",
+			wantCode:  http.StatusOK,
+			wantCalls: []string{"prefix:user.removed"},
+		},
+		{
+			name:      "fallback",
+			payload:   `{"event_type":"project.added"}`,
+			key:       "key",
+			wantCode:  http.StatusOK,
+			wantCalls: []string{"fallback:project.added"},
+		},
+		{
+			name:     "handler error",
+			payload:  `{"event_type":"org.removed"}`,
+			key:      "key",
+			wantCode: http.StatusInternalServerError,
+		},
+		{
+			name:     "invalid payload",
+			payload:  `{"event_type":"user.human.added","event_payload":{"userName":1}}`,
+			key:      "key",
+			wantCode: http.StatusInternalServerError,
+		},
+		{
+			name:     "invalid signature",
+			payload:  `{"event_type":"user.removed"}`,
+			key:      "wrong",
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = nil
+			rec := call(t, receiver, tt.payload, tt.key)
+			assert.Equal(t, tt.wantCode, rec.Code)
+			assert.Equal(t, tt.wantCalls, calls)
+		})
+	}
+}
+
+func TestNewReceiver(t *testing.T) {
+	_, err := NewReceiver("")
+	assert.ErrorIs(t, err, ErrMissingSigningKey)
+}
@@ -0,0 +1,99 @@
+package actions
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// SigningHeader is the header containing the signature of the payload sent by ZITADEL to the target.
+	SigningHeader = "ZITADEL-Signature"
+	// DefaultTolerance is the maximum age of the signature accepted by [VerifySignature].
+	DefaultTolerance = 5 * time.Minute
+
+	signingTimestamp = "t"
+	signingVersion   = "v1"
+)
+
+var (
+	ErrMissingSignature  = errors.New("missing signature")
+	ErrInvalidSignature  = errors.New("invalid signature")
+	ErrSignatureExpired  = errors.New("signature expired")
+	ErrMissingSigningKey = errors.New("missing signing key")
+)
+
+// ComputeSignature creates the value of the [SigningHeader] for the payload at time t,
+// e.g. to test a target handler. It contains a signature for each of the signing keys.
+func ComputeSignature(t time.Time, payload []byte, signingKeys ...string) string {
+	parts := make([]string, 0, len(signingKeys)+1)
+	parts = append(parts, signingTimestamp+"="+strconv.FormatInt(t.Unix(), 10))
+	for _, key := range signingKeys {
+		parts = append(parts, signingVersion+"="+hex.EncodeToString(computeSignature(t.Unix(), payload, key)))
+	}
+	return strings.Join(parts, ",")
+}
+
+// VerifySignature checks the [SigningHeader] value (header) of the payload with the signing key of the target,
+// which must not be older than the tolerance (nor further in the future, e.g. because of a clock skew).
+// An empty signing key is rejected, as anyone could compute a signature with it.
+func VerifySignature(payload []byte, header, signingKey string, tolerance time.Duration) error {
+	if signingKey == "" {
+		return ErrMissingSigningKey
+	}
+	if header == "" {
+		return ErrMissingSignature
+	}
+	var timestamp int64
+	var signatures [][]byte
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case signingTimestamp:
+			t, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("%w: invalid timestamp", ErrInvalidSignature)
+			}
+			timestamp = t
+		case signingVersion:
+			signature, err := hex.DecodeString(value)
+			if err != nil {
+				continue
+			}
+			signatures = append(signatures, signature)
+		}
+	}
+	if timestamp == 0 || len(signatures) == 0 {
+		return ErrMissingSignature
+	}
+	age := time.Since(time.Unix(timestamp, 0))
+	if age > tolerance {
+		return ErrSignatureExpired
+	}
+	if age < -tolerance {
+		return fmt.Errorf("%w: timestamp in the future", ErrInvalidSignature)
+	}
+	expected := computeSignature(timestamp, payload, signingKey)
+	for _, signature := range signatures {
+		if hmac.Equal(expected, signature) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+func computeSignature(timestamp int64, payload []byte, signingKey string) []byte {
+	mac := hmac.New(sha256.New, []byte(signingKey))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,57 @@
+package actions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifySignature(t *testing.T) {
+	payload := []byte(`{"function":"preuserinfo"}`)
+	tests := []struct {
+		name    string
+		header  string
+		wantErr error
+	}{
+		{
+			name:   "valid",
+			header: ComputeSignature(time.Now(), payload, "key"),
+		},
+		{
+			name:   "valid, rotated keys",
+			header: ComputeSignature(time.Now(), payload, "old", "key"),
+		},
+		{
+			name:    "missing",
+			wantErr: ErrMissingSignature,
+		},
+		{
+			name:    "no signature",
+			header:  "t=1700000000",
+			wantErr: ErrMissingSignature,
+		},
+		{
+			name:    "wrong key",
+			header:  ComputeSignature(time.Now(), payload, "other"),
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "expired",
+			header:  ComputeSignature(time.Now().Add(-time.Hour), payload, "key"),
+			wantErr: ErrSignatureExpired,
+		},
+		{
+			name:    "future",
+			header:  ComputeSignature(time.Now().Add(time.Hour), payload, "key"),
+			wantErr: ErrInvalidSignature,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifySignature(payload, tt.header, "key", DefaultTolerance)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+	assert.ErrorIs(t, VerifySignature(payload, ComputeSignature(time.Now(), payload, ""), "", DefaultTolerance), ErrMissingSigningKey)
+}
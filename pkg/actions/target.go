@@ -0,0 +1,182 @@
+// Package actions provides the HTTP handlers to implement the targets of ZITADEL Actions (v2):
+//
+//	target, err := actions.NewTarget(signingKey)
+//	http.Handle("/actions/userinfo", target.Function(func(ctx context.Context, f *actions.Function) (*actions.FunctionResponse, error) {
+//		return actions.NewFunctionResponse().AppendClaim("urn:example:tenant", tenantOf(f.Org)), nil
+//	}))
+//	http.Handle("/actions/user-created", target.Event(func(ctx context.Context, e *actions.Event) error {
+//		return provision(ctx, e.AggregateID)
+//	}))
+//
+// The signature of every call is verified with the signing key of the target ([VerifySignature]),
+// before the typed payload is passed to the handler function.
+// Use a [Receiver] to dispatch the events of multiple event types on a single endpoint.
+//
+// For the management of the actions (v1) see the helper/actions package.
+package actions
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// maxPayloadSize limits the size of the payload read from the request.
+const maxPayloadSize = 1 << 20
+
+// Target provides the handlers for the different types of executions calling the target.
+type Target struct {
+	signingKey string
+	tolerance  time.Duration
+}
+
+// Option allows customization of the [Target].
+type Option func(*Target)
+
+// WithTolerance allows a maximum age of the signature other than the [DefaultTolerance].
+func WithTolerance(tolerance time.Duration) Option {
+	return func(t *Target) {
+		t.tolerance = tolerance
+	}
+}
+
+// NewTarget creates a [Target] verifying the calls with the signing key returned by ZITADEL when creating the target.
+// An empty signing key results in an [ErrMissingSigningKey].
+func NewTarget(signingKey string, opts ...Option) (*Target, error) {
+	if signingKey == "" {
+		return nil, ErrMissingSigningKey
+	}
+	return newTarget(signingKey, opts...), nil
+}
+
+func newTarget(signingKey string, opts ...Option) *Target {
+	t := &Target{
+		signingKey: signingKey,
+		tolerance:  DefaultTolerance,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// Request creates the handler for a `request` execution. The handler returns the (manipulated) request
+// of the API call, e.g. as proto message of the client packages. If it returns nil, the request is left unchanged.
+// An error results in a failed call (which will interrupt the API call, if the target is configured to do so).
+func (t *Target) Request(handle func(ctx context.Context, req *Request) (any, error)) http.Handler {
+	return t.handler(func(ctx context.Context, payload []byte) (any, error) {
+		req := new(Request)
+		if err := decode(payload, req); err != nil {
+			return nil, err
+		}
+		res, err := handle(ctx, req)
+		if err != nil || res != nil {
+			return res, err
+		}
+		return req.Request, nil
+	})
+}
+
+// Response creates the handler for a `response` execution. The handler returns the (manipulated) response
+// of the API call, e.g. as proto message of the client packages. If it returns nil, the response is left unchanged.
+func (t *Target) Response(handle func(ctx context.Context, resp *Response) (any, error)) http.Handler {
+	return t.handler(func(ctx context.Context, payload []byte) (any, error) {
+		resp := new(Response)
+		if err := decode(payload, resp); err != nil {
+			return nil, err
+		}
+		res, err := handle(ctx, resp)
+		if err != nil || res != nil {
+			return res, err
+		}
+		return resp.Response, nil
+	})
+}
+
+// Function creates the handler for a `function` execution, e.g. `preuserinfo` or `preaccesstoken`.
+// If the handler returns a nil [FunctionResponse], the claims are left unchanged.
+func (t *Target) Function(handle func(ctx context.Context, f *Function) (*FunctionResponse, error)) http.Handler {
+	return t.handler(func(ctx context.Context, payload []byte) (any, error) {
+		f := new(Function)
+		if err := decode(payload, f); err != nil {
+			return nil, err
+		}
+		res, err := handle(ctx, f)
+		if err != nil {
+			return nil, err
+		}
+		if res == nil {
+			res = NewFunctionResponse()
+		}
+		return res, nil
+	})
+}
+
+// Event creates the handler for an `event` execution.
+func (t *Target) Event(handle func(ctx context.Context, e *Event) error) http.Handler {
+	return t.handler(func(ctx context.Context, payload []byte) (any, error) {
+		e := new(Event)
+		if err := decode(payload, e); err != nil {
+			return nil, err
+		}
+		return nil, handle(ctx, e)
+	})
+}
+
+// handler verifies the signature of the payload and writes the result of the handle function as JSON.
+func (t *Target) handler(handle func(ctx context.Context, payload []byte) (any, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, ok := ReadSignedPayload(w, r, t.signingKey, t.tolerance)
+		if !ok {
+			return
+		}
+		res, err := handle(r.Context(), payload)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if res == nil {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		body, err := encode(res)
+		if err != nil {
+			http.Error(w, "failed to encode response: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	})
+}
+
+// ReadSignedPayload reads the payload of a call of ZITADEL like [ReadPayload] and verifies its signature
+// with the signing key ([VerifySignature]), e.g. for the handlers of the HTTP providers of the notification package.
+// If the signature is invalid, the response is written with 401 Unauthorized and false is returned.
+func ReadSignedPayload(w http.ResponseWriter, r *http.Request, signingKey string, tolerance time.Duration) ([]byte, bool) {
+	payload, ok := ReadPayload(w, r)
+	if !ok {
+		return nil, false
+	}
+	if err := VerifySignature(payload, r.Header.Get(SigningHeader), signingKey, tolerance); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return nil, false
+	}
+	return payload, true
+}
+
+// ReadPayload reads the payload (up to 1MB) of a POST request of ZITADEL without verifying its signature.
+// If the request is invalid, the response is written with 405 Method Not Allowed, resp. 400 Bad Request
+// and false is returned.
+func ReadPayload(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return nil, false
+	}
+	payload, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadSize))
+	if err != nil {
+		http.Error(w, "failed to read payload: "+err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	return payload, true
+}
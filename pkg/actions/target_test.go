@@ -0,0 +1,106 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user/v2"
+)
+
+func call(t *testing.T, handler http.Handler, payload string, signingKey string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload))
+	req.Header.Set(SigningHeader, ComputeSignature(time.Now(), []byte(payload), signingKey))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestTarget_Function(t *testing.T) {
+	target, err := NewTarget("key")
+	require.NoError(t, err)
+	handler := target.Function(func(_ context.Context, f *Function) (*FunctionResponse, error) {
+		if f.Org.ID != "org" {
+			return nil, nil
+		}
+		return NewFunctionResponse().
+			AppendClaim("urn:example:tenant", f.Org.Name).
+			AppendLogClaim("tenant added").
+			SetUserMetadata("tenant", []byte(f.Org.Name)), nil
+	})
+
+	rec := call(t, handler, `{"function":"preuserinfo","org":{"id":"org","name":"ACME"}}`, "key")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{
+		"set_user_metadata":[{"key":"tenant","value":"QUNNRQ=="}],
+		"append_claims":[{"key":"urn:example:tenant","value":"ACME"}],
+		"append_log_claims":["tenant added"]
+	}`, rec.Body.String())
+
+	rec = call(t, handler, `{"function":"preuserinfo","org":{"id":"other"}}`, "key")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{}`, rec.Body.String())
+
+	rec = call(t, handler, `{"function":"preuserinfo"}`, "wrong")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestTarget_Request(t *testing.T) {
+	handler := newTarget("key").Request(func(_ context.Context, req *Request) (any, error) {
+		addHuman := new(user.AddHumanUserRequest)
+		if err := req.DecodeRequest(addHuman); err != nil {
+			return nil, err
+		}
+		if addHuman.GetUsername() == "unchanged" {
+			return nil, nil
+		}
+		username := strings.ToLower(addHuman.GetUsername())
+		addHuman.Username = &username
+		return addHuman, nil
+	})
+
+	rec := call(t, handler, `{"fullMethod":"/zitadel.user.v2.UserService/AddHumanUser","request":{"username":"Alice","unknownField":true}}`, "key")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"username":"alice"}`, rec.Body.String())
+
+	rec = call(t, handler, `{"fullMethod":"/zitadel.user.v2.UserService/AddHumanUser","request":{"username":"unchanged"}}`, "key")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"username":"unchanged"}`, rec.Body.String())
+}
+
+func TestTarget_Event(t *testing.T) {
+	var got *Event
+	handler := newTarget("key").Event(func(_ context.Context, e *Event) error {
+		got = e
+		if e.EventType == "user.removed" {
+			return errors.New("failed")
+		}
+		return nil
+	})
+
+	rec := call(t, handler, `{"aggregateID":"user","event_type":"user.human.added","sequence":3,"created_at":"2024-01-02T03:04:05Z","event_payload":{"userName":"alice"}}`, "key")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "user", got.AggregateID)
+	assert.Equal(t, uint64(3), got.Sequence)
+	var payload struct {
+		UserName string `json:"userName"`
+	}
+	require.NoError(t, got.DecodePayload(&payload))
+	assert.Equal(t, "alice", payload.UserName)
+
+	rec = call(t, handler, `{"event_type":"user.removed"}`, "key")
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestNewTarget(t *testing.T) {
+	_, err := NewTarget("")
+	assert.ErrorIs(t, err, ErrMissingSigningKey)
+}
@@ -0,0 +1,132 @@
+package auditlog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Checkpoint is the position of the last exported record.
+type Checkpoint struct {
+	// Time is the latest time of the exported records.
+	Time time.Time `json:"time"`
+	// Exported contains the exported records within the lag window before the time ([WithLagWindow]),
+	// since multiple events can share the same creation date and events can be committed after later ones.
+	Exported []ExportedRecord `json:"exported,omitempty"`
+}
+
+// ExportedRecord identifies an exported [Record] of the [Checkpoint].
+type ExportedRecord struct {
+	ID   string    `json:"id"`
+	Time time.Time `json:"time"`
+}
+
+// exported returns whether the record was exported or is older than the lag window and therefore skipped.
+func (c *Checkpoint) exported(record *Record, lag time.Duration) bool {
+	if record.Time.Before(c.Time.Add(-lag)) {
+		return true
+	}
+	return slices.ContainsFunc(c.Exported, func(exported ExportedRecord) bool {
+		return exported.ID == record.ID
+	})
+}
+
+// advance adds the records and removes the ones, which are older than the lag window afterward.
+func (c *Checkpoint) advance(records []*Record, lag time.Duration) {
+	for _, record := range records {
+		if record.Time.After(c.Time) {
+			c.Time = record.Time
+		}
+		c.Exported = append(c.Exported, ExportedRecord{ID: record.ID, Time: record.Time})
+	}
+	c.Exported = slices.DeleteFunc(c.Exported, func(exported ExportedRecord) bool {
+		return exported.Time.Before(c.Time.Add(-lag))
+	})
+}
+
+// CheckpointStore persists the [Checkpoint], so the export resumes where it stopped.
+type CheckpointStore interface {
+	// Load returns the stored checkpoint or nil if there's none.
+	Load(ctx context.Context) (*Checkpoint, error)
+	Save(ctx context.Context, checkpoint *Checkpoint) error
+}
+
+// MemoryCheckpointStore keeps the [Checkpoint] in memory, e.g. for tests.
+type MemoryCheckpointStore struct {
+	mu         sync.Mutex
+	checkpoint *Checkpoint
+}
+
+// Load implements [CheckpointStore].
+func (s *MemoryCheckpointStore) Load(context.Context) (*Checkpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.checkpoint == nil {
+		return nil, nil
+	}
+	checkpoint := *s.checkpoint
+	checkpoint.Exported = slices.Clone(s.checkpoint.Exported)
+	return &checkpoint, nil
+}
+
+// Save implements [CheckpointStore].
+func (s *MemoryCheckpointStore) Save(_ context.Context, checkpoint *Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	saved := *checkpoint
+	saved.Exported = slices.Clone(checkpoint.Exported)
+	s.checkpoint = &saved
+	return nil
+}
+
+// FileCheckpointStore persists the [Checkpoint] as JSON file.
+type FileCheckpointStore struct {
+	path string
+}
+
+// NewFileCheckpointStore creates a [FileCheckpointStore] for the path.
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{path: path}
+}
+
+// Load implements [CheckpointStore].
+func (s *FileCheckpointStore) Load(context.Context) (*Checkpoint, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	checkpoint := new(Checkpoint)
+	if err = json.Unmarshal(data, checkpoint); err != nil {
+		return nil, err
+	}
+	return checkpoint, nil
+}
+
+// Save implements [CheckpointStore]. The file is replaced atomically, so a crash never leaves a partial checkpoint.
+func (s *FileCheckpointStore) Save(_ context.Context, checkpoint *Checkpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
@@ -0,0 +1,200 @@
+// Package auditlog continuously exports the events of a ZITADEL instance as structured audit records,
+// e.g. to a SIEM or an archive for compliance:
+//
+//	sink, err := auditlog.NewFileSink("/var/log/zitadel/audit.jsonl")
+//	exporter := auditlog.NewExporter(api, sink,
+//		auditlog.WithCheckpointStore(auditlog.NewFileCheckpointStore("/var/lib/zitadel/audit.checkpoint")),
+//	)
+//	err = exporter.Run(ctx)
+//
+// The events are tailed with the watcher of the helper/events package and transformed into a [Record]
+// (see [NewRecord]). The records are shipped in batches to the [Sink] and the [Checkpoint] is saved after every
+// successful batch, so a restarted export resumes after the last exported record.
+package auditlog
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/events"
+)
+
+// maxBackoff is the maximum interval between two attempts to write a batch to the sink.
+const maxBackoff = time.Minute
+
+// Exporter exports the events of the instance to a [Sink].
+type Exporter struct {
+	client        events.Client
+	sink          Sink
+	store         CheckpointStore
+	filter        events.Filter
+	transform     func(events.Event) (*Record, error)
+	batchSize     int
+	flushInterval time.Duration
+	lag           time.Duration
+	watchOptions  []events.WatchOption
+	errorHandler  func(error)
+}
+
+// Option allows customization of the [Exporter].
+type Option func(*Exporter)
+
+// WithCheckpointStore sets the store of the [Checkpoint]. By default, the checkpoint is kept in memory only,
+// so a restarted export starts from the beginning again (resp. from the [events.Filter] From).
+func WithCheckpointStore(store CheckpointStore) Option {
+	return func(e *Exporter) {
+		e.store = store
+	}
+}
+
+// WithFilter restricts the exported events, e.g. to the events of an organization.
+// Its From is only used if there's no checkpoint yet.
+func WithFilter(filter events.Filter) Option {
+	return func(e *Exporter) {
+		e.filter = filter
+	}
+}
+
+// WithTransform replaces [NewRecord] to transform the events into records, e.g. to enrich them.
+// Events are not exported if the transformation returns nil. The id of the records must be unique per event.
+func WithTransform(transform func(events.Event) (*Record, error)) Option {
+	return func(e *Exporter) {
+		e.transform = transform
+	}
+}
+
+// WithBatchSize sets the maximum number of records written to the sink at once (default 100).
+func WithBatchSize(size int) Option {
+	return func(e *Exporter) {
+		e.batchSize = size
+	}
+}
+
+// WithFlushInterval sets the maximum time records are buffered before they are written to the sink (default 1s).
+func WithFlushInterval(interval time.Duration) Option {
+	return func(e *Exporter) {
+		e.flushInterval = interval
+	}
+}
+
+// WithLagWindow sets the lag window of the watcher ([events.WithLagWindow]), so events committed after later ones
+// are still exported, if they were created within the window before the last exported record
+// (default [events.DefaultLagWindow]). It must be used instead of passing the [events.WithLagWindow] to [WithWatchOptions].
+func WithLagWindow(lag time.Duration) Option {
+	return func(e *Exporter) {
+		e.lag = lag
+	}
+}
+
+// WithWatchOptions customizes the watcher of the events, e.g. its poll interval.
+func WithWatchOptions(opts ...events.WatchOption) Option {
+	return func(e *Exporter) {
+		e.watchOptions = append(e.watchOptions, opts...)
+	}
+}
+
+// WithErrorHandler is called with every error not stopping the export, e.g. for logging.
+// This includes failed requests of the watcher, failed transformations and failed writes to the sink or the store.
+func WithErrorHandler(handler func(error)) Option {
+	return func(e *Exporter) {
+		e.errorHandler = handler
+	}
+}
+
+// NewExporter creates an [Exporter] shipping the records to the sink.
+func NewExporter(client events.Client, sink Sink, opts ...Option) *Exporter {
+	e := &Exporter{
+		client:        client,
+		sink:          sink,
+		store:         new(MemoryCheckpointStore),
+		transform:     NewRecord,
+		batchSize:     100,
+		flushInterval: time.Second,
+		lag:           events.DefaultLagWindow,
+		errorHandler:  func(error) {},
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Run exports the events until the context is done and returns its error.
+// A batch failing to be written to the sink is retried with an exponential backoff and records buffered
+// when the context is done are exported again by the next run, so no record of a received event is lost.
+// Events committed later than the lag window ([WithLagWindow]) after a later event are not received by the watcher
+// and therefore not exported.
+func (e *Exporter) Run(ctx context.Context) error {
+	checkpoint, err := e.store.Load(ctx)
+	if err != nil {
+		return err
+	}
+	if checkpoint == nil {
+		checkpoint = &Checkpoint{Time: e.filter.From}
+	}
+	filter := e.filter
+	if !checkpoint.Time.IsZero() {
+		filter.From = checkpoint.Time.Add(-e.lag)
+	}
+	watchOptions := append([]events.WatchOption{events.WithErrorHandler(e.errorHandler)}, e.watchOptions...)
+	watchOptions = append(watchOptions, events.WithLagWindow(e.lag))
+	eventCh := events.Watch(ctx, e.client, filter, watchOptions...)
+
+	ticker := time.NewTicker(e.flushInterval)
+	defer ticker.Stop()
+	batch := make([]*Record, 0, e.batchSize)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-eventCh:
+			if !ok {
+				return ctx.Err()
+			}
+			record, err := e.transform(event)
+			if err != nil {
+				e.errorHandler(err)
+				continue
+			}
+			if record == nil || checkpoint.exported(record, e.lag) {
+				continue
+			}
+			batch = append(batch, record)
+			if len(batch) < e.batchSize {
+				continue
+			}
+		case <-ticker.C:
+			if len(batch) == 0 {
+				continue
+			}
+		}
+		if err := e.flush(ctx, checkpoint, batch); err != nil {
+			return err
+		}
+		// sinks may retain the records, so the batch is not reused
+		batch = make([]*Record, 0, e.batchSize)
+	}
+}
+
+// flush writes the batch to the sink until it succeeds or the context is done and saves the checkpoint afterward.
+func (e *Exporter) flush(ctx context.Context, checkpoint *Checkpoint, batch []*Record) error {
+	backoff := e.flushInterval
+	for {
+		err := e.sink.Write(ctx, batch)
+		if err == nil {
+			break
+		}
+		e.errorHandler(err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, maxBackoff)
+	}
+	checkpoint.advance(batch, e.lag)
+	if err := e.store.Save(ctx, checkpoint); err != nil {
+		e.errorHandler(err)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package auditlog
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/admin"
+	eventV1 "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/event"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/events"
+)
+
+var errTest = errors.New("test")
+
+func TestExporter_Run(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	service := &adminService{events: []*eventV1.Event{
+		testEvent(t, "1", 1, start, map[string]any{"userName": "alice", "secret": map[string]any{"crypted": "abc"}}),
+		testEvent(t, "2", 1, start.Add(time.Second), nil),
+		testEvent(t, "3", 1, start.Add(time.Second), nil),
+	}}
+	store := new(MemoryCheckpointStore)
+	sink := &testSink{failures: 1}
+	var errs []error
+	run := func(t *testing.T, wantRecords int) {
+		t.Helper()
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() {
+			done <- NewExporter(&testClient{service}, sink,
+				WithCheckpointStore(store),
+				WithBatchSize(2),
+				WithFlushInterval(time.Millisecond),
+				WithWatchOptions(events.WithPollInterval(time.Millisecond)),
+				WithErrorHandler(func(err error) { errs = append(errs, err) }),
+			).Run(ctx)
+		}()
+		require.Eventually(t, func() bool { return len(sink.ids()) >= wantRecords }, 5*time.Second, time.Millisecond)
+		cancel()
+		assert.ErrorIs(t, <-done, context.Canceled)
+	}
+
+	run(t, 3)
+	assert.Equal(t, []string{"1:1", "2:1", "3:1"}, sink.ids())
+	assert.JSONEq(t, `{"userName":"alice","secret":"[REDACTED]"}`, string(sink.records[0].Details))
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], errTest)
+
+	checkpoint, err := store.Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &Checkpoint{Time: start.Add(time.Second), Exported: []ExportedRecord{
+		{ID: "1:1", Time: start},
+		{ID: "2:1", Time: start.Add(time.Second)},
+		{ID: "3:1", Time: start.Add(time.Second)},
+	}}, checkpoint)
+
+	service.add(testEvent(t, "4", 1, start.Add(time.Second), nil))
+	// committed after the later events, but within the lag window
+	service.add(testEvent(t, "5", 1, start.Add(500*time.Millisecond), nil))
+	// committed later than the lag window
+	service.add(testEvent(t, "6", 1, start.Add(-time.Minute), nil))
+	run(t, 5)
+	assert.Equal(t, []string{"1:1", "2:1", "3:1", "4:1", "5:1"}, sink.ids())
+}
+
+func testEvent(t *testing.T, aggregateID string, sequence uint64, createdAt time.Time, payload map[string]any) *eventV1.Event {
+	e := &eventV1.Event{
+		Aggregate:    &eventV1.Aggregate{Id: aggregateID, Type: &eventV1.AggregateType{Type: "user"}, ResourceOwner: "org"},
+		Editor:       &eventV1.Editor{UserId: "admin", Service: "zitadel.admin.v1.AdminService"},
+		Sequence:     sequence,
+		CreationDate: timestamppb.New(createdAt),
+		Type:         &eventV1.EventType{Type: "user.human.added"},
+	}
+	if payload != nil {
+		p, err := structpb.NewStruct(payload)
+		require.NoError(t, err)
+		e.Payload = p
+	}
+	return e
+}
+
+type testSink struct {
+	mu       sync.Mutex
+	failures int
+	records  []*Record
+}
+
+func (s *testSink) Write(_ context.Context, records []*Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return errTest
+	}
+	s.records = append(s.records, records...)
+	return nil
+}
+
+func (s *testSink) ids() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]string, len(s.records))
+	for i, record := range s.records {
+		ids[i] = record.ID
+	}
+	return ids
+}
+
+type testClient struct {
+	admin admin.AdminServiceClient
+}
+
+func (c *testClient) AdminService() admin.AdminServiceClient {
+	return c.admin
+}
+
+type adminService struct {
+	admin.AdminServiceClient
+	mu     sync.Mutex
+	events []*eventV1.Event
+}
+
+func (s *adminService) add(e *eventV1.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, e)
+}
+
+func (s *adminService) ListEvents(_ context.Context, req *admin.ListEventsRequest, _ ...grpc.CallOption) (*admin.ListEventsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var result []*eventV1.Event
+	for _, e := range s.events {
+		if from := req.GetFrom(); from != nil && e.GetCreationDate().AsTime().Before(from.AsTime()) {
+			continue
+		}
+		if len(result) == int(req.GetLimit()) {
+			break
+		}
+		result = append(result, e)
+	}
+	return &admin.ListEventsResponse{Events: result}, nil
+}
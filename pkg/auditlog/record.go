@@ -0,0 +1,101 @@
+package auditlog
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/events"
+)
+
+// Redacted replaces the values of sensitive attributes in the details of a [Record].
+const Redacted = "[REDACTED]"
+
+// sensitiveAttributes are the (lower case) payload attributes containing secrets or their hashes,
+// e.g. the password hash of `user.human.password.changed` or the code of `user.human.email.code.added`.
+var sensitiveAttributes = map[string]bool{
+	"secret":         true,
+	"password":       true,
+	"hashedpassword": true,
+	"encodedhash":    true,
+	"code":           true,
+	"clientsecret":   true,
+	"token":          true,
+	"privatekey":     true,
+}
+
+// Record is the structured audit record of an event.
+type Record struct {
+	// ID is unique per event, it consists of the id of the resource and the sequence of the event.
+	ID       string    `json:"id"`
+	Time     time.Time `json:"time"`
+	Action   string    `json:"action"`
+	Actor    Actor     `json:"actor"`
+	Resource Resource  `json:"resource"`
+	// Details is the payload of the event with redacted secrets.
+	Details json.RawMessage `json:"details,omitempty"`
+}
+
+// Actor is the user (or service) which caused the event.
+type Actor struct {
+	UserID  string `json:"userId,omitempty"`
+	Service string `json:"service,omitempty"`
+}
+
+// Resource is the resource (aggregate) changed by the event.
+type Resource struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+	// Owner is the id of the organization (or instance) owning the resource.
+	Owner string `json:"owner,omitempty"`
+}
+
+// NewRecord transforms the event into a [Record]. Secrets in the payload of the event are redacted.
+func NewRecord(e events.Event) (*Record, error) {
+	record := &Record{
+		ID:     e.AggregateID + ":" + strconv.FormatUint(e.Sequence, 10),
+		Time:   e.CreatedAt,
+		Action: e.Type,
+		Actor: Actor{
+			UserID:  e.EditorUserID,
+			Service: e.EditorService,
+		},
+		Resource: Resource{
+			Type:  e.AggregateType,
+			ID:    e.AggregateID,
+			Owner: e.ResourceOwner,
+		},
+	}
+	if len(e.Payload) == 0 {
+		return record, nil
+	}
+	var payload any
+	if err := e.DecodePayload(&payload); err != nil {
+		return nil, err
+	}
+	details, err := json.Marshal(redact(payload))
+	if err != nil {
+		return nil, err
+	}
+	record.Details = details
+	return record, nil
+}
+
+func redact(v any) any {
+	switch value := v.(type) {
+	case map[string]any:
+		for key, attribute := range value {
+			if sensitiveAttributes[strings.ToLower(key)] {
+				value[key] = Redacted
+				continue
+			}
+			value[key] = redact(attribute)
+		}
+	case []any:
+		for i, element := range value {
+			value[i] = redact(element)
+		}
+	}
+	return v
+}
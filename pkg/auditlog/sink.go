@@ -0,0 +1,166 @@
+package auditlog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// Sink ships the records to their destination. The records of a call are in order of their occurrence.
+// A failed call is retried with the same records, so sinks should be idempotent (e.g. using the [Record.ID]).
+type Sink interface {
+	Write(ctx context.Context, records []*Record) error
+}
+
+// SinkFunc allows a function to be used as [Sink].
+type SinkFunc func(ctx context.Context, records []*Record) error
+
+// Write implements [Sink].
+func (f SinkFunc) Write(ctx context.Context, records []*Record) error {
+	return f(ctx, records)
+}
+
+// WriterSink writes the records as JSON lines to an [io.Writer].
+type WriterSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriterSink creates a [WriterSink], e.g. to write the records to [os.Stdout].
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{w: w}
+}
+
+// FileSink appends the records as JSON lines to a file.
+type FileSink struct {
+	*WriterSink
+	file *os.File
+}
+
+// NewFileSink creates a [FileSink], the file is created if it does not exist.
+func NewFileSink(path string) (*FileSink, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSink{WriterSink: NewWriterSink(f), file: f}, nil
+}
+
+// Close closes the file, call it once the export is stopped.
+func (s *FileSink) Close() error {
+	return s.file.Close()
+}
+
+// Write implements [Sink].
+func (s *WriterSink) Write(_ context.Context, records []*Record) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err := s.w.Write(buf.Bytes())
+	return err
+}
+
+// HTTPSink posts the records as JSON array to an HTTP endpoint, e.g. of a log management system.
+type HTTPSink struct {
+	url        string
+	httpClient *http.Client
+	header     http.Header
+}
+
+// HTTPSinkOption allows customization of the [HTTPSink].
+type HTTPSinkOption func(*HTTPSink)
+
+// WithHTTPClient sets the client used to post the records, e.g. for authentication.
+func WithHTTPClient(httpClient *http.Client) HTTPSinkOption {
+	return func(s *HTTPSink) {
+		s.httpClient = httpClient
+	}
+}
+
+// WithHeader sets a header sent with every request, e.g. an API key.
+func WithHeader(key, value string) HTTPSinkOption {
+	return func(s *HTTPSink) {
+		s.header.Set(key, value)
+	}
+}
+
+// NewHTTPSink creates an [HTTPSink] posting to the url.
+func NewHTTPSink(url string, opts ...HTTPSinkOption) *HTTPSink {
+	s := &HTTPSink{
+		url:        url,
+		httpClient: http.DefaultClient,
+		header:     make(http.Header),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Write implements [Sink]. Any response status other than 2xx results in an error.
+func (s *HTTPSink) Write(ctx context.Context, records []*Record) error {
+	body, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for key, values := range s.header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("audit log endpoint responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Producer publishes a message, e.g. to a Kafka topic. It's implemented by a small adapter of the Kafka client
+// of choice, so this package does not depend on any of them.
+type Producer interface {
+	Produce(ctx context.Context, key, value []byte) error
+}
+
+// ProducerSink publishes every record as JSON message with the id of the resource as key,
+// so the records of a resource stay in order when partitioned by key.
+type ProducerSink struct {
+	producer Producer
+}
+
+// NewProducerSink creates a [ProducerSink].
+func NewProducerSink(producer Producer) *ProducerSink {
+	return &ProducerSink{producer: producer}
+}
+
+// Write implements [Sink].
+func (s *ProducerSink) Write(ctx context.Context, records []*Record) error {
+	for _, record := range records {
+		value, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		if err = s.producer.Produce(ctx, []byte(record.Resource.ID), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
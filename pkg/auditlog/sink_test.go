@@ -0,0 +1,78 @@
+package auditlog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testRecords = []*Record{
+	{ID: "1:1", Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Action: "user.human.added", Resource: Resource{Type: "user", ID: "1"}},
+	{ID: "2:1", Time: time.Date(2024, 1, 1, 0, 0, 1, 0, time.UTC), Action: "org.added", Resource: Resource{Type: "org", ID: "2"}},
+}
+
+func TestWriterSink(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, NewWriterSink(&buf).Write(context.Background(), testRecords))
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+	assert.JSONEq(t, `{"id":"1:1","time":"2024-01-01T00:00:00Z","action":"user.human.added","actor":{},"resource":{"type":"user","id":"1"}}`, string(lines[0]))
+}
+
+func TestHTTPSink(t *testing.T) {
+	var got []*Record
+	var apiKey string
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiKey = r.Header.Get("X-API-Key")
+		body, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(body, &got))
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+	sink := NewHTTPSink(server.URL, WithHeader("X-API-Key", "key"))
+
+	require.NoError(t, sink.Write(context.Background(), testRecords))
+	assert.Equal(t, testRecords, got)
+	assert.Equal(t, "key", apiKey)
+
+	status = http.StatusServiceUnavailable
+	assert.Error(t, sink.Write(context.Background(), testRecords))
+}
+
+func TestProducerSink(t *testing.T) {
+	producer := new(testProducer)
+	require.NoError(t, NewProducerSink(producer).Write(context.Background(), testRecords))
+	assert.Equal(t, []string{"1", "2"}, producer.keys)
+}
+
+func TestFileCheckpointStore(t *testing.T) {
+	store := NewFileCheckpointStore(filepath.Join(t.TempDir(), "checkpoint"))
+	checkpoint, err := store.Load(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, checkpoint)
+
+	want := &Checkpoint{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Exported: []ExportedRecord{{ID: "1:1", Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}}}
+	require.NoError(t, store.Save(context.Background(), want))
+	checkpoint, err = store.Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, want, checkpoint)
+}
+
+type testProducer struct {
+	keys []string
+}
+
+func (p *testProducer) Produce(_ context.Context, key, _ []byte) error {
+	p.keys = append(p.keys, string(key))
+	return nil
+}
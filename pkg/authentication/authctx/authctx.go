@@ -0,0 +1,134 @@
+// Package authctx provides typed getters for the information of the authenticated user in the context
+// (provided by the [authentication.Interceptor]), without knowing the concrete type of the [authentication.Ctx]:
+//
+//	if slices.Contains(authctx.Roles(req.Context()), "admin") {
+//		...
+//	}
+//
+// Each getter returns the zero value, if the user is not authenticated or the [authentication.Ctx] does not
+// implement the respective interface. The [oidc.UserInfoContext] implements all of them.
+//
+// [oidc.UserInfoContext]: https://pkg.go.dev/github.com/zitadel/zitadel-go/v3/pkg/authentication/oidc#UserInfoContext
+package authctx
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authentication"
+)
+
+// TokenHolder provides the access token of the authentication and its expiry.
+type TokenHolder interface {
+	GetAccessToken() string
+	GetTokenExpiry() time.Time
+}
+
+// AuthenticationMethods provides the authentication methods references (`amr` claim).
+type AuthenticationMethods interface {
+	GetAMR() []string
+}
+
+// Organization provides the id of the organization of the user.
+type Organization interface {
+	GetOrgID() string
+}
+
+// RoleHolder provides the roles granted to the user.
+type RoleHolder interface {
+	GetRoles() []string
+}
+
+// LocaleHolder provides the preferred language of the user (`locale` claim).
+type LocaleHolder interface {
+	GetLocale() string
+}
+
+// UserID returns the id (subject) of the authenticated user ([authentication.SessionIdentifier]).
+func UserID(ctx context.Context) string {
+	if c, ok := get[authentication.SessionIdentifier](ctx); ok {
+		return c.GetSubject()
+	}
+	return ""
+}
+
+// AccessToken returns the access token of the authenticated user ([TokenHolder]).
+func AccessToken(ctx context.Context) string {
+	if c, ok := get[TokenHolder](ctx); ok {
+		return c.GetAccessToken()
+	}
+	return ""
+}
+
+// TokenExpiry returns the expiry of the access token of the authenticated user ([TokenHolder]).
+func TokenExpiry(ctx context.Context) time.Time {
+	if c, ok := get[TokenHolder](ctx); ok {
+		return c.GetTokenExpiry()
+	}
+	return time.Time{}
+}
+
+// IDToken returns the id_token of the authenticated user ([authentication.IDTokenHolder]).
+func IDToken(ctx context.Context) string {
+	if c, ok := get[authentication.IDTokenHolder](ctx); ok {
+		return c.GetIDToken()
+	}
+	return ""
+}
+
+// AuthTime returns the time of the authentication of the user ([authentication.AuthenticationLevel]).
+func AuthTime(ctx context.Context) time.Time {
+	if c, ok := get[authentication.AuthenticationLevel](ctx); ok {
+		return c.GetAuthTime()
+	}
+	return time.Time{}
+}
+
+// ACR returns the authentication context class reference of the authentication ([authentication.AuthenticationLevel]).
+func ACR(ctx context.Context) string {
+	if c, ok := get[authentication.AuthenticationLevel](ctx); ok {
+		return c.GetACR()
+	}
+	return ""
+}
+
+// AMR returns the authentication methods used by the user, e.g. `pwd` and `mfa` ([AuthenticationMethods]).
+func AMR(ctx context.Context) []string {
+	if c, ok := get[AuthenticationMethods](ctx); ok {
+		return c.GetAMR()
+	}
+	return nil
+}
+
+// OrgID returns the id of the organization of the authenticated user ([Organization]).
+func OrgID(ctx context.Context) string {
+	if c, ok := get[Organization](ctx); ok {
+		return c.GetOrgID()
+	}
+	return ""
+}
+
+// Roles returns the roles granted to the authenticated user ([RoleHolder]).
+func Roles(ctx context.Context) []string {
+	if c, ok := get[RoleHolder](ctx); ok {
+		return c.GetRoles()
+	}
+	return nil
+}
+
+// Locale returns the preferred language of the authenticated user ([LocaleHolder]), e.g. `de-CH`.
+func Locale(ctx context.Context) string {
+	if c, ok := get[LocaleHolder](ctx); ok {
+		return c.GetLocale()
+	}
+	return ""
+}
+
+// get returns the authentication context as I, if the user is authenticated.
+func get[I any](ctx context.Context) (i I, ok bool) {
+	if !authentication.IsAuthenticated(ctx) {
+		return i, false
+	}
+	i, ok = authentication.Context[authentication.Ctx](ctx).(I)
+	return i, ok
+}
@@ -0,0 +1,94 @@
+package authctx_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"golang.org/x/oauth2"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authentication"
+	"github.com/zitadel/zitadel-go/v3/pkg/authentication/authctx"
+	openid "github.com/zitadel/zitadel-go/v3/pkg/authentication/oidc"
+)
+
+func signToken(t *testing.T, claims map[string]any) string {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key}, nil)
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	jws, err := signer.Sign(payload)
+	require.NoError(t, err)
+	token, err := jws.CompactSerialize()
+	require.NoError(t, err)
+	return token
+}
+
+func TestGetters(t *testing.T) {
+	authTime := time.Now().Add(-time.Minute).Truncate(time.Second)
+	expiry := time.Now().Add(time.Hour)
+	idToken := signToken(t, map[string]any{
+		"sub":                                   "user",
+		"auth_time":                             authTime.Unix(),
+		"acr":                                   "mfa",
+		"amr":                                   []string{"pwd", "otp"},
+		"locale":                                "de-CH",
+		"urn:zitadel:iam:user:resourceowner:id": "org",
+		"urn:zitadel:iam:org:project:roles":     map[string]any{"viewer": map[string]any{"org": "example.com"}, "admin": map[string]any{"org": "example.com"}},
+	})
+	authCtx := &openid.UserInfoContext[*oidc.IDTokenClaims, *oidc.UserInfo]{
+		UserInfo: &oidc.UserInfo{Subject: "user"},
+		Tokens: &oidc.Tokens[*oidc.IDTokenClaims]{
+			Token:   &oauth2.Token{AccessToken: "access", Expiry: expiry},
+			IDToken: idToken,
+		},
+	}
+	ctx := authentication.WithAuthContext(context.Background(), authCtx)
+
+	assert.Equal(t, "user", authctx.UserID(ctx))
+	assert.Equal(t, "access", authctx.AccessToken(ctx))
+	assert.Equal(t, expiry, authctx.TokenExpiry(ctx))
+	assert.Equal(t, idToken, authctx.IDToken(ctx))
+	assert.Equal(t, authTime, authctx.AuthTime(ctx).Local())
+	assert.Equal(t, "mfa", authctx.ACR(ctx))
+	assert.Equal(t, []string{"pwd", "otp"}, authctx.AMR(ctx))
+	assert.Equal(t, "org", authctx.OrgID(ctx))
+	assert.Equal(t, []string{"admin", "viewer"}, authctx.Roles(ctx))
+	assert.Equal(t, "de-CH", authctx.Locale(ctx))
+}
+
+func TestGetters_userInfo(t *testing.T) {
+	authCtx := &openid.UserInfoContext[*oidc.IDTokenClaims, *oidc.UserInfo]{
+		UserInfo: &oidc.UserInfo{Subject: "user", UserInfoProfile: oidc.UserInfoProfile{Locale: oidc.NewLocale(language.German)}, Claims: map[string]any{
+			"urn:zitadel:iam:user:resourceowner:id": "org",
+			"urn:zitadel:iam:org:project:roles":     map[string]any{"admin": map[string]any{"org": "example.com"}},
+		}},
+	}
+	ctx := authentication.WithAuthContext(context.Background(), authCtx)
+	assert.Equal(t, "org", authctx.OrgID(ctx))
+	assert.Equal(t, []string{"admin"}, authctx.Roles(ctx))
+	assert.Equal(t, "de", authctx.Locale(ctx))
+}
+
+func TestGetters_notAuthenticated(t *testing.T) {
+	ctx := context.Background()
+	assert.Empty(t, authctx.UserID(ctx))
+	assert.Empty(t, authctx.AccessToken(ctx))
+	assert.True(t, authctx.TokenExpiry(ctx).IsZero())
+	assert.True(t, authctx.AuthTime(ctx).IsZero())
+	assert.Nil(t, authctx.AMR(ctx))
+	assert.Empty(t, authctx.OrgID(ctx))
+	assert.Nil(t, authctx.Roles(ctx))
+	assert.Empty(t, authctx.Locale(ctx))
+}
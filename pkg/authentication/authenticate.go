@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/zitadel/oidc/v3/pkg/crypto"
@@ -21,13 +23,28 @@ var (
 // Authenticator provides the functionality to handle authentication including check for existing session,
 // starting a new authentication by redirecting the user to the Login UI and more.
 type Authenticator[T Ctx] struct {
-	authN             Handler[T]
-	logger            *slog.Logger
-	router            *http.ServeMux
-	sessions          Sessions[T]
-	encryptionKey     string
-	sessionCookieName string
-	externalSecure    bool
+	authN                      Handler[T]
+	logger                     *slog.Logger
+	router                     *http.ServeMux
+	sessions                   Sessions[T]
+	encryptionKey              string
+	sessionCookieName          string
+	externalSecure             bool
+	postLogoutRedirectURI      string
+	allowedPostLogoutRedirects []string
+	refreshes                  singleflight[T]
+	tenantResolver             TenantResolver
+	silentAuthentication       bool
+	csrfProtection             bool
+	defaultRedirectURI         string
+	allowedRedirects           []*url.URL
+	errorHandler               ErrorHandler
+	unauthenticatedHandler     http.HandlerFunc
+	absoluteTimeout            time.Duration
+	idleTimeout                time.Duration
+	rememberMe                 time.Duration
+	acceptLanguage             bool
+	idpHint                    string
 }
 
 // Option allows customization of the [Authenticator] such as logging and more.
@@ -63,17 +80,35 @@ func WithExternalSecure[T Ctx](externalSecure bool) Option[T] {
 	}
 }
 
+// WithTenantResolver allows resolving the [Tenant] (organization) of the user from the request,
+// e.g. with [TenantByHost] or [TenantByPath], so the user will log in to the organization of the tenant.
+func WithTenantResolver[T Ctx](resolver TenantResolver) Option[T] {
+	return func(a *Authenticator[T]) {
+		a.tenantResolver = resolver
+	}
+}
+
+// WithPostLogoutRedirectURI allows a redirect after the logout other than "/".
+func WithPostLogoutRedirectURI[T Ctx](uri string) Option[T] {
+	return func(a *Authenticator[T]) {
+		a.postLogoutRedirectURI = uri
+	}
+}
+
 func New[T Ctx](ctx context.Context, zitadel *zitadel.Zitadel, encryptionKey string, initAuthentication HandlerInitializer[T], options ...Option[T]) (*Authenticator[T], error) {
 	authN, err := initAuthentication(ctx, zitadel)
 	if err != nil {
 		return nil, err
 	}
 	authenticator := &Authenticator[T]{
-		authN:             authN,
-		sessions:          &InMemorySessions[T]{sessions: make(map[string]T)},
-		encryptionKey:     encryptionKey,
-		sessionCookieName: "zitadel.session",
-		logger:            slog.Default(),
+		authN:                 authN,
+		sessions:              &InMemorySessions[T]{sessions: make(map[string]T)},
+		encryptionKey:         encryptionKey,
+		sessionCookieName:     "zitadel.session",
+		postLogoutRedirectURI: "/",
+		defaultRedirectURI:    "/",
+		errorHandler:          DefaultErrorHandler,
+		logger:                slog.Default(),
 	}
 	for _, option := range options {
 		option(authenticator)
@@ -82,19 +117,57 @@ func New[T Ctx](ctx context.Context, zitadel *zitadel.Zitadel, encryptionKey str
 	return authenticator, nil
 }
 
-// ServeHTTP serves the authentication handler and its three subroutes.
+// ServeHTTP serves the authentication handler and its subroutes.
 func (a *Authenticator[T]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	http.StripPrefix("/auth", a.router).ServeHTTP(w, r)
 }
 
 // Authenticate starts a new authentication (by redirecting the user to the Login UI)
 // The initially requested URI (in the application) is passed as encrypted state.
-func (a *Authenticator[T]) Authenticate(w http.ResponseWriter, r *http.Request, requestedURI string) {
-	s := &State{RequestedURI: requestedURI}
+// It must be a relative URI or match a target allowed by [WithAllowedRedirects],
+// otherwise the user is redirected to the default URI ([WithDefaultRedirectURI]) after the login.
+// On the login endpoint, it's taken from the `return_to` query parameter, e.g. `/auth/login?return_to=/orders`.
+// The [Tenant] is resolved by the [TenantResolver] (if set) and the `login_hint` query parameter
+// is passed to the Login UI, e.g. `/auth/login?login_hint=user@example.com`, as well as the `ui_locales`
+// (see [WithUILocales] and [WithAcceptLanguage]) and the `idp_hint` (see [WithIdentityProvider]).
+// Additional scopes and claims can be requested with the [LoginOption].
+func (a *Authenticator[T]) Authenticate(w http.ResponseWriter, r *http.Request, requestedURI string, options ...LoginOption) {
+	a.authenticate(w, r, applyLoginOptions(&State{RequestedURI: requestedURI}, options))
+}
+
+func (a *Authenticator[T]) authenticate(w http.ResponseWriter, r *http.Request, s *State) {
+	s.RequestedURI = a.redirectURI(s.RequestedURI)
+	authRequest := AuthRequest{LoginHint: r.URL.Query().Get("login_hint")}
+	if a.tenantResolver != nil {
+		authRequest.Tenant = a.tenantResolver(r)
+	}
+	if s.OrgID != "" {
+		authRequest.Tenant = Tenant{OrgID: s.OrgID}
+	}
+	if s.StepUp != nil {
+		authRequest.ACRValues = s.StepUp.ACRValues
+		authRequest.MaxAge = s.StepUp.MaxAge
+	}
+	if a.rememberMe > 0 && r.URL.Query().Get(rememberMeParam) == "true" {
+		s.RememberMe = true
+	}
+	if s.Silent {
+		authRequest.Prompt = []string{"none"}
+	}
+	if s.Silent || s.StepUp != nil || s.OrgID != "" {
+		authRequest.IDTokenHint = a.IDToken(r)
+	}
+	authRequest.Scopes = s.Scopes
+	authRequest.Claims = s.Claims
+	s.UILocales = a.uiLocales(r, s)
+	authRequest.UILocales = s.UILocales
+	s.IDPHint = a.idpHintOf(r, s)
+	authRequest.IDPHint = s.IDPHint
+	r = r.WithContext(WithAuthRequest(r.Context(), authRequest))
 	stateParam, err := s.Encrypt(a.encryptionKey)
 
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		a.error(w, r, err, http.StatusInternalServerError)
 		return
 	}
 	a.authN.Authenticate(w, r, stateParam)
@@ -102,87 +175,218 @@ func (a *Authenticator[T]) Authenticate(w http.ResponseWriter, r *http.Request,
 
 // Callback handles the redirect back from the Login UI. On successful authentication a new session
 // will be created and its id will be stored in a cookie.
-// The user will be redirected to the initially requested UI (passed as encrypted state)
+// The user will be redirected to the initially requested UI (passed as encrypted state),
+// unless the authentication does not satisfy the requirements of a [StepUp].
+// If a silent authentication ([Authenticator.SilentAuthenticate]) failed, an interactive one is started.
+// Other errors returned by the Login UI are passed as [CallbackError] to the [ErrorHandler].
 func (a *Authenticator[T]) Callback(w http.ResponseWriter, req *http.Request) {
+	if a.silentCallbackFailed(w, req) {
+		return
+	}
+	if err := callbackError(req); err != nil {
+		a.logger.Warn("authentication failed", "error", err.ErrorType, "description", err.Description)
+		statusCode := http.StatusBadRequest
+		if err.ErrorType == "access_denied" {
+			statusCode = http.StatusForbidden
+		}
+		a.error(w, req, err, statusCode)
+		return
+	}
 	ctx, stateParam := a.authN.Callback(w, req)
 	if !ctx.IsAuthenticated() {
 		a.logger.Error("unauthenticated after callback")
-		http.Error(w, "not authenticated", http.StatusForbidden)
+		a.error(w, req, ErrNotAuthenticated, http.StatusForbidden)
 		return
 	}
 	state, err := DecryptState(stateParam, a.encryptionKey)
 	if err != nil {
 		a.logger.Error("unable to decrypt state", "state", stateParam)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		a.error(w, req, err, http.StatusInternalServerError)
+		return
+	}
+	if state.StepUp != nil && !state.StepUp.SatisfiedBy(ctx) {
+		a.logger.Error("authentication does not satisfy the step-up requirements")
+		a.error(w, req, ErrInsufficientAuthentication, http.StatusForbidden)
 		return
 	}
 
+	a.replaceSession(req, state)
 	id := uuid.NewString()
-	err = a.setSessionCookie(w, id)
+	err = a.storeSession(w, id, ctx, a.newLifetime(state.RememberMe))
 	if err != nil {
-		a.logger.Error("unable to save session cookie", "error", err, "id", id)
-		http.Error(w, "session could not be stored", http.StatusInternalServerError)
+		a.logger.Error("unable to save session", "error", err, "id", id)
+		a.error(w, req, fmt.Errorf("session could not be stored: %w", err), http.StatusInternalServerError)
 		return
 	}
-	err = a.sessions.Set(id, ctx)
-	if err != nil {
-		a.logger.Error("unable to save session", "error", err, "id", id)
-		http.Error(w, "session could not be stored", http.StatusInternalServerError)
+	if _, err = a.setCSRFCookie(w); err != nil {
+		a.logger.Error("unable to set csrf cookie", "error", err)
+		a.error(w, req, fmt.Errorf("csrf token could not be created: %w", err), http.StatusInternalServerError)
 		return
 	}
 
-	http.Redirect(w, req, state.RequestedURI, http.StatusFound)
+	http.Redirect(w, req, a.redirectURI(state.RequestedURI), http.StatusFound)
 }
 
-// Logout will terminate the exising session.
+// Logout will terminate the existing session (RP-initiated logout):
+// The session is removed from the [Sessions] store, the session cookie is deleted and the user is redirected
+// to the end_session_endpoint of the Login UI with the id_token_hint to terminate the session(s) there as well.
+// Afterward, the Login UI redirects the user back to the `/auth/logout/done` endpoint ([Authenticator.LogoutDone]),
+// which needs to be registered as post logout redirect URI of the application.
+// If [WithCSRFProtection] is set, only POST requests with a valid CSRF token are accepted.
+// The user is finally redirected to the URI of the `return_to` parameter, if it's allowed by [WithAllowedPostLogoutRedirects],
+// otherwise to the one set by [WithPostLogoutRedirectURI].
 func (a *Authenticator[T]) Logout(w http.ResponseWriter, req *http.Request) {
-	ctx, err := a.IsAuthenticated(req)
+	a.LogoutTo(w, req, req.FormValue(returnToParam))
+}
+
+// LogoutTo will terminate the existing session like [Authenticator.Logout], but redirect the user to the
+// redirectURI afterward, if it's allowed by [WithAllowedPostLogoutRedirects].
+func (a *Authenticator[T]) LogoutTo(w http.ResponseWriter, req *http.Request, redirectURI string) {
+	redirectURI = a.postLogoutRedirectURIOf(redirectURI)
+	if a.csrfProtection {
+		if req.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			a.error(w, req, ErrMethodNotAllowed, http.StatusMethodNotAllowed)
+			return
+		}
+		if err := a.ValidateCSRF(req); err != nil {
+			a.error(w, req, err, http.StatusForbidden)
+			return
+		}
+	}
+	sessionID, ctx, err := a.session(req)
 	if err != nil {
-		http.Redirect(w, req, "/", http.StatusFound)
+		a.deleteSessionCookie(w)
+		a.deleteCSRFCookie(w)
+		http.Redirect(w, req, redirectURI, http.StatusFound)
 		return
 	}
-	s := &State{RequestedURI: ""}
+	s := &State{RequestedURI: redirectURI}
 	stateParam, err := s.Encrypt(a.encryptionKey)
 
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		a.error(w, req, err, http.StatusInternalServerError)
+		return
+	}
+	if err = a.sessions.Delete(sessionID); err != nil {
+		a.logger.Error("unable to delete session", "error", err, "id", sessionID)
+		a.error(w, req, fmt.Errorf("session could not be deleted: %w", err), http.StatusInternalServerError)
 		return
 	}
 	a.deleteSessionCookie(w)
+	a.deleteCSRFCookie(w)
 
 	proto := "http"
 	if req.TLS != nil || a.externalSecure {
 		proto = "https"
 	}
-	postLogout := fmt.Sprintf("%s://%s/", proto, req.Host)
+	postLogout := fmt.Sprintf("%s://%s/auth/logout/done", proto, req.Host)
 	a.authN.Logout(w, req, ctx, stateParam, postLogout)
 }
 
+// LogoutDone handles the redirect back from the Login UI after the logout.
+// The user will be redirected to the URI passed as encrypted state (selected on the logout),
+// resp. the one set by [WithPostLogoutRedirectURI].
+func (a *Authenticator[T]) LogoutDone(w http.ResponseWriter, req *http.Request) {
+	redirectURI := a.postLogoutRedirectURI
+	if stateParam := req.URL.Query().Get("state"); stateParam != "" {
+		state, err := DecryptState(stateParam, a.encryptionKey)
+		if err != nil {
+			a.logger.Warn("unable to decrypt logout state", "state", stateParam)
+		} else if state.RequestedURI != "" {
+			redirectURI = state.RequestedURI
+		}
+	}
+	http.Redirect(w, req, redirectURI, http.StatusFound)
+}
+
 // IsAuthenticated checks whether there is an existing session of not.
 // In case there is one, it will be returned.
+// If the [Handler] implements the [Refresher] interface, an expired session will be refreshed (and stored) first.
+// If the refresh token is rejected, the session is removed and [ErrNoSession] is returned, so the user needs to authenticate again.
+// If the refresh fails otherwise, e.g. because ZITADEL is not reachable, the session is kept and [ErrRefreshFailed] is returned.
 func (a *Authenticator[T]) IsAuthenticated(req *http.Request) (T, error) {
+	return a.authenticated(nil, req)
+}
+
+// authenticated implements [Authenticator.IsAuthenticated]. If a [http.ResponseWriter] is provided,
+// the session cookie of [StatelessSessions] will be updated after a refresh.
+func (a *Authenticator[T]) authenticated(w http.ResponseWriter, req *http.Request) (T, error) {
+	var t T
+	sessionID, session, err := a.session(req)
+	if err != nil {
+		return t, err
+	}
+	lifetime, err := a.sessionLifetime(req, sessionID)
+	if err != nil {
+		a.logger.Log(req.Context(), slog.LevelInfo, "session expired", "sessionID", sessionID)
+		a.expireSession(w, sessionID)
+		return t, err
+	}
+	session, refreshed, err := a.refresh(req.Context(), sessionID, session)
+	if err != nil {
+		a.logger.Log(req.Context(), slog.LevelWarn, "unable to refresh session", "sessionID", sessionID, "error", err)
+		if errors.Is(err, ErrRefreshTokenRejected) {
+			a.expireSession(w, sessionID)
+			return t, ErrNoSession
+		}
+		return t, ErrRefreshFailed
+	}
+	if w == nil {
+		return session, nil
+	}
+	if _, stateless := a.sessions.(StatelessSessions[T]); stateless && refreshed {
+		if lifetime != nil {
+			lifetime.LastActivity = time.Now()
+		}
+		if err = a.updateStatelessSession(w, sessionID, session, lifetime); err != nil {
+			a.logger.Log(req.Context(), slog.LevelWarn, "unable to update session cookie", "error", err)
+		}
+		return session, nil
+	}
+	a.renewLifetime(w, sessionID, lifetime)
+	return session, nil
+}
+
+// session returns the session (and its id) of the session cookie.
+func (a *Authenticator[T]) session(req *http.Request) (string, T, error) {
 	var t T
 	cookie, err := req.Cookie(a.sessionCookieName)
 	if err != nil {
-		return t, ErrNoCookie
+		return "", t, ErrNoCookie
+	}
+	if stateless, ok := a.sessions.(StatelessSessions[T]); ok {
+		session, err := stateless.Decode(cookie.Value)
+		if err != nil {
+			a.logger.Log(req.Context(), slog.LevelWarn, "unable to decode session cookie", "error", err)
+			return "", t, ErrNoSession
+		}
+		// the cookie value is used as id, e.g. to refresh it only once
+		return cookie.Value, session, nil
 	}
 	sessionID, err := crypto.DecryptAES(cookie.Value, a.encryptionKey)
 	if err != nil {
 		a.logger.Log(req.Context(), slog.LevelWarn, "unable to decrypt session cookie", "cookie value", cookie.Value)
-		return t, ErrNoSession
+		return "", t, ErrNoSession
 	}
 	session, err := a.sessions.Get(sessionID)
 	if err != nil {
 		a.logger.Log(req.Context(), slog.LevelWarn, "no session found for cookie", "sessionID", sessionID)
-		return t, ErrNoSession
+		return "", t, ErrNoSession
 	}
-	return session, nil
+	return sessionID, session, nil
 }
 
 func (a *Authenticator[T]) createRouter() {
 	a.router = http.NewServeMux()
 	a.router.Handle("/login", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-		a.Authenticate(w, req, "")
+		a.Authenticate(w, req, req.URL.Query().Get(returnToParam))
+	}))
+	a.router.Handle("/silent", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		a.SilentAuthenticate(w, req, req.URL.Query().Get(returnToParam))
+	}))
+	a.router.Handle("/switch-organization", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		a.switchOrganization(w, req)
 	}))
 	a.router.Handle("/callback", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		a.Callback(w, req)
@@ -190,24 +394,80 @@ func (a *Authenticator[T]) createRouter() {
 	a.router.Handle("/logout", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		a.Logout(w, req)
 	}))
+	a.router.Handle("/backchannel-logout", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		a.BackChannelLogout(w, req)
+	}))
+	a.router.Handle("/frontchannel-logout", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		a.FrontChannelLogout(w, req)
+	}))
+	a.router.Handle("/logout/done", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		a.LogoutDone(w, req)
+	}))
 }
 
-func (a *Authenticator[T]) setSessionCookie(w http.ResponseWriter, sessionID string) error {
+// storeSession stores the session and sets the session cookie with its id.
+// For [StatelessSessions], the encoded session itself is set as session cookie.
+// If a lifetime is provided, the lifetime cookie is set as well.
+func (a *Authenticator[T]) storeSession(w http.ResponseWriter, id string, session T, lifetime *sessionLifetime) error {
+	if stateless, ok := a.sessions.(StatelessSessions[T]); ok {
+		value, err := stateless.Encode(session)
+		if err != nil {
+			return err
+		}
+		a.writeSessionCookie(w, value, lifetime)
+		return a.storeLifetime(w, value, lifetime)
+	}
+	if err := a.setSessionCookie(w, id, lifetime); err != nil {
+		return err
+	}
+	if err := a.sessions.Set(id, session); err != nil {
+		return err
+	}
+	return a.storeLifetime(w, id, lifetime)
+}
+
+// updateStatelessSession sets the session cookie of the refreshed session of [StatelessSessions],
+// using the [StatelessSessionsUpdater] if implemented.
+func (a *Authenticator[T]) updateStatelessSession(w http.ResponseWriter, previous string, session T, lifetime *sessionLifetime) error {
+	updater, ok := a.sessions.(StatelessSessionsUpdater[T])
+	if !ok {
+		return a.storeSession(w, previous, session, lifetime)
+	}
+	value, err := updater.Update(previous, session)
+	if err != nil {
+		return err
+	}
+	a.writeSessionCookie(w, value, lifetime)
+	return a.storeLifetime(w, value, lifetime)
+}
+
+func (a *Authenticator[T]) storeLifetime(w http.ResponseWriter, sessionID string, lifetime *sessionLifetime) error {
+	if lifetime == nil {
+		return nil
+	}
+	return a.setLifetimeCookie(w, sessionID, lifetime)
+}
+
+func (a *Authenticator[T]) setSessionCookie(w http.ResponseWriter, sessionID string, lifetime *sessionLifetime) error {
 	value, err := crypto.EncryptAES(sessionID, a.encryptionKey)
 	if err != nil {
 		return err
 	}
+	a.writeSessionCookie(w, value, lifetime)
+	return nil
+}
+
+func (a *Authenticator[T]) writeSessionCookie(w http.ResponseWriter, value string, lifetime *sessionLifetime) {
 	http.SetCookie(w, &http.Cookie{
 		Name:     a.sessionCookieName,
 		Value:    value,
 		Path:     "/",
 		Domain:   "",
-		MaxAge:   0,
+		MaxAge:   a.cookieMaxAge(lifetime),
 		Secure:   true,
 		HttpOnly: true,
 		SameSite: http.SameSiteLaxMode,
 	})
-	return nil
 }
 
 func (a *Authenticator[T]) deleteSessionCookie(w http.ResponseWriter) {
@@ -220,6 +480,9 @@ func (a *Authenticator[T]) deleteSessionCookie(w http.ResponseWriter) {
 		HttpOnly: true,
 		SameSite: http.SameSiteLaxMode,
 	})
+	if a.lifetimeEnabled() {
+		a.deleteLifetimeCookie(w)
+	}
 }
 
 // Handler defines the handling of authentication and logout
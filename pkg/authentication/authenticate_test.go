@@ -0,0 +1,144 @@
+package authentication
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthenticator_Logout(t *testing.T) {
+	a, cookie := newTestAuthenticator(t, new(testHandler), &testCtx{token: "token"})
+	a.postLogoutRedirectURI = "/bye"
+	a.createRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "http://app.example.com/auth/logout", nil)
+	req.AddCookie(cookie)
+	w := httptest.NewRecorder()
+	a.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusFound, w.Code)
+	_, err := a.sessions.Get("session")
+	assert.Error(t, err, "session not deleted")
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 2)
+	assert.Equal(t, a.sessionCookieName, cookies[0].Name)
+	assert.Equal(t, -1, cookies[0].MaxAge)
+	assert.Equal(t, a.csrfCookieName(), cookies[1].Name)
+	assert.Equal(t, -1, cookies[1].MaxAge)
+
+	endSession, err := url.Parse(w.Header().Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, "http://app.example.com/auth/logout/done", endSession.Query().Get("post_logout_redirect_uri"))
+
+	// the Login UI redirects back with the state
+	req = httptest.NewRequest(http.MethodGet, "http://app.example.com/auth/logout/done?state="+url.QueryEscape(endSession.Query().Get("state")), nil)
+	w = httptest.NewRecorder()
+	a.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "/bye", w.Header().Get("Location"))
+}
+
+func TestAuthenticator_Logout_noSession(t *testing.T) {
+	a, _ := newTestAuthenticator(t, new(testHandler), &testCtx{token: "token"})
+
+	w := httptest.NewRecorder()
+	a.Logout(w, httptest.NewRequest(http.MethodGet, "/auth/logout", nil))
+
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "/", w.Header().Get("Location"))
+	_, err := a.sessions.Get("session")
+	assert.NoError(t, err, "other session must not be deleted")
+}
+
+func TestAuthenticator_LogoutDone(t *testing.T) {
+	tests := []struct {
+		name  string
+		state string
+		want  string
+	}{
+		{
+			name: "no state",
+			want: "/",
+		},
+		{
+			name:  "invalid state",
+			state: "invalid",
+			want:  "/",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, _ := newTestAuthenticator(t, new(testHandler), nil)
+			w := httptest.NewRecorder()
+			a.LogoutDone(w, httptest.NewRequest(http.MethodGet, "/auth/logout/done?state="+tt.state, nil))
+			assert.Equal(t, http.StatusFound, w.Code)
+			assert.Equal(t, tt.want, w.Header().Get("Location"))
+		})
+	}
+}
+
+func TestAuthenticator_Logout_postLogoutRedirect(t *testing.T) {
+	tests := []struct {
+		name      string
+		returnTo  string
+		noSession bool
+		want      string
+	}{
+		{
+			name: "default",
+			want: "/bye",
+		},
+		{
+			name:     "allowed",
+			returnTo: "https://www.example.com/goodbye",
+			want:     "https://www.example.com/goodbye",
+		},
+		{
+			name:     "allowed relative",
+			returnTo: "/signed-out",
+			want:     "/signed-out",
+		},
+		{
+			name:     "not allowed",
+			returnTo: "https://evil.example.com/goodbye",
+			want:     "/bye",
+		},
+		{
+			name:      "allowed without session",
+			returnTo:  "/signed-out",
+			noSession: true,
+			want:      "/signed-out",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, cookie := newTestAuthenticator(t, new(testHandler), &testCtx{token: "token"})
+			a.postLogoutRedirectURI = "/bye"
+			WithAllowedPostLogoutRedirects[*testCtx]("https://www.example.com/goodbye", "/signed-out")(a)
+			a.createRouter()
+
+			req := httptest.NewRequest(http.MethodGet, "http://app.example.com/auth/logout?"+url.Values{"return_to": {tt.returnTo}}.Encode(), nil)
+			if !tt.noSession {
+				req.AddCookie(cookie)
+			}
+			w := httptest.NewRecorder()
+			a.ServeHTTP(w, req)
+			require.Equal(t, http.StatusFound, w.Code)
+			if tt.noSession {
+				assert.Equal(t, tt.want, w.Header().Get("Location"))
+				return
+			}
+
+			endSession, err := url.Parse(w.Header().Get("Location"))
+			require.NoError(t, err)
+			req = httptest.NewRequest(http.MethodGet, "http://app.example.com/auth/logout/done?state="+url.QueryEscape(endSession.Query().Get("state")), nil)
+			w = httptest.NewRecorder()
+			a.ServeHTTP(w, req)
+			assert.Equal(t, tt.want, w.Header().Get("Location"))
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package authentication
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// LogoutTokenVerifier is an optional interface of a [Handler], which allows verifying the logout token
+// of an OpenID Connect Back-Channel Logout.
+type LogoutTokenVerifier interface {
+	// VerifyLogoutToken verifies the logout token and returns its subject (sub) and session id (sid).
+	// At least one of them is set.
+	VerifyLogoutToken(ctx context.Context, token string) (subject, sessionID string, err error)
+}
+
+// SessionTerminator is an optional interface of the [Sessions] store, which allows deleting the sessions
+// of a user or of a session at the identity provider, e.g. on a backchannel logout.
+type SessionTerminator interface {
+	// Terminate deletes all sessions of the session id (sid) at the identity provider.
+	// If no session id is provided, all sessions of the subject (user) are deleted.
+	Terminate(subject, sessionID string) error
+}
+
+// SessionIdentifier is an optional interface of the [Ctx], which provides the subject (user) and the session id (sid)
+// at the identity provider. It's required by the [InMemorySessions] to terminate sessions.
+type SessionIdentifier interface {
+	GetSubject() string
+	GetSessionID() string
+}
+
+// BackChannelLogout handles the OpenID Connect Back-Channel Logout requests of the identity provider,
+// e.g. if a user or an administrator terminated the session in ZITADEL.
+// The `/auth/backchannel-logout` endpoint needs to be registered as backchannel logout URI of the application.
+//
+// The logout token is verified by the [Handler] ([LogoutTokenVerifier]) and the corresponding sessions are deleted
+// from the [Sessions] store ([SessionTerminator]). If either does not implement the interface, the request is rejected.
+func (a *Authenticator[T]) BackChannelLogout(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	verifier, ok := a.authN.(LogoutTokenVerifier)
+	if !ok {
+		http.Error(w, "backchannel logout not supported by authentication handler", http.StatusNotImplemented)
+		return
+	}
+	terminator, ok := a.sessions.(SessionTerminator)
+	if !ok {
+		http.Error(w, "backchannel logout not supported by session store", http.StatusNotImplemented)
+		return
+	}
+	token := req.PostFormValue("logout_token")
+	if token == "" {
+		backChannelLogoutError(w, "logout_token missing")
+		return
+	}
+	subject, sessionID, err := verifier.VerifyLogoutToken(req.Context(), token)
+	if err != nil {
+		a.logger.Warn("invalid logout token", "error", err)
+		backChannelLogoutError(w, err.Error())
+		return
+	}
+	if err = terminator.Terminate(subject, sessionID); err != nil {
+		a.logger.Error("unable to terminate sessions", "error", err, "subject", subject, "sid", sessionID)
+		http.Error(w, "sessions could not be terminated", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func backChannelLogoutError(w http.ResponseWriter, description string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error":             "invalid_request",
+		"error_description": description,
+	})
+}
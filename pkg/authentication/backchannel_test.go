@@ -0,0 +1,105 @@
+package authentication
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// VerifyLogoutToken expects the token in the form `subject:sid`
+func (h *testHandler) VerifyLogoutToken(_ context.Context, token string) (string, string, error) {
+	subject, sid, ok := strings.Cut(token, ":")
+	if !ok {
+		return "", "", errors.New("invalid")
+	}
+	return subject, sid, nil
+}
+
+func TestAuthenticator_BackChannelLogout(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		token        string
+		wantStatus   int
+		wantSessions []string
+	}{
+		{
+			name:         "method not allowed",
+			method:       http.MethodGet,
+			token:        "user:sid1",
+			wantStatus:   http.StatusMethodNotAllowed,
+			wantSessions: []string{"1", "2", "3"},
+		},
+		{
+			name:         "token missing",
+			method:       http.MethodPost,
+			wantStatus:   http.StatusBadRequest,
+			wantSessions: []string{"1", "2", "3"},
+		},
+		{
+			name:         "invalid token",
+			method:       http.MethodPost,
+			token:        "invalid",
+			wantStatus:   http.StatusBadRequest,
+			wantSessions: []string{"1", "2", "3"},
+		},
+		{
+			name:         "session",
+			method:       http.MethodPost,
+			token:        "user:sid1",
+			wantStatus:   http.StatusOK,
+			wantSessions: []string{"2", "3"},
+		},
+		{
+			name:         "all sessions of user",
+			method:       http.MethodPost,
+			token:        "user:",
+			wantStatus:   http.StatusOK,
+			wantSessions: []string{"3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, _ := newTestAuthenticator(t, new(testHandler), nil)
+			sessions := &InMemorySessions[*testCtx]{sessions: map[string]*testCtx{
+				"1": {token: "token", subject: "user", sid: "sid1"},
+				"2": {token: "token", subject: "user", sid: "sid2"},
+				"3": {token: "token", subject: "other", sid: "sid3"},
+			}}
+			a.sessions = sessions
+			a.createRouter()
+
+			body := url.Values{}
+			if tt.token != "" {
+				body.Set("logout_token", tt.token)
+			}
+			req := httptest.NewRequest(tt.method, "/auth/backchannel-logout", strings.NewReader(body.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+			a.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+			remaining := make([]string, 0, len(sessions.sessions))
+			for id := range sessions.sessions {
+				remaining = append(remaining, id)
+			}
+			assert.ElementsMatch(t, tt.wantSessions, remaining)
+		})
+	}
+}
+
+func TestAuthenticator_BackChannelLogout_notSupported(t *testing.T) {
+	a, _ := newTestAuthenticator(t, struct{ Handler[*testCtx] }{}, nil)
+	req := httptest.NewRequest(http.MethodPost, "/auth/backchannel-logout", strings.NewReader("logout_token=user:sid"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	a.BackChannelLogout(w, req)
+	assert.Equal(t, http.StatusNotImplemented, w.Code)
+}
@@ -0,0 +1,144 @@
+// Package cookie provides a stateless implementation of the [authentication.Sessions] store,
+// which stores the sessions in the (encrypted) session cookie itself instead of on the server.
+// It's suitable for small applications, which want to avoid any server-side session storage.
+//
+// Since the session is not stored on the server, it cannot be terminated by a backchannel logout
+// and a logout only removes the cookie from the browser of the user. Use the [WithMaxAge] to limit the lifetime
+// of a session.
+package cookie
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authentication"
+	"github.com/zitadel/zitadel-go/v3/pkg/authentication/internal/codec"
+)
+
+// MaxCookieSize is the maximum size of the session cookie value, which is supported by all major browsers
+// (including the name and attributes of the cookie).
+const MaxCookieSize = 4000
+
+var (
+	ErrStateless       = errors.New("sessions are stored in the cookie")
+	ErrCookieTooLarge  = errors.New("session too large for a cookie")
+	ErrSessionExpired  = errors.New("session expired")
+	ErrInvalidEncoding = errors.New("invalid session encoding")
+)
+
+// Sessions implements the [authentication.Sessions], [authentication.StatelessSessions] and
+// [authentication.StatelessSessionsUpdater] interfaces by storing the session as JSON, encrypted and authenticated
+// with AES-GCM, in the session cookie.
+type Sessions[T authentication.Ctx] struct {
+	codec  *codec.Codec[*envelope[T]]
+	maxAge time.Duration
+}
+
+// envelope adds the expiration to the session, so it cannot be replayed after the max age.
+type envelope[T authentication.Ctx] struct {
+	Session   T         `json:"s"`
+	ExpiresAt time.Time `json:"e"`
+}
+
+// Option allows customization of the [Sessions] store.
+type Option func(*options)
+
+type options struct {
+	maxAge time.Duration
+}
+
+// WithMaxAge allows a lifetime of the sessions other than 24 hours.
+func WithMaxAge(maxAge time.Duration) Option {
+	return func(o *options) {
+		o.maxAge = maxAge
+	}
+}
+
+// New creates a [Sessions] store encrypting the sessions with the first of the keys and decrypting them with any of them.
+// To rotate the keys, prepend the new key and remove the oldest once all sessions encrypted with it expired.
+// The keys must be 16, 24 or 32 bytes long.
+func New[T authentication.Ctx](keys []string, opts ...Option) (*Sessions[T], error) {
+	o := &options{
+		maxAge: 24 * time.Hour,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	c, err := codec.New[*envelope[T]](keys...)
+	if err != nil {
+		return nil, err
+	}
+	return &Sessions[T]{
+		codec:  c,
+		maxAge: o.maxAge,
+	}, nil
+}
+
+// Encode implements [authentication.StatelessSessions].
+// It returns [ErrCookieTooLarge] if the encoded session exceeds the [MaxCookieSize].
+func (s *Sessions[T]) Encode(session T) (string, error) {
+	return s.encode(&envelope[T]{Session: session, ExpiresAt: time.Now().Add(s.maxAge)})
+}
+
+// Update implements [authentication.StatelessSessionsUpdater].
+// The refreshed session keeps the expiration of the previous one, so refreshing does not extend the max age.
+func (s *Sessions[T]) Update(previous string, session T) (string, error) {
+	e, err := s.decode(previous)
+	if err != nil {
+		return "", err
+	}
+	return s.encode(&envelope[T]{Session: session, ExpiresAt: e.ExpiresAt})
+}
+
+func (s *Sessions[T]) encode(e *envelope[T]) (string, error) {
+	data, err := s.codec.Encode(e)
+	if err != nil {
+		return "", err
+	}
+	value := base64.RawURLEncoding.EncodeToString(data)
+	if len(value) > MaxCookieSize {
+		return "", fmt.Errorf("%w: %d bytes", ErrCookieTooLarge, len(value))
+	}
+	return value, nil
+}
+
+// Decode implements [authentication.StatelessSessions].
+func (s *Sessions[T]) Decode(value string) (session T, err error) {
+	e, err := s.decode(value)
+	if err != nil {
+		return session, err
+	}
+	return e.Session, nil
+}
+
+func (s *Sessions[T]) decode(value string) (*envelope[T], error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidEncoding
+	}
+	e, err := s.codec.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	if !time.Now().Before(e.ExpiresAt) {
+		return nil, ErrSessionExpired
+	}
+	return e, nil
+}
+
+// Set implements [authentication.Sessions]. It does nothing, since the session is stored in the cookie.
+func (s *Sessions[T]) Set(string, T) error {
+	return nil
+}
+
+// Get implements [authentication.Sessions]. It always returns [ErrStateless], since the session is stored in the cookie.
+func (s *Sessions[T]) Get(string) (session T, err error) {
+	return session, ErrStateless
+}
+
+// Delete implements [authentication.Sessions]. It does nothing, since the session cookie is deleted by the [authentication.Authenticator].
+func (s *Sessions[T]) Delete(string) error {
+	return nil
+}
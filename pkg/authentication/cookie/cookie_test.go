@@ -0,0 +1,83 @@
+package cookie
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSession struct {
+	Token string
+}
+
+func (s *testSession) IsAuthenticated() bool {
+	return s != nil && s.Token != ""
+}
+
+func TestSessions(t *testing.T) {
+	oldKey := "01234567890123456789012345678901"
+	newKey := "abcdefghijklmnopqrstuvwxyz012345"
+
+	previous, err := New[*testSession]([]string{oldKey})
+	require.NoError(t, err)
+	value, err := previous.Encode(&testSession{Token: "secret-token"})
+	require.NoError(t, err)
+	assert.NotContains(t, value, "secret-token")
+
+	got, err := previous.Decode(value)
+	require.NoError(t, err)
+	assert.Equal(t, "secret-token", got.Token)
+
+	rotated, err := New[*testSession]([]string{newKey, oldKey})
+	require.NoError(t, err)
+	got, err = rotated.Decode(value)
+	require.NoError(t, err)
+	assert.Equal(t, "secret-token", got.Token)
+
+	current, err := New[*testSession]([]string{newKey})
+	require.NoError(t, err)
+	_, err = current.Decode(value)
+	assert.Error(t, err, "old key removed")
+
+	_, err = current.Decode("not base64!")
+	assert.ErrorIs(t, err, ErrInvalidEncoding)
+}
+
+func TestSessions_expired(t *testing.T) {
+	sessions, err := New[*testSession]([]string{"01234567890123456789012345678901"}, WithMaxAge(-time.Second))
+	require.NoError(t, err)
+	value, err := sessions.Encode(&testSession{Token: "token"})
+	require.NoError(t, err)
+	_, err = sessions.Decode(value)
+	assert.ErrorIs(t, err, ErrSessionExpired)
+}
+
+func TestSessions_Update(t *testing.T) {
+	sessions, err := New[*testSession]([]string{"01234567890123456789012345678901"}, WithMaxAge(100*time.Millisecond))
+	require.NoError(t, err)
+	value, err := sessions.Encode(&testSession{Token: "token"})
+	require.NoError(t, err)
+
+	time.Sleep(60 * time.Millisecond)
+	refreshed, err := sessions.Update(value, &testSession{Token: "refreshed"})
+	require.NoError(t, err)
+	got, err := sessions.Decode(refreshed)
+	require.NoError(t, err)
+	assert.Equal(t, "refreshed", got.Token)
+
+	time.Sleep(60 * time.Millisecond)
+	_, err = sessions.Decode(refreshed)
+	assert.ErrorIs(t, err, ErrSessionExpired, "the refreshed session must expire at the original time")
+	_, err = sessions.Update(refreshed, &testSession{Token: "again"})
+	assert.ErrorIs(t, err, ErrSessionExpired)
+}
+
+func TestSessions_tooLarge(t *testing.T) {
+	sessions, err := New[*testSession]([]string{"01234567890123456789012345678901"})
+	require.NoError(t, err)
+	_, err = sessions.Encode(&testSession{Token: strings.Repeat("a", MaxCookieSize)})
+	assert.ErrorIs(t, err, ErrCookieTooLarge)
+}
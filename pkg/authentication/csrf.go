@@ -0,0 +1,140 @@
+package authentication
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"html/template"
+	"net/http"
+)
+
+const (
+	// CSRFFieldName is the name of the form field containing the CSRF token.
+	CSRFFieldName = "csrf_token"
+	// CSRFHeaderName is the name of the header containing the CSRF token, e.g. for XHR and fetch requests.
+	CSRFHeaderName = "X-CSRF-Token"
+)
+
+var (
+	ErrInvalidCSRFToken = errors.New("invalid csrf token")
+)
+
+// WithCSRFProtection requires the logout to be a POST request with a valid CSRF token
+// (e.g. from a form with the [Authenticator.CSRFField]), so other sites cannot log out the user.
+func WithCSRFProtection[T Ctx]() Option[T] {
+	return func(a *Authenticator[T]) {
+		a.csrfProtection = true
+	}
+}
+
+// CSRFToken returns the CSRF token of the user, which needs to be sent with every state changing request
+// checked by the [Interceptor.RequireCSRF] as form field [CSRFFieldName] or header [CSRFHeaderName].
+//
+// The token is bound to a random secret in a cookie (signed double-submit cookie), which is renewed on every login
+// and deleted on logout, so a token is only valid for the authentication session it was issued for.
+// If there is no secret yet, it's created and set as cookie.
+func (a *Authenticator[T]) CSRFToken(w http.ResponseWriter, req *http.Request) (string, error) {
+	if cookie, err := req.Cookie(a.csrfCookieName()); err == nil && cookie.Value != "" {
+		return a.csrfToken(cookie.Value), nil
+	}
+	secret, err := a.setCSRFCookie(w)
+	if err != nil {
+		return "", err
+	}
+	return a.csrfToken(secret), nil
+}
+
+// CSRFField returns a hidden input field containing the [Authenticator.CSRFToken] to be embedded in forms
+// of a [html/template]:
+//
+//	<form method="post" action="/auth/logout">{{ .CSRFField }}<button>Logout</button></form>
+func (a *Authenticator[T]) CSRFField(w http.ResponseWriter, req *http.Request) (template.HTML, error) {
+	token, err := a.CSRFToken(w, req)
+	if err != nil {
+		return "", err
+	}
+	return template.HTML(`<input type="hidden" name="` + CSRFFieldName + `" value="` + template.HTMLEscapeString(token) + `">`), nil
+}
+
+// ValidateCSRF checks the CSRF token of the request (form field [CSRFFieldName] or header [CSRFHeaderName])
+// against the secret of the cookie and returns an [ErrInvalidCSRFToken] if they do not match.
+func (a *Authenticator[T]) ValidateCSRF(req *http.Request) error {
+	cookie, err := req.Cookie(a.csrfCookieName())
+	if err != nil || cookie.Value == "" {
+		return ErrInvalidCSRFToken
+	}
+	token := req.Header.Get(CSRFHeaderName)
+	if token == "" {
+		token = req.PostFormValue(CSRFFieldName)
+	}
+	if !hmac.Equal([]byte(token), []byte(a.csrfToken(cookie.Value))) {
+		return ErrInvalidCSRFToken
+	}
+	return nil
+}
+
+// RequireCSRF will check the CSRF token ([Authenticator.ValidateCSRF]) of all requests with methods
+// other than GET, HEAD, OPTIONS and TRACE and respond with 403 Forbidden if it's invalid.
+func (i *Interceptor[T]) RequireCSRF() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if !isSafeMethod(req.Method) {
+				if err := i.authenticator.ValidateCSRF(req); err != nil {
+					i.authenticator.error(w, req, err, http.StatusForbidden)
+					return
+				}
+			}
+			next.ServeHTTP(w, req)
+		})
+	}
+}
+
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	default:
+		return false
+	}
+}
+
+func (a *Authenticator[T]) csrfToken(secret string) string {
+	mac := hmac.New(sha256.New, a.deriveKey("csrf"))
+	mac.Write([]byte(secret))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (a *Authenticator[T]) csrfCookieName() string {
+	return a.sessionCookieName + ".csrf"
+}
+
+// setCSRFCookie creates a new random secret and sets it as cookie.
+func (a *Authenticator[T]) setCSRFCookie(w http.ResponseWriter) (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	value := base64.RawURLEncoding.EncodeToString(secret)
+	http.SetCookie(w, &http.Cookie{
+		Name:     a.csrfCookieName(),
+		Value:    value,
+		Path:     "/",
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return value, nil
+}
+
+func (a *Authenticator[T]) deleteCSRFCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     a.csrfCookieName(),
+		Path:     "/",
+		MaxAge:   -1,
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
@@ -0,0 +1,155 @@
+package authentication
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthenticator_CSRFToken(t *testing.T) {
+	a, _ := newTestAuthenticator(t, new(testHandler), nil)
+
+	// a new secret is set as cookie, if there is none
+	w := httptest.NewRecorder()
+	token, err := a.CSRFToken(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.NoError(t, err)
+	require.Len(t, w.Result().Cookies(), 1)
+	cookie := w.Result().Cookies()[0]
+	assert.Equal(t, "zitadel.session.csrf", cookie.Name)
+	assert.NotEqual(t, cookie.Value, token, "secret must not be exposed as token")
+
+	// the token of an existing secret stays the same
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(cookie)
+	w = httptest.NewRecorder()
+	field, err := a.CSRFField(w, req)
+	require.NoError(t, err)
+	assert.Empty(t, w.Result().Cookies())
+	assert.Equal(t, `<input type="hidden" name="csrf_token" value="`+token+`">`, string(field))
+}
+
+func TestAuthenticator_deriveKey(t *testing.T) {
+	a, _ := newTestAuthenticator(t, new(testHandler), nil)
+	csrf, lifetime := a.deriveKey("csrf"), a.deriveKey("lifetime")
+	assert.Len(t, csrf, 32)
+	assert.NotEqual(t, csrf, lifetime)
+	assert.NotEqual(t, []byte(a.encryptionKey), csrf, "the encryption key must not be used for the csrf tokens")
+	assert.Equal(t, csrf, a.deriveKey("csrf"))
+}
+
+func TestInterceptor_RequireCSRF(t *testing.T) {
+	a, _ := newTestAuthenticator(t, new(testHandler), nil)
+	w := httptest.NewRecorder()
+	token, err := a.CSRFToken(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.NoError(t, err)
+	cookie := w.Result().Cookies()[0]
+
+	tests := []struct {
+		name     string
+		req      func() *http.Request
+		wantCode int
+	}{
+		{
+			name: "safe method",
+			req: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/", nil)
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "form field",
+			req: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(url.Values{CSRFFieldName: {token}}.Encode()))
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+				req.AddCookie(cookie)
+				return req
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "header",
+			req: func() *http.Request {
+				req := httptest.NewRequest(http.MethodDelete, "/", nil)
+				req.Header.Set(CSRFHeaderName, token)
+				req.AddCookie(cookie)
+				return req
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "missing token",
+			req: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/", nil)
+				req.AddCookie(cookie)
+				return req
+			},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name: "missing cookie",
+			req: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/", nil)
+				req.Header.Set(CSRFHeaderName, token)
+				return req
+			},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name: "token of other secret",
+			req: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/", nil)
+				req.Header.Set(CSRFHeaderName, token)
+				req.AddCookie(&http.Cookie{Name: cookie.Name, Value: "other"})
+				return req
+			},
+			wantCode: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			Middleware(a).RequireCSRF()(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})).ServeHTTP(w, tt.req())
+			assert.Equal(t, tt.wantCode, w.Code)
+		})
+	}
+}
+
+func TestAuthenticator_Logout_csrfProtection(t *testing.T) {
+	a, cookie := newTestAuthenticator(t, new(testHandler), &testCtx{token: "token"})
+	WithCSRFProtection[*testCtx]()(a)
+	w := httptest.NewRecorder()
+	token, err := a.CSRFToken(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.NoError(t, err)
+	csrfCookie := w.Result().Cookies()[0]
+
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/auth/logout", nil)
+	req.AddCookie(cookie)
+	a.Logout(w, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/auth/logout", nil)
+	req.AddCookie(cookie)
+	a.Logout(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	_, err = a.sessions.Get("session")
+	require.NoError(t, err, "session must not be deleted")
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/auth/logout", strings.NewReader(url.Values{CSRFFieldName: {token}}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.AddCookie(cookie)
+	req.AddCookie(csrfCookie)
+	a.Logout(w, req)
+	assert.Equal(t, http.StatusFound, w.Code)
+	_, err = a.sessions.Get("session")
+	assert.Error(t, err, "session not deleted")
+}
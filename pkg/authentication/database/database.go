@@ -0,0 +1,294 @@
+// Package database provides a SQL implementation of the [authentication.Sessions] store for PostgreSQL and MySQL,
+// which allows multiple replicas of an application to share the sessions of their users.
+//
+// The store uses [database/sql], so any driver can be used, e.g. github.com/jackc/pgx/v5/stdlib
+// or github.com/go-sql-driver/mysql. The table can be created with [Sessions.Migrate]
+// and expired sessions can be removed periodically with [Sessions.RunCleanup].
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/exp/slog"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authentication"
+	"github.com/zitadel/zitadel-go/v3/pkg/authentication/internal/codec"
+)
+
+var (
+	ErrNotFound = errors.New("session not found")
+)
+
+// Dialect defines the SQL dialect of the database.
+type Dialect int
+
+const (
+	Postgres Dialect = iota
+	MySQL
+)
+
+// Sessions implements the [authentication.Sessions] and [authentication.SessionTerminator] interfaces by storing
+// the sessions in a SQL database. The sessions are stored as JSON, encrypted and authenticated with AES-GCM,
+// so the tokens cannot be read or altered by anyone with access to the database.
+//
+// For sessions implementing the [authentication.SessionIdentifier] interface, the subject and session id (sid)
+// are stored as well, which allows terminating them on a backchannel or front-channel logout.
+type Sessions[T authentication.Ctx] struct {
+	db      *sql.DB
+	dialect Dialect
+	codec   *codec.Codec[T]
+	table   string
+	ttl     time.Duration
+	sliding bool
+	timeout time.Duration
+	logger  *slog.Logger
+}
+
+// Option allows customization of the [Sessions] store.
+type Option func(*options)
+
+type options struct {
+	table   string
+	ttl     time.Duration
+	sliding bool
+	timeout time.Duration
+	logger  *slog.Logger
+
+	previousKeys []string
+}
+
+// WithPreviousKeys allows decrypting existing sessions with the previous encryption keys after rotating the key.
+// New and updated sessions are always encrypted with the current key.
+func WithPreviousKeys(keys ...string) Option {
+	return func(o *options) {
+		o.previousKeys = append(o.previousKeys, keys...)
+	}
+}
+
+// WithTable allows a table name other than "zitadel_sessions".
+func WithTable(table string) Option {
+	return func(o *options) {
+		o.table = table
+	}
+}
+
+// WithTTL allows a lifetime of the sessions other than 24 hours.
+func WithTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.ttl = ttl
+	}
+}
+
+// WithSlidingExpiration extends the lifetime of a session by the TTL every time it is used,
+// so only inactive sessions expire.
+func WithSlidingExpiration() Option {
+	return func(o *options) {
+		o.sliding = true
+	}
+}
+
+// WithTimeout allows a timeout of the database calls other than 5 seconds.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithLogger allows a logger other than slog.Default() for the [Sessions.RunCleanup].
+//
+// EXPERIMENTAL: Will change to log/slog import after we drop support for Go 1.20
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// New creates a [Sessions] store using the db of the dialect.
+// The encryptionKey must be 16, 24 or 32 bytes long.
+func New[T authentication.Ctx](db *sql.DB, dialect Dialect, encryptionKey string, opts ...Option) (*Sessions[T], error) {
+	o := &options{
+		table:   "zitadel_sessions",
+		ttl:     24 * time.Hour,
+		timeout: 5 * time.Second,
+		logger:  slog.Default(),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	c, err := codec.New[T](append([]string{encryptionKey}, o.previousKeys...)...)
+	if err != nil {
+		return nil, err
+	}
+	return &Sessions[T]{
+		db:      db,
+		dialect: dialect,
+		codec:   c,
+		table:   o.table,
+		ttl:     o.ttl,
+		sliding: o.sliding,
+		timeout: o.timeout,
+		logger:  o.logger,
+	}, nil
+}
+
+// Migrate creates the table (and its indexes) if it does not exist yet.
+func (s *Sessions[T]) Migrate(ctx context.Context) error {
+	for _, stmt := range s.schema() {
+		if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("migration of %s failed: %w", s.table, err)
+		}
+	}
+	return nil
+}
+
+func (s *Sessions[T]) schema() []string {
+	if s.dialect == MySQL {
+		return []string{
+			"CREATE TABLE IF NOT EXISTS " + s.table + " (" +
+				"id VARCHAR(64) NOT NULL PRIMARY KEY, " +
+				"subject VARCHAR(255) NOT NULL DEFAULT '', " +
+				"sid VARCHAR(255) NOT NULL DEFAULT '', " +
+				"data BLOB NOT NULL, " +
+				"expires_at DATETIME(6) NOT NULL, " +
+				"INDEX " + s.table + "_subject_idx (subject), " +
+				"INDEX " + s.table + "_sid_idx (sid), " +
+				"INDEX " + s.table + "_expires_at_idx (expires_at))",
+		}
+	}
+	return []string{
+		"CREATE TABLE IF NOT EXISTS " + s.table + " (" +
+			"id VARCHAR(64) NOT NULL PRIMARY KEY, " +
+			"subject VARCHAR(255) NOT NULL DEFAULT '', " +
+			"sid VARCHAR(255) NOT NULL DEFAULT '', " +
+			"data BYTEA NOT NULL, " +
+			"expires_at TIMESTAMPTZ NOT NULL)",
+		"CREATE INDEX IF NOT EXISTS " + s.table + "_subject_idx ON " + s.table + " (subject)",
+		"CREATE INDEX IF NOT EXISTS " + s.table + "_sid_idx ON " + s.table + " (sid)",
+		"CREATE INDEX IF NOT EXISTS " + s.table + "_expires_at_idx ON " + s.table + " (expires_at)",
+	}
+}
+
+// Set implements [authentication.Sessions] and stores the session for the TTL.
+func (s *Sessions[T]) Set(id string, session T) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	data, err := s.codec.Encode(session)
+	if err != nil {
+		return err
+	}
+	var subject, sid string
+	if identifier, ok := any(session).(authentication.SessionIdentifier); ok {
+		subject, sid = identifier.GetSubject(), identifier.GetSessionID()
+	}
+	stmt := "INSERT INTO " + s.table + " (id, subject, sid, data, expires_at) VALUES ($1, $2, $3, $4, $5) " +
+		"ON CONFLICT (id) DO UPDATE SET subject = EXCLUDED.subject, sid = EXCLUDED.sid, data = EXCLUDED.data, expires_at = EXCLUDED.expires_at"
+	if s.dialect == MySQL {
+		stmt = "INSERT INTO " + s.table + " (id, subject, sid, data, expires_at) VALUES (?, ?, ?, ?, ?) " +
+			"ON DUPLICATE KEY UPDATE subject = VALUES(subject), sid = VALUES(sid), data = VALUES(data), expires_at = VALUES(expires_at)"
+	}
+	_, err = s.db.ExecContext(ctx, stmt, id, subject, sid, data, s.expiration())
+	return err
+}
+
+// Get implements [authentication.Sessions]. If sliding expiration is enabled, the lifetime of the session is extended.
+func (s *Sessions[T]) Get(id string) (session T, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	var data []byte
+	err = s.db.QueryRowContext(ctx, s.query("SELECT data FROM "+s.table+" WHERE id = $1 AND expires_at > $2"), id, time.Now().UTC()).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return session, ErrNotFound
+	}
+	if err != nil {
+		return session, err
+	}
+	session, err = s.codec.Decode(data)
+	if err != nil || !s.sliding {
+		return session, err
+	}
+	_, err = s.db.ExecContext(ctx, s.query("UPDATE "+s.table+" SET expires_at = $1 WHERE id = $2"), s.expiration(), id)
+	return session, err
+}
+
+// Delete implements [authentication.Sessions].
+func (s *Sessions[T]) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	_, err := s.db.ExecContext(ctx, s.query("DELETE FROM "+s.table+" WHERE id = $1"), id)
+	return err
+}
+
+// Terminate implements [authentication.SessionTerminator] by deleting all sessions of the session id,
+// resp. the subject if no session id is provided.
+func (s *Sessions[T]) Terminate(subject, sessionID string) error {
+	if subject == "" && sessionID == "" {
+		return errors.New("subject or session id required")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	column, value := "subject", subject
+	if sessionID != "" {
+		column, value = "sid", sessionID
+	}
+	_, err := s.db.ExecContext(ctx, s.query("DELETE FROM "+s.table+" WHERE "+column+" = $1"), value)
+	return err
+}
+
+// Cleanup deletes all expired sessions and returns the number of deleted sessions.
+func (s *Sessions[T]) Cleanup(ctx context.Context) (int64, error) {
+	result, err := s.db.ExecContext(ctx, s.query("DELETE FROM "+s.table+" WHERE expires_at <= $1"), time.Now().UTC())
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// RunCleanup runs [Sessions.Cleanup] in the provided interval until the ctx is done.
+// Failed cleanups are logged and retried in the next interval.
+// It blocks and is therefore meant to be run in a separate goroutine:
+//
+//	go sessions.RunCleanup(ctx, time.Hour)
+func (s *Sessions[T]) RunCleanup(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			deleted, err := s.Cleanup(ctx)
+			if err != nil {
+				s.logger.Error("cleanup of expired sessions failed", "error", err)
+				continue
+			}
+			s.logger.Debug("expired sessions deleted", "count", deleted)
+		}
+	}
+}
+
+func (s *Sessions[T]) expiration() time.Time {
+	return time.Now().UTC().Add(s.ttl)
+}
+
+// query replaces the (Postgres) placeholders `$n` with `?` for MySQL.
+func (s *Sessions[T]) query(stmt string) string {
+	if s.dialect != MySQL {
+		return stmt
+	}
+	converted := make([]byte, 0, len(stmt))
+	for i := 0; i < len(stmt); i++ {
+		if stmt[i] != '$' {
+			converted = append(converted, stmt[i])
+			continue
+		}
+		converted = append(converted, '?')
+		for i+1 < len(stmt) && stmt[i+1] >= '0' && stmt[i+1] <= '9' {
+			i++
+		}
+	}
+	return string(converted)
+}
@@ -0,0 +1,252 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const key = "01234567890123456789012345678901"
+
+type testSession struct {
+	Token   string
+	Subject string
+	SID     string
+}
+
+func (s *testSession) IsAuthenticated() bool {
+	return s != nil && s.Token != ""
+}
+
+func (s *testSession) GetSubject() string {
+	return s.Subject
+}
+
+func (s *testSession) GetSessionID() string {
+	return s.SID
+}
+
+type row struct {
+	subject, sid string
+	data         []byte
+	expiresAt    time.Time
+}
+
+// fakeDB is a minimal in-memory [driver.Conn], which understands the statements of the [Sessions] store.
+type fakeDB struct {
+	mu         sync.Mutex
+	rows       map[string]*row
+	statements []string
+}
+
+var fakeDBs sync.Map
+
+func init() {
+	sql.Register("fake", fakeDriver{})
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	db, ok := fakeDBs.Load(name)
+	if !ok {
+		return nil, errors.New("unknown db")
+	}
+	return db.(*fakeDB), nil
+}
+
+func newFakeDB(t *testing.T) (*sql.DB, *fakeDB) {
+	t.Helper()
+	fake := &fakeDB{rows: make(map[string]*row)}
+	fakeDBs.Store(t.Name(), fake)
+	db, err := sql.Open("fake", t.Name())
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db, fake
+}
+
+func (f *fakeDB) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (f *fakeDB) Close() error                        { return nil }
+func (f *fakeDB) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (f *fakeDB) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.statements = append(f.statements, query)
+	var affected int64
+	switch {
+	case strings.HasPrefix(query, "CREATE"):
+	case strings.HasPrefix(query, "INSERT"):
+		f.rows[args[0].Value.(string)] = &row{
+			subject:   args[1].Value.(string),
+			sid:       args[2].Value.(string),
+			data:      args[3].Value.([]byte),
+			expiresAt: args[4].Value.(time.Time),
+		}
+		affected = 1
+	case strings.HasPrefix(query, "UPDATE"):
+		if r, ok := f.rows[args[1].Value.(string)]; ok {
+			r.expiresAt = args[0].Value.(time.Time)
+			affected = 1
+		}
+	case strings.HasPrefix(query, "DELETE"):
+		for id, r := range f.rows {
+			var match bool
+			switch {
+			case strings.Contains(query, "WHERE id"):
+				match = id == args[0].Value
+			case strings.Contains(query, "WHERE subject"):
+				match = r.subject == args[0].Value
+			case strings.Contains(query, "WHERE sid"):
+				match = r.sid == args[0].Value
+			case strings.Contains(query, "WHERE expires_at <="):
+				match = !r.expiresAt.After(args[0].Value.(time.Time))
+			}
+			if match {
+				delete(f.rows, id)
+				affected++
+			}
+		}
+	default:
+		return nil, errors.New("unexpected statement: " + query)
+	}
+	return driver.RowsAffected(affected), nil
+}
+
+func (f *fakeDB) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.statements = append(f.statements, query)
+	if !strings.HasPrefix(query, "SELECT data") {
+		return nil, errors.New("unexpected query: " + query)
+	}
+	rows := new(fakeRows)
+	if r, ok := f.rows[args[0].Value.(string)]; ok && r.expiresAt.After(args[1].Value.(time.Time)) {
+		rows.data = [][]byte{r.data}
+	}
+	return rows, nil
+}
+
+type fakeRows struct {
+	data [][]byte
+}
+
+func (r *fakeRows) Columns() []string { return []string{"data"} }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.data) == 0 {
+		return io.EOF
+	}
+	dest[0], r.data = r.data[0], r.data[1:]
+	return nil
+}
+
+func TestSessions(t *testing.T) {
+	tests := []struct {
+		name          string
+		dialect       Dialect
+		wantStatement string
+	}{
+		{
+			name:          "postgres",
+			dialect:       Postgres,
+			wantStatement: "SELECT data FROM sessions WHERE id = $1 AND expires_at > $2",
+		},
+		{
+			name:          "mysql",
+			dialect:       MySQL,
+			wantStatement: "SELECT data FROM sessions WHERE id = ? AND expires_at > ?",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, fake := newFakeDB(t)
+			sessions, err := New[*testSession](db, tt.dialect, key, WithTable("sessions"), WithTTL(time.Hour))
+			require.NoError(t, err)
+			require.NoError(t, sessions.Migrate(context.Background()))
+
+			require.NoError(t, sessions.Set("id", &testSession{Token: "secret-token", Subject: "user", SID: "sid"}))
+			assert.NotContains(t, string(fake.rows["id"].data), "secret-token", "tokens must be encrypted")
+			assert.Equal(t, "user", fake.rows["id"].subject)
+			assert.Equal(t, "sid", fake.rows["id"].sid)
+			assert.WithinDuration(t, time.Now().Add(time.Hour), fake.rows["id"].expiresAt, time.Minute)
+
+			got, err := sessions.Get("id")
+			require.NoError(t, err)
+			assert.Equal(t, &testSession{Token: "secret-token", Subject: "user", SID: "sid"}, got)
+			assert.Contains(t, fake.statements, tt.wantStatement)
+
+			require.NoError(t, sessions.Delete("id"))
+			_, err = sessions.Get("id")
+			assert.ErrorIs(t, err, ErrNotFound)
+		})
+	}
+}
+
+func TestSessions_expiration(t *testing.T) {
+	db, fake := newFakeDB(t)
+	sessions, err := New[*testSession](db, Postgres, key, WithTTL(time.Hour), WithSlidingExpiration())
+	require.NoError(t, err)
+	require.NoError(t, sessions.Set("active", &testSession{Token: "token"}))
+	require.NoError(t, sessions.Set("expired", &testSession{Token: "token"}))
+
+	fake.rows["active"].expiresAt = time.Now().Add(time.Minute)
+	fake.rows["expired"].expiresAt = time.Now().Add(-time.Minute)
+
+	_, err = sessions.Get("active")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), fake.rows["active"].expiresAt, time.Minute, "sliding expiration")
+	_, err = sessions.Get("expired")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	deleted, err := sessions.Cleanup(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	assert.Contains(t, fake.rows, "active")
+}
+
+func TestSessions_Terminate(t *testing.T) {
+	db, fake := newFakeDB(t)
+	sessions, err := New[*testSession](db, Postgres, key)
+	require.NoError(t, err)
+	require.NoError(t, sessions.Set("1", &testSession{Token: "token", Subject: "user", SID: "sid1"}))
+	require.NoError(t, sessions.Set("2", &testSession{Token: "token", Subject: "user", SID: "sid2"}))
+	require.NoError(t, sessions.Set("3", &testSession{Token: "token", Subject: "other", SID: "sid3"}))
+
+	require.NoError(t, sessions.Terminate("user", "sid1"))
+	assert.NotContains(t, fake.rows, "1")
+	assert.Contains(t, fake.rows, "2")
+
+	require.NoError(t, sessions.Terminate("user", ""))
+	assert.NotContains(t, fake.rows, "2")
+	assert.Contains(t, fake.rows, "3")
+
+	assert.Error(t, sessions.Terminate("", ""))
+}
+
+func TestSessions_RunCleanup(t *testing.T) {
+	db, fake := newFakeDB(t)
+	sessions, err := New[*testSession](db, MySQL, key)
+	require.NoError(t, err)
+	require.NoError(t, sessions.Set("expired", &testSession{Token: "token"}))
+	fake.rows["expired"].expiresAt = time.Now().Add(-time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err = sessions.RunCleanup(ctx, 10*time.Millisecond)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	assert.NotContains(t, fake.rows, "expired")
+	assert.Contains(t, fake.statements, "DELETE FROM zitadel_sessions WHERE expires_at <= ?")
+}
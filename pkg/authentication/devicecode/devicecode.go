@@ -0,0 +1,282 @@
+// Package devicecode provides the OAuth 2.0 Device Authorization Grant (device flow) for CLI applications,
+// which cannot open a browser and receive a redirect themselves.
+// The user is asked to open the verification URI on any device and enter the displayed code,
+// while the application polls the token endpoint until the user authorized (or denied) it.
+//
+// The tokens are persisted in a [Store], so the user only needs to authenticate again
+// once the tokens expired and cannot be refreshed:
+//
+//	flow, err := devicecode.New(ctx, zitadel.New("my-instance.zitadel.cloud"), "clientID",
+//		devicecode.WithStore(devicecode.FileStore(filepath.Join(home, ".my-cli", "tokens.json"))),
+//	)
+//	token, err := flow.Token(ctx)
+package devicecode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/zitadel/oidc/v3/pkg/client"
+	"github.com/zitadel/oidc/v3/pkg/client/rp"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/zitadel"
+)
+
+var (
+	ErrAccessDenied = errors.New("device authorization denied by the user")
+	ErrExpired      = errors.New("device code expired before the user authorized it")
+)
+
+const (
+	// defaultInterval is the polling interval (in seconds) if none is provided by the authorization server (RFC 8628, section 3.2).
+	defaultInterval = 5
+	// slowDownIncrease is the increase of the polling interval (in seconds) on a slow_down response (RFC 8628, section 3.5).
+	slowDownIncrease = 5
+)
+
+// Authorization contains the information the user needs to authorize the device.
+type Authorization struct {
+	UserCode string
+	// VerificationURI is the URI, where the user needs to enter the UserCode.
+	VerificationURI string
+	// VerificationURIComplete is the VerificationURI including the UserCode, e.g. to be displayed as QR code.
+	VerificationURIComplete string
+	ExpiresAt               time.Time
+}
+
+// Prompt displays the [Authorization] to the user.
+type Prompt func(ctx context.Context, authorization *Authorization) error
+
+// PrintPrompt returns a [Prompt] printing the verification URI and code to w.
+func PrintPrompt(w io.Writer) Prompt {
+	return func(_ context.Context, authorization *Authorization) error {
+		uri := authorization.VerificationURIComplete
+		if uri == "" {
+			uri = authorization.VerificationURI
+		}
+		_, err := fmt.Fprintf(w, "To authenticate, open %s and enter the code %s\n", uri, authorization.UserCode)
+		return err
+	}
+}
+
+// Flow runs the device authorization flow.
+type Flow struct {
+	relyingParty rp.RelyingParty
+	scopes       []string
+	store        Store
+	prompt       Prompt
+
+	// intervalUnit is the unit of the polling interval, which is only changed for tests.
+	intervalUnit time.Duration
+}
+
+// Option allows customization of the [Flow].
+type Option func(*options)
+
+type options struct {
+	scopes       []string
+	clientSecret string
+	store        Store
+	prompt       Prompt
+	httpClient   *http.Client
+}
+
+// WithScopes allows scopes other than `"openid", "profile", "email", "offline_access"`.
+// Without the `offline_access` scope, no refresh token is issued and the user needs to authenticate
+// again every time the access token expired.
+func WithScopes(scopes ...string) Option {
+	return func(o *options) {
+		o.scopes = scopes
+	}
+}
+
+// WithClientSecret authenticates the application with a client secret, if it's not a public client (auth method none).
+func WithClientSecret(clientSecret string) Option {
+	return func(o *options) {
+		o.clientSecret = clientSecret
+	}
+}
+
+// WithStore allows persisting the tokens other than only in memory, e.g. with the [FileStore].
+func WithStore(store Store) Option {
+	return func(o *options) {
+		o.store = store
+	}
+}
+
+// WithPrompt allows displaying the code to the user other than printing it to stderr.
+func WithPrompt(prompt Prompt) Option {
+	return func(o *options) {
+		o.prompt = prompt
+	}
+}
+
+// WithHTTPClient allows a http.Client other than http.DefaultClient for the calls to ZITADEL.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = httpClient
+	}
+}
+
+// New creates the device authorization [Flow] for the ZITADEL instance and the client_id of the (native) application.
+func New(ctx context.Context, zitadel *zitadel.Zitadel, clientID string, opts ...Option) (*Flow, error) {
+	o := &options{
+		scopes: []string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
+		store:  new(MemoryStore),
+		prompt: PrintPrompt(os.Stderr),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	rpOptions := make([]rp.Option, 0, 1)
+	if o.httpClient != nil {
+		rpOptions = append(rpOptions, rp.WithHTTPClient(o.httpClient))
+	}
+	relyingParty, err := rp.NewRelyingPartyOIDC(ctx, zitadel.Origin(), clientID, o.clientSecret, "", o.scopes, rpOptions...)
+	if err != nil {
+		return nil, err
+	}
+	return &Flow{
+		relyingParty: relyingParty,
+		scopes:       o.scopes,
+		store:        o.store,
+		prompt:       o.prompt,
+		intervalUnit: time.Second,
+	}, nil
+}
+
+// Token returns a valid token of the [Store]. If the stored token is expired, it's refreshed.
+// If there's none or it cannot be refreshed, a new device authorization is started ([Flow.Authenticate]).
+func (f *Flow) Token(ctx context.Context) (*Token, error) {
+	token, err := f.store.Load()
+	if err != nil {
+		return nil, err
+	}
+	if token.Valid() {
+		return token, nil
+	}
+	if token != nil && token.RefreshToken != "" {
+		if refreshed, err := f.Refresh(ctx, token); err == nil {
+			return refreshed, nil
+		}
+	}
+	return f.Authenticate(ctx)
+}
+
+// Authenticate runs a new device authorization: it prompts the user with the code, polls the token endpoint
+// until the user authorized the device and saves the token in the [Store].
+// It returns [ErrAccessDenied] if the user denied the authorization and [ErrExpired] if the code expired.
+func (f *Flow) Authenticate(ctx context.Context) (*Token, error) {
+	resp, err := rp.DeviceAuthorization(ctx, f.scopes, f.relyingParty, nil)
+	if err != nil {
+		return nil, err
+	}
+	authorization := &Authorization{
+		UserCode:                resp.UserCode,
+		VerificationURI:         resp.VerificationURI,
+		VerificationURIComplete: resp.VerificationURIComplete,
+		ExpiresAt:               time.Now().Add(time.Duration(resp.ExpiresIn) * time.Second),
+	}
+	if err = f.prompt(ctx, authorization); err != nil {
+		return nil, err
+	}
+	tokens, err := f.poll(ctx, resp)
+	if err != nil {
+		return nil, err
+	}
+	token := newToken(tokens.AccessToken, tokens.TokenType, tokens.RefreshToken, tokens.IDToken, tokens.ExpiresIn)
+	return token, f.store.Save(token)
+}
+
+// Refresh exchanges the refresh token of the token for a new token and saves it in the [Store].
+func (f *Flow) Refresh(ctx context.Context, token *Token) (*Token, error) {
+	tokens, err := rp.RefreshTokens[*oidc.IDTokenClaims](ctx, f.relyingParty, token.RefreshToken, "", "")
+	if err != nil {
+		return nil, err
+	}
+	refreshed := &Token{
+		AccessToken:  tokens.AccessToken,
+		TokenType:    tokens.TokenType,
+		RefreshToken: tokens.RefreshToken,
+		IDToken:      tokens.IDToken,
+		Expiry:       tokens.Expiry,
+	}
+	if refreshed.RefreshToken == "" {
+		refreshed.RefreshToken = token.RefreshToken
+	}
+	if refreshed.IDToken == "" {
+		refreshed.IDToken = token.IDToken
+	}
+	return refreshed, f.store.Save(refreshed)
+}
+
+// poll polls the token endpoint as defined in RFC 8628, section 3.4 and 3.5:
+// it waits the interval between the requests and increases it by 5 seconds on every slow_down response.
+func (f *Flow) poll(ctx context.Context, authorization *oidc.DeviceAuthorizationResponse) (*oidc.AccessTokenResponse, error) {
+	interval := defaultInterval * f.intervalUnit
+	if authorization.Interval > 0 {
+		interval = time.Duration(authorization.Interval) * f.intervalUnit
+	}
+	if authorization.ExpiresIn > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(authorization.ExpiresIn)*f.intervalUnit)
+		defer cancel()
+	}
+	req := &client.DeviceAccessTokenRequest{
+		ClientCredentialsRequest: &oidc.ClientCredentialsRequest{
+			ClientID:     f.relyingParty.OAuthConfig().ClientID,
+			ClientSecret: f.relyingParty.OAuthConfig().ClientSecret,
+		},
+		DeviceAccessTokenRequest: oidc.DeviceAccessTokenRequest{
+			GrantType:  oidc.GrantTypeDeviceCode,
+			DeviceCode: authorization.DeviceCode,
+		},
+	}
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, ErrExpired
+			}
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		resp, err := client.CallDeviceAccessTokenEndpoint(ctx, req, tokenEndpoint{f.relyingParty})
+		if err == nil {
+			return resp, nil
+		}
+		var oidcErr *oidc.Error
+		if !errors.As(err, &oidcErr) {
+			return nil, err
+		}
+		switch oidcErr.ErrorType {
+		case oidc.AuthorizationPending:
+		case oidc.SlowDown:
+			interval += slowDownIncrease * f.intervalUnit
+		case oidc.AccessDenied:
+			return nil, ErrAccessDenied
+		case oidc.ExpiredToken:
+			return nil, ErrExpired
+		default:
+			return nil, err
+		}
+		timer.Reset(interval)
+	}
+}
+
+// tokenEndpoint implements the [client.TokenEndpointCaller] for the [rp.RelyingParty].
+type tokenEndpoint struct {
+	rp.RelyingParty
+}
+
+func (t tokenEndpoint) TokenEndpoint() string {
+	return t.OAuthConfig().Endpoint.TokenURL
+}
@@ -0,0 +1,228 @@
+package devicecode
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/zitadel"
+)
+
+// testServer serves the device authorization and responds to the token requests with the errors in order,
+// before it issues the token.
+type testServer struct {
+	*httptest.Server
+	mu         sync.Mutex
+	errors     []*oidc.Error
+	polls      []time.Time
+	refreshed  bool
+	deviceResp oidc.DeviceAuthorizationResponse
+}
+
+func newTestServer(t *testing.T, errs ...*oidc.Error) *testServer {
+	t.Helper()
+	s := &testServer{
+		errors: errs,
+		deviceResp: oidc.DeviceAuthorizationResponse{
+			DeviceCode:              "deviceCode",
+			UserCode:                "ABCD-EFGH",
+			VerificationURI:         "https://zitadel.example.com/device",
+			VerificationURIComplete: "https://zitadel.example.com/device?user_code=ABCD-EFGH",
+			ExpiresIn:               300,
+			Interval:                1,
+		},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *testServer) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	switch r.URL.Path {
+	case oidc.DiscoveryEndpoint:
+		json.NewEncoder(w).Encode(&oidc.DiscoveryConfiguration{
+			Issuer:                      s.URL,
+			TokenEndpoint:               s.URL + "/oauth/v2/token",
+			DeviceAuthorizationEndpoint: s.URL + "/oauth/v2/device_authorization",
+			JwksURI:                     s.URL + "/oauth/v2/keys",
+		})
+	case "/oauth/v2/device_authorization":
+		json.NewEncoder(w).Encode(&s.deviceResp)
+	case "/oauth/v2/token":
+		r.ParseForm()
+		if r.PostForm.Get("grant_type") == string(oidc.GrantTypeRefreshToken) {
+			s.refreshed = true
+			json.NewEncoder(w).Encode(&oidc.AccessTokenResponse{AccessToken: "refreshed", TokenType: oidc.BearerToken, ExpiresIn: 3600})
+			return
+		}
+		if r.PostForm.Get("device_code") != "deviceCode" || r.PostForm.Get("client_id") != "clientID" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(oidc.ErrInvalidGrant())
+			return
+		}
+		s.polls = append(s.polls, time.Now())
+		if len(s.errors) > 0 {
+			err := s.errors[0]
+			s.errors = s.errors[1:]
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(err)
+			return
+		}
+		json.NewEncoder(w).Encode(&oidc.AccessTokenResponse{
+			AccessToken:  "accessToken",
+			TokenType:    oidc.BearerToken,
+			RefreshToken: "refreshToken",
+			ExpiresIn:    3600,
+		})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newTestFlow(t *testing.T, server *testServer, opts ...Option) *Flow {
+	t.Helper()
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	flow, err := New(context.Background(), zitadel.New(u.Hostname(), zitadel.WithInsecure(u.Port())), "clientID", opts...)
+	require.NoError(t, err)
+	flow.intervalUnit = 10 * time.Millisecond
+	return flow
+}
+
+func TestFlow_Authenticate(t *testing.T) {
+	tests := []struct {
+		name      string
+		errors    []*oidc.Error
+		wantErr   error
+		wantPolls int
+	}{
+		{
+			name:      "authorized",
+			errors:    []*oidc.Error{oidc.ErrAuthorizationPending(), oidc.ErrAuthorizationPending()},
+			wantPolls: 3,
+		},
+		{
+			name:      "denied",
+			errors:    []*oidc.Error{oidc.ErrAuthorizationPending(), {ErrorType: oidc.AccessDenied}},
+			wantErr:   ErrAccessDenied,
+			wantPolls: 2,
+		},
+		{
+			name:      "expired",
+			errors:    []*oidc.Error{{ErrorType: oidc.ExpiredToken}},
+			wantErr:   ErrExpired,
+			wantPolls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, tt.errors...)
+			var prompted *Authorization
+			store := new(MemoryStore)
+			flow := newTestFlow(t, server, WithStore(store), WithPrompt(func(_ context.Context, authorization *Authorization) error {
+				prompted = authorization
+				return nil
+			}))
+
+			token, err := flow.Authenticate(context.Background())
+			require.NotNil(t, prompted)
+			assert.Equal(t, "ABCD-EFGH", prompted.UserCode)
+			assert.Equal(t, "https://zitadel.example.com/device?user_code=ABCD-EFGH", prompted.VerificationURIComplete)
+			assert.Len(t, server.polls, tt.wantPolls)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "accessToken", token.AccessToken)
+			assert.True(t, token.Valid())
+			stored, err := store.Load()
+			require.NoError(t, err)
+			assert.Equal(t, token, stored)
+		})
+	}
+}
+
+func TestFlow_Authenticate_slowDown(t *testing.T) {
+	server := newTestServer(t, oidc.ErrSlowDown(), oidc.ErrAuthorizationPending())
+	flow := newTestFlow(t, server, WithPrompt(func(context.Context, *Authorization) error { return nil }))
+
+	_, err := flow.Authenticate(context.Background())
+	require.NoError(t, err)
+	require.Len(t, server.polls, 3)
+	// the interval of 1 (10ms) is increased by 5 (50ms) after the slow_down
+	assert.GreaterOrEqual(t, server.polls[2].Sub(server.polls[1]), 60*time.Millisecond)
+}
+
+func TestFlow_Authenticate_codeExpired(t *testing.T) {
+	server := newTestServer(t)
+	server.deviceResp.ExpiresIn = 1
+	server.deviceResp.Interval = 2
+	flow := newTestFlow(t, server, WithPrompt(func(context.Context, *Authorization) error { return nil }))
+
+	_, err := flow.Authenticate(context.Background())
+	assert.ErrorIs(t, err, ErrExpired)
+	assert.Empty(t, server.polls)
+}
+
+func TestFlow_Token(t *testing.T) {
+	tests := []struct {
+		name          string
+		stored        *Token
+		wantToken     string
+		wantRefreshed bool
+		wantPolls     int
+	}{
+		{
+			name:      "valid",
+			stored:    &Token{AccessToken: "stored", Expiry: time.Now().Add(time.Hour)},
+			wantToken: "stored",
+		},
+		{
+			name:          "expired",
+			stored:        &Token{AccessToken: "stored", RefreshToken: "refreshToken", Expiry: time.Now().Add(-time.Minute)},
+			wantToken:     "refreshed",
+			wantRefreshed: true,
+		},
+		{
+			name:      "none",
+			wantToken: "accessToken",
+			wantPolls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t)
+			store := FileStore(filepath.Join(t.TempDir(), "cli", "tokens.json"))
+			if tt.stored != nil {
+				require.NoError(t, store.Save(tt.stored))
+			}
+			flow := newTestFlow(t, server, WithStore(store), WithPrompt(func(context.Context, *Authorization) error { return nil }))
+
+			token, err := flow.Token(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantToken, token.AccessToken)
+			assert.Equal(t, tt.wantRefreshed, server.refreshed)
+			assert.Len(t, server.polls, tt.wantPolls)
+			stored, err := store.Load()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantToken, stored.AccessToken)
+			if tt.wantRefreshed {
+				assert.Equal(t, "refreshToken", stored.RefreshToken, "refresh token must be kept")
+			}
+		})
+	}
+}
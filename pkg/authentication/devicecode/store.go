@@ -0,0 +1,111 @@
+package devicecode
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// expiryDelta is the time before the expiry, when a token is already considered expired.
+const expiryDelta = 10 * time.Second
+
+// Token is the token set of the device authorization, which is persisted in the [Store].
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	TokenType    string    `json:"token_type,omitempty"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	IDToken      string    `json:"id_token,omitempty"`
+	Expiry       time.Time `json:"expiry,omitempty"`
+}
+
+func newToken(accessToken, tokenType, refreshToken, idToken string, expiresIn uint64) *Token {
+	token := &Token{
+		AccessToken:  accessToken,
+		TokenType:    tokenType,
+		RefreshToken: refreshToken,
+		IDToken:      idToken,
+	}
+	if expiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(expiresIn) * time.Second)
+	}
+	return token
+}
+
+// Valid returns if the token has an access token, which is not expired.
+func (t *Token) Valid() bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	return t.Expiry.IsZero() || time.Now().Add(expiryDelta).Before(t.Expiry)
+}
+
+// OAuth2 returns the token as [oauth2.Token], e.g. for an [oauth2.StaticTokenSource].
+func (t *Token) OAuth2() *oauth2.Token {
+	return &oauth2.Token{
+		AccessToken:  t.AccessToken,
+		TokenType:    t.TokenType,
+		RefreshToken: t.RefreshToken,
+		Expiry:       t.Expiry,
+	}
+}
+
+// Store persists the [Token] of the device authorization, e.g. in a file or the keychain of the OS.
+type Store interface {
+	// Load returns the persisted token or nil (without an error) if there is none.
+	Load() (*Token, error)
+	Save(token *Token) error
+}
+
+// MemoryStore implements the [Store] by keeping the token in memory only.
+type MemoryStore struct {
+	mu    sync.Mutex
+	token *Token
+}
+
+func (s *MemoryStore) Load() (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.token, nil
+}
+
+func (s *MemoryStore) Save(token *Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = token
+	return nil
+}
+
+// FileStore implements the [Store] by persisting the token as JSON in the file of the path,
+// which is only readable by the current user.
+type FileStore string
+
+func (s FileStore) Load() (*Token, error) {
+	data, err := os.ReadFile(string(s))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	token := new(Token)
+	if err = json.Unmarshal(data, token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func (s FileStore) Save(token *Token) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(string(s)), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(string(s), data, 0o600)
+}
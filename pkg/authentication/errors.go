@@ -0,0 +1,118 @@
+package authentication
+
+import (
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+var (
+	ErrNotAuthenticated           = errors.New("not authenticated")
+	ErrInsufficientAuthentication = errors.New("insufficient authentication")
+	ErrMethodNotAllowed           = errors.New("method not allowed")
+)
+
+// ErrorHandler responds to the errors of the [Authenticator] and its [Interceptor], e.g. failed callbacks
+// or forbidden requests. The statusCode is the suggested status of the response.
+type ErrorHandler func(w http.ResponseWriter, req *http.Request, err error, statusCode int)
+
+// DefaultErrorHandler responds with the error message as plain text.
+func DefaultErrorHandler(w http.ResponseWriter, _ *http.Request, err error, statusCode int) {
+	http.Error(w, err.Error(), statusCode)
+}
+
+// JSONErrorHandler responds with the error as JSON object, e.g. `{"error":"access_denied","error_description":"..."}`
+// for a [CallbackError] or `{"error":"not authenticated"}` otherwise.
+func JSONErrorHandler(w http.ResponseWriter, _ *http.Request, err error, statusCode int) {
+	resp := struct {
+		Error       string `json:"error"`
+		Description string `json:"error_description,omitempty"`
+	}{
+		Error: err.Error(),
+	}
+	var callbackErr *CallbackError
+	if errors.As(err, &callbackErr) {
+		resp.Error = callbackErr.ErrorType
+		resp.Description = callbackErr.Description
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// WantsJSON returns if the request is an XHR / fetch request, which expects a JSON response instead of HTML,
+// e.g. to choose between the [JSONErrorHandler] and rendering an error page.
+func WantsJSON(req *http.Request) bool {
+	if req.Header.Get("X-Requested-With") == "XMLHttpRequest" {
+		return true
+	}
+	for _, accept := range strings.Split(req.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accept))
+		if err != nil {
+			continue
+		}
+		switch mediaType {
+		case "application/json":
+			return true
+		case "text/html":
+			return false
+		}
+	}
+	return false
+}
+
+// CallbackError is the error returned by the Login UI on the callback, e.g. `access_denied` if the user aborted the login.
+type CallbackError struct {
+	ErrorType   string
+	Description string
+}
+
+func (e *CallbackError) Error() string {
+	if e.Description == "" {
+		return e.ErrorType
+	}
+	return e.ErrorType + ": " + e.Description
+}
+
+// WithErrorHandler allows responding to errors other than with plain text ([DefaultErrorHandler]),
+// e.g. by rendering a template or with the [JSONErrorHandler] for XHR requests.
+func WithErrorHandler[T Ctx](handler ErrorHandler) Option[T] {
+	return func(a *Authenticator[T]) {
+		a.errorHandler = handler
+	}
+}
+
+// WithUnauthenticatedHandler allows handling unauthenticated requests of the [Interceptor.RequireAuthentication]
+// other than by starting a new authentication, e.g. to respond with 401 Unauthorized to XHR requests:
+//
+//	func(w http.ResponseWriter, req *http.Request) {
+//		if authentication.WantsJSON(req) {
+//			authentication.JSONErrorHandler(w, req, authentication.ErrNotAuthenticated, http.StatusUnauthorized)
+//			return
+//		}
+//		authN.Authenticate(w, req, req.RequestURI)
+//	}
+func WithUnauthenticatedHandler[T Ctx](handler http.HandlerFunc) Option[T] {
+	return func(a *Authenticator[T]) {
+		a.unauthenticatedHandler = handler
+	}
+}
+
+// callbackError returns the [CallbackError] of the request, if the Login UI returned one.
+func callbackError(req *http.Request) *CallbackError {
+	errorType := req.FormValue("error")
+	if errorType == "" {
+		return nil
+	}
+	return &CallbackError{
+		ErrorType:   errorType,
+		Description: req.FormValue("error_description"),
+	}
+}
+
+func (a *Authenticator[T]) error(w http.ResponseWriter, req *http.Request, err error, statusCode int) {
+	a.errorHandler(w, req, err, statusCode)
+}
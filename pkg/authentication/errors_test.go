@@ -0,0 +1,110 @@
+package authentication
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthenticator_Callback_errorHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		callback *testCtx
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:     "access denied",
+			target:   "/auth/callback?error=access_denied&error_description=user+aborted",
+			wantCode: http.StatusForbidden,
+			wantBody: `{"error":"access_denied","error_description":"user aborted"}`,
+		},
+		{
+			name:     "invalid request",
+			target:   "/auth/callback?error=invalid_request",
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"error":"invalid_request"}`,
+		},
+		{
+			name:     "not authenticated",
+			target:   "/auth/callback?code=code",
+			wantCode: http.StatusForbidden,
+			wantBody: `{"error":"not authenticated"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, _ := newTestAuthenticator(t, &stepUpHandler{callback: tt.callback}, nil)
+			WithErrorHandler[*testCtx](JSONErrorHandler)(a)
+
+			w := httptest.NewRecorder()
+			a.Callback(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			assert.Equal(t, tt.wantCode, w.Code)
+			assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+			assert.JSONEq(t, tt.wantBody, w.Body.String())
+		})
+	}
+}
+
+func TestInterceptor_RequireAuthentication_unauthenticatedHandler(t *testing.T) {
+	handler := new(stepUpHandler)
+	a, _ := newTestAuthenticator(t, handler, nil)
+	WithUnauthenticatedHandler[*testCtx](func(w http.ResponseWriter, req *http.Request) {
+		if WantsJSON(req) {
+			JSONErrorHandler(w, req, ErrNotAuthenticated, http.StatusUnauthorized)
+			return
+		}
+		a.Authenticate(w, req, req.RequestURI)
+	})(a)
+	mw := Middleware(a).RequireAuthentication()(http.NotFoundHandler())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/orders", nil)
+	req.Header.Set("Accept", "application/json")
+	w := httptest.NewRecorder()
+	mw.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Empty(t, handler.state, "authentication must not be started")
+
+	w = httptest.NewRecorder()
+	mw.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders", nil))
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.NotEmpty(t, handler.state)
+}
+
+func TestWantsJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    bool
+	}{
+		{
+			name: "none",
+		},
+		{
+			name:    "browser navigation",
+			headers: map[string]string{"Accept": "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8"},
+		},
+		{
+			name:    "json",
+			headers: map[string]string{"Accept": "application/json, text/plain, */*"},
+			want:    true,
+		},
+		{
+			name:    "xhr",
+			headers: map[string]string{"X-Requested-With": "XMLHttpRequest"},
+			want:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+			assert.Equal(t, tt.want, WantsJSON(req))
+		})
+	}
+}
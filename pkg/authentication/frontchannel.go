@@ -0,0 +1,63 @@
+package authentication
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// IssuerProvider is an optional interface of a [Handler], which provides the issuer of the identity provider.
+// It's required for the front-channel logout.
+type IssuerProvider interface {
+	Issuer() string
+}
+
+// FrontChannelLogout handles the OpenID Connect Front-Channel Logout requests of the identity provider,
+// which are rendered by the browser of the user in an iframe of the Login UI.
+// It can be used for deployments where the identity provider cannot reach the application for a [Authenticator.BackChannelLogout].
+// The `/auth/frontchannel-logout` endpoint needs to be registered as front-channel logout URI of the application.
+//
+// If the request contains the iss and sid parameters, the issuer is validated against the [Handler] ([IssuerProvider])
+// and the sessions of the sid are deleted from the [Sessions] store ([SessionTerminator]).
+// Otherwise, the session of the session cookie (if sent by the browser) is deleted.
+// The response can only be framed by the identity provider.
+func (a *Authenticator[T]) FrontChannelLogout(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Cache-Control", "no-cache, no-store")
+	w.Header().Set("Pragma", "no-cache")
+	provider, ok := a.authN.(IssuerProvider)
+	if !ok {
+		http.Error(w, "front-channel logout not supported by authentication handler", http.StatusNotImplemented)
+		return
+	}
+	issuer := provider.Issuer()
+	if origin, err := url.Parse(issuer); err == nil {
+		w.Header().Set("Content-Security-Policy", "frame-ancestors "+origin.Scheme+"://"+origin.Host)
+	}
+
+	iss, sid := req.URL.Query().Get("iss"), req.URL.Query().Get("sid")
+	if iss != "" || sid != "" {
+		if iss != issuer || sid == "" {
+			http.Error(w, "invalid iss or sid", http.StatusBadRequest)
+			return
+		}
+		terminator, ok := a.sessions.(SessionTerminator)
+		if !ok {
+			http.Error(w, "front-channel logout not supported by session store", http.StatusNotImplemented)
+			return
+		}
+		if err := terminator.Terminate("", sid); err != nil {
+			a.logger.Error("unable to terminate sessions", "error", err, "sid", sid)
+			http.Error(w, "sessions could not be terminated", http.StatusInternalServerError)
+			return
+		}
+	} else if sessionID, _, err := a.session(req); err == nil {
+		if err = a.sessions.Delete(sessionID); err != nil {
+			a.logger.Error("unable to delete session", "error", err, "id", sessionID)
+			http.Error(w, "session could not be deleted", http.StatusInternalServerError)
+			return
+		}
+	}
+	a.deleteSessionCookie(w)
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("<!DOCTYPE html><html><head><title>Logged out</title></head><body></body></html>"))
+}
@@ -0,0 +1,81 @@
+package authentication
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (h *testHandler) Issuer() string {
+	return "https://zitadel.example.com"
+}
+
+func TestAuthenticator_FrontChannelLogout(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		cookie       bool
+		wantStatus   int
+		wantSessions []string
+	}{
+		{
+			name:         "sid",
+			query:        "?iss=https://zitadel.example.com&sid=sid1",
+			wantStatus:   http.StatusOK,
+			wantSessions: []string{"session"},
+		},
+		{
+			name:         "wrong issuer",
+			query:        "?iss=https://other.example.com&sid=sid1",
+			wantStatus:   http.StatusBadRequest,
+			wantSessions: []string{"1", "session"},
+		},
+		{
+			name:         "sid without issuer",
+			query:        "?sid=sid1",
+			wantStatus:   http.StatusBadRequest,
+			wantSessions: []string{"1", "session"},
+		},
+		{
+			name:         "session cookie",
+			cookie:       true,
+			wantStatus:   http.StatusOK,
+			wantSessions: []string{"1"},
+		},
+		{
+			name:         "no session",
+			wantStatus:   http.StatusOK,
+			wantSessions: []string{"1", "session"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, cookie := newTestAuthenticator(t, new(testHandler), nil)
+			// the cookie is issued for the id "session"
+			sessions := &InMemorySessions[*testCtx]{sessions: map[string]*testCtx{
+				"1":       {token: "token", subject: "user", sid: "sid1"},
+				"session": {token: "token", subject: "user", sid: "sid2"},
+			}}
+			a.sessions = sessions
+			a.createRouter()
+
+			req := httptest.NewRequest(http.MethodGet, "/auth/frontchannel-logout"+tt.query, nil)
+			if tt.cookie {
+				req.AddCookie(cookie)
+			}
+			w := httptest.NewRecorder()
+			a.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, "frame-ancestors https://zitadel.example.com", w.Header().Get("Content-Security-Policy"))
+			assert.Contains(t, w.Header().Get("Cache-Control"), "no-store")
+			remaining := make([]string, 0, len(sessions.sessions))
+			for id := range sessions.sessions {
+				remaining = append(remaining, id)
+			}
+			assert.ElementsMatch(t, tt.wantSessions, remaining)
+		})
+	}
+}
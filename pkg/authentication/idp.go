@@ -0,0 +1,37 @@
+package authentication
+
+import (
+	"net/http"
+)
+
+// idpHintParam is the query parameter of the login endpoint containing the id of the identity provider,
+// e.g. `/auth/login?idp_hint=123`.
+const idpHintParam = "idp_hint"
+
+// WithIdentityProvider skips the Login UI and redirects the user directly to the external identity provider
+// (configured in ZITADEL) with the id on every authentication, e.g. if the organization has exactly one upstream IdP.
+// It can be overruled per authentication by [WithIDPHint] or the `idp_hint` query parameter.
+func WithIdentityProvider[T Ctx](idpID string) Option[T] {
+	return func(a *Authenticator[T]) {
+		a.idpHint = idpID
+	}
+}
+
+// WithIDPHint redirects the user directly to the external identity provider with the id,
+// instead of showing the Login UI.
+func WithIDPHint(idpID string) LoginOption {
+	return func(s *State) {
+		s.IDPHint = idpID
+	}
+}
+
+// idpHintOf returns the identity provider of the [State], the query parameter or the default one.
+func (a *Authenticator[T]) idpHintOf(r *http.Request, s *State) string {
+	if s.IDPHint != "" {
+		return s.IDPHint
+	}
+	if idpID := r.URL.Query().Get(idpHintParam); idpID != "" {
+		return idpID
+	}
+	return a.idpHint
+}
@@ -0,0 +1,58 @@
+package authentication
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthenticator_Authenticate_idpHint(t *testing.T) {
+	tests := []struct {
+		name    string
+		idpID   string
+		target  string
+		options []LoginOption
+		want    string
+	}{
+		{
+			name:   "none",
+			target: "/auth/login",
+		},
+		{
+			name:   "default",
+			idpID:  "default",
+			target: "/auth/login",
+			want:   "default",
+		},
+		{
+			name:   "query parameter",
+			idpID:  "default",
+			target: "/auth/login?idp_hint=query",
+			want:   "query",
+		},
+		{
+			name:    "option",
+			idpID:   "default",
+			target:  "/auth/login?idp_hint=query",
+			options: []LoginOption{WithIDPHint("option")},
+			want:    "option",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := new(stepUpHandler)
+			a, _ := newTestAuthenticator(t, handler, nil)
+			WithIdentityProvider[*testCtx](tt.idpID)(a)
+
+			a.Authenticate(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.target, nil), "/profile", tt.options...)
+
+			assert.Equal(t, tt.want, handler.authRequest.IDPHint)
+			state, err := DecryptState(handler.state, a.encryptionKey)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, state.IDPHint)
+		})
+	}
+}
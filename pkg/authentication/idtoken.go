@@ -0,0 +1,30 @@
+package authentication
+
+import (
+	"net/http"
+)
+
+// IDTokenHolder is an optional interface of the [Ctx], which provides the id_token of the authentication.
+// The id_token is stored with the session and passed as `id_token_hint` to the Login UI
+// on logout and re-authentications (silent and step-up), so ZITADEL can identify the user and session.
+type IDTokenHolder interface {
+	GetIDToken() string
+}
+
+// IDToken returns the id_token of the current session, e.g. for a custom logout flow.
+// It returns an empty string, if there is no session or the [Ctx] does not implement the [IDTokenHolder] interface.
+func (a *Authenticator[T]) IDToken(req *http.Request) string {
+	_, session, err := a.session(req)
+	if err != nil {
+		return ""
+	}
+	return idToken(session)
+}
+
+func idToken(authCtx Ctx) string {
+	holder, ok := authCtx.(IDTokenHolder)
+	if !ok {
+		return ""
+	}
+	return holder.GetIDToken()
+}
@@ -0,0 +1,71 @@
+// Package codec provides the shared encoding and encryption of sessions for the session stores.
+package codec
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrNoKey      = errors.New("no encryption key")
+	ErrDecryption = errors.New("unable to decrypt session")
+)
+
+// Codec encodes sessions as JSON and encrypts (and authenticates) them with AES-GCM.
+type Codec[T any] struct {
+	aeads []cipher.AEAD
+}
+
+// New creates a [Codec] with the provided keys of 16, 24 or 32 bytes (AES-128, AES-192 or AES-256).
+// Sessions are encrypted with the first key and decrypted with any of them, which allows rotating the keys.
+func New[T any](keys ...string) (*Codec[T], error) {
+	if len(keys) == 0 {
+		return nil, ErrNoKey
+	}
+	aeads := make([]cipher.AEAD, len(keys))
+	for i, key := range keys {
+		block, err := aes.NewCipher([]byte(key))
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption key %d: %w", i, err)
+		}
+		aeads[i], err = cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &Codec[T]{aeads: aeads}, nil
+}
+
+// Encode marshals the session and encrypts it with the first key.
+func (c *Codec[T]) Encode(session T) ([]byte, error) {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return nil, err
+	}
+	aead := c.aeads[0]
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(data)+aead.Overhead())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, data, nil), nil
+}
+
+// Decode decrypts the session with any of the keys and unmarshals it.
+func (c *Codec[T]) Decode(data []byte) (session T, err error) {
+	for _, aead := range c.aeads {
+		if len(data) < aead.NonceSize() {
+			continue
+		}
+		plain, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+		if err != nil {
+			continue
+		}
+		err = json.Unmarshal(plain, &session)
+		return session, err
+	}
+	return session, ErrDecryption
+}
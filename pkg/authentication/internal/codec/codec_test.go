@@ -0,0 +1,46 @@
+package codec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type session struct {
+	Subject string
+	Token   string
+}
+
+func TestCodec(t *testing.T) {
+	oldKey := "01234567890123456789012345678901"
+	newKey := "abcdefghijklmnopqrstuvwxyz012345"
+
+	previous, err := New[*session](oldKey)
+	require.NoError(t, err)
+	encoded, err := previous.Encode(&session{Subject: "user", Token: "token"})
+	require.NoError(t, err)
+	assert.NotContains(t, string(encoded), "token")
+
+	rotated, err := New[*session](newKey, oldKey)
+	require.NoError(t, err)
+	decoded, err := rotated.Decode(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, &session{Subject: "user", Token: "token"}, decoded)
+
+	current, err := New[*session](newKey)
+	require.NoError(t, err)
+	_, err = current.Decode(encoded)
+	assert.ErrorIs(t, err, ErrDecryption)
+
+	encoded[len(encoded)-1] ^= 1
+	_, err = rotated.Decode(encoded)
+	assert.ErrorIs(t, err, ErrDecryption, "tampered session must not be decrypted")
+}
+
+func TestNew(t *testing.T) {
+	_, err := New[*session]()
+	assert.ErrorIs(t, err, ErrNoKey)
+	_, err = New[*session]("short")
+	assert.Error(t, err)
+}
@@ -0,0 +1,14 @@
+package authentication
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+)
+
+// deriveKey derives a key of 32 bytes for the purpose (e.g. `csrf`) from the encryption key,
+// so the encryption key itself is not reused for other algorithms or cookies.
+func (a *Authenticator[T]) deriveKey(purpose string) []byte {
+	mac := hmac.New(sha256.New, []byte(a.encryptionKey))
+	mac.Write([]byte("zitadel-go/authentication/" + purpose))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,206 @@
+package authentication
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authentication/internal/codec"
+)
+
+const (
+	// rememberMeParam is the query parameter of the login endpoint to create a persistent session,
+	// e.g. `/auth/login?remember_me=true`. It's only used if [WithRememberMe] is set.
+	rememberMeParam = "remember_me"
+	// activityInterval is the minimal interval of the renewal of the last activity,
+	// so the lifetime cookie is not set on every request.
+	activityInterval = time.Minute
+)
+
+var (
+	ErrSessionExpired = errors.New("session expired")
+)
+
+// WithSessionLifetime limits the lifetime of the sessions:
+// The absolute timeout terminates a session after the duration since the login,
+// the idle timeout terminates it if there was no (authenticated) request for the duration.
+// The last activity is renewed by the [Interceptor] (sliding expiration).
+// A zero duration disables the respective timeout.
+//
+// The times are stored in an encrypted and authenticated cookie bound to the session,
+// so they also apply to [StatelessSessions].
+func WithSessionLifetime[T Ctx](absolute, idle time.Duration) Option[T] {
+	return func(a *Authenticator[T]) {
+		a.absoluteTimeout = absolute
+		a.idleTimeout = idle
+	}
+}
+
+// WithRememberMe allows users to choose a persistent session on the login (`/auth/login?remember_me=true`),
+// which survives closing the browser and lasts for the provided lifetime since the last activity.
+// Unlike normal sessions, the cookie of such a session is set with a max age and both timeouts of
+// [WithSessionLifetime] are replaced by the lifetime.
+//
+// For [StatelessSessions] the lifetime of the store itself must be at least as long, e.g. [cookie.WithMaxAge].
+func WithRememberMe[T Ctx](lifetime time.Duration) Option[T] {
+	return func(a *Authenticator[T]) {
+		a.rememberMe = lifetime
+	}
+}
+
+// sessionLifetime contains the times of a session needed to check the timeouts.
+type sessionLifetime struct {
+	// Binding is the hash of the session (id) the lifetime belongs to,
+	// so the lifetime of another (newer) session cannot be used.
+	Binding      string    `json:"b"`
+	CreatedAt    time.Time `json:"c"`
+	LastActivity time.Time `json:"a"`
+	RememberMe   bool      `json:"r,omitempty"`
+}
+
+func (a *Authenticator[T]) lifetimeEnabled() bool {
+	return a.absoluteTimeout > 0 || a.idleTimeout > 0 || a.rememberMe > 0
+}
+
+func (a *Authenticator[T]) lifetimeCookieName() string {
+	return a.sessionCookieName + ".lifetime"
+}
+
+// newLifetime returns the lifetime of a new session or nil, if no lifetime is configured.
+func (a *Authenticator[T]) newLifetime(rememberMe bool) *sessionLifetime {
+	if !a.lifetimeEnabled() {
+		return nil
+	}
+	now := time.Now()
+	return &sessionLifetime{
+		CreatedAt:    now,
+		LastActivity: now,
+		RememberMe:   rememberMe && a.rememberMe > 0,
+	}
+}
+
+// sessionLifetime returns the lifetime of the session (id) from the lifetime cookie
+// and [ErrSessionExpired] if it's missing, does not belong to the session or any timeout is exceeded.
+// It returns nil, if no lifetime is configured.
+func (a *Authenticator[T]) sessionLifetime(req *http.Request, sessionID string) (*sessionLifetime, error) {
+	if !a.lifetimeEnabled() {
+		return nil, nil
+	}
+	cookie, err := req.Cookie(a.lifetimeCookieName())
+	if err != nil {
+		return nil, ErrSessionExpired
+	}
+	data, err := base64.RawURLEncoding.DecodeString(cookie.Value)
+	if err != nil {
+		return nil, ErrSessionExpired
+	}
+	c, err := a.lifetimeCodec()
+	if err != nil {
+		return nil, err
+	}
+	lifetime, err := c.Decode(data)
+	if err != nil || lifetime == nil || lifetime.Binding != sessionBinding(sessionID) {
+		return nil, ErrSessionExpired
+	}
+	if a.expired(lifetime, time.Now()) {
+		return nil, ErrSessionExpired
+	}
+	return lifetime, nil
+}
+
+func (a *Authenticator[T]) expired(lifetime *sessionLifetime, now time.Time) bool {
+	if lifetime.RememberMe {
+		return now.Sub(lifetime.LastActivity) > a.rememberMe
+	}
+	if a.absoluteTimeout > 0 && now.Sub(lifetime.CreatedAt) > a.absoluteTimeout {
+		return true
+	}
+	return a.idleTimeout > 0 && now.Sub(lifetime.LastActivity) > a.idleTimeout
+}
+
+// renewLifetime updates the last activity of the session and sets the lifetime cookie, resp. extends the
+// session cookie of a remember me session, if the last activity is older than the [activityInterval].
+func (a *Authenticator[T]) renewLifetime(w http.ResponseWriter, sessionID string, lifetime *sessionLifetime) {
+	if lifetime == nil || time.Since(lifetime.LastActivity) < activityInterval {
+		return
+	}
+	lifetime.LastActivity = time.Now()
+	if err := a.setLifetimeCookie(w, sessionID, lifetime); err != nil {
+		a.logger.Warn("unable to renew session lifetime", "error", err)
+		return
+	}
+	if !lifetime.RememberMe {
+		return
+	}
+	if _, stateless := a.sessions.(StatelessSessions[T]); stateless {
+		a.writeSessionCookie(w, sessionID, lifetime)
+		return
+	}
+	if err := a.setSessionCookie(w, sessionID, lifetime); err != nil {
+		a.logger.Warn("unable to renew session cookie", "error", err)
+	}
+}
+
+// expireSession deletes the expired session and its cookies.
+func (a *Authenticator[T]) expireSession(w http.ResponseWriter, sessionID string) {
+	if err := a.sessions.Delete(sessionID); err != nil {
+		a.logger.Warn("unable to delete expired session", "error", err)
+	}
+	if w != nil {
+		a.deleteSessionCookie(w)
+	}
+}
+
+func (a *Authenticator[T]) setLifetimeCookie(w http.ResponseWriter, sessionID string, lifetime *sessionLifetime) error {
+	lifetime.Binding = sessionBinding(sessionID)
+	c, err := a.lifetimeCodec()
+	if err != nil {
+		return err
+	}
+	data, err := c.Encode(lifetime)
+	if err != nil {
+		return err
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     a.lifetimeCookieName(),
+		Value:    base64.RawURLEncoding.EncodeToString(data),
+		Path:     "/",
+		MaxAge:   a.cookieMaxAge(lifetime),
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return nil
+}
+
+// lifetimeCodec encrypts and authenticates the lifetime cookie (AES-GCM) with its own key,
+// so the timeouts cannot be tampered with.
+func (a *Authenticator[T]) lifetimeCodec() (*codec.Codec[*sessionLifetime], error) {
+	return codec.New[*sessionLifetime](string(a.deriveKey("lifetime")))
+}
+
+func (a *Authenticator[T]) deleteLifetimeCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     a.lifetimeCookieName(),
+		Path:     "/",
+		MaxAge:   -1,
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// cookieMaxAge returns the max age of the session cookies, which is only set for remember me sessions.
+func (a *Authenticator[T]) cookieMaxAge(lifetime *sessionLifetime) int {
+	if lifetime == nil || !lifetime.RememberMe {
+		return 0
+	}
+	return int(a.rememberMe.Seconds())
+}
+
+func sessionBinding(sessionID string) string {
+	hash := sha256.Sum256([]byte(sessionID))
+	return base64.RawURLEncoding.EncodeToString(hash[:16])
+}
@@ -0,0 +1,170 @@
+package authentication
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthenticator_IsAuthenticated_lifetime(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name       string
+		lifetime   *sessionLifetime
+		binding    string
+		wantErr    error
+		wantRenew  bool
+		wantMaxAge int
+	}{
+		{
+			name:     "valid",
+			lifetime: &sessionLifetime{CreatedAt: now.Add(-time.Hour), LastActivity: now.Add(-time.Second)},
+		},
+		{
+			name:      "valid, renewed",
+			lifetime:  &sessionLifetime{CreatedAt: now.Add(-time.Hour), LastActivity: now.Add(-10 * time.Minute)},
+			wantRenew: true,
+		},
+		{
+			name:     "missing",
+			lifetime: nil,
+			wantErr:  ErrSessionExpired,
+		},
+		{
+			name:     "other session",
+			lifetime: &sessionLifetime{CreatedAt: now, LastActivity: now},
+			binding:  "other",
+			wantErr:  ErrSessionExpired,
+		},
+		{
+			name:     "absolute timeout",
+			lifetime: &sessionLifetime{CreatedAt: now.Add(-9 * time.Hour), LastActivity: now.Add(-time.Second)},
+			wantErr:  ErrSessionExpired,
+		},
+		{
+			name:     "idle timeout",
+			lifetime: &sessionLifetime{CreatedAt: now.Add(-time.Hour), LastActivity: now.Add(-31 * time.Minute)},
+			wantErr:  ErrSessionExpired,
+		},
+		{
+			name:       "remember me",
+			lifetime:   &sessionLifetime{CreatedAt: now.Add(-72 * time.Hour), LastActivity: now.Add(-24 * time.Hour), RememberMe: true},
+			wantRenew:  true,
+			wantMaxAge: int((30 * 24 * time.Hour).Seconds()),
+		},
+		{
+			name:     "remember me expired",
+			lifetime: &sessionLifetime{CreatedAt: now.Add(-40 * 24 * time.Hour), LastActivity: now.Add(-31 * 24 * time.Hour), RememberMe: true},
+			wantErr:  ErrSessionExpired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, cookie := newTestAuthenticator(t, &testHandler{}, &testCtx{token: "token"})
+			WithSessionLifetime[*testCtx](8*time.Hour, 30*time.Minute)(a)
+			WithRememberMe[*testCtx](30 * 24 * time.Hour)(a)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.AddCookie(cookie)
+			if tt.lifetime != nil {
+				binding := tt.binding
+				if binding == "" {
+					binding = "session"
+				}
+				w := httptest.NewRecorder()
+				require.NoError(t, a.setLifetimeCookie(w, binding, tt.lifetime))
+				req.AddCookie(w.Result().Cookies()[0])
+			}
+
+			w := httptest.NewRecorder()
+			session, err := a.authenticated(w, req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				_, err = a.sessions.Get("session")
+				assert.Error(t, err, "expired session must be deleted")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "token", session.token)
+			cookies := w.Result().Cookies()
+			if !tt.wantRenew {
+				assert.Empty(t, cookies)
+				return
+			}
+			require.NotEmpty(t, cookies)
+			assert.Equal(t, a.lifetimeCookieName(), cookies[0].Name)
+			assert.Equal(t, tt.wantMaxAge, cookies[0].MaxAge)
+			if tt.wantMaxAge > 0 {
+				require.Len(t, cookies, 2)
+				assert.Equal(t, a.sessionCookieName, cookies[1].Name)
+				assert.Equal(t, tt.wantMaxAge, cookies[1].MaxAge)
+			}
+		})
+	}
+}
+
+func TestAuthenticator_IsAuthenticated_tamperedLifetime(t *testing.T) {
+	a, cookie := newTestAuthenticator(t, &testHandler{}, &testCtx{token: "token"})
+	WithSessionLifetime[*testCtx](8*time.Hour, 30*time.Minute)(a)
+
+	w := httptest.NewRecorder()
+	require.NoError(t, a.setLifetimeCookie(w, "session", a.newLifetime(false)))
+	lifetime := w.Result().Cookies()[0]
+	value, err := base64.RawURLEncoding.DecodeString(lifetime.Value)
+	require.NoError(t, err)
+	value[len(value)-1] ^= 1
+	lifetime.Value = base64.RawURLEncoding.EncodeToString(value)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(cookie)
+	req.AddCookie(lifetime)
+	_, err = a.authenticated(httptest.NewRecorder(), req)
+	assert.ErrorIs(t, err, ErrSessionExpired)
+}
+
+func TestAuthenticator_Callback_rememberMe(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		wantMaxAge int
+	}{
+		{
+			name:       "session cookie",
+			target:     "/auth/login",
+			wantMaxAge: 0,
+		},
+		{
+			name:       "remember me",
+			target:     "/auth/login?remember_me=true",
+			wantMaxAge: int((30 * 24 * time.Hour).Seconds()),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &stepUpHandler{callback: &testCtx{token: "token"}}
+			a, _ := newTestAuthenticator(t, handler, nil)
+			WithRememberMe[*testCtx](30 * 24 * time.Hour)(a)
+
+			a.Authenticate(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.target, nil), "/")
+			w := httptest.NewRecorder()
+			a.Callback(w, httptest.NewRequest(http.MethodGet, "/auth/callback", nil))
+			assert.Equal(t, http.StatusFound, w.Code)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			maxAges := make(map[string]int)
+			for _, cookie := range w.Result().Cookies() {
+				maxAges[cookie.Name] = cookie.MaxAge
+				req.AddCookie(cookie)
+			}
+			assert.Equal(t, tt.wantMaxAge, maxAges[a.sessionCookieName])
+			assert.Equal(t, tt.wantMaxAge, maxAges[a.lifetimeCookieName()])
+			_, err := a.IsAuthenticated(req)
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,245 @@
+// Package linking provides a flow to link an external identity (e.g. a Google account) to the ZITADEL account
+// of an authenticated user, e.g. for a "connect your Google account" settings page:
+//
+//	linker := linking.New[*openid.UserInfoContext[*oidc.IDTokenClaims, *oidc.UserInfo]](client, key, "https://app.example.com/settings/link/callback")
+//	mux.Handle("/settings/link", mw.RequireAuthentication()(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+//		linker.Start(w, req, req.URL.Query().Get("idp_id"), "/settings")
+//	})))
+//	mux.Handle("/settings/link/callback", mw.RequireAuthentication()(http.HandlerFunc(linker.Callback)))
+//
+// The flow starts an IdP intent in ZITADEL, redirects the user to the identity provider and links the retrieved
+// identity to the user (AddIDPLink) on the callback. Both endpoints must be protected by the
+// [authentication.Interceptor.RequireAuthentication] and the authentication context must implement
+// the [authentication.SessionIdentifier] interface to provide the id of the user.
+// The client needs to be authorized to manage the users, e.g. with a service user.
+package linking
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/zitadel/oidc/v3/pkg/crypto"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authentication"
+	user "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user/v2"
+)
+
+var (
+	ErrNotAuthenticated = errors.New("not authenticated")
+	ErrInvalidState     = errors.New("invalid linking state")
+	ErrIntentFailed     = errors.New("identity provider intent failed")
+	ErrAlreadyLinked    = errors.New("identity is already linked to another user")
+)
+
+// Client is the part of the [client.Client] used by this package.
+type Client interface {
+	UserServiceV2() user.UserServiceClient
+}
+
+// Linker links external identities to the authenticated user.
+type Linker[T authentication.Ctx] struct {
+	client        Client
+	encryptionKey string
+	callbackURL   string
+	cookieName    string
+	errorHandler  authentication.ErrorHandler
+}
+
+// Option allows customization of the [Linker].
+type Option[T authentication.Ctx] func(*Linker[T])
+
+// WithCookieName allows a name of the cookie containing the state of the flow other than "zitadel.linking".
+func WithCookieName[T authentication.Ctx](name string) Option[T] {
+	return func(l *Linker[T]) {
+		l.cookieName = name
+	}
+}
+
+// WithErrorHandler allows responding to errors other than with plain text ([authentication.DefaultErrorHandler]).
+func WithErrorHandler[T authentication.Ctx](handler authentication.ErrorHandler) Option[T] {
+	return func(l *Linker[T]) {
+		l.errorHandler = handler
+	}
+}
+
+// New creates a [Linker]. The callbackURL is the absolute URL of the endpoint serving the [Linker.Callback],
+// the encryptionKey (16, 24 or 32 bytes) is used to encrypt the state of the flow in a cookie.
+func New[T authentication.Ctx](client Client, encryptionKey, callbackURL string, options ...Option[T]) *Linker[T] {
+	l := &Linker[T]{
+		client:        client,
+		encryptionKey: encryptionKey,
+		callbackURL:   callbackURL,
+		cookieName:    "zitadel.linking",
+		errorHandler:  authentication.DefaultErrorHandler,
+	}
+	for _, option := range options {
+		option(l)
+	}
+	return l
+}
+
+// state is stored in the cookie between the start and the callback of the flow,
+// so the identity can only be linked to the user who started it.
+type state struct {
+	UserID   string `json:"u"`
+	IdpID    string `json:"i"`
+	ReturnTo string `json:"r"`
+}
+
+// Start starts the IdP intent for the identity provider (idpID) and redirects the authenticated user to it.
+// After the identity was linked on the callback, the user is redirected to the returnTo path.
+func (l *Linker[T]) Start(w http.ResponseWriter, req *http.Request, idpID, returnTo string) {
+	userID := l.userID(req)
+	if userID == "" {
+		l.errorHandler(w, req, ErrNotAuthenticated, http.StatusUnauthorized)
+		return
+	}
+	resp, err := l.client.UserServiceV2().StartIdentityProviderIntent(req.Context(), &user.StartIdentityProviderIntentRequest{
+		IdpId: idpID,
+		Content: &user.StartIdentityProviderIntentRequest_Urls{
+			Urls: &user.RedirectURLs{
+				SuccessUrl: l.callbackURL,
+				FailureUrl: l.callbackURL,
+			},
+		},
+	})
+	if err != nil {
+		l.errorHandler(w, req, fmt.Errorf("%w: %w", ErrIntentFailed, err), http.StatusBadGateway)
+		return
+	}
+	if err = l.setState(w, &state{UserID: userID, IdpID: idpID, ReturnTo: returnTo}); err != nil {
+		l.errorHandler(w, req, err, http.StatusInternalServerError)
+		return
+	}
+	if postForm := resp.GetPostForm(); len(postForm) > 0 {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write(postForm)
+		return
+	}
+	http.Redirect(w, req, resp.GetAuthUrl(), http.StatusFound)
+}
+
+// Callback handles the redirect back from the identity provider (through ZITADEL) by retrieving the identity
+// of the intent and linking it to the authenticated user, who must be the one who started the flow.
+func (l *Linker[T]) Callback(w http.ResponseWriter, req *http.Request) {
+	s, err := l.state(req)
+	l.deleteState(w)
+	if err != nil {
+		l.errorHandler(w, req, err, http.StatusBadRequest)
+		return
+	}
+	if userID := l.userID(req); userID == "" || userID != s.UserID {
+		l.errorHandler(w, req, ErrInvalidState, http.StatusForbidden)
+		return
+	}
+	intentID, token := req.URL.Query().Get("id"), req.URL.Query().Get("token")
+	if intentID == "" || token == "" {
+		l.errorHandler(w, req, ErrIntentFailed, http.StatusBadRequest)
+		return
+	}
+	intent, err := l.client.UserServiceV2().RetrieveIdentityProviderIntent(req.Context(), &user.RetrieveIdentityProviderIntentRequest{
+		IdpIntentId:    intentID,
+		IdpIntentToken: token,
+	})
+	if err != nil {
+		l.errorHandler(w, req, fmt.Errorf("%w: %w", ErrIntentFailed, err), http.StatusBadRequest)
+		return
+	}
+	info := intent.GetIdpInformation()
+	if info.GetIdpId() != s.IdpID {
+		l.errorHandler(w, req, ErrInvalidState, http.StatusBadRequest)
+		return
+	}
+	if intent.GetUserId() != "" {
+		if intent.GetUserId() != s.UserID {
+			l.errorHandler(w, req, ErrAlreadyLinked, http.StatusConflict)
+			return
+		}
+		// already linked to the user
+		http.Redirect(w, req, returnTo(s.ReturnTo), http.StatusFound)
+		return
+	}
+	_, err = l.client.UserServiceV2().AddIDPLink(req.Context(), &user.AddIDPLinkRequest{
+		UserId: s.UserID,
+		IdpLink: &user.IDPLink{
+			IdpId:    info.GetIdpId(),
+			UserId:   info.GetUserId(),
+			UserName: info.GetUserName(),
+		},
+	})
+	if err != nil {
+		l.errorHandler(w, req, err, http.StatusBadGateway)
+		return
+	}
+	http.Redirect(w, req, returnTo(s.ReturnTo), http.StatusFound)
+}
+
+func (l *Linker[T]) userID(req *http.Request) string {
+	if !authentication.IsAuthenticated(req.Context()) {
+		return ""
+	}
+	identifier, ok := any(authentication.Context[T](req.Context())).(authentication.SessionIdentifier)
+	if !ok {
+		return ""
+	}
+	return identifier.GetSubject()
+}
+
+func (l *Linker[T]) setState(w http.ResponseWriter, s *state) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	value, err := crypto.EncryptAES(string(data), l.encryptionKey)
+	if err != nil {
+		return err
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     l.cookieName,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   600,
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return nil
+}
+
+func (l *Linker[T]) state(req *http.Request) (*state, error) {
+	cookie, err := req.Cookie(l.cookieName)
+	if err != nil {
+		return nil, ErrInvalidState
+	}
+	decrypted, err := crypto.DecryptAES(cookie.Value, l.encryptionKey)
+	if err != nil {
+		return nil, ErrInvalidState
+	}
+	s := new(state)
+	if err = json.Unmarshal([]byte(decrypted), s); err != nil {
+		return nil, ErrInvalidState
+	}
+	return s, nil
+}
+
+func (l *Linker[T]) deleteState(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     l.cookieName,
+		Path:     "/",
+		MaxAge:   -1,
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// returnTo only allows relative paths to prevent open redirects.
+func returnTo(uri string) string {
+	if !strings.HasPrefix(uri, "/") || strings.HasPrefix(uri, "//") || strings.ContainsAny(uri, "\\\r\n\t") {
+		return "/"
+	}
+	return uri
+}
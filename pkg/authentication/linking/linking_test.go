@@ -0,0 +1,165 @@
+package linking
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authentication"
+	user "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user/v2"
+)
+
+const testKey = "01234567890123456789012345678901"
+
+type testCtx struct {
+	subject string
+}
+
+func (c *testCtx) IsAuthenticated() bool { return c != nil && c.subject != "" }
+func (c *testCtx) GetSubject() string    { return c.subject }
+func (c *testCtx) GetSessionID() string  { return "" }
+
+type testClient struct {
+	user *userService
+}
+
+func (c *testClient) UserServiceV2() user.UserServiceClient {
+	return c.user
+}
+
+type userService struct {
+	user.UserServiceClient
+	intentUserID string
+	startReq     *user.StartIdentityProviderIntentRequest
+	link         *user.AddIDPLinkRequest
+}
+
+func (s *userService) StartIdentityProviderIntent(_ context.Context, req *user.StartIdentityProviderIntentRequest, _ ...grpc.CallOption) (*user.StartIdentityProviderIntentResponse, error) {
+	s.startReq = req
+	return &user.StartIdentityProviderIntentResponse{
+		NextStep: &user.StartIdentityProviderIntentResponse_AuthUrl{AuthUrl: "https://accounts.google.com/auth"},
+	}, nil
+}
+
+func (s *userService) RetrieveIdentityProviderIntent(_ context.Context, req *user.RetrieveIdentityProviderIntentRequest, _ ...grpc.CallOption) (*user.RetrieveIdentityProviderIntentResponse, error) {
+	if req.GetIdpIntentId() != "intent" || req.GetIdpIntentToken() != "token" {
+		return nil, errors.New("invalid intent")
+	}
+	return &user.RetrieveIdentityProviderIntentResponse{
+		IdpInformation: &user.IDPInformation{IdpId: "google", UserId: "external", UserName: "user@gmail.com"},
+		UserId:         s.intentUserID,
+	}, nil
+}
+
+func (s *userService) AddIDPLink(_ context.Context, req *user.AddIDPLinkRequest, _ ...grpc.CallOption) (*user.AddIDPLinkResponse, error) {
+	s.link = req
+	return &user.AddIDPLinkResponse{}, nil
+}
+
+func authenticated(req *http.Request, subject string) *http.Request {
+	return req.WithContext(authentication.WithAuthContext(req.Context(), &testCtx{subject: subject}))
+}
+
+func TestLinker(t *testing.T) {
+	tests := []struct {
+		name          string
+		callbackUser  string
+		callbackQuery string
+		intentUserID  string
+		wantCode      int
+		wantLocation  string
+		wantLink      *user.AddIDPLinkRequest
+	}{
+		{
+			name:          "linked",
+			callbackUser:  "user",
+			callbackQuery: "?id=intent&token=token",
+			wantCode:      http.StatusFound,
+			wantLocation:  "/settings",
+			wantLink: &user.AddIDPLinkRequest{
+				UserId:  "user",
+				IdpLink: &user.IDPLink{IdpId: "google", UserId: "external", UserName: "user@gmail.com"},
+			},
+		},
+		{
+			name:          "already linked",
+			callbackUser:  "user",
+			callbackQuery: "?id=intent&token=token",
+			intentUserID:  "user",
+			wantCode:      http.StatusFound,
+			wantLocation:  "/settings",
+		},
+		{
+			name:          "linked to other user",
+			callbackUser:  "user",
+			callbackQuery: "?id=intent&token=token",
+			intentUserID:  "other",
+			wantCode:      http.StatusConflict,
+		},
+		{
+			name:          "other user on callback",
+			callbackUser:  "other",
+			callbackQuery: "?id=intent&token=token",
+			wantCode:      http.StatusForbidden,
+		},
+		{
+			name:          "intent failed",
+			callbackUser:  "user",
+			callbackQuery: "?id=intent&error=access_denied",
+			wantCode:      http.StatusBadRequest,
+		},
+		{
+			name:          "invalid token",
+			callbackUser:  "user",
+			callbackQuery: "?id=intent&token=invalid",
+			wantCode:      http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := &userService{intentUserID: tt.intentUserID}
+			l := New[*testCtx](&testClient{user: users}, testKey, "https://app.example.com/settings/link/callback")
+
+			w := httptest.NewRecorder()
+			l.Start(w, authenticated(httptest.NewRequest(http.MethodGet, "/settings/link", nil), "user"), "google", "/settings")
+			assert.Equal(t, http.StatusFound, w.Code)
+			assert.Equal(t, "https://accounts.google.com/auth", w.Header().Get("Location"))
+			assert.Equal(t, "google", users.startReq.GetIdpId())
+			assert.Equal(t, "https://app.example.com/settings/link/callback", users.startReq.GetUrls().GetSuccessUrl())
+
+			req := authenticated(httptest.NewRequest(http.MethodGet, "/settings/link/callback"+tt.callbackQuery, nil), tt.callbackUser)
+			for _, cookie := range w.Result().Cookies() {
+				req.AddCookie(cookie)
+			}
+			w = httptest.NewRecorder()
+			l.Callback(w, req)
+			assert.Equal(t, tt.wantCode, w.Code)
+			assert.Equal(t, tt.wantLocation, w.Header().Get("Location"))
+			assert.Equal(t, tt.wantLink, users.link)
+		})
+	}
+}
+
+func TestLinker_Start_notAuthenticated(t *testing.T) {
+	users := new(userService)
+	l := New[*testCtx](&testClient{user: users}, testKey, "https://app.example.com/settings/link/callback")
+	w := httptest.NewRecorder()
+	l.Start(w, httptest.NewRequest(http.MethodGet, "/settings/link", nil), "google", "/settings")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Nil(t, users.startReq)
+}
+
+func TestLinker_Callback_noState(t *testing.T) {
+	l := New[*testCtx](&testClient{user: new(userService)}, testKey, "https://app.example.com/settings/link/callback")
+	w := httptest.NewRecorder()
+	l.Callback(w, authenticated(httptest.NewRequest(http.MethodGet, "/settings/link/callback?id=intent&token=token", nil), "user"))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	require.Len(t, w.Result().Cookies(), 1)
+	assert.Equal(t, -1, w.Result().Cookies()[0].MaxAge)
+}
@@ -0,0 +1,51 @@
+package authentication
+
+import (
+	"net/http"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// uiLocalesParam is the query parameter of the login endpoint containing the preferred languages of the Login UI,
+// e.g. `/auth/login?ui_locales=de-CH de`.
+const uiLocalesParam = "ui_locales"
+
+// WithAcceptLanguage passes the languages of the `Accept-Language` header of the request as `ui_locales`
+// to the Login UI, if they are not set explicitly ([WithUILocales] or the `ui_locales` query parameter).
+func WithAcceptLanguage[T Ctx]() Option[T] {
+	return func(a *Authenticator[T]) {
+		a.acceptLanguage = true
+	}
+}
+
+// WithUILocales requests the Login UI to be shown in the preferred languages (BCP47 language tags),
+// e.g. the language chosen by the user in the application.
+func WithUILocales(locales ...string) LoginOption {
+	return func(s *State) {
+		s.UILocales = locales
+	}
+}
+
+// uiLocales returns the preferred languages of the [State], the query parameter
+// or if enabled the `Accept-Language` header (ordered by their quality).
+func (a *Authenticator[T]) uiLocales(r *http.Request, s *State) []string {
+	if len(s.UILocales) > 0 {
+		return s.UILocales
+	}
+	if locales := strings.Fields(r.URL.Query().Get(uiLocalesParam)); len(locales) > 0 {
+		return locales
+	}
+	if !a.acceptLanguage {
+		return nil
+	}
+	tags, _, err := language.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+	if err != nil || len(tags) == 0 {
+		return nil
+	}
+	locales := make([]string, len(tags))
+	for i, tag := range tags {
+		locales[i] = tag.String()
+	}
+	return locales
+}
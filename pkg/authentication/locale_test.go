@@ -0,0 +1,60 @@
+package authentication
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthenticator_Authenticate_uiLocales(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage bool
+		target         string
+		options        []LoginOption
+		want           []string
+	}{
+		{
+			name:   "accept language disabled",
+			target: "/auth/login",
+		},
+		{
+			name:           "accept language",
+			acceptLanguage: true,
+			target:         "/auth/login",
+			want:           []string{"de-CH", "de", "en"},
+		},
+		{
+			name:           "query parameter",
+			acceptLanguage: true,
+			target:         "/auth/login?ui_locales=fr+it",
+			want:           []string{"fr", "it"},
+		},
+		{
+			name:           "option",
+			acceptLanguage: true,
+			target:         "/auth/login?ui_locales=fr",
+			options:        []LoginOption{WithUILocales("rm")},
+			want:           []string{"rm"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := new(stepUpHandler)
+			a, _ := newTestAuthenticator(t, handler, nil)
+			a.acceptLanguage = tt.acceptLanguage
+
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			req.Header.Set("Accept-Language", "en;q=0.5, de-CH, de;q=0.9")
+			a.Authenticate(httptest.NewRecorder(), req, "/profile", tt.options...)
+
+			assert.Equal(t, tt.want, handler.authRequest.UILocales)
+			state, err := DecryptState(handler.state, a.encryptionKey)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, state.UILocales)
+		})
+	}
+}
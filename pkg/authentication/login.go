@@ -0,0 +1,41 @@
+package authentication
+
+// LoginOption allows to customize a single authentication, e.g. to request additional scopes
+// for the routes protected by an [Interceptor.RequireAuthentication].
+type LoginOption func(*State)
+
+// WithScopes requests the scopes in addition to the ones configured on the [Handler],
+// e.g. the reserved scopes of ZITADEL for the audience of a project, its roles or the metadata of the user.
+func WithScopes(scopes ...string) LoginOption {
+	return func(s *State) {
+		s.Scopes = append(s.Scopes, scopes...)
+	}
+}
+
+// WithClaims requests individual claims using the `claims` parameter.
+func WithClaims(claims *ClaimsRequest) LoginOption {
+	return func(s *State) {
+		s.Claims = claims
+	}
+}
+
+// ClaimsRequest requests individual claims to be returned in the id_token and / or from the userinfo endpoint
+// (OpenID Connect Core 1.0, section 5.5). A nil [ClaimRequest] requests the claim as voluntary.
+type ClaimsRequest struct {
+	IDToken  map[string]*ClaimRequest `json:"id_token,omitempty"`
+	UserInfo map[string]*ClaimRequest `json:"userinfo,omitempty"`
+}
+
+// ClaimRequest defines the requirements of a single requested claim.
+type ClaimRequest struct {
+	Essential bool     `json:"essential,omitempty"`
+	Value     string   `json:"value,omitempty"`
+	Values    []string `json:"values,omitempty"`
+}
+
+func applyLoginOptions(s *State, options []LoginOption) *State {
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
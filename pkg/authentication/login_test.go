@@ -0,0 +1,69 @@
+package authentication
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterceptor_RequireAuthentication_loginOptions(t *testing.T) {
+	claims := &ClaimsRequest{IDToken: map[string]*ClaimRequest{"email": {Essential: true}, "locale": nil}}
+	tests := []struct {
+		name    string
+		silent  bool
+		options []LoginOption
+		want    AuthRequest
+	}{
+		{
+			name: "none",
+		},
+		{
+			name:    "scopes and claims",
+			options: []LoginOption{WithScopes("urn:zitadel:iam:org:projects:roles"), WithScopes("urn:zitadel:iam:user:metadata"), WithClaims(claims)},
+			want: AuthRequest{
+				Scopes: []string{"urn:zitadel:iam:org:projects:roles", "urn:zitadel:iam:user:metadata"},
+				Claims: claims,
+			},
+		},
+		{
+			name:    "silent",
+			silent:  true,
+			options: []LoginOption{WithScopes("urn:zitadel:iam:user:metadata")},
+			want: AuthRequest{
+				Prompt: []string{"none"},
+				Scopes: []string{"urn:zitadel:iam:user:metadata"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := new(stepUpHandler)
+			a, _ := newTestAuthenticator(t, handler, nil)
+			a.silentAuthentication = tt.silent
+
+			req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+			Middleware(a).RequireAuthentication(tt.options...)(http.NotFoundHandler()).ServeHTTP(httptest.NewRecorder(), req)
+			assert.Equal(t, tt.want, handler.authRequest)
+			state, err := DecryptState(handler.state, a.encryptionKey)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.Scopes, state.Scopes)
+			assert.Equal(t, tt.want.Claims, state.Claims)
+		})
+	}
+}
+
+func TestAuthenticator_Callback_silentLoginOptions(t *testing.T) {
+	handler := new(stepUpHandler)
+	a, _ := newTestAuthenticator(t, handler, nil)
+	stateParam, err := (&State{RequestedURI: "/admin", Silent: true, Scopes: []string{"scope"}}).Encrypt(a.encryptionKey)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/auth/callback?"+url.Values{"error": {"login_required"}, "state": {stateParam}}.Encode(), nil)
+	a.Callback(httptest.NewRecorder(), req)
+
+	assert.Equal(t, AuthRequest{Scopes: []string{"scope"}}, handler.authRequest)
+}
@@ -2,6 +2,7 @@ package authentication
 
 import (
 	"context"
+	"errors"
 	"net/http"
 )
 
@@ -16,13 +17,30 @@ func Middleware[T Ctx](authenticator *Authenticator[T]) *Interceptor[T] {
 }
 
 // RequireAuthentication will check if there is a valid session and provide it in the context.
-// If there is no session, it will automatically start a new authentication (by redirecting the user to the Login UI)
-func (i *Interceptor[T]) RequireAuthentication() func(next http.Handler) http.Handler {
+// If there is no session, it will automatically start a new authentication (by redirecting the user to the Login UI),
+// resp. a silent authentication first if [WithSilentAuthentication] is set.
+// A custom handling can be set with [WithUnauthenticatedHandler].
+// If an expired session could not be refreshed temporarily ([ErrRefreshFailed]), it responds with 503 Service Unavailable.
+// The options allow requesting additional scopes and claims for the routes, e.g. [WithScopes].
+// They only apply to a new authentication, an existing session is not checked against them.
+func (i *Interceptor[T]) RequireAuthentication(options ...LoginOption) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-			ctx, err := i.authenticator.IsAuthenticated(req)
+			ctx, err := i.authenticator.authenticated(w, req)
+			if errors.Is(err, ErrRefreshFailed) {
+				i.authenticator.error(w, req, err, http.StatusServiceUnavailable)
+				return
+			}
 			if err != nil {
-				i.authenticator.Authenticate(w, req, req.RequestURI)
+				if i.authenticator.unauthenticatedHandler != nil {
+					i.authenticator.unauthenticatedHandler(w, req)
+					return
+				}
+				if i.authenticator.silentAuthentication {
+					i.authenticator.SilentAuthenticate(w, req, req.RequestURI, options...)
+					return
+				}
+				i.authenticator.Authenticate(w, req, req.RequestURI, options...)
 				return
 			}
 			req = req.WithContext(WithAuthContext(req.Context(), ctx))
@@ -36,7 +54,7 @@ func (i *Interceptor[T]) RequireAuthentication() func(next http.Handler) http.Ha
 func (i *Interceptor[T]) CheckAuthentication() func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-			ctx, err := i.authenticator.IsAuthenticated(req)
+			ctx, err := i.authenticator.authenticated(w, req)
 			if err == nil {
 				req = req.WithContext(WithAuthContext(req.Context(), ctx))
 			}
@@ -2,8 +2,14 @@ package oidc
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/zitadel/oidc/v3/pkg/client"
 	"github.com/zitadel/oidc/v3/pkg/client/rp"
@@ -28,12 +34,18 @@ type Ctx[C oidc.IDClaims, S rp.SubjectGetter] interface {
 // Use [WithCodeFlow] for implementation.
 type codeFlowAuthentication[T Ctx[C, S], C oidc.IDClaims, S rp.SubjectGetter] struct {
 	relyingParty rp.RelyingParty
+	flowStore    FlowStore
 }
 
 // WithCodeFlow creates the OIDC/OAuth2 Authorization Code Flow implementation of the [authentication.Handler] interface.
 // The token endpoint itself requires some [ClientAuthentication] of the client.
 // Possible implementation are [PKCEAuthentication] and [ClientIDSecretAuthentication].
-func WithCodeFlow[T Ctx[C, S], C oidc.IDClaims, S rp.SubjectGetter](auth ClientAuthentication) authentication.HandlerInitializer[T] {
+// The flow can be customized using [CodeFlowOption], e.g. [WithFlowStore].
+func WithCodeFlow[T Ctx[C, S], C oidc.IDClaims, S rp.SubjectGetter](auth ClientAuthentication, options ...CodeFlowOption) authentication.HandlerInitializer[T] {
+	o := &codeFlowOptions{}
+	for _, option := range options {
+		option(o)
+	}
 	return func(ctx context.Context, zitadel *zitadel.Zitadel) (authentication.Handler[T], error) {
 		relyingParty, err := auth(ctx, zitadel.Origin())
 		if err != nil {
@@ -41,13 +53,17 @@ func WithCodeFlow[T Ctx[C, S], C oidc.IDClaims, S rp.SubjectGetter](auth ClientA
 		}
 		return &codeFlowAuthentication[T, C, S]{
 			relyingParty: relyingParty,
+			flowStore:    o.flowStore,
 		}, nil
 	}
 }
 
 type ClientAuthentication func(ctx context.Context, domain string) (rp.RelyingParty, error)
 
-// PKCEAuthentication allows to authenticate the code exchange request with Proof Key of Code Exchange (PKCE).
+// PKCEAuthentication allows to authenticate the code exchange request with Proof Key of Code Exchange (PKCE)
+// using the S256 code challenge method. No client secret is required, which allows public clients (e.g. with auth method `none`).
+// The code verifier is stored in an encrypted cookie of the cookieHandler between the redirect to the Login UI and the callback,
+// unless a [FlowStore] is used.
 func PKCEAuthentication(clientID, redirectURI string, scopes []string, cookieHandler *httphelper.CookieHandler) ClientAuthentication {
 	return func(ctx context.Context, domain string) (rp.RelyingParty, error) {
 		return newRP(ctx, domain, clientID, "", redirectURI, scopes, rp.WithPKCE(cookieHandler))
@@ -55,9 +71,10 @@ func PKCEAuthentication(clientID, redirectURI string, scopes []string, cookieHan
 }
 
 // ClientIDSecretAuthentication allows to authenticate the code exchange request with client_id and client_secret provide by ZITADEL.
+// Additionally, the request is protected with PKCE (S256) as in [PKCEAuthentication].
 func ClientIDSecretAuthentication(clientID, clientSecret, redirectURI string, scopes []string, cookieHandler *httphelper.CookieHandler) ClientAuthentication {
 	return func(ctx context.Context, domain string) (rp.RelyingParty, error) {
-		return newRP(ctx, domain, clientID, clientSecret, redirectURI, scopes, rp.WithCookieHandler(cookieHandler))
+		return newRP(ctx, domain, clientID, clientSecret, redirectURI, scopes, rp.WithPKCE(cookieHandler))
 	}
 }
 
@@ -81,13 +98,108 @@ func newRP(ctx context.Context, domain, clientID, clientSecret, redirectURI stri
 }
 
 // Authenticate starts the OIDC/OAuth2 Authorization Code Flow and redirects the user to the Login UI.
+// The [authentication.AuthRequest] of the request is passed as additional (organization, idp) scopes, claims,
+// login_hint, acr_values, prompt, max_age and ui_locales.
 func (c *codeFlowAuthentication[T, C, S]) Authenticate(w http.ResponseWriter, r *http.Request, state string) {
-	rp.AuthURLHandler(func() string { return state }, c.relyingParty)(w, r)
+	params, err := c.authURLParams(authentication.AuthRequestFromContext(r.Context()))
+	if err != nil {
+		http.Error(w, "failed to build auth request parameters: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if c.flowStore != nil {
+		c.authenticateWithStore(w, r, state, params)
+		return
+	}
+	rp.AuthURLHandler(func() string { return state }, c.relyingParty, params...)(w, r)
+}
+
+func (c *codeFlowAuthentication[T, C, S]) authURLParams(authRequest authentication.AuthRequest) ([]rp.URLParamOpt, error) {
+	params := make([]rp.URLParamOpt, 0, 8)
+	additionalScopes := slices.Clone(authRequest.Scopes)
+	if authRequest.IDPHint != "" {
+		additionalScopes = append(additionalScopes, ScopeIDP(authRequest.IDPHint))
+	}
+	switch {
+	case authRequest.Tenant.OrgID != "":
+		additionalScopes = append(additionalScopes, ScopeOrgID(authRequest.Tenant.OrgID))
+	case authRequest.Tenant.OrgDomain != "":
+		additionalScopes = append(additionalScopes, ScopeOrgDomain(authRequest.Tenant.OrgDomain))
+	}
+	if len(additionalScopes) > 0 {
+		scopes := slices.Clone(c.relyingParty.OAuthConfig().Scopes)
+		for _, scope := range additionalScopes {
+			if !slices.Contains(scopes, scope) {
+				scopes = append(scopes, scope)
+			}
+		}
+		params = append(params, rp.WithURLParam("scope", strings.Join(scopes, " ")))
+	}
+	if authRequest.Claims != nil {
+		claims, err := json.Marshal(authRequest.Claims)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, rp.WithURLParam("claims", string(claims)))
+	}
+	if authRequest.LoginHint != "" {
+		params = append(params, rp.WithURLParam("login_hint", authRequest.LoginHint))
+	}
+	if len(authRequest.ACRValues) > 0 {
+		params = append(params, rp.WithURLParam("acr_values", strings.Join(authRequest.ACRValues, " ")))
+	}
+	if len(authRequest.Prompt) > 0 {
+		params = append(params, rp.WithPromptURLParam(authRequest.Prompt...))
+	}
+	if authRequest.MaxAge > 0 {
+		params = append(params, rp.WithURLParam("max_age", strconv.FormatInt(int64(authRequest.MaxAge.Seconds()), 10)))
+	}
+	if len(authRequest.UILocales) > 0 {
+		params = append(params, rp.WithURLParam("ui_locales", strings.Join(authRequest.UILocales, " ")))
+	}
+	if authRequest.IDTokenHint != "" {
+		params = append(params, rp.WithURLParam("id_token_hint", authRequest.IDTokenHint))
+	}
+	return params, nil
+}
+
+// ScopeOrgID returns the scope, which restricts the login to the organization with the id.
+func ScopeOrgID(orgID string) string {
+	return "urn:zitadel:iam:org:id:" + orgID
+}
+
+// ScopeIDP returns the scope, which redirects the user directly to the external identity provider with the id.
+func ScopeIDP(idpID string) string {
+	return "urn:zitadel:iam:org:idp:id:" + idpID
+}
+
+// ScopeProjectAudience returns the scope, which adds the project with the id to the audience of the tokens.
+func ScopeProjectAudience(projectID string) string {
+	return "urn:zitadel:iam:org:project:id:" + projectID + ":aud"
+}
+
+// ScopeRole returns the scope, which requests the role of the project in the roles claim.
+func ScopeRole(role string) string {
+	return "urn:zitadel:iam:org:project:role:" + role
+}
+
+const (
+	// ScopeProjectsRoles requests the roles of all projects in the audience.
+	ScopeProjectsRoles = "urn:zitadel:iam:org:projects:roles"
+	// ScopeUserMetadata requests the metadata of the user.
+	ScopeUserMetadata = "urn:zitadel:iam:user:metadata"
+)
+
+// ScopeOrgDomain returns the scope, which restricts the login to the organization with the primary domain.
+func ScopeOrgDomain(domain string) string {
+	return "urn:zitadel:iam:org:domain:primary:" + domain
 }
 
 // Callback handles the redirect back from the Login UI and will exchange the code for the tokens.
 // Additionally, it will retrieve the information from the userinfo_endpoint and store everything in the [Ctx].
 func (c *codeFlowAuthentication[T, C, S]) Callback(w http.ResponseWriter, r *http.Request) (authCtx T, state string) {
+	if c.flowStore != nil {
+		return c.callbackWithStore(r)
+	}
 	rp.CodeExchangeHandler[C](rp.UserinfoCallback[C, S](func(w http.ResponseWriter, r *http.Request, tokens *oidc.Tokens[C], callbackState string, provider rp.RelyingParty, info S) {
 		state = callbackState
 		authCtx = authCtx.New().(T)
@@ -97,6 +209,48 @@ func (c *codeFlowAuthentication[T, C, S]) Callback(w http.ResponseWriter, r *htt
 	return authCtx, state
 }
 
+// IsExpired implements the [authentication.Refresher] interface.
+// It returns true if the access token is expired (or about to expire) and a refresh token is available.
+// To receive a refresh token, the scope `offline_access` ([oidc.ScopeOfflineAccess]) must be requested
+// and the refresh token grant type must be enabled on the application in ZITADEL.
+func (c *codeFlowAuthentication[T, C, S]) IsExpired(authCtx T) bool {
+	tokens := authCtx.GetTokens()
+	if tokens == nil || tokens.Token == nil || tokens.RefreshToken == "" {
+		return false
+	}
+	return !tokens.Valid()
+}
+
+// Refresh implements the [authentication.Refresher] interface.
+// It exchanges the refresh token for new tokens and retrieves the information from the userinfo_endpoint again.
+// If the token response does not contain a new refresh token, resp. id_token, the previous ones are kept.
+// If ZITADEL rejects the refresh token (`invalid_grant`), the error wraps [authentication.ErrRefreshTokenRejected].
+func (c *codeFlowAuthentication[T, C, S]) Refresh(ctx context.Context, authCtx T) (refreshed T, err error) {
+	previous := authCtx.GetTokens()
+	tokens, err := rp.RefreshTokens[C](ctx, c.relyingParty, previous.RefreshToken, "", "")
+	if errors.Is(err, oidc.ErrInvalidGrant()) {
+		return refreshed, fmt.Errorf("%w: %w", authentication.ErrRefreshTokenRejected, err)
+	}
+	if err != nil {
+		return refreshed, err
+	}
+	if tokens.RefreshToken == "" {
+		tokens.RefreshToken = previous.RefreshToken
+	}
+	if tokens.IDToken == "" {
+		tokens.IDToken = previous.IDToken
+		tokens.IDTokenClaims = previous.IDTokenClaims
+	}
+	info, err := rp.Userinfo[S](ctx, tokens.AccessToken, tokens.TokenType, tokens.IDTokenClaims.GetSubject(), c.relyingParty)
+	if err != nil {
+		return refreshed, err
+	}
+	refreshed = authCtx.New().(T)
+	refreshed.SetTokens(tokens)
+	refreshed.SetUserInfo(info)
+	return refreshed, nil
+}
+
 // Logout will call, resp. redirect to the end_session_endpoint at the Authorization Server (Login UI).
 func (c *codeFlowAuthentication[T, C, S]) Logout(w http.ResponseWriter, r *http.Request, authCtx T, state, optionalRedirectURI string) {
 	// the OIDC library currently does a server side POST request, but the spec. requires a browser call
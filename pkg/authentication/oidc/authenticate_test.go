@@ -0,0 +1,299 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	httphelper "github.com/zitadel/oidc/v3/pkg/http"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"golang.org/x/oauth2"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authentication"
+)
+
+func newDiscoveryServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/oauth/v2/token":
+			if r.FormValue("grant_type") == string(oidc.GrantTypeCode) {
+				if r.FormValue("code") != "code" || r.FormValue("code_verifier") == "" {
+					w.WriteHeader(http.StatusBadRequest)
+					json.NewEncoder(w).Encode(oidc.ErrInvalidGrant())
+					return
+				}
+				json.NewEncoder(w).Encode(&oidc.AccessTokenResponse{
+					AccessToken: "access",
+					TokenType:   oidc.BearerToken,
+					ExpiresIn:   3600,
+					IDToken: signToken(t, testKey, map[string]any{
+						"iss": server.URL,
+						"sub": "user",
+						"aud": "clientID",
+						"exp": time.Now().Add(time.Hour).Unix(),
+						"iat": time.Now().Unix(),
+					}),
+				})
+				return
+			}
+			if r.FormValue("grant_type") != string(oidc.GrantTypeRefreshToken) || r.FormValue("refresh_token") != "refresh" {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(oidc.ErrInvalidGrant())
+				return
+			}
+			json.NewEncoder(w).Encode(&oidc.AccessTokenResponse{
+				AccessToken: "refreshed",
+				TokenType:   oidc.BearerToken,
+				ExpiresIn:   3600,
+			})
+			return
+		case "/oidc/v1/userinfo":
+			if r.Header.Get("authorization") == "Bearer access" {
+				json.NewEncoder(w).Encode(&oidc.UserInfo{Subject: "user"})
+				return
+			}
+			if r.Header.Get("authorization") != "Bearer refreshed" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			json.NewEncoder(w).Encode(&oidc.UserInfo{Subject: "user", UserInfoProfile: oidc.UserInfoProfile{Name: "refreshed"}})
+			return
+		case "/oauth/v2/keys":
+			json.NewEncoder(w).Encode(&jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+				{Key: &testKey.PublicKey, KeyID: "key", Algorithm: string(jose.RS256), Use: "sig"},
+			}})
+			return
+		case oidc.DiscoveryEndpoint:
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(&oidc.DiscoveryConfiguration{
+			Issuer:                server.URL,
+			AuthorizationEndpoint: server.URL + "/oauth/v2/authorize",
+			TokenEndpoint:         server.URL + "/oauth/v2/token",
+			UserinfoEndpoint:      server.URL + "/oidc/v1/userinfo",
+			JwksURI:               server.URL + "/oauth/v2/keys",
+			EndSessionEndpoint:    server.URL + "/oidc/v1/end_session",
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCodeFlowAuthentication_Authenticate(t *testing.T) {
+	key := "01234567890123456789012345678901"
+	tests := []struct {
+		name string
+		auth ClientAuthentication
+	}{
+		{
+			name: "pkce",
+			auth: PKCEAuthentication("clientID", "http://localhost/auth/callback", nil, httphelper.NewCookieHandler([]byte(key), []byte(key), httphelper.WithUnsecure())),
+		},
+		{
+			name: "client secret",
+			auth: ClientIDSecretAuthentication("clientID", "secret", "http://localhost/auth/callback", nil, httphelper.NewCookieHandler([]byte(key), []byte(key), httphelper.WithUnsecure())),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newDiscoveryServer(t)
+			relyingParty, err := tt.auth(context.Background(), server.URL)
+			require.NoError(t, err)
+			c := &codeFlowAuthentication[*UserInfoContext[*oidc.IDTokenClaims, *oidc.UserInfo], *oidc.IDTokenClaims, *oidc.UserInfo]{relyingParty: relyingParty}
+
+			w := httptest.NewRecorder()
+			c.Authenticate(w, httptest.NewRequest(http.MethodGet, "/auth/login", nil), "state")
+
+			require.Equal(t, http.StatusFound, w.Code)
+			location, err := url.Parse(w.Header().Get("Location"))
+			require.NoError(t, err)
+			assert.Equal(t, "/oauth/v2/authorize", location.Path)
+			assert.Equal(t, "S256", location.Query().Get("code_challenge_method"))
+			assert.NotEmpty(t, location.Query().Get("code_challenge"))
+
+			var verifierCookie bool
+			for _, cookie := range w.Result().Cookies() {
+				if cookie.Name == "pkce" {
+					verifierCookie = true
+				}
+			}
+			assert.True(t, verifierCookie, "code verifier cookie not set")
+		})
+	}
+}
+
+func TestCodeFlowAuthentication_Refresh(t *testing.T) {
+	type authCtx = *UserInfoContext[*oidc.IDTokenClaims, *oidc.UserInfo]
+	tests := []struct {
+		name        string
+		tokens      *oidc.Tokens[*oidc.IDTokenClaims]
+		wantExpired bool
+		wantErr     bool
+	}{
+		{
+			name: "valid",
+			tokens: &oidc.Tokens[*oidc.IDTokenClaims]{
+				Token: &oauth2.Token{AccessToken: "access", RefreshToken: "refresh", Expiry: time.Now().Add(time.Hour)},
+			},
+		},
+		{
+			name: "expired without refresh token",
+			tokens: &oidc.Tokens[*oidc.IDTokenClaims]{
+				Token: &oauth2.Token{AccessToken: "access", Expiry: time.Now().Add(-time.Minute)},
+			},
+		},
+		{
+			name: "expired",
+			tokens: &oidc.Tokens[*oidc.IDTokenClaims]{
+				Token:         &oauth2.Token{AccessToken: "access", RefreshToken: "refresh", Expiry: time.Now().Add(-time.Minute)},
+				IDToken:       "idToken",
+				IDTokenClaims: &oidc.IDTokenClaims{TokenClaims: oidc.TokenClaims{Subject: "user"}},
+			},
+			wantExpired: true,
+		},
+		{
+			name: "invalid refresh token",
+			tokens: &oidc.Tokens[*oidc.IDTokenClaims]{
+				Token:         &oauth2.Token{AccessToken: "access", RefreshToken: "invalid", Expiry: time.Now().Add(-time.Minute)},
+				IDTokenClaims: &oidc.IDTokenClaims{TokenClaims: oidc.TokenClaims{Subject: "user"}},
+			},
+			wantExpired: true,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newDiscoveryServer(t)
+			relyingParty, err := ClientIDSecretAuthentication("clientID", "secret", "http://localhost/auth/callback", nil, nil)(context.Background(), server.URL)
+			require.NoError(t, err)
+			c := &codeFlowAuthentication[authCtx, *oidc.IDTokenClaims, *oidc.UserInfo]{relyingParty: relyingParty}
+			session := &UserInfoContext[*oidc.IDTokenClaims, *oidc.UserInfo]{Tokens: tt.tokens}
+
+			assert.Equal(t, tt.wantExpired, c.IsExpired(session))
+			if !tt.wantExpired {
+				return
+			}
+			refreshed, err := c.Refresh(context.Background(), session)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, authentication.ErrRefreshTokenRejected)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "refreshed", refreshed.GetTokens().AccessToken)
+			assert.Equal(t, "refresh", refreshed.GetTokens().RefreshToken)
+			assert.Equal(t, "idToken", refreshed.GetTokens().IDToken)
+			assert.Equal(t, "refreshed", refreshed.GetUserInfo().Name)
+			assert.False(t, c.IsExpired(refreshed))
+		})
+	}
+}
+
+func TestCodeFlowAuthentication_Authenticate_authRequest(t *testing.T) {
+	tests := []struct {
+		name          string
+		authRequest   authentication.AuthRequest
+		wantScope     string
+		wantLoginHint string
+		wantACRValues string
+		wantMaxAge    string
+		wantPrompt    string
+		wantIDToken   string
+		wantClaims    string
+		wantUILocales string
+	}{
+		{
+			name:      "none",
+			wantScope: "openid profile",
+		},
+		{
+			name:          "org id and login hint",
+			authRequest:   authentication.AuthRequest{Tenant: authentication.Tenant{OrgID: "orgID", OrgDomain: "ignored"}, LoginHint: "user@example.com"},
+			wantScope:     "openid profile urn:zitadel:iam:org:id:orgID",
+			wantLoginHint: "user@example.com",
+		},
+		{
+			name:        "org domain",
+			authRequest: authentication.AuthRequest{Tenant: authentication.Tenant{OrgDomain: "acme.example.com"}},
+			wantScope:   "openid profile urn:zitadel:iam:org:domain:primary:acme.example.com",
+		},
+		{
+			name:          "step-up",
+			authRequest:   authentication.AuthRequest{ACRValues: []string{"mfa", "phr"}, MaxAge: 5 * time.Minute},
+			wantScope:     "openid profile",
+			wantACRValues: "mfa phr",
+			wantMaxAge:    "300",
+		},
+		{
+			name:        "silent",
+			authRequest: authentication.AuthRequest{Prompt: []string{"none"}},
+			wantScope:   "openid profile",
+			wantPrompt:  "none",
+		},
+		{
+			name:        "id token hint",
+			authRequest: authentication.AuthRequest{Prompt: []string{"none"}, IDTokenHint: "idToken"},
+			wantScope:   "openid profile",
+			wantPrompt:  "none",
+			wantIDToken: "idToken",
+		},
+		{
+			name: "additional scopes and claims",
+			authRequest: authentication.AuthRequest{
+				Tenant: authentication.Tenant{OrgID: "orgID"},
+				Scopes: []string{ScopeProjectAudience("projectID"), ScopeProjectsRoles, "profile"},
+				Claims: &authentication.ClaimsRequest{UserInfo: map[string]*authentication.ClaimRequest{"email": {Essential: true}}},
+			},
+			wantScope:  "openid profile urn:zitadel:iam:org:project:id:projectID:aud urn:zitadel:iam:org:projects:roles urn:zitadel:iam:org:id:orgID",
+			wantClaims: `{"userinfo":{"email":{"essential":true}}}`,
+		},
+		{
+			name:          "ui locales",
+			authRequest:   authentication.AuthRequest{UILocales: []string{"de-CH", "de"}},
+			wantScope:     "openid profile",
+			wantUILocales: "de-CH de",
+		},
+		{
+			name:        "idp hint",
+			authRequest: authentication.AuthRequest{IDPHint: "idpID"},
+			wantScope:   "openid profile urn:zitadel:iam:org:idp:id:idpID",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newDiscoveryServer(t)
+			key := "01234567890123456789012345678901"
+			relyingParty, err := PKCEAuthentication("clientID", "http://localhost/auth/callback", []string{"openid", "profile"}, httphelper.NewCookieHandler([]byte(key), []byte(key), httphelper.WithUnsecure()))(context.Background(), server.URL)
+			require.NoError(t, err)
+			c := &codeFlowAuthentication[*UserInfoContext[*oidc.IDTokenClaims, *oidc.UserInfo], *oidc.IDTokenClaims, *oidc.UserInfo]{relyingParty: relyingParty}
+
+			req := httptest.NewRequest(http.MethodGet, "/auth/login", nil)
+			req = req.WithContext(authentication.WithAuthRequest(req.Context(), tt.authRequest))
+			w := httptest.NewRecorder()
+			c.Authenticate(w, req, "state")
+
+			location, err := url.Parse(w.Header().Get("Location"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantScope, location.Query().Get("scope"))
+			assert.Equal(t, tt.wantLoginHint, location.Query().Get("login_hint"))
+			assert.Equal(t, tt.wantACRValues, location.Query().Get("acr_values"))
+			assert.Equal(t, tt.wantMaxAge, location.Query().Get("max_age"))
+			assert.Equal(t, tt.wantPrompt, location.Query().Get("prompt"))
+			assert.Equal(t, tt.wantIDToken, location.Query().Get("id_token_hint"))
+			assert.Equal(t, tt.wantClaims, location.Query().Get("claims"))
+			assert.Equal(t, tt.wantUILocales, location.Query().Get("ui_locales"))
+			assert.Equal(t, []string{"openid", "profile"}, relyingParty.OAuthConfig().Scopes, "configured scopes must not change")
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+
+	httphelper "github.com/zitadel/oidc/v3/pkg/http"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authentication"
+)
+
+// ClaimsContext extends the [UserInfoContext] with typed claims CC, into which all claims of the userinfo
+// are unmarshaled, e.g. the custom claims added by ZITADEL actions (tenant ID, plan, ...):
+//
+//	type MyClaims struct {
+//		TenantID string                       `json:"tenant_id"`
+//		Plan     string                       `json:"plan"`
+//		Roles    map[string]map[string]string `json:"urn:zitadel:iam:org:project:roles"`
+//	}
+//
+// If the claims cannot be unmarshaled into CC, the user is not authenticated.
+type ClaimsContext[CC any] struct {
+	UserInfoContext[*oidc.IDTokenClaims, *oidc.UserInfo]
+	Claims CC
+
+	claimsErr error
+}
+
+func (c *ClaimsContext[CC]) New() Ctx[*oidc.IDTokenClaims, *oidc.UserInfo] {
+	return &ClaimsContext[CC]{}
+}
+
+// IsAuthenticated implements [authentication.Ctx] by checking the `sub` claim of the [oidc.UserInfo]
+// and the successful unmarshalling of the claims.
+func (c *ClaimsContext[CC]) IsAuthenticated() bool {
+	if c == nil || c.claimsErr != nil {
+		return false
+	}
+	return c.UserInfoContext.IsAuthenticated()
+}
+
+// SetUserInfo implements [Ctx] and unmarshals the claims of the userinfo into the typed Claims.
+func (c *ClaimsContext[CC]) SetUserInfo(info *oidc.UserInfo) {
+	c.UserInfo = info
+	c.Claims, c.claimsErr = unmarshalClaims[CC](info)
+}
+
+// ClaimsError returns the error, if the claims of the userinfo could not be unmarshaled into the typed Claims.
+func (c *ClaimsContext[CC]) ClaimsError() error {
+	return c.claimsErr
+}
+
+func unmarshalClaims[CC any](info *oidc.UserInfo) (claims CC, err error) {
+	if info == nil {
+		return claims, nil
+	}
+	// the marshalled userinfo contains the standard claims as well as all additional claims
+	data, err := json.Marshal(info)
+	if err != nil {
+		return claims, err
+	}
+	err = json.Unmarshal(data, &claims)
+	return claims, err
+}
+
+// Claims returns the typed claims of the authenticated user of the [ClaimsContext] in the context,
+// resp. the zero value if there is none.
+func Claims[CC any](ctx context.Context) (claims CC) {
+	authCtx := authentication.Context[*ClaimsContext[CC]](ctx)
+	if authCtx == nil {
+		return claims
+	}
+	return authCtx.Claims
+}
+
+// DefaultAuthenticationWithClaims is a short version of [WithCodeFlow[*ClaimsContext[CC], *oidc.IDTokenClaims, *oidc.UserInfo]]
+// with the client_id, redirectURI and encryptionKey and optional scopes, providing the typed claims CC.
+// If no scopes are provided, `"openid", "profile", "email"` will be used.
+func DefaultAuthenticationWithClaims[CC any](clientID, redirectURI string, key string, scopes ...string) authentication.HandlerInitializer[*ClaimsContext[CC]] {
+	if len(scopes) == 0 {
+		scopes = []string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail}
+	}
+	return WithCodeFlow[*ClaimsContext[CC], *oidc.IDTokenClaims, *oidc.UserInfo](
+		PKCEAuthentication(clientID, redirectURI, scopes, httphelper.NewCookieHandler([]byte(key), []byte(key))),
+	)
+}
@@ -0,0 +1,93 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authentication"
+)
+
+type testClaims struct {
+	Email    string                       `json:"email"`
+	TenantID string                       `json:"tenant_id"`
+	Plan     string                       `json:"plan"`
+	Roles    map[string]map[string]string `json:"urn:zitadel:iam:org:project:roles"`
+}
+
+func TestClaimsContext(t *testing.T) {
+	tests := []struct {
+		name     string
+		info     *oidc.UserInfo
+		want     testClaims
+		wantAuth bool
+	}{
+		{
+			name: "custom claims",
+			info: &oidc.UserInfo{
+				Subject:       "user",
+				UserInfoEmail: oidc.UserInfoEmail{Email: "user@example.com"},
+				Claims: map[string]any{
+					"tenant_id": "tenant",
+					"plan":      "pro",
+					"urn:zitadel:iam:org:project:roles": map[string]any{
+						"admin": map[string]any{"org": "example.com"},
+					},
+				},
+			},
+			want: testClaims{
+				Email:    "user@example.com",
+				TenantID: "tenant",
+				Plan:     "pro",
+				Roles:    map[string]map[string]string{"admin": {"org": "example.com"}},
+			},
+			wantAuth: true,
+		},
+		{
+			name:     "missing claims",
+			info:     &oidc.UserInfo{Subject: "user"},
+			wantAuth: true,
+		},
+		{
+			name: "invalid claims",
+			info: &oidc.UserInfo{
+				Subject: "user",
+				Claims:  map[string]any{"plan": 1},
+			},
+			wantAuth: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authCtx := (&ClaimsContext[testClaims]{}).New().(*ClaimsContext[testClaims])
+			authCtx.SetUserInfo(tt.info)
+			assert.Equal(t, tt.wantAuth, authCtx.IsAuthenticated())
+			assert.Equal(t, tt.wantAuth, authCtx.ClaimsError() == nil)
+			if !tt.wantAuth {
+				return
+			}
+			assert.Equal(t, tt.want, authCtx.Claims)
+			assert.Equal(t, tt.want, Claims[testClaims](authentication.WithAuthContext(context.Background(), authCtx)))
+		})
+	}
+}
+
+func TestClaimsContext_json(t *testing.T) {
+	authCtx := new(ClaimsContext[testClaims])
+	authCtx.SetUserInfo(&oidc.UserInfo{Subject: "user", Claims: map[string]any{"tenant_id": "tenant"}})
+
+	data, err := json.Marshal(authCtx)
+	require.NoError(t, err)
+	stored := new(ClaimsContext[testClaims])
+	require.NoError(t, json.Unmarshal(data, stored))
+	assert.True(t, stored.IsAuthenticated())
+	assert.Equal(t, "tenant", stored.Claims.TenantID)
+}
+
+func TestClaims_noContext(t *testing.T) {
+	assert.Equal(t, testClaims{}, Claims[testClaims](context.Background()))
+}
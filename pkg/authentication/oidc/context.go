@@ -1,6 +1,10 @@
 package oidc
 
 import (
+	"encoding/json"
+	"slices"
+	"time"
+
 	"github.com/zitadel/oidc/v3/pkg/client/rp"
 	"github.com/zitadel/oidc/v3/pkg/oidc"
 )
@@ -42,3 +46,131 @@ func (c *UserInfoContext[C, S]) SetUserInfo(info S) {
 func (c *UserInfoContext[C, S]) GetUserInfo() S {
 	return c.UserInfo
 }
+
+// GetIDToken implements [authentication.IDTokenHolder] by returning the id_token of the [oidc.Tokens].
+// It's kept on a refresh, if the token response does not contain a new one.
+func (c *UserInfoContext[C, S]) GetIDToken() string {
+	if c == nil || c.Tokens == nil {
+		return ""
+	}
+	return c.Tokens.IDToken
+}
+
+// GetSubject implements [authentication.SessionIdentifier]
+func (c *UserInfoContext[C, S]) GetSubject() string {
+	return c.UserInfo.GetSubject()
+}
+
+// GetSessionID implements [authentication.SessionIdentifier] by returning the `sid` claim of the id_token.
+func (c *UserInfoContext[C, S]) GetSessionID() string {
+	if c.Tokens == nil || c.Tokens.IDToken == "" {
+		return ""
+	}
+	claims := new(struct {
+		SessionID string `json:"sid"`
+	})
+	if _, err := oidc.ParseToken(c.Tokens.IDToken, claims); err != nil {
+		return ""
+	}
+	return claims.SessionID
+}
+
+// GetAuthTime implements [authentication.AuthenticationLevel] by returning the `auth_time` claim of the id_token.
+func (c *UserInfoContext[C, S]) GetAuthTime() time.Time {
+	return c.authenticationClaims().AuthTime.AsTime()
+}
+
+// GetACR implements [authentication.AuthenticationLevel] by returning the `acr` claim of the id_token.
+func (c *UserInfoContext[C, S]) GetACR() string {
+	return c.authenticationClaims().ACR
+}
+
+// GetAccessToken returns the access token of the [oidc.Tokens], e.g. to call other APIs on behalf of the user.
+func (c *UserInfoContext[C, S]) GetAccessToken() string {
+	if c == nil || c.Tokens == nil || c.Tokens.Token == nil {
+		return ""
+	}
+	return c.Tokens.AccessToken
+}
+
+// GetTokenExpiry returns the expiry of the access token.
+func (c *UserInfoContext[C, S]) GetTokenExpiry() time.Time {
+	if c == nil || c.Tokens == nil || c.Tokens.Token == nil {
+		return time.Time{}
+	}
+	return c.Tokens.Expiry
+}
+
+// GetAMR returns the authentication methods (`amr` claim) of the id_token, e.g. `pwd` and `mfa`.
+func (c *UserInfoContext[C, S]) GetAMR() []string {
+	return c.authenticationClaims().AMR
+}
+
+// GetOrgID returns the id of the organization of the user (`urn:zitadel:iam:user:resourceowner:id` claim)
+// of the id_token, resp. the userinfo.
+func (c *UserInfoContext[C, S]) GetOrgID() string {
+	if orgID := c.authenticationClaims().OrgID; orgID != "" {
+		return orgID
+	}
+	return c.userInfoClaims().OrgID
+}
+
+// GetRoles returns the (sorted) roles granted to the user (`urn:zitadel:iam:org:project:roles` claim)
+// of the id_token, resp. the userinfo. The roles are only returned by ZITADEL, if requested by the scope
+// `urn:zitadel:iam:org:projects:roles` or if enabled on the project.
+func (c *UserInfoContext[C, S]) GetRoles() []string {
+	roles := c.authenticationClaims().Roles
+	if len(roles) == 0 {
+		roles = c.userInfoClaims().Roles
+	}
+	result := make([]string, 0, len(roles))
+	for role := range roles {
+		result = append(result, role)
+	}
+	slices.Sort(result)
+	return result
+}
+
+// GetLocale returns the preferred language of the user (`locale` claim) of the id_token, resp. the userinfo.
+func (c *UserInfoContext[C, S]) GetLocale() string {
+	if locale := c.authenticationClaims().Locale; locale != "" {
+		return locale
+	}
+	return c.userInfoClaims().Locale
+}
+
+type authenticationClaims struct {
+	AuthTime oidc.Time                    `json:"auth_time"`
+	ACR      string                       `json:"acr"`
+	AMR      []string                     `json:"amr"`
+	OrgID    string                       `json:"urn:zitadel:iam:user:resourceowner:id"`
+	Roles    map[string]map[string]string `json:"urn:zitadel:iam:org:project:roles"`
+	Locale   string                       `json:"locale"`
+}
+
+func (c *UserInfoContext[C, S]) authenticationClaims() *authenticationClaims {
+	claims := new(authenticationClaims)
+	if c == nil || c.Tokens == nil || c.Tokens.IDToken == "" {
+		return claims
+	}
+	if _, err := oidc.ParseToken(c.Tokens.IDToken, claims); err != nil {
+		return new(authenticationClaims)
+	}
+	return claims
+}
+
+// userInfoClaims returns the claims of the userinfo, which might contain claims not present in the id_token.
+func (c *UserInfoContext[C, S]) userInfoClaims() *authenticationClaims {
+	claims := new(authenticationClaims)
+	if c == nil {
+		return claims
+	}
+	data, err := json.Marshal(c.UserInfo)
+	if err != nil {
+		return claims
+	}
+	if err = json.Unmarshal(data, claims); err != nil {
+		return new(authenticationClaims)
+	}
+	return claims
+}
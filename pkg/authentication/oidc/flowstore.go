@@ -0,0 +1,101 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/zitadel/oidc/v3/pkg/client/rp"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+)
+
+// flowExpiration is the time a user has to complete the login at the Login UI,
+// before the stored code verifier expires.
+const flowExpiration = 10 * time.Minute
+
+var (
+	ErrFlowNotFound = errors.New("no pending authentication found for the state")
+)
+
+// FlowStore stores the PKCE code verifier of a pending authentication between the redirect to the Login UI
+// and the callback. Entries are keyed by the state parameter and must be used only once.
+// Use a FlowStore instead of the cookies of the [httphelper.CookieHandler], if cookies
+// cannot be used, e.g. because of strict cookie policies or if the callback is served on a different domain.
+type FlowStore interface {
+	// Set stores the codeVerifier for the state until the expiration.
+	Set(ctx context.Context, state, codeVerifier string, expiration time.Duration) error
+	// GetAndDelete returns the code verifier of the state and removes it from the store.
+	// If there is none, [ErrFlowNotFound] must be returned.
+	GetAndDelete(ctx context.Context, state string) (string, error)
+}
+
+// CodeFlowOption allows to customize the [WithCodeFlow] implementation.
+type CodeFlowOption func(*codeFlowOptions)
+
+type codeFlowOptions struct {
+	flowStore FlowStore
+}
+
+// WithFlowStore stores the PKCE code verifier in the [FlowStore] instead of a cookie.
+// The state parameter itself is still protected by the encryption of the [authentication.Authenticator].
+func WithFlowStore(store FlowStore) CodeFlowOption {
+	return func(o *codeFlowOptions) {
+		o.flowStore = store
+	}
+}
+
+// authenticateWithStore is the equivalent of [rp.AuthURLHandler], but stores the code verifier in the [FlowStore].
+func (c *codeFlowAuthentication[T, C, S]) authenticateWithStore(w http.ResponseWriter, r *http.Request, state string, params []rp.URLParamOpt) {
+	codeVerifier, err := newCodeVerifier()
+	if err != nil {
+		http.Error(w, "failed to create code challenge: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err = c.flowStore.Set(r.Context(), state, codeVerifier, flowExpiration); err != nil {
+		http.Error(w, "failed to store code verifier: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	opts := make([]rp.AuthURLOpt, 0, len(params)+1)
+	for _, p := range params {
+		opts = append(opts, rp.AuthURLOpt(p))
+	}
+	opts = append(opts, rp.WithCodeChallenge(oidc.NewSHACodeChallenge(codeVerifier)))
+	http.Redirect(w, r, rp.AuthURL(state, c.relyingParty, opts...), http.StatusFound)
+}
+
+// callbackWithStore is the equivalent of [rp.CodeExchangeHandler] and [rp.UserinfoCallback],
+// but uses the code verifier from the [FlowStore].
+// Any error results in an unauthenticated [Ctx].
+func (c *codeFlowAuthentication[T, C, S]) callbackWithStore(r *http.Request) (authCtx T, state string) {
+	state = r.FormValue("state")
+	if state == "" {
+		return authCtx, ""
+	}
+	codeVerifier, err := c.flowStore.GetAndDelete(r.Context(), state)
+	if err != nil {
+		return authCtx, state
+	}
+	tokens, err := rp.CodeExchange[C](r.Context(), r.FormValue("code"), c.relyingParty, rp.WithCodeVerifier(codeVerifier))
+	if err != nil {
+		return authCtx, state
+	}
+	info, err := rp.Userinfo[S](r.Context(), tokens.AccessToken, tokens.TokenType, tokens.IDTokenClaims.GetSubject(), c.relyingParty)
+	if err != nil {
+		return authCtx, state
+	}
+	authCtx = authCtx.New().(T)
+	authCtx.SetTokens(tokens)
+	authCtx.SetUserInfo(info)
+	return authCtx, state
+}
+
+func newCodeVerifier() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,102 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+)
+
+type memoryFlowStore struct {
+	mu        sync.Mutex
+	verifiers map[string]string
+}
+
+func (m *memoryFlowStore) Set(_ context.Context, state, codeVerifier string, _ time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.verifiers[state] = codeVerifier
+	return nil
+}
+
+func (m *memoryFlowStore) GetAndDelete(_ context.Context, state string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	codeVerifier, ok := m.verifiers[state]
+	if !ok {
+		return "", ErrFlowNotFound
+	}
+	delete(m.verifiers, state)
+	return codeVerifier, nil
+}
+
+func TestCodeFlowAuthentication_flowStore(t *testing.T) {
+	tests := []struct {
+		name            string
+		callbackState   string
+		code            string
+		wantAuthCtx     bool
+		wantStoredAfter bool
+	}{
+		{
+			name:          "authenticated",
+			callbackState: "state",
+			code:          "code",
+			wantAuthCtx:   true,
+		},
+		{
+			name:            "unknown state",
+			callbackState:   "other",
+			code:            "code",
+			wantStoredAfter: true,
+		},
+		{
+			name:          "invalid code",
+			callbackState: "state",
+			code:          "invalid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newDiscoveryServer(t)
+			relyingParty, err := PKCEAuthentication("clientID", "http://localhost/auth/callback", nil, nil)(context.Background(), server.URL)
+			require.NoError(t, err)
+			store := &memoryFlowStore{verifiers: make(map[string]string)}
+			c := &codeFlowAuthentication[*UserInfoContext[*oidc.IDTokenClaims, *oidc.UserInfo], *oidc.IDTokenClaims, *oidc.UserInfo]{
+				relyingParty: relyingParty,
+				flowStore:    store,
+			}
+
+			w := httptest.NewRecorder()
+			c.Authenticate(w, httptest.NewRequest(http.MethodGet, "/auth/login", nil), "state")
+
+			require.Equal(t, http.StatusFound, w.Code)
+			assert.Empty(t, w.Result().Cookies(), "no cookie must be set")
+			location, err := url.Parse(w.Header().Get("Location"))
+			require.NoError(t, err)
+			require.Contains(t, store.verifiers, "state")
+			assert.Equal(t, "state", location.Query().Get("state"))
+			assert.Equal(t, "S256", location.Query().Get("code_challenge_method"))
+			assert.Equal(t, oidc.NewSHACodeChallenge(store.verifiers["state"]), location.Query().Get("code_challenge"))
+
+			callback := httptest.NewRequest(http.MethodGet, "/auth/callback?"+url.Values{"code": {tt.code}, "state": {tt.callbackState}}.Encode(), nil)
+			authCtx, state := c.Callback(httptest.NewRecorder(), callback)
+
+			assert.Equal(t, tt.callbackState, state)
+			assert.Equal(t, tt.wantAuthCtx, authCtx.IsAuthenticated())
+			if tt.wantAuthCtx {
+				assert.Equal(t, "user", authCtx.UserInfo.Subject)
+				assert.Equal(t, "access", authCtx.GetTokens().AccessToken)
+			}
+			_, stored := store.verifiers["state"]
+			assert.Equal(t, tt.wantStoredAfter, stored)
+		})
+	}
+}
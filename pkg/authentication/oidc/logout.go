@@ -0,0 +1,101 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/zitadel/oidc/v3/pkg/client"
+	"github.com/zitadel/oidc/v3/pkg/client/rp"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/zitadel"
+)
+
+const backChannelLogoutEvent = "http://schemas.openid.net/event/backchannel-logout"
+
+var (
+	ErrInvalidLogoutToken = errors.New("invalid logout token")
+)
+
+// logoutTokenClaims are the claims of a logout token as defined in
+// https://openid.net/specs/openid-connect-backchannel-1_0.html#LogoutToken
+type logoutTokenClaims struct {
+	oidc.TokenClaims
+	SessionID string         `json:"sid,omitempty"`
+	Events    map[string]any `json:"events,omitempty"`
+}
+
+// Issuer implements the [authentication.IssuerProvider] interface.
+func (c *codeFlowAuthentication[T, C, S]) Issuer() string {
+	return c.relyingParty.Issuer()
+}
+
+// VerifyLogoutToken implements the [authentication.LogoutTokenVerifier] interface.
+// It validates the logout token as described in [LogoutTokenVerifier.VerifyLogoutToken].
+func (c *codeFlowAuthentication[T, C, S]) VerifyLogoutToken(ctx context.Context, token string) (subject, sessionID string, err error) {
+	return verifyLogoutToken(ctx, token, c.relyingParty.IDTokenVerifier())
+}
+
+// LogoutTokenVerifier verifies the logout tokens of the OpenID Connect Back-Channel Logout independent of
+// an [authentication.Authenticator], e.g. for services managing their own sessions.
+type LogoutTokenVerifier struct {
+	verifier *rp.IDTokenVerifier
+}
+
+// NewLogoutTokenVerifier creates a [LogoutTokenVerifier] for logout tokens issued by ZITADEL to the clientID.
+// The issuer and the public keys (JWKS endpoint) are retrieved by the discovery endpoint of ZITADEL.
+func NewLogoutTokenVerifier(ctx context.Context, zitadel *zitadel.Zitadel, clientID string, options ...rp.VerifierOption) (*LogoutTokenVerifier, error) {
+	discovery, err := client.Discover(ctx, zitadel.Origin(), http.DefaultClient)
+	if err != nil {
+		return nil, err
+	}
+	keySet := rp.NewRemoteKeySet(http.DefaultClient, discovery.JwksURI)
+	return &LogoutTokenVerifier{
+		verifier: rp.NewIDTokenVerifier(discovery.Issuer, clientID, keySet, options...),
+	}, nil
+}
+
+// VerifyLogoutToken validates the logout token as defined by the OpenID Connect Back-Channel Logout specification:
+// the signature, issuer, audience, iat (and exp if present), the backchannel logout event,
+// the presence of the sub and / or sid claim and the absence of a nonce.
+// It returns the subject (sub) and session id (sid) of the sessions to be terminated.
+func (l *LogoutTokenVerifier) VerifyLogoutToken(ctx context.Context, token string) (subject, sessionID string, err error) {
+	return verifyLogoutToken(ctx, token, l.verifier)
+}
+
+func verifyLogoutToken(ctx context.Context, token string, v *rp.IDTokenVerifier) (subject, sessionID string, err error) {
+	claims := new(logoutTokenClaims)
+	payload, err := oidc.ParseToken(token, claims)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %v", ErrInvalidLogoutToken, err)
+	}
+	if err = oidc.CheckIssuer(claims, v.Issuer); err != nil {
+		return "", "", fmt.Errorf("%w: %v", ErrInvalidLogoutToken, err)
+	}
+	if err = oidc.CheckAudience(claims, v.ClientID); err != nil {
+		return "", "", fmt.Errorf("%w: %v", ErrInvalidLogoutToken, err)
+	}
+	if err = oidc.CheckSignature(ctx, token, payload, claims, v.SupportedSignAlgs, v.KeySet); err != nil {
+		return "", "", fmt.Errorf("%w: %v", ErrInvalidLogoutToken, err)
+	}
+	if !claims.Expiration.AsTime().IsZero() {
+		if err = oidc.CheckExpiration(claims, v.Offset); err != nil {
+			return "", "", fmt.Errorf("%w: %v", ErrInvalidLogoutToken, err)
+		}
+	}
+	if err = oidc.CheckIssuedAt(claims, v.MaxAgeIAT, v.Offset); err != nil {
+		return "", "", fmt.Errorf("%w: %v", ErrInvalidLogoutToken, err)
+	}
+	if _, ok := claims.Events[backChannelLogoutEvent]; !ok {
+		return "", "", fmt.Errorf("%w: backchannel logout event missing", ErrInvalidLogoutToken)
+	}
+	if claims.Subject == "" && claims.SessionID == "" {
+		return "", "", fmt.Errorf("%w: sub and sid missing", ErrInvalidLogoutToken)
+	}
+	if claims.Nonce != "" {
+		return "", "", fmt.Errorf("%w: nonce must not be present", ErrInvalidLogoutToken)
+	}
+	return claims.Subject, claims.SessionID, nil
+}
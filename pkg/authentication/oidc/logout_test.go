@@ -0,0 +1,199 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"golang.org/x/oauth2"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authentication"
+	"github.com/zitadel/zitadel-go/v3/pkg/zitadel"
+)
+
+var testKey, _ = rsa.GenerateKey(rand.Reader, 2048)
+
+func signToken(t *testing.T, key *rsa.PrivateKey, claims map[string]any) string {
+	t.Helper()
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: jose.JSONWebKey{Key: key, KeyID: "key"}}, nil)
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	jws, err := signer.Sign(payload)
+	require.NoError(t, err)
+	token, err := jws.CompactSerialize()
+	require.NoError(t, err)
+	return token
+}
+
+func TestCodeFlowAuthentication_VerifyLogoutToken(t *testing.T) {
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	server := newDiscoveryServer(t)
+	relyingParty, err := PKCEAuthentication("clientID", "http://localhost/auth/callback", nil, nil)(context.Background(), server.URL)
+	require.NoError(t, err)
+	c := &codeFlowAuthentication[*UserInfoContext[*oidc.IDTokenClaims, *oidc.UserInfo], *oidc.IDTokenClaims, *oidc.UserInfo]{relyingParty: relyingParty}
+
+	validClaims := func(modify func(claims map[string]any)) map[string]any {
+		claims := map[string]any{
+			"iss":    server.URL,
+			"aud":    "clientID",
+			"iat":    time.Now().Unix(),
+			"exp":    time.Now().Add(time.Minute).Unix(),
+			"jti":    "jti",
+			"sub":    "user",
+			"sid":    "sid",
+			"events": map[string]any{"http://schemas.openid.net/event/backchannel-logout": map[string]any{}},
+		}
+		if modify != nil {
+			modify(claims)
+		}
+		return claims
+	}
+	tests := []struct {
+		name        string
+		key         *rsa.PrivateKey
+		claims      map[string]any
+		wantSubject string
+		wantSID     string
+		wantErr     bool
+	}{
+		{
+			name:        "valid",
+			claims:      validClaims(nil),
+			wantSubject: "user",
+			wantSID:     "sid",
+		},
+		{
+			name:        "sid only",
+			claims:      validClaims(func(claims map[string]any) { delete(claims, "sub") }),
+			wantSubject: "",
+			wantSID:     "sid",
+		},
+		{
+			name:    "invalid signature",
+			key:     otherKey,
+			claims:  validClaims(nil),
+			wantErr: true,
+		},
+		{
+			name:    "wrong issuer",
+			claims:  validClaims(func(claims map[string]any) { claims["iss"] = "https://other.example.com" }),
+			wantErr: true,
+		},
+		{
+			name:    "wrong audience",
+			claims:  validClaims(func(claims map[string]any) { claims["aud"] = "other" }),
+			wantErr: true,
+		},
+		{
+			name:    "expired",
+			claims:  validClaims(func(claims map[string]any) { claims["exp"] = time.Now().Add(-time.Minute).Unix() }),
+			wantErr: true,
+		},
+		{
+			name:    "event missing",
+			claims:  validClaims(func(claims map[string]any) { delete(claims, "events") }),
+			wantErr: true,
+		},
+		{
+			name:    "sub and sid missing",
+			claims:  validClaims(func(claims map[string]any) { delete(claims, "sub"); delete(claims, "sid") }),
+			wantErr: true,
+		},
+		{
+			name:    "nonce present",
+			claims:  validClaims(func(claims map[string]any) { claims["nonce"] = "nonce" }),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := tt.key
+			if key == nil {
+				key = testKey
+			}
+			subject, sid, err := c.VerifyLogoutToken(context.Background(), signToken(t, key, tt.claims))
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidLogoutToken)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSubject, subject)
+			assert.Equal(t, tt.wantSID, sid)
+		})
+	}
+}
+
+func TestLogoutTokenVerifier_VerifyLogoutToken(t *testing.T) {
+	server := newDiscoveryServer(t)
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	verifier, err := NewLogoutTokenVerifier(context.Background(), zitadel.New(serverURL.Hostname(), zitadel.WithInsecure(serverURL.Port())), "clientID")
+	require.NoError(t, err)
+
+	claims := map[string]any{
+		"iss":    server.URL,
+		"aud":    "clientID",
+		"iat":    time.Now().Unix(),
+		"jti":    "jti",
+		"sub":    "user",
+		"sid":    "sid",
+		"events": map[string]any{"http://schemas.openid.net/event/backchannel-logout": map[string]any{}},
+	}
+	subject, sid, err := verifier.VerifyLogoutToken(context.Background(), signToken(t, testKey, claims))
+	require.NoError(t, err)
+	assert.Equal(t, "user", subject)
+	assert.Equal(t, "sid", sid)
+
+	claims["aud"] = "other"
+	_, _, err = verifier.VerifyLogoutToken(context.Background(), signToken(t, testKey, claims))
+	assert.ErrorIs(t, err, ErrInvalidLogoutToken)
+}
+
+func TestUserInfoContext_GetSessionID(t *testing.T) {
+	authCtx := &UserInfoContext[*oidc.IDTokenClaims, *oidc.UserInfo]{
+		UserInfo: &oidc.UserInfo{Subject: "user"},
+		Tokens:   &oidc.Tokens[*oidc.IDTokenClaims]{IDToken: signToken(t, testKey, map[string]any{"sub": "user", "sid": "sid"})},
+	}
+	assert.Equal(t, "user", authCtx.GetSubject())
+	assert.Equal(t, "sid", authCtx.GetSessionID())
+	assert.Empty(t, (&UserInfoContext[*oidc.IDTokenClaims, *oidc.UserInfo]{}).GetSessionID())
+}
+
+func TestUserInfoContext_GetIDToken(t *testing.T) {
+	idToken := signToken(t, testKey, map[string]any{"sub": "user", "sid": "sid"})
+	authCtx := &UserInfoContext[*oidc.IDTokenClaims, *oidc.UserInfo]{
+		UserInfo: &oidc.UserInfo{Subject: "user"},
+		Tokens:   &oidc.Tokens[*oidc.IDTokenClaims]{Token: &oauth2.Token{AccessToken: "access"}, IDToken: idToken},
+	}
+	data, err := json.Marshal(authCtx)
+	require.NoError(t, err)
+	stored := new(UserInfoContext[*oidc.IDTokenClaims, *oidc.UserInfo])
+	require.NoError(t, json.Unmarshal(data, stored))
+	assert.Equal(t, idToken, stored.GetIDToken())
+	assert.Empty(t, (&UserInfoContext[*oidc.IDTokenClaims, *oidc.UserInfo]{}).GetIDToken())
+}
+
+func TestUserInfoContext_AuthenticationLevel(t *testing.T) {
+	authTime := time.Now().Add(-time.Minute).Truncate(time.Second)
+	authCtx := &UserInfoContext[*oidc.IDTokenClaims, *oidc.UserInfo]{
+		UserInfo: &oidc.UserInfo{Subject: "user"},
+		Tokens:   &oidc.Tokens[*oidc.IDTokenClaims]{IDToken: signToken(t, testKey, map[string]any{"sub": "user", "auth_time": authTime.Unix(), "acr": "mfa"})},
+	}
+	assert.Equal(t, authTime, authCtx.GetAuthTime().Local())
+	assert.Equal(t, "mfa", authCtx.GetACR())
+	assert.True(t, authentication.StepUp{ACRValues: []string{"mfa"}, MaxAge: 5 * time.Minute}.SatisfiedBy(authCtx))
+	assert.False(t, authentication.StepUp{MaxAge: 30 * time.Second}.SatisfiedBy(authCtx))
+	assert.Empty(t, (&UserInfoContext[*oidc.IDTokenClaims, *oidc.UserInfo]{}).GetACR())
+	assert.True(t, (&UserInfoContext[*oidc.IDTokenClaims, *oidc.UserInfo]{}).GetAuthTime().IsZero())
+}
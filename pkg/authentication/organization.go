@@ -0,0 +1,50 @@
+package authentication
+
+import (
+	"net/http"
+)
+
+// orgIDParam is the form parameter of the switch organization endpoint containing the id of the organization,
+// e.g. a POST to `/auth/switch-organization` with `org_id=123&return_to=/dashboard`.
+const orgIDParam = "org_id"
+
+// SwitchOrganization changes the active organization of the authenticated user without a logout:
+// The user is re-authenticated silently ([Authenticator.SilentAuthenticate]) with the scope of the organization
+// ([Tenant.OrgID]), so the new session contains the claims (e.g. roles) of that organization.
+// If ZITADEL requires an interaction (e.g. because there is no SSO session), the Login UI is shown.
+// On the callback, the previous session is replaced by the new one.
+//
+// Since the session is replaced, the request needs to be protected against CSRF by the caller (see [Interceptor.RequireCSRF]).
+// The `/auth/switch-organization` endpoint only accepts POST requests with a valid CSRF token ([Authenticator.CSRFField]).
+func (a *Authenticator[T]) SwitchOrganization(w http.ResponseWriter, r *http.Request, orgID, requestedURI string) {
+	a.authenticate(w, r, &State{RequestedURI: requestedURI, Silent: true, OrgID: orgID})
+}
+
+// switchOrganization handles the `/auth/switch-organization` endpoint ([Authenticator.SwitchOrganization]).
+func (a *Authenticator[T]) switchOrganization(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		a.error(w, req, ErrMethodNotAllowed, http.StatusMethodNotAllowed)
+		return
+	}
+	// the session is replaced, so the CSRF token is always required (independent of [WithCSRFProtection])
+	if err := a.ValidateCSRF(req); err != nil {
+		a.error(w, req, err, http.StatusForbidden)
+		return
+	}
+	a.SwitchOrganization(w, req, req.PostFormValue(orgIDParam), req.PostFormValue(returnToParam))
+}
+
+// replaceSession deletes the previous session of the request, if the authentication was an organization switch.
+func (a *Authenticator[T]) replaceSession(req *http.Request, state *State) {
+	if state.OrgID == "" {
+		return
+	}
+	sessionID, _, err := a.session(req)
+	if err != nil {
+		return
+	}
+	if err = a.sessions.Delete(sessionID); err != nil {
+		a.logger.Warn("unable to delete previous session", "error", err, "id", sessionID)
+	}
+}
@@ -0,0 +1,98 @@
+package authentication
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthenticator_SwitchOrganization(t *testing.T) {
+	handler := &stepUpHandler{callback: &testCtx{token: "orgToken"}}
+	a, cookie := newTestAuthenticator(t, handler, &testCtx{token: "token", idToken: "idToken"})
+	a.createRouter()
+	w := httptest.NewRecorder()
+	token, err := a.CSRFToken(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.NoError(t, err)
+	csrfCookie := w.Result().Cookies()[0]
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/switch-organization", strings.NewReader(url.Values{orgIDParam: {"org"}, returnToParam: {"/dashboard"}, CSRFFieldName: {token}}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.AddCookie(cookie)
+	req.AddCookie(csrfCookie)
+	a.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, AuthRequest{Tenant: Tenant{OrgID: "org"}, Prompt: []string{"none"}, IDTokenHint: "idToken"}, handler.authRequest)
+	state, err := DecryptState(handler.state, a.encryptionKey)
+	require.NoError(t, err)
+	assert.Equal(t, &State{RequestedURI: "/dashboard", Silent: true, OrgID: "org"}, state)
+
+	req = httptest.NewRequest(http.MethodGet, "/auth/callback", nil)
+	req.AddCookie(cookie)
+	w = httptest.NewRecorder()
+	a.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "/dashboard", w.Header().Get("Location"))
+	_, err = a.sessions.Get("session")
+	assert.Error(t, err, "previous session must be deleted")
+
+	req = httptest.NewRequest(http.MethodGet, "/dashboard", nil)
+	for _, c := range w.Result().Cookies() {
+		req.AddCookie(c)
+	}
+	session, err := a.IsAuthenticated(req)
+	require.NoError(t, err)
+	assert.Equal(t, "orgToken", session.token)
+}
+
+func TestAuthenticator_SwitchOrganization_csrf(t *testing.T) {
+	handler := new(stepUpHandler)
+	a, cookie := newTestAuthenticator(t, handler, &testCtx{token: "token", idToken: "idToken"})
+	a.createRouter()
+	w := httptest.NewRecorder()
+	token, err := a.CSRFToken(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.NoError(t, err)
+	csrfCookie := w.Result().Cookies()[0]
+	form := url.Values{orgIDParam: {"org"}, returnToParam: {"/dashboard"}}
+
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/auth/switch-organization?"+form.Encode(), nil)
+	req.AddCookie(cookie)
+	a.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.Empty(t, handler.state)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/auth/switch-organization", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.AddCookie(cookie)
+	a.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Empty(t, handler.state)
+
+	form.Set(CSRFFieldName, token)
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/auth/switch-organization", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.AddCookie(cookie)
+	req.AddCookie(csrfCookie)
+	a.ServeHTTP(w, req)
+	assert.Equal(t, AuthRequest{Tenant: Tenant{OrgID: "org"}, Prompt: []string{"none"}, IDTokenHint: "idToken"}, handler.authRequest)
+}
+
+func TestAuthenticator_SwitchOrganization_interactionRequired(t *testing.T) {
+	handler := new(stepUpHandler)
+	a, cookie := newTestAuthenticator(t, handler, &testCtx{token: "token", idToken: "idToken"})
+	a.SwitchOrganization(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil), "org", "/dashboard")
+
+	req := httptest.NewRequest(http.MethodGet, "/auth/callback?error=login_required&state="+handler.state, nil)
+	req.AddCookie(cookie)
+	a.Callback(httptest.NewRecorder(), req)
+	assert.Equal(t, AuthRequest{Tenant: Tenant{OrgID: "org"}, IDTokenHint: "idToken"}, handler.authRequest)
+	state, err := DecryptState(handler.state, a.encryptionKey)
+	require.NoError(t, err)
+	assert.Equal(t, &State{RequestedURI: "/dashboard", OrgID: "org"}, state)
+}
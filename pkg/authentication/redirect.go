@@ -0,0 +1,87 @@
+package authentication
+
+import (
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+)
+
+// returnToParam is the query parameter of the login endpoint containing the URI to return to after the login,
+// e.g. `/auth/login?return_to=/orders%3Fpage%3D2`.
+const returnToParam = "return_to"
+
+// WithAllowedRedirects allows redirecting the user to absolute URLs after the login, e.g. to other applications
+// sharing the authentication. Each allowed target is a URL prefix with scheme and host, e.g. `https://admin.example.com/`.
+// Relative URIs (of the application itself) are always allowed, others are replaced by the default redirect URI.
+func WithAllowedRedirects[T Ctx](targets ...string) Option[T] {
+	return func(a *Authenticator[T]) {
+		for _, target := range targets {
+			if u, err := url.Parse(target); err == nil && u.Scheme != "" && u.Host != "" {
+				a.allowedRedirects = append(a.allowedRedirects, u)
+			}
+		}
+	}
+}
+
+// WithDefaultRedirectURI allows a redirect after the login other than "/",
+// if no (or no allowed) URI was requested.
+func WithDefaultRedirectURI[T Ctx](uri string) Option[T] {
+	return func(a *Authenticator[T]) {
+		a.defaultRedirectURI = uri
+	}
+}
+
+// WithAllowedPostLogoutRedirects allows selecting a redirect after the logout other than the one set by
+// [WithPostLogoutRedirectURI] on each logout, e.g. `/auth/logout?return_to=https://www.example.com/goodbye`.
+// The requested URI must exactly match one of the uris (relative or absolute), otherwise the default one is used.
+func WithAllowedPostLogoutRedirects[T Ctx](uris ...string) Option[T] {
+	return func(a *Authenticator[T]) {
+		a.allowedPostLogoutRedirects = append(a.allowedPostLogoutRedirects, uris...)
+	}
+}
+
+// postLogoutRedirectURIOf returns the requested URI, if it's allowed by [WithAllowedPostLogoutRedirects],
+// otherwise the default post logout redirect URI.
+func (a *Authenticator[T]) postLogoutRedirectURIOf(requested string) string {
+	if requested != "" && slices.Contains(a.allowedPostLogoutRedirects, requested) {
+		return requested
+	}
+	return a.postLogoutRedirectURI
+}
+
+// redirectURI returns the requested URI, if it's a relative URI or matches any allowed redirect,
+// otherwise the default redirect URI to prevent open redirects.
+func (a *Authenticator[T]) redirectURI(requested string) string {
+	if requested == "" || strings.ContainsAny(requested, "\\\r\n\t") {
+		return a.defaultRedirectURI
+	}
+	u, err := url.Parse(requested)
+	if err != nil {
+		return a.defaultRedirectURI
+	}
+	if u.Scheme == "" && u.Host == "" && u.User == nil {
+		// only absolute paths, which are not protocol-relative (`//evil.example.com`)
+		if strings.HasPrefix(requested, "/") && !strings.HasPrefix(requested, "//") {
+			return requested
+		}
+		return a.defaultRedirectURI
+	}
+	for _, allowed := range a.allowedRedirects {
+		if u.Scheme == allowed.Scheme && u.Host == allowed.Host && u.User == nil && hasPathPrefix(u.Path, allowed.Path) {
+			return requested
+		}
+	}
+	return a.defaultRedirectURI
+}
+
+// hasPathPrefix returns whether the cleaned path is the prefix or below it, respecting the segment boundaries,
+// so neither `/app-evil` nor `/app/../admin` match the prefix `/app`.
+func hasPathPrefix(p, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	if prefix == "" {
+		return true
+	}
+	p = path.Clean("/" + p)
+	return p == prefix || strings.HasPrefix(p, prefix+"/")
+}
@@ -0,0 +1,160 @@
+package authentication
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthenticator_redirectURI(t *testing.T) {
+	tests := []struct {
+		name      string
+		requested string
+		want      string
+	}{
+		{
+			name:      "empty",
+			requested: "",
+			want:      "/home",
+		},
+		{
+			name:      "relative with query",
+			requested: "/orders?page=2&sort=desc#top",
+			want:      "/orders?page=2&sort=desc#top",
+		},
+		{
+			name:      "relative without leading slash",
+			requested: "orders",
+			want:      "/home",
+		},
+		{
+			name:      "protocol relative",
+			requested: "//evil.example.com/orders",
+			want:      "/home",
+		},
+		{
+			name:      "backslash",
+			requested: "/\\evil.example.com",
+			want:      "/home",
+		},
+		{
+			name:      "not allowed host",
+			requested: "https://evil.example.com/orders",
+			want:      "/home",
+		},
+		{
+			name:      "allowed host with other scheme",
+			requested: "http://admin.example.com/users",
+			want:      "/home",
+		},
+		{
+			name:      "allowed host with other path",
+			requested: "https://shop.example.com/other",
+			want:      "/home",
+		},
+		{
+			name:      "allowed host with userinfo",
+			requested: "https://user@admin.example.com/users",
+			want:      "/home",
+		},
+		{
+			name:      "allowed host",
+			requested: "https://admin.example.com/users?id=1",
+			want:      "https://admin.example.com/users?id=1",
+		},
+		{
+			name:      "allowed host and path",
+			requested: "https://shop.example.com/cart/checkout",
+			want:      "https://shop.example.com/cart/checkout",
+		},
+		{
+			name:      "allowed path",
+			requested: "https://app.example.com/app",
+			want:      "https://app.example.com/app",
+		},
+		{
+			name:      "below allowed path",
+			requested: "https://app.example.com/app/settings",
+			want:      "https://app.example.com/app/settings",
+		},
+		{
+			name:      "allowed path without segment boundary",
+			requested: "https://app.example.com/app-evil/settings",
+			want:      "/home",
+		},
+		{
+			name:      "dot segments leaving allowed path",
+			requested: "https://app.example.com/app/../admin",
+			want:      "/home",
+		},
+		{
+			name:      "encoded dot segments leaving allowed path",
+			requested: "https://app.example.com/app/%2e%2e/admin",
+			want:      "/home",
+		},
+		{
+			name:      "javascript",
+			requested: "javascript:alert(1)",
+			want:      "/home",
+		},
+	}
+	a, _ := newTestAuthenticator(t, new(testHandler), nil)
+	WithDefaultRedirectURI[*testCtx]("/home")(a)
+	WithAllowedRedirects[*testCtx]("https://admin.example.com", "https://shop.example.com/cart/", "https://app.example.com/app", "invalid")(a)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, a.redirectURI(tt.requested))
+		})
+	}
+}
+
+func TestAuthenticator_deepLink(t *testing.T) {
+	tests := []struct {
+		name         string
+		middleware   bool
+		req          *http.Request
+		wantRedirect string
+	}{
+		{
+			name:         "middleware",
+			middleware:   true,
+			req:          httptest.NewRequest(http.MethodGet, "/orders?page=2", nil),
+			wantRedirect: "/orders?page=2",
+		},
+		{
+			name:         "login with return_to",
+			req:          httptest.NewRequest(http.MethodGet, "/auth/login?return_to="+url.QueryEscape("/orders?page=2"), nil),
+			wantRedirect: "/orders?page=2",
+		},
+		{
+			name:         "login with open redirect",
+			req:          httptest.NewRequest(http.MethodGet, "/auth/login?return_to="+url.QueryEscape("https://evil.example.com"), nil),
+			wantRedirect: "/",
+		},
+		{
+			name:         "login",
+			req:          httptest.NewRequest(http.MethodGet, "/auth/login", nil),
+			wantRedirect: "/",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, _ := newTestAuthenticator(t, &stepUpHandler{callback: &testCtx{token: "token"}}, nil)
+			a.createRouter()
+			var handler http.Handler = a
+			if tt.middleware {
+				handler = Middleware(a).RequireAuthentication()(http.NotFoundHandler())
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), tt.req)
+
+			w := httptest.NewRecorder()
+			a.Callback(w, httptest.NewRequest(http.MethodGet, "/auth/callback", nil))
+			require.Equal(t, http.StatusFound, w.Code)
+			assert.Equal(t, tt.wantRedirect, w.Header().Get("Location"))
+		})
+	}
+}
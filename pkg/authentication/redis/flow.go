@@ -0,0 +1,69 @@
+package redis
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authentication/oidc"
+)
+
+var _ oidc.FlowStore = (*FlowStore)(nil)
+
+// FlowStore implements the [oidc.FlowStore] interface by storing the code verifiers
+// of pending authentications in Redis, which allows the callback to be handled by any replica
+// and does not require any cookie. Use it with [oidc.WithFlowStore].
+type FlowStore struct {
+	client  Client
+	prefix  string
+	timeout time.Duration
+}
+
+// NewFlowStore creates a [FlowStore] using the client.
+// The [WithPrefix] and [WithTimeout] options are supported, the default prefix is "zitadel:flow:".
+func NewFlowStore(client Client, opts ...Option) *FlowStore {
+	o := &options{
+		prefix:  "zitadel:flow:",
+		timeout: 5 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &FlowStore{
+		client:  client,
+		prefix:  o.prefix,
+		timeout: o.timeout,
+	}
+}
+
+// Set implements [oidc.FlowStore].
+func (f *FlowStore) Set(ctx context.Context, state, codeVerifier string, expiration time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, f.timeout)
+	defer cancel()
+	return f.client.Set(ctx, f.flowKey(state), []byte(codeVerifier), expiration)
+}
+
+// GetAndDelete implements [oidc.FlowStore].
+func (f *FlowStore) GetAndDelete(ctx context.Context, state string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.timeout)
+	defer cancel()
+	key := f.flowKey(state)
+	data, err := f.client.Get(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	if data == nil {
+		return "", oidc.ErrFlowNotFound
+	}
+	if err = f.client.Del(ctx, key); err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// flowKey hashes the (encrypted and therefore rather long) state.
+func (f *FlowStore) flowKey(state string) string {
+	hash := sha256.Sum256([]byte(state))
+	return f.prefix + hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,37 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	authoidc "github.com/zitadel/zitadel-go/v3/pkg/authentication/oidc"
+)
+
+func TestFlowStore(t *testing.T) {
+	client := newMemoryClient()
+	store := NewFlowStore(client, WithPrefix("app:"))
+	ctx := context.Background()
+
+	require.NoError(t, store.Set(ctx, "state", "verifier", 10*time.Minute))
+	require.Len(t, client.values, 1)
+	for key, value := range client.values {
+		assert.Regexp(t, "^app:[0-9a-f]{64}$", key)
+		assert.Equal(t, "verifier", string(value))
+		assert.Equal(t, 10*time.Minute, client.expirations[key])
+	}
+
+	_, err := store.GetAndDelete(ctx, "other")
+	assert.ErrorIs(t, err, authoidc.ErrFlowNotFound)
+
+	codeVerifier, err := store.GetAndDelete(ctx, "state")
+	require.NoError(t, err)
+	assert.Equal(t, "verifier", codeVerifier)
+	assert.Empty(t, client.values)
+
+	_, err = store.GetAndDelete(ctx, "state")
+	assert.ErrorIs(t, err, authoidc.ErrFlowNotFound)
+}
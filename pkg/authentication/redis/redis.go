@@ -0,0 +1,295 @@
+// Package redis provides a Redis implementation of the [authentication.Sessions] store,
+// which allows multiple replicas of an application to share the sessions of their users.
+//
+// The package does not depend on a specific Redis client library. Any client can be used
+// by implementing the small [Client] interface, e.g. for github.com/redis/go-redis/v9:
+//
+//	type goRedis struct{ *redis.Client }
+//
+//	func (c goRedis) Get(ctx context.Context, key string) ([]byte, error) {
+//		value, err := c.Client.Get(ctx, key).Bytes()
+//		if errors.Is(err, redis.Nil) {
+//			return nil, nil
+//		}
+//		return value, err
+//	}
+//
+//	func (c goRedis) Set(ctx context.Context, key string, value []byte, expiration time.Duration) error {
+//		return c.Client.Set(ctx, key, value, expiration).Err()
+//	}
+//
+//	func (c goRedis) Expire(ctx context.Context, key string, expiration time.Duration) error {
+//		return c.Client.Expire(ctx, key, expiration).Err()
+//	}
+//
+//	func (c goRedis) Del(ctx context.Context, keys ...string) error {
+//		return c.Client.Del(ctx, keys...).Err()
+//	}
+//
+//	func (c goRedis) SAdd(ctx context.Context, key string, members ...string) error {
+//		return c.Client.SAdd(ctx, key, members).Err()
+//	}
+//
+//	func (c goRedis) SMembers(ctx context.Context, key string) ([]string, error) {
+//		return c.Client.SMembers(ctx, key).Result()
+//	}
+package redis
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authentication"
+	"github.com/zitadel/zitadel-go/v3/pkg/authentication/internal/codec"
+)
+
+var (
+	ErrNotFound = errors.New("session not found")
+)
+
+// Client is the part of a Redis client used by the [Sessions] store.
+type Client interface {
+	// Get returns the value of the key or nil (without an error) if the key does not exist.
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, expiration time.Duration) error
+	Expire(ctx context.Context, key string, expiration time.Duration) error
+	Del(ctx context.Context, keys ...string) error
+	SAdd(ctx context.Context, key string, members ...string) error
+	SMembers(ctx context.Context, key string) ([]string, error)
+}
+
+// Sessions implements the [authentication.Sessions] and [authentication.SessionTerminator] interfaces by storing
+// the sessions in Redis. The sessions are stored as JSON, encrypted and authenticated with AES-GCM,
+// so the tokens cannot be read or altered by anyone with access to Redis.
+//
+// Sessions implementing the [authentication.SessionIdentifier] interface are additionally indexed
+// by their subject and session id (sid), which allows terminating them on a backchannel or front-channel logout.
+type Sessions[T authentication.Ctx] struct {
+	client  Client
+	codec   *codec.Codec[T]
+	prefix  string
+	ttl     time.Duration
+	sliding bool
+	timeout time.Duration
+}
+
+// Option allows customization of the [Sessions] store.
+type Option func(*options)
+
+type options struct {
+	prefix  string
+	ttl     time.Duration
+	sliding bool
+	timeout time.Duration
+
+	previousKeys []string
+}
+
+// WithPreviousKeys allows decrypting existing sessions with the previous encryption keys after rotating the key.
+// New and updated sessions are always encrypted with the current key.
+func WithPreviousKeys(keys ...string) Option {
+	return func(o *options) {
+		o.previousKeys = append(o.previousKeys, keys...)
+	}
+}
+
+// WithPrefix allows a key prefix other than "zitadel:session:" (resp. "zitadel:flow:" for the [FlowStore]),
+// e.g. if multiple applications share a Redis.
+func WithPrefix(prefix string) Option {
+	return func(o *options) {
+		o.prefix = prefix
+	}
+}
+
+// WithTTL allows a lifetime of the sessions other than 24 hours.
+func WithTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.ttl = ttl
+	}
+}
+
+// WithSlidingExpiration extends the lifetime of a session by the TTL every time it is used,
+// so only inactive sessions expire.
+func WithSlidingExpiration() Option {
+	return func(o *options) {
+		o.sliding = true
+	}
+}
+
+// WithTimeout allows a timeout of the Redis calls other than 5 seconds.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// New creates a [Sessions] store using the client.
+// The encryptionKey must be 16, 24 or 32 bytes long.
+func New[T authentication.Ctx](client Client, encryptionKey string, opts ...Option) (*Sessions[T], error) {
+	o := &options{
+		prefix:  "zitadel:session:",
+		ttl:     24 * time.Hour,
+		timeout: 5 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	c, err := codec.New[T](append([]string{encryptionKey}, o.previousKeys...)...)
+	if err != nil {
+		return nil, err
+	}
+	return &Sessions[T]{
+		client:  client,
+		codec:   c,
+		prefix:  o.prefix,
+		ttl:     o.ttl,
+		sliding: o.sliding,
+		timeout: o.timeout,
+	}, nil
+}
+
+// Set implements [authentication.Sessions] and stores the session for the TTL.
+func (s *Sessions[T]) Set(id string, session T) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	data, err := s.codec.Encode(session)
+	if err != nil {
+		return err
+	}
+	if err = s.client.Set(ctx, s.sessionKey(id), data, s.ttl); err != nil {
+		return err
+	}
+	return s.index(ctx, id, session)
+}
+
+// Get implements [authentication.Sessions]. If sliding expiration is enabled, the lifetime of the session is extended.
+func (s *Sessions[T]) Get(id string) (session T, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	data, err := s.client.Get(ctx, s.sessionKey(id))
+	if err != nil {
+		return session, err
+	}
+	if data == nil {
+		return session, ErrNotFound
+	}
+	session, err = s.codec.Decode(data)
+	if err != nil {
+		return session, err
+	}
+	if !s.sliding {
+		return session, nil
+	}
+	if err = s.client.Expire(ctx, s.sessionKey(id), s.ttl); err != nil {
+		return session, err
+	}
+	for _, key := range s.indexKeys(session) {
+		if err = s.client.Expire(ctx, key, s.ttl); err != nil {
+			return session, err
+		}
+	}
+	return session, nil
+}
+
+// Delete implements [authentication.Sessions].
+func (s *Sessions[T]) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	return s.client.Del(ctx, s.sessionKey(id))
+}
+
+// Terminate implements [authentication.SessionTerminator] by deleting all sessions indexed by the session id,
+// resp. the subject if no session id is provided. If both are provided, only the sessions indexed by both are deleted.
+func (s *Sessions[T]) Terminate(subject, sessionID string) error {
+	if subject == "" && sessionID == "" {
+		return errors.New("subject or session id required")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	indexKey := s.subjectKey(subject)
+	if sessionID != "" {
+		indexKey = s.sidKey(sessionID)
+	}
+	ids, err := s.client.SMembers(ctx, indexKey)
+	if err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(ids)+1)
+	if subject != "" && sessionID != "" {
+		matching, err := s.subjectSessions(ctx, subject, ids)
+		if err != nil {
+			return err
+		}
+		// the index must be kept for the sessions of other subjects
+		if len(matching) < len(ids) {
+			indexKey = ""
+		}
+		ids = matching
+	}
+	for _, id := range ids {
+		keys = append(keys, s.sessionKey(id))
+	}
+	if indexKey != "" {
+		keys = append(keys, indexKey)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	return s.client.Del(ctx, keys...)
+}
+
+// subjectSessions returns the ids, which are indexed by the subject as well.
+func (s *Sessions[T]) subjectSessions(ctx context.Context, subject string, ids []string) ([]string, error) {
+	subjectIDs, err := s.client.SMembers(ctx, s.subjectKey(subject))
+	if err != nil {
+		return nil, err
+	}
+	matching := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if slices.Contains(subjectIDs, id) {
+			matching = append(matching, id)
+		}
+	}
+	return matching, nil
+}
+
+func (s *Sessions[T]) index(ctx context.Context, id string, session T) error {
+	for _, key := range s.indexKeys(session) {
+		if err := s.client.SAdd(ctx, key, id); err != nil {
+			return err
+		}
+		if err := s.client.Expire(ctx, key, s.ttl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Sessions[T]) indexKeys(session T) []string {
+	identifier, ok := any(session).(authentication.SessionIdentifier)
+	if !ok {
+		return nil
+	}
+	keys := make([]string, 0, 2)
+	if subject := identifier.GetSubject(); subject != "" {
+		keys = append(keys, s.subjectKey(subject))
+	}
+	if sid := identifier.GetSessionID(); sid != "" {
+		keys = append(keys, s.sidKey(sid))
+	}
+	return keys
+}
+
+func (s *Sessions[T]) sessionKey(id string) string {
+	return s.prefix + id
+}
+
+func (s *Sessions[T]) subjectKey(subject string) string {
+	return s.prefix + "sub:" + subject
+}
+
+func (s *Sessions[T]) sidKey(sid string) string {
+	return s.prefix + "sid:" + sid
+}
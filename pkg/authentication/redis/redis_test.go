@@ -0,0 +1,196 @@
+package redis
+
+import (
+	"context"
+	"encoding/base64"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"golang.org/x/oauth2"
+
+	authoidc "github.com/zitadel/zitadel-go/v3/pkg/authentication/oidc"
+)
+
+const key = "01234567890123456789012345678901"
+
+type session = *authoidc.UserInfoContext[*oidc.IDTokenClaims, *oidc.UserInfo]
+
+// memoryClient is an in-memory implementation of the [Client] recording the expirations.
+type memoryClient struct {
+	mu          sync.Mutex
+	values      map[string][]byte
+	sets        map[string]map[string]struct{}
+	expirations map[string]time.Duration
+}
+
+func newMemoryClient() *memoryClient {
+	return &memoryClient{
+		values:      make(map[string][]byte),
+		sets:        make(map[string]map[string]struct{}),
+		expirations: make(map[string]time.Duration),
+	}
+}
+
+func (c *memoryClient) Get(_ context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.values[key], nil
+}
+
+func (c *memoryClient) Set(_ context.Context, key string, value []byte, expiration time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[key] = value
+	c.expirations[key] = expiration
+	return nil
+}
+
+func (c *memoryClient) Expire(_ context.Context, key string, expiration time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.expirations[key] = expiration
+	return nil
+}
+
+func (c *memoryClient) Del(_ context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range keys {
+		delete(c.values, key)
+		delete(c.sets, key)
+		delete(c.expirations, key)
+	}
+	return nil
+}
+
+func (c *memoryClient) SAdd(_ context.Context, key string, members ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.sets[key] == nil {
+		c.sets[key] = make(map[string]struct{})
+	}
+	for _, member := range members {
+		c.sets[key][member] = struct{}{}
+	}
+	return nil
+}
+
+func (c *memoryClient) SMembers(_ context.Context, key string) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	members := make([]string, 0, len(c.sets[key]))
+	for member := range c.sets[key] {
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+func newSession(subject, accessToken string) session {
+	return &authoidc.UserInfoContext[*oidc.IDTokenClaims, *oidc.UserInfo]{
+		UserInfo: &oidc.UserInfo{Subject: subject},
+		Tokens: &oidc.Tokens[*oidc.IDTokenClaims]{
+			Token:         &oauth2.Token{AccessToken: accessToken, RefreshToken: "refresh", Expiry: time.Now().Add(time.Hour).Round(time.Second)},
+			IDTokenClaims: &oidc.IDTokenClaims{TokenClaims: oidc.TokenClaims{Subject: subject}},
+		},
+	}
+}
+
+func TestSessions(t *testing.T) {
+	client := newMemoryClient()
+	sessions, err := New[session](client, key, WithPrefix("app:"), WithTTL(time.Hour))
+	require.NoError(t, err)
+
+	stored := newSession("user", "secret-token")
+	require.NoError(t, sessions.Set("id", stored))
+	assert.NotContains(t, string(client.values["app:id"]), "secret-token", "tokens must be encrypted")
+	assert.Equal(t, time.Hour, client.expirations["app:id"])
+
+	got, err := sessions.Get("id")
+	require.NoError(t, err)
+	assert.Equal(t, "user", got.GetSubject())
+	assert.Equal(t, "secret-token", got.GetTokens().AccessToken)
+	assert.Equal(t, "refresh", got.GetTokens().RefreshToken)
+	assert.True(t, got.IsAuthenticated())
+
+	require.NoError(t, sessions.Delete("id"))
+	_, err = sessions.Get("id")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestSessions_slidingExpiration(t *testing.T) {
+	client := newMemoryClient()
+	sessions, err := New[session](client, key, WithTTL(time.Hour), WithSlidingExpiration())
+	require.NoError(t, err)
+	require.NoError(t, sessions.Set("id", newSession("user", "token")))
+
+	client.expirations["zitadel:session:id"] = time.Minute
+	client.expirations["zitadel:session:sub:user"] = time.Minute
+	_, err = sessions.Get("id")
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, client.expirations["zitadel:session:id"])
+	assert.Equal(t, time.Hour, client.expirations["zitadel:session:sub:user"])
+}
+
+func TestSessions_keyRotation(t *testing.T) {
+	client := newMemoryClient()
+	previous, err := New[session](client, key)
+	require.NoError(t, err)
+	require.NoError(t, previous.Set("id", newSession("user", "token")))
+
+	newKey := "abcdefghijklmnopqrstuvwxyz012345"
+	rotated, err := New[session](client, newKey, WithPreviousKeys(key))
+	require.NoError(t, err)
+	got, err := rotated.Get("id")
+	require.NoError(t, err)
+	assert.Equal(t, "user", got.GetSubject())
+
+	current, err := New[session](client, newKey)
+	require.NoError(t, err)
+	_, err = current.Get("id")
+	assert.Error(t, err)
+}
+
+func TestSessions_Terminate(t *testing.T) {
+	client := newMemoryClient()
+	sessions, err := New[session](client, key)
+	require.NoError(t, err)
+	require.NoError(t, sessions.Set("1", newSession("user", "token")))
+	require.NoError(t, sessions.Set("2", newSession("user", "token")))
+	require.NoError(t, sessions.Set("3", newSession("other", "token")))
+
+	require.NoError(t, sessions.Terminate("user", ""))
+	for id, wantFound := range map[string]bool{"1": false, "2": false, "3": true} {
+		_, err = sessions.Get(id)
+		assert.Equal(t, wantFound, err == nil, id)
+	}
+	assert.Error(t, sessions.Terminate("", ""))
+}
+
+func TestSessions_Terminate_subjectAndSessionID(t *testing.T) {
+	client := newMemoryClient()
+	sessions, err := New[session](client, key)
+	require.NoError(t, err)
+	withSID := func(s session, sid string) session {
+		claims := base64.RawURLEncoding.EncodeToString([]byte(`{"sid":"` + sid + `"}`))
+		s.GetTokens().IDToken = "eyJhbGciOiJub25lIn0." + claims + ".signature"
+		return s
+	}
+	require.NoError(t, sessions.Set("1", withSID(newSession("user", "token"), "sid")))
+	require.NoError(t, sessions.Set("2", withSID(newSession("other", "token"), "sid")))
+	require.NoError(t, sessions.Set("3", withSID(newSession("user", "token"), "sid2")))
+
+	require.NoError(t, sessions.Terminate("user", "sid"))
+	for id, wantFound := range map[string]bool{"1": false, "2": true, "3": true} {
+		_, err = sessions.Get(id)
+		assert.Equal(t, wantFound, err == nil, id)
+	}
+
+	// the session of the other subject is still indexed by the sid
+	require.NoError(t, sessions.Terminate("", "sid"))
+	_, err = sessions.Get("2")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,93 @@
+package authentication
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrRefreshTokenRejected must be wrapped by the error of [Refresher.Refresh], if the refresh token was rejected,
+	// e.g. because it expired or was revoked. The session is removed, so the user needs to authenticate again.
+	ErrRefreshTokenRejected = errors.New("refresh token rejected")
+	// ErrRefreshFailed is returned if an expired session could not be refreshed for any other reason,
+	// e.g. the token endpoint was not reachable. The session is kept, so a later request can refresh it.
+	ErrRefreshFailed = errors.New("unable to refresh session")
+)
+
+// refreshTimeout limits a refresh, which is not canceled with the request that started it,
+// since concurrent requests of the same session wait for its result.
+const refreshTimeout = 30 * time.Second
+
+// Refresher is an optional interface of a [Handler], which allows refreshing the tokens of an expired session
+// instead of requiring the user to authenticate again.
+// If the [Handler] implements it, the [Authenticator] will refresh expired sessions when checking them,
+// e.g. in the [Interceptor.RequireAuthentication] and [Interceptor.CheckAuthentication] middleware.
+type Refresher[T Ctx] interface {
+	// IsExpired returns if the authentication context is expired and can be refreshed.
+	IsExpired(authCtx T) bool
+	// Refresh returns a new authentication context with refreshed tokens.
+	// If the refresh token was rejected, the error must wrap [ErrRefreshTokenRejected],
+	// any other error is considered temporary and the session is kept.
+	Refresh(ctx context.Context, authCtx T) (T, error)
+}
+
+// refresh refreshes the session if the [Handler] implements the [Refresher] interface and the session is expired.
+// Concurrent requests of the same session will only refresh it once and share the result,
+// so the refresh is not canceled with the request, which started it, but after the refreshTimeout.
+// [StatelessSessions] are not stored, but need to be set as cookie by the caller.
+// It returns whether the session was refreshed.
+func (a *Authenticator[T]) refresh(ctx context.Context, sessionID string, session T) (T, bool, error) {
+	refresher, ok := a.authN.(Refresher[T])
+	if !ok || !refresher.IsExpired(session) {
+		return session, false, nil
+	}
+	refreshed, err := a.refreshes.do(sessionID, func() (T, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), refreshTimeout)
+		defer cancel()
+		refreshed, err := refresher.Refresh(ctx, session)
+		if _, stateless := a.sessions.(StatelessSessions[T]); err != nil || stateless {
+			return refreshed, err
+		}
+		return refreshed, a.sessions.Set(sessionID, refreshed)
+	})
+	return refreshed, err == nil, err
+}
+
+// singleflight makes sure that only one call per key is in-flight at the same time.
+// Callers of the same key, arriving while the call is running, will wait for and receive its result.
+type singleflight[T any] struct {
+	mu    sync.Mutex
+	calls map[string]*flight[T]
+}
+
+type flight[T any] struct {
+	wg  sync.WaitGroup
+	val T
+	err error
+}
+
+func (g *singleflight[T]) do(key string, fn func() (T, error)) (T, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flight[T])
+	}
+	if f, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		f.wg.Wait()
+		return f.val, f.err
+	}
+	f := new(flight[T])
+	f.wg.Add(1)
+	g.calls[key] = f
+	g.mu.Unlock()
+
+	f.val, f.err = fn()
+	f.wg.Done()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	return f.val, f.err
+}
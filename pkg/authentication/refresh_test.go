@@ -0,0 +1,298 @@
+package authentication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slog"
+)
+
+type testCtx struct {
+	token    string
+	expired  bool
+	subject  string
+	sid      string
+	authTime time.Time
+	acr      string
+	idToken  string
+}
+
+func (c *testCtx) GetIDToken() string {
+	return c.idToken
+}
+
+func (c *testCtx) GetAuthTime() time.Time {
+	return c.authTime
+}
+
+func (c *testCtx) GetACR() string {
+	return c.acr
+}
+
+func (c *testCtx) GetSubject() string {
+	return c.subject
+}
+
+func (c *testCtx) GetSessionID() string {
+	return c.sid
+}
+
+func (c *testCtx) IsAuthenticated() bool {
+	return c != nil && c.token != ""
+}
+
+type testHandler struct {
+	Handler[*testCtx]
+	refreshes atomic.Int32
+	release   chan struct{}
+	err       error
+}
+
+func (h *testHandler) Logout(w http.ResponseWriter, r *http.Request, _ *testCtx, state, optionalRedirectURI string) {
+	http.Redirect(w, r, "https://zitadel.example.com/oidc/v1/end_session?state="+state+"&post_logout_redirect_uri="+optionalRedirectURI, http.StatusFound)
+}
+
+func (h *testHandler) IsExpired(authCtx *testCtx) bool {
+	return authCtx.expired
+}
+
+func (h *testHandler) Refresh(ctx context.Context, authCtx *testCtx) (*testCtx, error) {
+	h.refreshes.Add(1)
+	if h.release != nil {
+		<-h.release
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if h.err != nil {
+		return nil, h.err
+	}
+	return &testCtx{token: authCtx.token + "-refreshed"}, nil
+}
+
+func newTestAuthenticator(t *testing.T, handler Handler[*testCtx], session *testCtx) (*Authenticator[*testCtx], *http.Cookie) {
+	t.Helper()
+	a := &Authenticator[*testCtx]{
+		authN:                 handler,
+		logger:                slog.Default(),
+		sessions:              &InMemorySessions[*testCtx]{sessions: map[string]*testCtx{"session": session}},
+		encryptionKey:         "01234567890123456789012345678901",
+		sessionCookieName:     "zitadel.session",
+		postLogoutRedirectURI: "/",
+		defaultRedirectURI:    "/",
+		errorHandler:          DefaultErrorHandler,
+	}
+	w := httptest.NewRecorder()
+	require.NoError(t, a.setSessionCookie(w, "session", nil))
+	return a, w.Result().Cookies()[0]
+}
+
+func TestAuthenticator_IsAuthenticated_refresh(t *testing.T) {
+	tests := []struct {
+		name          string
+		session       *testCtx
+		err           error
+		wantToken     string
+		wantErr       error
+		wantRefreshes int32
+	}{
+		{
+			name:      "valid",
+			session:   &testCtx{token: "token"},
+			wantToken: "token",
+		},
+		{
+			name:          "expired",
+			session:       &testCtx{token: "token", expired: true},
+			wantToken:     "token-refreshed",
+			wantRefreshes: 1,
+		},
+		{
+			name:          "refresh token rejected",
+			session:       &testCtx{token: "token", expired: true},
+			err:           fmt.Errorf("%w: invalid_grant", ErrRefreshTokenRejected),
+			wantErr:       ErrNoSession,
+			wantRefreshes: 1,
+		},
+		{
+			name:          "refresh failed",
+			session:       &testCtx{token: "token", expired: true},
+			err:           errors.New("connection refused"),
+			wantErr:       ErrRefreshFailed,
+			wantRefreshes: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &testHandler{err: tt.err}
+			a, cookie := newTestAuthenticator(t, handler, tt.session)
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.AddCookie(cookie)
+
+			authCtx, err := a.IsAuthenticated(req)
+			assert.Equal(t, tt.wantRefreshes, handler.refreshes.Load())
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				_, err = a.sessions.Get("session")
+				assert.Equal(t, tt.wantErr == ErrRefreshFailed, err == nil, "only a session with a rejected refresh token must be deleted")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantToken, authCtx.token)
+			stored, err := a.sessions.Get("session")
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantToken, stored.token)
+		})
+	}
+}
+
+func TestAuthenticator_IsAuthenticated_refreshOnce(t *testing.T) {
+	handler := &testHandler{release: make(chan struct{})}
+	a, cookie := newTestAuthenticator(t, handler, &testCtx{token: "token", expired: true})
+
+	const requests = 10
+	var started, done sync.WaitGroup
+	started.Add(requests)
+	done.Add(requests)
+	tokens := make([]string, requests)
+	for i := 0; i < requests; i++ {
+		go func(i int) {
+			defer done.Done()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.AddCookie(cookie)
+			started.Done()
+			authCtx, err := a.IsAuthenticated(req)
+			if assert.NoError(t, err) {
+				tokens[i] = authCtx.token
+			}
+		}(i)
+	}
+	started.Wait()
+	// the first request is blocked in the refresh, give the others time to join it
+	time.Sleep(50 * time.Millisecond)
+	close(handler.release)
+	done.Wait()
+
+	for _, token := range tokens {
+		assert.Equal(t, "token-refreshed", token)
+	}
+	assert.Equal(t, int32(1), handler.refreshes.Load())
+}
+
+func TestAuthenticator_IsAuthenticated_refreshCanceled(t *testing.T) {
+	handler := &testHandler{release: make(chan struct{})}
+	a, cookie := newTestAuthenticator(t, handler, &testCtx{token: "token", expired: true})
+
+	// the request starting the refresh is canceled, while another one waits for its result
+	ctx, cancel := context.WithCancel(context.Background())
+	first := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+	first.AddCookie(cookie)
+	go func() {
+		_, _ = a.IsAuthenticated(first)
+	}()
+	for handler.refreshes.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	second := httptest.NewRequest(http.MethodGet, "/", nil)
+	second.AddCookie(cookie)
+	result := make(chan *testCtx)
+	go func() {
+		authCtx, err := a.IsAuthenticated(second)
+		assert.NoError(t, err)
+		result <- authCtx
+	}()
+	time.Sleep(50 * time.Millisecond)
+	close(handler.release)
+
+	assert.Equal(t, "token-refreshed", (<-result).token)
+	assert.Equal(t, int32(1), handler.refreshes.Load())
+}
+
+func TestInterceptor_RequireAuthentication_refreshFailed(t *testing.T) {
+	handler := &testHandler{err: errors.New("connection refused")}
+	a, cookie := newTestAuthenticator(t, handler, &testCtx{token: "token", expired: true})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(cookie)
+	w := httptest.NewRecorder()
+	Middleware(a).RequireAuthentication()(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		t.Error("handler must not be called")
+	})).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Empty(t, w.Result().Cookies(), "the session must be kept")
+}
+
+// statelessSessions encodes the token of the session as cookie value.
+type statelessSessions struct {
+	InMemorySessions[*testCtx]
+}
+
+func (s *statelessSessions) Encode(session *testCtx) (string, error) {
+	return "stateless:" + session.token, nil
+}
+
+func (s *statelessSessions) Decode(value string) (*testCtx, error) {
+	token, ok := strings.CutPrefix(value, "stateless:")
+	if !ok {
+		return nil, errors.New("invalid")
+	}
+	return &testCtx{token: token, expired: !strings.HasSuffix(token, "-refreshed")}, nil
+}
+
+func TestInterceptor_statelessRefresh(t *testing.T) {
+	handler := new(testHandler)
+	a, _ := newTestAuthenticator(t, handler, nil)
+	a.sessions = new(statelessSessions)
+
+	var authCtx *testCtx
+	next := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		authCtx = Context[*testCtx](req.Context())
+	})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: a.sessionCookieName, Value: "stateless:token"})
+	w := httptest.NewRecorder()
+	Middleware(a).RequireAuthentication()(next).ServeHTTP(w, req)
+
+	assert.Equal(t, int32(1), handler.refreshes.Load())
+	require.NotNil(t, authCtx)
+	assert.Equal(t, "token-refreshed", authCtx.token)
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, "stateless:token-refreshed", cookies[0].Value)
+}
+
+// updatingSessions keeps the previous cookie value on an update.
+type updatingSessions struct {
+	statelessSessions
+}
+
+func (s *updatingSessions) Update(previous string, session *testCtx) (string, error) {
+	return previous + "|" + session.token, nil
+}
+
+func TestInterceptor_statelessRefresh_update(t *testing.T) {
+	handler := new(testHandler)
+	a, _ := newTestAuthenticator(t, handler, nil)
+	a.sessions = new(updatingSessions)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: a.sessionCookieName, Value: "stateless:token"})
+	w := httptest.NewRecorder()
+	Middleware(a).RequireAuthentication()(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})).ServeHTTP(w, req)
+
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, "stateless:token|token-refreshed", cookies[0].Value)
+}
@@ -1,20 +1,44 @@
 package authentication
 
-import "errors"
+import (
+	"errors"
+	"sync"
+)
 
 // Sessions is an abstraction of the session storage
 type Sessions[T Ctx] interface {
 	Set(id string, session T) error
 	Get(id string) (T, error)
+	Delete(id string) error
+}
+
+// StatelessSessions is an optional interface of the [Sessions] store, which does not store the sessions on the server,
+// but encodes the session into the session cookie itself. The Set, Get and Delete functions of the [Sessions]
+// are therefore not used to store or retrieve the session.
+type StatelessSessions[T Ctx] interface {
+	// Encode encodes (and encrypts) the session into the value of the session cookie.
+	Encode(session T) (string, error)
+	// Decode decodes (and decrypts) the session from the value of the session cookie.
+	Decode(value string) (T, error)
+}
+
+// StatelessSessionsUpdater is an optional interface of the [StatelessSessions], which is used instead of Encode
+// after a refresh, so the properties of the previous value (e.g. its expiration) can be kept.
+type StatelessSessionsUpdater[T Ctx] interface {
+	// Update encodes (and encrypts) the refreshed session of the previous value of the session cookie.
+	Update(previous string, session T) (string, error)
 }
 
 // InMemorySessions implements the [Sessions] interface by storing the sessions
 // in-memory. This is obviously not suitable for production and only meant for testing purposes.
 type InMemorySessions[T Ctx] struct {
+	mu       sync.RWMutex
 	sessions map[string]T
 }
 
 func (s *InMemorySessions[T]) Get(id string) (T, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	t, ok := s.sessions[id]
 	if !ok {
 		return t, errors.New("not found")
@@ -22,6 +46,38 @@ func (s *InMemorySessions[T]) Get(id string) (T, error) {
 	return t, nil
 }
 func (s *InMemorySessions[T]) Set(id string, session T) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.sessions[id] = session
 	return nil
 }
+
+func (s *InMemorySessions[T]) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, id)
+	return nil
+}
+
+// Terminate implements the [SessionTerminator] interface for sessions implementing the [SessionIdentifier] interface.
+func (s *InMemorySessions[T]) Terminate(subject, sessionID string) error {
+	if subject == "" && sessionID == "" {
+		return errors.New("subject or session id required")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, session := range s.sessions {
+		identifier, ok := any(session).(SessionIdentifier)
+		if !ok {
+			continue
+		}
+		if sessionID != "" && identifier.GetSessionID() != sessionID {
+			continue
+		}
+		if subject != "" && identifier.GetSubject() != subject {
+			continue
+		}
+		delete(s.sessions, id)
+	}
+	return nil
+}
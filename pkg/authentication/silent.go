@@ -0,0 +1,56 @@
+package authentication
+
+import (
+	"net/http"
+	"slices"
+)
+
+// interactionRequiredErrors are the errors returned by the Login UI on a silent authentication (`prompt=none`),
+// if the user needs to interact with it, e.g. because there is no SSO session (anymore).
+var interactionRequiredErrors = []string{
+	"login_required",
+	"interaction_required",
+	"consent_required",
+	"account_selection_required",
+}
+
+// WithSilentAuthentication lets the [Interceptor.RequireAuthentication] try a silent authentication
+// ([Authenticator.SilentAuthenticate]) first, so users with an existing SSO session in ZITADEL are signed in
+// to the application without seeing the Login UI.
+func WithSilentAuthentication[T Ctx]() Option[T] {
+	return func(a *Authenticator[T]) {
+		a.silentAuthentication = true
+	}
+}
+
+// SilentAuthenticate starts a new authentication without any user interaction (`prompt=none`),
+// which succeeds as long as the user has a valid SSO session in ZITADEL, e.g. to renew an expired application session.
+// If the Login UI responds that an interaction is required (e.g. `login_required`), the [Authenticator.Callback]
+// falls back to an interactive authentication ([Authenticator.Authenticate]) with the same requestedURI.
+func (a *Authenticator[T]) SilentAuthenticate(w http.ResponseWriter, r *http.Request, requestedURI string, options ...LoginOption) {
+	a.authenticate(w, r, applyLoginOptions(&State{RequestedURI: requestedURI, Silent: true}, options))
+}
+
+// silentCallbackFailed handles the error of a silent authentication on the callback by starting an interactive one.
+// It returns false, if the callback is not a failed silent authentication.
+func (a *Authenticator[T]) silentCallbackFailed(w http.ResponseWriter, req *http.Request) bool {
+	if !slices.Contains(interactionRequiredErrors, req.FormValue("error")) {
+		return false
+	}
+	state, err := DecryptState(req.FormValue("state"), a.encryptionKey)
+	if err != nil || !state.Silent {
+		return false
+	}
+	a.logger.Debug("silent authentication failed, falling back to interactive authentication", "error", req.FormValue("error"))
+	a.authenticate(w, req, &State{
+		RequestedURI: state.RequestedURI,
+		StepUp:       state.StepUp,
+		OrgID:        state.OrgID,
+		RememberMe:   state.RememberMe,
+		Scopes:       state.Scopes,
+		Claims:       state.Claims,
+		UILocales:    state.UILocales,
+		IDPHint:      state.IDPHint,
+	})
+	return true
+}
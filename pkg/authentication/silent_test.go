@@ -0,0 +1,79 @@
+package authentication
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterceptor_RequireAuthentication_silent(t *testing.T) {
+	handler := new(stepUpHandler)
+	a, _ := newTestAuthenticator(t, handler, nil)
+	WithSilentAuthentication[*testCtx]()(a)
+
+	req := httptest.NewRequest(http.MethodGet, "/profile", nil)
+	Middleware(a).RequireAuthentication()(http.NotFoundHandler()).ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, AuthRequest{Prompt: []string{"none"}}, handler.authRequest)
+	state, err := DecryptState(handler.state, a.encryptionKey)
+	require.NoError(t, err)
+	assert.Equal(t, &State{RequestedURI: "/profile", Silent: true}, state)
+}
+
+func TestAuthenticator_Callback_silent(t *testing.T) {
+	tests := []struct {
+		name            string
+		state           *State
+		error           string
+		wantInteractive bool
+		wantCode        int
+	}{
+		{
+			name:            "login required",
+			state:           &State{RequestedURI: "/profile", Silent: true},
+			error:           "login_required",
+			wantInteractive: true,
+		},
+		{
+			name:            "interaction required",
+			state:           &State{RequestedURI: "/profile", Silent: true},
+			error:           "interaction_required",
+			wantInteractive: true,
+		},
+		{
+			name:     "other error",
+			state:    &State{RequestedURI: "/profile", Silent: true},
+			error:    "access_denied",
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "interactive authentication",
+			state:    &State{RequestedURI: "/profile"},
+			error:    "login_required",
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := new(stepUpHandler)
+			a, _ := newTestAuthenticator(t, handler, nil)
+			stateParam, err := tt.state.Encrypt(a.encryptionKey)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			a.Callback(w, httptest.NewRequest(http.MethodGet, "/auth/callback?"+url.Values{"state": {stateParam}, "error": {tt.error}}.Encode(), nil))
+			if !tt.wantInteractive {
+				assert.Equal(t, tt.wantCode, w.Code)
+				return
+			}
+			assert.Equal(t, http.StatusFound, w.Code)
+			assert.Equal(t, AuthRequest{}, handler.authRequest)
+			state, err := DecryptState(handler.state, a.encryptionKey)
+			require.NoError(t, err)
+			assert.Equal(t, &State{RequestedURI: "/profile"}, state)
+		})
+	}
+}
@@ -10,6 +10,21 @@ import (
 // It is used to transfer the state from the application to the Login UI and back, e.g. when starting the login flow.
 type State struct {
 	RequestedURI string
+	// StepUp contains the requirements of a step-up authentication, which are checked on the callback.
+	StepUp *StepUp `json:",omitempty"`
+	// Silent marks a silent authentication (`prompt=none`), which falls back to an interactive one on the callback.
+	Silent bool `json:",omitempty"`
+	// OrgID is the organization of an organization switch ([Authenticator.SwitchOrganization]).
+	OrgID string `json:",omitempty"`
+	// RememberMe requests a persistent session ([WithRememberMe]).
+	RememberMe bool `json:",omitempty"`
+	// Scopes and Claims are additionally requested for the authentication ([LoginOption]).
+	Scopes []string       `json:",omitempty"`
+	Claims *ClaimsRequest `json:",omitempty"`
+	// UILocales are the preferred languages of the Login UI ([WithUILocales]).
+	UILocales []string `json:",omitempty"`
+	// IDPHint is the id of the identity provider the user is directly redirected to ([WithIDPHint]).
+	IDPHint string `json:",omitempty"`
 }
 
 func (s *State) Encrypt(key string) (string, error) {
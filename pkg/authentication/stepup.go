@@ -0,0 +1,77 @@
+package authentication
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// AuthenticationLevel can be implemented by the [Ctx] to provide the time and the authentication context class
+// of the authentication of the user, which are needed to check a [StepUp] requirement.
+type AuthenticationLevel interface {
+	// GetAuthTime returns the time of the (last) authentication of the user (`auth_time` claim).
+	GetAuthTime() time.Time
+	// GetACR returns the authentication context class reference (`acr` claim).
+	GetACR() string
+}
+
+// StepUp defines the requirements of the authentication for a route, e.g. a recent authentication with MFA for payments.
+// If the current session does not satisfy them, the user has to authenticate again ([Interceptor.RequireStepUp]).
+type StepUp struct {
+	// ACRValues requires the session to be authenticated with any of the authentication context classes.
+	// They are passed as `acr_values` to the Login UI.
+	ACRValues []string `json:"acr,omitempty"`
+	// MaxAge requires the last authentication of the session to be no longer ago than the duration.
+	// It's passed as `max_age` to the Login UI, which forces the user to authenticate again if needed.
+	MaxAge time.Duration `json:"max_age,omitempty"`
+}
+
+// SatisfiedBy returns if the authentication of the authCtx satisfies the requirements.
+// If any requirement is set, the authCtx needs to implement the [AuthenticationLevel] interface.
+func (s StepUp) SatisfiedBy(authCtx Ctx) bool {
+	if len(s.ACRValues) == 0 && s.MaxAge == 0 {
+		return true
+	}
+	level, ok := authCtx.(AuthenticationLevel)
+	if !ok {
+		return false
+	}
+	if len(s.ACRValues) > 0 && !slices.Contains(s.ACRValues, level.GetACR()) {
+		return false
+	}
+	if s.MaxAge > 0 && time.Since(level.GetAuthTime()) > s.MaxAge {
+		return false
+	}
+	return true
+}
+
+// StepUp starts a new authentication (by redirecting the user to the Login UI) with the requirements of the [StepUp].
+// If the authentication after the callback still does not satisfy them, the user will not be redirected
+// to the requestedURI but receive a 403 Forbidden.
+func (a *Authenticator[T]) StepUp(w http.ResponseWriter, r *http.Request, requestedURI string, stepUp StepUp) {
+	a.authenticate(w, r, &State{RequestedURI: requestedURI, StepUp: &stepUp})
+}
+
+// RequireStepUp will check if there is a valid session satisfying the requirements of the [StepUp]
+// and provide it in the context.
+// If there is no session or the authentication is not sufficient (e.g. too old or without the required acr),
+// it will automatically start a new authentication with the requirements ([Authenticator.StepUp]).
+// Like [Interceptor.RequireAuthentication] it responds with 503 Service Unavailable on [ErrRefreshFailed].
+func (i *Interceptor[T]) RequireStepUp(stepUp StepUp) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			ctx, err := i.authenticator.authenticated(w, req)
+			if errors.Is(err, ErrRefreshFailed) {
+				i.authenticator.error(w, req, err, http.StatusServiceUnavailable)
+				return
+			}
+			if err != nil || !stepUp.SatisfiedBy(ctx) {
+				i.authenticator.StepUp(w, req, req.RequestURI, stepUp)
+				return
+			}
+			req = req.WithContext(WithAuthContext(req.Context(), ctx))
+			next.ServeHTTP(w, req)
+		})
+	}
+}
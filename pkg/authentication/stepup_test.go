@@ -0,0 +1,139 @@
+package authentication
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepUp_SatisfiedBy(t *testing.T) {
+	tests := []struct {
+		name    string
+		stepUp  StepUp
+		authCtx Ctx
+		want    bool
+	}{
+		{
+			name:    "no requirements",
+			authCtx: &testCtx{token: "token"},
+			want:    true,
+		},
+		{
+			name:    "acr satisfied",
+			stepUp:  StepUp{ACRValues: []string{"mfa", "phr"}},
+			authCtx: &testCtx{token: "token", acr: "phr"},
+			want:    true,
+		},
+		{
+			name:    "acr not satisfied",
+			stepUp:  StepUp{ACRValues: []string{"mfa"}},
+			authCtx: &testCtx{token: "token", acr: "pwd"},
+		},
+		{
+			name:    "recent authentication",
+			stepUp:  StepUp{MaxAge: 5 * time.Minute},
+			authCtx: &testCtx{token: "token", authTime: time.Now().Add(-time.Minute)},
+			want:    true,
+		},
+		{
+			name:    "authentication too old",
+			stepUp:  StepUp{MaxAge: 5 * time.Minute},
+			authCtx: &testCtx{token: "token", authTime: time.Now().Add(-time.Hour)},
+		},
+		{
+			name:    "no authentication level",
+			stepUp:  StepUp{MaxAge: 5 * time.Minute},
+			authCtx: &plainCtx{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.stepUp.SatisfiedBy(tt.authCtx))
+		})
+	}
+}
+
+type plainCtx struct{}
+
+func (c *plainCtx) IsAuthenticated() bool {
+	return true
+}
+
+type stepUpHandler struct {
+	authRequestHandler
+	state    string
+	callback *testCtx
+}
+
+func (h *stepUpHandler) Authenticate(w http.ResponseWriter, r *http.Request, state string) {
+	h.state = state
+	h.authRequestHandler.Authenticate(w, r, state)
+}
+
+func (h *stepUpHandler) Callback(_ http.ResponseWriter, _ *http.Request) (*testCtx, string) {
+	return h.callback, h.state
+}
+
+func TestInterceptor_RequireStepUp(t *testing.T) {
+	stepUp := StepUp{ACRValues: []string{"mfa"}, MaxAge: 5 * time.Minute}
+	tests := []struct {
+		name             string
+		session          *testCtx
+		callback         *testCtx
+		wantStepUp       bool
+		wantCallbackCode int
+	}{
+		{
+			name:    "satisfied",
+			session: &testCtx{token: "token", acr: "mfa", authTime: time.Now()},
+		},
+		{
+			name:             "stepped up",
+			session:          &testCtx{token: "token", acr: "pwd", authTime: time.Now(), idToken: "idToken"},
+			callback:         &testCtx{token: "token", acr: "mfa", authTime: time.Now()},
+			wantStepUp:       true,
+			wantCallbackCode: http.StatusFound,
+		},
+		{
+			name:             "step-up not satisfied",
+			session:          &testCtx{token: "token", acr: "mfa", authTime: time.Now().Add(-time.Hour)},
+			callback:         &testCtx{token: "token", acr: "mfa", authTime: time.Now().Add(-time.Hour)},
+			wantStepUp:       true,
+			wantCallbackCode: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &stepUpHandler{callback: tt.callback}
+			a, cookie := newTestAuthenticator(t, handler, tt.session)
+			var called bool
+			next := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				called = true
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/payments?amount=10", nil)
+			req.AddCookie(cookie)
+			Middleware(a).RequireStepUp(stepUp)(next).ServeHTTP(httptest.NewRecorder(), req)
+			assert.Equal(t, !tt.wantStepUp, called)
+			if !tt.wantStepUp {
+				return
+			}
+			assert.Equal(t, AuthRequest{ACRValues: []string{"mfa"}, MaxAge: 5 * time.Minute, IDTokenHint: tt.session.idToken}, handler.authRequest)
+
+			w := httptest.NewRecorder()
+			a.Callback(w, httptest.NewRequest(http.MethodGet, "/auth/callback", nil))
+			assert.Equal(t, tt.wantCallbackCode, w.Code)
+			if tt.wantCallbackCode != http.StatusFound {
+				return
+			}
+			location, err := url.Parse(w.Header().Get("Location"))
+			require.NoError(t, err)
+			assert.Equal(t, "/payments?amount=10", location.RequestURI())
+		})
+	}
+}
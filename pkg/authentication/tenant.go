@@ -0,0 +1,83 @@
+package authentication
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type authRequestKey struct{}
+
+// Tenant defines the organization, in which the user will log in (and register).
+// The Login UI will show the branding of the organization and only allow its users.
+type Tenant struct {
+	// OrgID restricts the login to the organization with the id.
+	OrgID string
+	// OrgDomain restricts the login to the organization with the (primary) domain.
+	// It's only used if no OrgID is set.
+	OrgDomain string
+}
+
+// TenantResolver resolves the [Tenant] of the request, e.g. from the host or path.
+// If no tenant can be resolved, the zero value lets the user log in to any organization.
+type TenantResolver func(r *http.Request) Tenant
+
+// TenantByHost resolves the [Tenant] by the host (without port) of the request, e.g. `acme.example.com`,
+// so each subdomain of a customer lands on the login of their organization.
+func TenantByHost(resolve func(host string) (Tenant, bool)) TenantResolver {
+	return func(r *http.Request) Tenant {
+		host := r.Host
+		if i := strings.LastIndexByte(host, ':'); i > strings.LastIndexByte(host, ']') {
+			host = host[:i]
+		}
+		tenant, _ := resolve(host)
+		return tenant
+	}
+}
+
+// TenantByPath resolves the [Tenant] by the first segment of the path of the request, e.g. `acme` of `/acme/profile`.
+func TenantByPath(resolve func(segment string) (Tenant, bool)) TenantResolver {
+	return func(r *http.Request) Tenant {
+		segment, _, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+		tenant, _ := resolve(segment)
+		return tenant
+	}
+}
+
+// AuthRequest contains the additional parameters of an authentication,
+// which are passed by the [Authenticator] to the [Handler] in the context of the request.
+type AuthRequest struct {
+	Tenant Tenant
+	// LoginHint prefills the login name in the Login UI.
+	LoginHint string
+	// ACRValues and MaxAge are the requirements of a [StepUp] authentication.
+	ACRValues []string
+	MaxAge    time.Duration
+	// Prompt is passed as `prompt` to the Login UI, e.g. `none` for a silent authentication.
+	Prompt []string
+	// IDTokenHint is the id_token of the current session ([IDTokenHolder]) on a re-authentication.
+	IDTokenHint string
+	// Scopes are requested in addition to the ones configured on the [Handler].
+	Scopes []string
+	// Claims are requested individually as `claims` parameter.
+	Claims *ClaimsRequest
+	// UILocales are passed as `ui_locales` to show the Login UI in the preferred languages of the user.
+	UILocales []string
+	// IDPHint is the id of the external identity provider, to which the user is directly redirected
+	// without showing the Login UI.
+	IDPHint string
+}
+
+// AuthRequestFromContext returns the [AuthRequest] of the context, which is used by the [Handler]
+// to start the authentication.
+func AuthRequestFromContext(ctx context.Context) AuthRequest {
+	authRequest, _ := ctx.Value(authRequestKey{}).(AuthRequest)
+	return authRequest
+}
+
+// WithAuthRequest allows to set the [AuthRequest] for the [Handler], which can later be retrieved
+// by calling the [AuthRequestFromContext] function.
+func WithAuthRequest(ctx context.Context, authRequest AuthRequest) context.Context {
+	return context.WithValue(ctx, authRequestKey{}, authRequest)
+}
@@ -0,0 +1,86 @@
+package authentication
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var tenants = map[string]Tenant{
+	"acme":             {OrgID: "acmeID"},
+	"acme.example.com": {OrgDomain: "acme.example.com"},
+}
+
+func resolveTenant(key string) (Tenant, bool) {
+	tenant, ok := tenants[key]
+	return tenant, ok
+}
+
+func TestTenantResolver(t *testing.T) {
+	tests := []struct {
+		name     string
+		resolver TenantResolver
+		url      string
+		want     Tenant
+	}{
+		{
+			name:     "host",
+			resolver: TenantByHost(resolveTenant),
+			url:      "https://acme.example.com/profile",
+			want:     Tenant{OrgDomain: "acme.example.com"},
+		},
+		{
+			name:     "host with port",
+			resolver: TenantByHost(resolveTenant),
+			url:      "http://acme.example.com:8080/profile",
+			want:     Tenant{OrgDomain: "acme.example.com"},
+		},
+		{
+			name:     "unknown host",
+			resolver: TenantByHost(resolveTenant),
+			url:      "https://example.com/profile",
+		},
+		{
+			name:     "path",
+			resolver: TenantByPath(resolveTenant),
+			url:      "https://example.com/acme/profile",
+			want:     Tenant{OrgID: "acmeID"},
+		},
+		{
+			name:     "unknown path",
+			resolver: TenantByPath(resolveTenant),
+			url:      "https://example.com/profile",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.resolver(httptest.NewRequest(http.MethodGet, tt.url, nil)))
+		})
+	}
+}
+
+type authRequestHandler struct {
+	testHandler
+	authRequest AuthRequest
+}
+
+func (h *authRequestHandler) Authenticate(w http.ResponseWriter, r *http.Request, _ string) {
+	h.authRequest = AuthRequestFromContext(r.Context())
+	w.WriteHeader(http.StatusFound)
+}
+
+func TestAuthenticator_Authenticate_authRequest(t *testing.T) {
+	handler := new(authRequestHandler)
+	a, _ := newTestAuthenticator(t, handler, nil)
+	a.tenantResolver = TenantByHost(resolveTenant)
+
+	req := httptest.NewRequest(http.MethodGet, "https://acme.example.com/auth/login?login_hint=user@acme.example.com", nil)
+	a.Authenticate(httptest.NewRecorder(), req, "/profile")
+
+	assert.Equal(t, AuthRequest{
+		Tenant:    Tenant{OrgDomain: "acme.example.com"},
+		LoginHint: "user@acme.example.com",
+	}, handler.authRequest)
+}
@@ -0,0 +1,71 @@
+package authorization
+
+import (
+	"context"
+	"time"
+)
+
+type resourceKey struct{}
+
+// Decision describes the outcome of a single authorization check, see [AuditHook].
+type Decision struct {
+	// Subject is the id of the authorized user. It is empty if the token could not be verified.
+	Subject string
+	// Resource is the requested resource set by [WithResource], e.g. `GET /api/documents` or a full gRPC method name.
+	Resource string
+	// Requirements describe the required permissions, e.g. `role:admin` or `scope:openid` (see [Check]).
+	Requirements []string
+	// Allowed is the outcome of the check.
+	Allowed bool
+	// Err is the reason for the denial, either an [UnauthorizedErr] or a [PermissionDeniedErr].
+	Err  error
+	Time time.Time
+}
+
+// AuditHook is invoked on every decision of the [Authorizer], e.g. to ship an audit trail to a SIEM.
+// It is called synchronously, so implementations must not block (e.g. by buffering the decisions).
+type AuditHook interface {
+	OnDecision(ctx context.Context, decision *Decision)
+}
+
+// AuditFunc allows using a function as [AuditHook].
+type AuditFunc func(ctx context.Context, decision *Decision)
+
+// OnDecision implements the [AuditHook] interface.
+func (f AuditFunc) OnDecision(ctx context.Context, decision *Decision) {
+	f(ctx, decision)
+}
+
+// WithAuditHook reports every allowed and denied authorization check to the hook.
+func WithAuditHook[T Ctx](hook AuditHook) Option[T] {
+	return func(a *Authorizer[T]) {
+		a.auditHook = hook
+	}
+}
+
+// WithResource allows to set the requested resource reported to the [AuditHook],
+// which can later be retrieved by calling the [Resource] function.
+// The HTTP and gRPC middlewares set it automatically.
+func WithResource(ctx context.Context, resource string) context.Context {
+	return context.WithValue(ctx, resourceKey{}, resource)
+}
+
+// Resource returns the requested resource set by [WithResource].
+func Resource(ctx context.Context) string {
+	resource, _ := ctx.Value(resourceKey{}).(string)
+	return resource
+}
+
+func (a *Authorizer[T]) audit(ctx context.Context, authCtx T, verified bool, checks *Check[Ctx], err error) {
+	decision := &Decision{
+		Resource:     Resource(ctx),
+		Requirements: checks.Requirements,
+		Allowed:      err == nil,
+		Err:          err,
+		Time:         time.Now(),
+	}
+	if verified {
+		decision.Subject = authCtx.UserID()
+	}
+	a.auditHook.OnDecision(ctx, decision)
+}
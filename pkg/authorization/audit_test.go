@@ -0,0 +1,65 @@
+package authorization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slog"
+)
+
+func TestWithAuditHook(t *testing.T) {
+	tests := []struct {
+		name      string
+		verifier  *testVerifier[*testCtx]
+		token     string
+		options   []CheckOption
+		want      Decision
+		wantError error
+	}{
+		{
+			name:      "unauthorized",
+			verifier:  &testVerifier[*testCtx]{},
+			token:     "token",
+			options:   []CheckOption{WithRole("admin")},
+			want:      Decision{Resource: "GET /documents", Requirements: []string{"role:admin"}},
+			wantError: &UnauthorizedErr{},
+		},
+		{
+			name:      "permission denied",
+			verifier:  &testVerifier[*testCtx]{ctx: &testCtx{isAuthorized: true, userID: "user"}},
+			token:     "token",
+			options:   []CheckOption{WithScope("documents"), WithAnyRole("owner", "admin")},
+			want:      Decision{Subject: "user", Resource: "GET /documents", Requirements: []string{"scope:documents", "anyRole:owner,admin"}},
+			wantError: &PermissionDeniedErr{},
+		},
+		{
+			name:     "allowed",
+			verifier: &testVerifier[*testCtx]{ctx: &testCtx{isAuthorized: true, isGrantedRole: true, userID: "user"}},
+			token:    "token",
+			options:  []CheckOption{WithRole("admin")},
+			want:     Decision{Subject: "user", Resource: "GET /documents", Requirements: []string{"role:admin"}, Allowed: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var decisions []*Decision
+			a := &Authorizer[*testCtx]{verifier: tt.verifier, logger: slog.Default()}
+			WithAuditHook[*testCtx](AuditFunc(func(_ context.Context, decision *Decision) {
+				decisions = append(decisions, decision)
+			}))(a)
+			_, err := a.CheckAuthorization(WithResource(context.Background(), "GET /documents"), tt.token, tt.options...)
+
+			require.Len(t, decisions, 1)
+			got := decisions[0]
+			assert.False(t, got.Time.IsZero())
+			assert.Equal(t, err, got.Err)
+			if tt.wantError != nil {
+				assert.ErrorIs(t, got.Err, tt.wantError)
+			}
+			got.Time, got.Err = tt.want.Time, nil
+			assert.Equal(t, &tt.want, got)
+		})
+	}
+}
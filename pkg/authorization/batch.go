@@ -0,0 +1,67 @@
+package authorization
+
+import (
+	"context"
+	"sync"
+)
+
+// defaultBatchConcurrency is the number of tokens verified in parallel by [Authorizer.CheckBatch].
+const defaultBatchConcurrency = 10
+
+// BatchResult is the result of a single token verified by [Authorizer.CheckBatch].
+type BatchResult[T Ctx] struct {
+	AuthCtx T
+	Err     error
+}
+
+// WithBatchConcurrency allows a number of tokens verified in parallel by [Authorizer.CheckBatch] other than 10.
+func WithBatchConcurrency[T Ctx](concurrency int) Option[T] {
+	return func(a *Authorizer[T]) {
+		a.batchConcurrency = concurrency
+	}
+}
+
+// CheckBatch verifies the tokens concurrently (see [WithBatchConcurrency]) like [Authorizer.CheckAuthorization],
+// e.g. for consumers processing events carrying the token of the user.
+// The results are returned in the order of the tokens. Identical tokens are verified only once,
+// other tokens share the cache of the [Authorizer] ([WithCache]).
+// If the ctx is canceled, the results of the remaining tokens contain the error of the ctx.
+func (a *Authorizer[T]) CheckBatch(ctx context.Context, tokens []string, options ...CheckOption) []BatchResult[T] {
+	results := make([]BatchResult[T], len(tokens))
+	first := make(map[string]int, len(tokens))
+	concurrency := a.batchConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, token := range tokens {
+		if _, ok := first[token]; ok {
+			continue
+		}
+		first[token] = i
+		if err := ctx.Err(); err != nil {
+			results[i].Err = err
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		case sem <- struct{}{}:
+		}
+		wg.Add(1)
+		go func(i int, token string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			results[i].AuthCtx, results[i].Err = a.CheckAuthorization(ctx, token, options...)
+		}(i, token)
+	}
+	wg.Wait()
+	for i, token := range tokens {
+		results[i] = results[first[token]]
+	}
+	return results
+}
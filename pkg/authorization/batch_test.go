@@ -0,0 +1,69 @@
+package authorization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slog"
+)
+
+type concurrencyVerifier struct {
+	calls   atomic.Int32
+	running atomic.Int32
+	max     atomic.Int32
+}
+
+func (v *concurrencyVerifier) CheckAuthorization(_ context.Context, token string) (*testCtx, error) {
+	v.calls.Add(1)
+	running := v.running.Add(1)
+	defer v.running.Add(-1)
+	for {
+		current := v.max.Load()
+		if running <= current || v.max.CompareAndSwap(current, running) {
+			break
+		}
+	}
+	time.Sleep(5 * time.Millisecond)
+	if token == "invalid" {
+		return nil, errors.New("invalid token")
+	}
+	return &testCtx{isAuthorized: true, userID: token}, nil
+}
+
+func TestAuthorizer_CheckBatch(t *testing.T) {
+	verifier := new(concurrencyVerifier)
+	a := &Authorizer[*testCtx]{verifier: verifier, logger: slog.Default()}
+	WithBatchConcurrency[*testCtx](3)(a)
+
+	tokens := []string{"invalid", "user0", "user0"}
+	for i := 1; i < 10; i++ {
+		tokens = append(tokens, fmt.Sprintf("user%d", i))
+	}
+	results := a.CheckBatch(context.Background(), tokens)
+
+	require.Len(t, results, len(tokens))
+	assert.ErrorIs(t, results[0].Err, &UnauthorizedErr{})
+	for i, token := range tokens[1:] {
+		require.NoError(t, results[i+1].Err)
+		assert.Equal(t, token, results[i+1].AuthCtx.UserID())
+	}
+	assert.Equal(t, int32(11), verifier.calls.Load())
+	assert.LessOrEqual(t, verifier.max.Load(), int32(3))
+}
+
+func TestAuthorizer_CheckBatch_canceled(t *testing.T) {
+	a := &Authorizer[*testCtx]{verifier: new(concurrencyVerifier), logger: slog.Default()}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := a.CheckBatch(ctx, []string{"user0", "user1"})
+	for _, result := range results {
+		assert.ErrorIs(t, result.Err, context.Canceled)
+	}
+}
@@ -0,0 +1,259 @@
+package authorization
+
+import (
+	"container/heap"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// WithCache caches the result of the [Verifier] (e.g. the introspection response) per token for the ttl,
+// so a remote verification is not needed on every request. Only successful verifications are cached
+// and the [Check] options (e.g. roles) are still evaluated on every call.
+//
+// A revoked token might still be accepted until its entry expires, so the ttl should be short (e.g. a minute).
+// Entries of tokens providing their expiration ([ExpiresAtHolder]) are never kept beyond it.
+// Use [Authorizer.InvalidateCache] to remove a token immediately, e.g. after it was refreshed or revoked.
+// The cache can be customized with [CacheOption], e.g. to cache negative results as well ([WithNegativeTTL]).
+func WithCache[T Ctx](ttl time.Duration, opts ...CacheOption) Option[T] {
+	return func(a *Authorizer[T]) {
+		c := &cachedVerifier[T]{
+			verifier: a.verifier,
+			ttl:      ttl,
+			entries:  make(map[[sha256.Size]byte]*cacheEntry[T]),
+		}
+		for _, opt := range opts {
+			opt(&c.cacheOptions)
+		}
+		a.verifier = c
+		a.cache = c
+	}
+}
+
+// CacheOption allows customization of the cache ([WithCache]).
+type CacheOption func(*cacheOptions)
+
+type cacheOptions struct {
+	negativeTTL time.Duration
+	jitter      time.Duration
+	maxEntries  int
+}
+
+// WithNegativeTTL caches negative results for the ttl as well, i.e. inactive tokens and tokens denied
+// by the [Verifier] with a [PermissionDeniedErr] (e.g. missing scopes). Other errors (e.g. a failed introspection
+// request) are never cached.
+func WithNegativeTTL(ttl time.Duration) CacheOption {
+	return func(o *cacheOptions) {
+		o.negativeTTL = ttl
+	}
+}
+
+// WithJitter extends the ttl of each entry by a random duration up to the jitter,
+// so entries cached at the same time (e.g. on startup) do not expire together.
+func WithJitter(jitter time.Duration) CacheOption {
+	return func(o *cacheOptions) {
+		o.jitter = jitter
+	}
+}
+
+// WithMaxEntries limits the number of cached tokens. If the limit is reached, the entry expiring first is removed.
+func WithMaxEntries(maxEntries int) CacheOption {
+	return func(o *cacheOptions) {
+		o.maxEntries = maxEntries
+	}
+}
+
+// CacheStats contains the number of cache hits and misses of the [WithCache].
+type CacheStats struct {
+	Hits   uint64
+	Misses uint64
+}
+
+// HitRate returns the ratio of hits to all lookups, resp. 0 if there were none.
+func (s CacheStats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// CacheStats returns the statistics of the cache ([WithCache]), e.g. to be exported as metric.
+// Without a cache, the zero value is returned.
+func (a *Authorizer[T]) CacheStats() CacheStats {
+	if a.cache == nil {
+		return CacheStats{}
+	}
+	return a.cache.stats()
+}
+
+// InvalidateCache removes the token from the cache ([WithCache]), so it will be verified again on the next call.
+func (a *Authorizer[T]) InvalidateCache(token string) {
+	if a.cache != nil {
+		a.cache.invalidate(token)
+	}
+}
+
+// InvalidateUser removes all tokens of the user from the cache ([WithCache]),
+// e.g. after the user was deactivated or their grants changed.
+func (a *Authorizer[T]) InvalidateUser(userID string) {
+	if a.cache != nil {
+		a.cache.invalidateUser(userID)
+	}
+}
+
+// cacheInvalidator is the part of the [cachedVerifier] independent of the type of the [Ctx].
+type cacheInvalidator interface {
+	stats() CacheStats
+	invalidate(token string)
+	invalidateUser(userID string)
+}
+
+// cachedVerifier implements the [Verifier] interface by caching the results of the underlying [Verifier].
+// The tokens are stored as hash only.
+type cachedVerifier[T Ctx] struct {
+	verifier Verifier[T]
+	ttl      time.Duration
+	cacheOptions
+
+	mu      sync.Mutex
+	entries map[[sha256.Size]byte]*cacheEntry[T]
+	// expiries orders the entries by their expiration, so expired entries can be swept
+	// and the entry expiring first evicted without scanning all entries.
+	expiries expiryHeap[T]
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+type cacheEntry[T Ctx] struct {
+	key       [sha256.Size]byte
+	authCtx   T
+	err       error
+	expiresAt time.Time
+	// index of the entry in the [expiryHeap]
+	index int
+}
+
+func (c *cachedVerifier[T]) CheckAuthorization(ctx context.Context, token string) (T, error) {
+	key := sha256.Sum256([]byte(token))
+	now := time.Now()
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if ok && now.Before(entry.expiresAt) {
+		authCtx, err := entry.authCtx, entry.err
+		c.mu.Unlock()
+		c.hits.Add(1)
+		return authCtx, err
+	}
+	c.mu.Unlock()
+	c.misses.Add(1)
+	authCtx, err := c.verifier.CheckAuthorization(ctx, token)
+	ttl := c.ttl
+	if err != nil || !authCtx.IsAuthorized() {
+		if c.negativeTTL <= 0 || (err != nil && !errors.Is(err, &PermissionDeniedErr{})) {
+			return authCtx, err
+		}
+		ttl = c.negativeTTL
+	} else {
+		// the cached context is shared between the calls, so it must not be modified afterward
+		authCtx.SetToken(token)
+	}
+	if c.jitter > 0 {
+		ttl += time.Duration(rand.Int63n(int64(c.jitter)))
+	}
+	expiresAt := now.Add(ttl)
+	if holder, ok := any(authCtx).(ExpiresAtHolder); ok && err == nil && authCtx.IsAuthorized() {
+		// a token must not be authorized from the cache after it expired
+		if exp := holder.ExpiresAt(); !exp.IsZero() && exp.Before(expiresAt) {
+			expiresAt = exp
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sweep(now)
+	c.remove(key)
+	c.evict()
+	entry = &cacheEntry[T]{key: key, authCtx: authCtx, err: err, expiresAt: expiresAt}
+	c.entries[key] = entry
+	heap.Push(&c.expiries, entry)
+	return authCtx, err
+}
+
+// evict removes the entry expiring first, if the maximum number of entries is reached.
+func (c *cachedVerifier[T]) evict() {
+	if c.maxEntries <= 0 || len(c.entries) < c.maxEntries {
+		return
+	}
+	entry := heap.Pop(&c.expiries).(*cacheEntry[T])
+	delete(c.entries, entry.key)
+}
+
+// remove deletes the entry of the key, if it's cached.
+func (c *cachedVerifier[T]) remove(key [sha256.Size]byte) {
+	entry, ok := c.entries[key]
+	if !ok {
+		return
+	}
+	heap.Remove(&c.expiries, entry.index)
+	delete(c.entries, key)
+}
+
+func (c *cachedVerifier[T]) stats() CacheStats {
+	return CacheStats{Hits: c.hits.Load(), Misses: c.misses.Load()}
+}
+
+func (c *cachedVerifier[T]) invalidate(token string) {
+	key := sha256.Sum256([]byte(token))
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.remove(key)
+}
+
+func (c *cachedVerifier[T]) invalidateUser(userID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, entry := range c.entries {
+		if entry.err == nil && entry.authCtx.IsAuthorized() && entry.authCtx.UserID() == userID {
+			c.remove(key)
+		}
+	}
+}
+
+// sweep removes the expired entries, so the cache does not grow with tokens not used anymore.
+func (c *cachedVerifier[T]) sweep(now time.Time) {
+	for len(c.expiries) > 0 && !now.Before(c.expiries[0].expiresAt) {
+		entry := heap.Pop(&c.expiries).(*cacheEntry[T])
+		delete(c.entries, entry.key)
+	}
+}
+
+// expiryHeap implements the [heap.Interface] as min-heap of the cache entries by their expiration.
+type expiryHeap[T Ctx] []*cacheEntry[T]
+
+func (h expiryHeap[T]) Len() int { return len(h) }
+
+func (h expiryHeap[T]) Less(i, j int) bool { return h[i].expiresAt.Before(h[j].expiresAt) }
+
+func (h expiryHeap[T]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *expiryHeap[T]) Push(x any) {
+	entry := x.(*cacheEntry[T])
+	entry.index = len(*h)
+	*h = append(*h, entry)
+}
+
+func (h *expiryHeap[T]) Pop() any {
+	old := *h
+	entry := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return entry
+}
@@ -0,0 +1,223 @@
+package authorization
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slog"
+)
+
+type countingVerifier struct {
+	calls    atomic.Int32
+	err      error
+	inactive bool
+}
+
+func (v *countingVerifier) CheckAuthorization(_ context.Context, token string) (*testCtx, error) {
+	v.calls.Add(1)
+	if v.err != nil {
+		return nil, v.err
+	}
+	// the user of the test tokens is the prefix before the dot, e.g. `alice.1`
+	userID, _, _ := strings.Cut(token, ".")
+	return &testCtx{isAuthorized: !v.inactive, isGrantedRole: true, userID: userID}, nil
+}
+
+func newCachedAuthorizer(verifier Verifier[*testCtx], ttl time.Duration, opts ...CacheOption) *Authorizer[*testCtx] {
+	a := &Authorizer[*testCtx]{verifier: verifier, logger: slog.Default()}
+	WithCache[*testCtx](ttl, opts...)(a)
+	return a
+}
+
+func TestWithCache(t *testing.T) {
+	verifier := new(countingVerifier)
+	a := newCachedAuthorizer(verifier, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		authCtx, err := a.CheckAuthorization(context.Background(), "token", WithRole("admin"))
+		require.NoError(t, err)
+		assert.Equal(t, "token", authCtx.GetToken())
+	}
+	assert.Equal(t, int32(1), verifier.calls.Load())
+
+	_, err := a.CheckAuthorization(context.Background(), "other")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), verifier.calls.Load())
+
+	a.InvalidateCache("token")
+	_, err = a.CheckAuthorization(context.Background(), "token")
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), verifier.calls.Load())
+}
+
+func TestWithCache_expired(t *testing.T) {
+	verifier := new(countingVerifier)
+	a := newCachedAuthorizer(verifier, time.Millisecond)
+
+	_, err := a.CheckAuthorization(context.Background(), "token")
+	require.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+	_, err = a.CheckAuthorization(context.Background(), "token")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), verifier.calls.Load())
+	assert.Len(t, a.verifier.(*cachedVerifier[*testCtx]).entries, 1, "expired entries must be removed")
+}
+
+type expiringCtx struct {
+	testCtx
+	expiresAt time.Time
+}
+
+func (t *expiringCtx) ExpiresAt() time.Time { return t.expiresAt }
+
+func TestWithCache_tokenExpiration(t *testing.T) {
+	var calls atomic.Int32
+	verifier := VerifierFunc[*expiringCtx](func(context.Context, string) (*expiringCtx, error) {
+		calls.Add(1)
+		return &expiringCtx{testCtx: testCtx{isAuthorized: true}, expiresAt: time.Now().Add(5 * time.Millisecond)}, nil
+	})
+	a := &Authorizer[*expiringCtx]{verifier: verifier, logger: slog.Default()}
+	WithCache[*expiringCtx](time.Hour, WithJitter(time.Minute))(a)
+
+	_, err := a.CheckAuthorization(context.Background(), "token")
+	require.NoError(t, err)
+	_, err = a.CheckAuthorization(context.Background(), "token")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+
+	time.Sleep(10 * time.Millisecond)
+	_, err = a.CheckAuthorization(context.Background(), "token")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load(), "the token must not be authorized from the cache after its expiration")
+}
+
+func TestWithCache_error(t *testing.T) {
+	verifier := &countingVerifier{err: errors.New("introspection failed")}
+	a := newCachedAuthorizer(verifier, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		_, err := a.CheckAuthorization(context.Background(), "token")
+		assert.ErrorIs(t, err, &UnauthorizedErr{})
+	}
+	assert.Equal(t, int32(2), verifier.calls.Load(), "errors must not be cached")
+}
+
+func TestWithNegativeTTL(t *testing.T) {
+	tests := []struct {
+		name      string
+		verifier  *countingVerifier
+		wantErr   error
+		wantCalls int32
+	}{
+		{
+			name:      "inactive",
+			verifier:  &countingVerifier{inactive: true},
+			wantErr:   &UnauthorizedErr{},
+			wantCalls: 1,
+		},
+		{
+			name:      "permission denied",
+			verifier:  &countingVerifier{err: NewErrorPermissionDenied(errors.New("invalid audience"))},
+			wantErr:   &PermissionDeniedErr{},
+			wantCalls: 1,
+		},
+		{
+			name:      "introspection failed",
+			verifier:  &countingVerifier{err: errors.New("introspection failed")},
+			wantErr:   &UnauthorizedErr{},
+			wantCalls: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newCachedAuthorizer(tt.verifier, time.Minute, WithNegativeTTL(time.Minute))
+			for i := 0; i < 3; i++ {
+				_, err := a.CheckAuthorization(context.Background(), "token")
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantCalls, tt.verifier.calls.Load())
+		})
+	}
+}
+
+func TestWithCache_options(t *testing.T) {
+	verifier := new(countingVerifier)
+	a := newCachedAuthorizer(verifier, time.Minute, WithMaxEntries(2), WithJitter(time.Minute))
+	cache := a.verifier.(*cachedVerifier[*testCtx])
+
+	for _, token := range []string{"first", "second", "third"} {
+		_, err := a.CheckAuthorization(context.Background(), token)
+		require.NoError(t, err)
+	}
+	assert.Len(t, cache.entries, 2)
+	for _, entry := range cache.entries {
+		assert.WithinRange(t, entry.expiresAt, time.Now().Add(time.Minute-time.Second), time.Now().Add(2*time.Minute))
+	}
+	// the tokens are evicted by their (random) expiration, so only the newest is certainly cached
+	_, err := a.CheckAuthorization(context.Background(), "third")
+	require.NoError(t, err)
+
+	stats := a.CacheStats()
+	assert.Equal(t, CacheStats{Hits: 1, Misses: 3}, stats)
+	assert.Equal(t, 0.25, stats.HitRate())
+	assert.Equal(t, int32(3), verifier.calls.Load())
+}
+
+func TestWithCache_evict(t *testing.T) {
+	verifier := new(countingVerifier)
+	a := newCachedAuthorizer(verifier, time.Minute, WithMaxEntries(2))
+	cache := a.verifier.(*cachedVerifier[*testCtx])
+
+	for _, token := range []string{"first", "second"} {
+		_, err := a.CheckAuthorization(context.Background(), token)
+		require.NoError(t, err)
+	}
+	a.InvalidateCache("first")
+	// without jitter, the oldest token expires first and is evicted
+	for _, token := range []string{"third", "fourth"} {
+		_, err := a.CheckAuthorization(context.Background(), token)
+		require.NoError(t, err)
+	}
+	assert.Len(t, cache.entries, 2)
+	assert.Len(t, cache.expiries, 2)
+	for _, token := range []string{"third", "fourth"} {
+		assert.Contains(t, cache.entries, sha256.Sum256([]byte(token)))
+	}
+}
+
+func TestAuthorizer_InvalidateUser(t *testing.T) {
+	verifier := new(countingVerifier)
+	a := newCachedAuthorizer(verifier, time.Minute)
+
+	for _, token := range []string{"alice.1", "alice.2", "bob.1"} {
+		_, err := a.CheckAuthorization(context.Background(), token)
+		require.NoError(t, err)
+	}
+	a.InvalidateUser("alice")
+	for _, token := range []string{"alice.1", "alice.2", "bob.1"} {
+		_, err := a.CheckAuthorization(context.Background(), token)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(5), verifier.calls.Load(), "only the tokens of alice must be verified again")
+}
+
+func TestWithCache_wrapped(t *testing.T) {
+	verifier := new(countingVerifier)
+	a := newCachedAuthorizer(verifier, time.Minute)
+	WithVerification[*testCtx](func(context.Context, *testCtx) error { return nil })(a)
+
+	_, err := a.CheckAuthorization(context.Background(), "alice.1")
+	require.NoError(t, err)
+	a.InvalidateCache("alice.1")
+	_, err = a.CheckAuthorization(context.Background(), "alice.1")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), verifier.calls.Load(), "the cache must be invalidated even if the verifier is wrapped")
+	assert.Equal(t, CacheStats{Misses: 2}, a.CacheStats())
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"golang.org/x/exp/slog"
 
@@ -17,12 +18,19 @@ const (
 var (
 	ErrEmptyAuthorizationHeader = errors.New("authorization header is empty")
 	ErrMissingRole              = errors.New("missing required role")
+	ErrMissingScope             = errors.New("missing required scope")
 )
 
 // Authorizer provides the functionality to check for authorization such as token verification including role checks.
 type Authorizer[T Ctx] struct {
-	verifier Verifier[T]
-	logger   *slog.Logger
+	verifier         Verifier[T]
+	logger           *slog.Logger
+	auditHook        AuditHook
+	batchConcurrency int
+	revocations      *revocationList
+	keyRefresher     KeyRefresher
+	// cache is kept separately, since the verifier might be wrapped by later options (e.g. [WithVerification]).
+	cache cacheInvalidator
 }
 
 // Option allows customization of the [Authorizer] such as caching, logging and more.
@@ -46,6 +54,9 @@ func New[T Ctx](ctx context.Context, zitadel *zitadel.Zitadel, initVerifier Veri
 		verifier: verifier,
 		logger:   slog.Default(),
 	}
+	if refresher, ok := verifier.(KeyRefresher); ok {
+		authorizer.keyRefresher = refresher
+	}
 	for _, option := range options {
 		option(authorizer)
 	}
@@ -55,31 +66,64 @@ func New[T Ctx](ctx context.Context, zitadel *zitadel.Zitadel, initVerifier Veri
 // CheckAuthorization will verify the token using the configured [Verifier] and provided [Check]
 func (a *Authorizer[T]) CheckAuthorization(ctx context.Context, token string, options ...CheckOption) (authCtx T, err error) {
 	a.logger.Log(ctx, slog.LevelDebug, "checking authorization")
-	var t T
-	if token == "" {
-		a.logger.Log(ctx, slog.LevelWarn, "no authorization header")
-		return t, NewErrorUnauthorized(ErrEmptyAuthorizationHeader)
-	}
 	checks := new(Check[Ctx])
 	for _, option := range options {
 		option(checks)
 	}
+	authCtx, verified, err := a.checkAuthorization(ctx, token, checks)
+	if a.auditHook != nil {
+		a.audit(ctx, authCtx, verified, checks, err)
+	}
+	if err != nil {
+		var t T
+		return t, err
+	}
+	if authCtx.GetToken() != token {
+		authCtx.SetToken(token)
+	}
+	return authCtx, nil
+}
+
+// checkAuthorization returns the verified [Ctx] (verified is true) even if a [Check] failed,
+// so the user can be reported to the [AuditHook].
+func (a *Authorizer[T]) checkAuthorization(ctx context.Context, token string, checks *Check[Ctx]) (authCtx T, verified bool, err error) {
+	var t T
+	if token == "" {
+		a.logger.Log(ctx, slog.LevelWarn, "no authorization header")
+		return t, false, NewErrorUnauthorized(ErrEmptyAuthorizationHeader)
+	}
 	authCtx, err = a.verifier.CheckAuthorization(ctx, token)
+	if errors.Is(err, &PermissionDeniedErr{}) {
+		a.logger.With("error", err).Log(ctx, slog.LevelWarn, "permission denied")
+		return t, false, err
+	}
 	if err != nil || !authCtx.IsAuthorized() {
 		a.logger.With("error", err).Log(ctx, slog.LevelWarn, "unauthorized")
-		return t, NewErrorUnauthorized(err)
+		return t, false, NewErrorUnauthorized(err)
+	}
+	if a.revocations != nil {
+		a.revocations.refresh(ctx, a.logger)
+		if a.revocations.isRevoked(authCtx) {
+			a.logger.With("user", authCtx.UserID()).Log(ctx, slog.LevelWarn, "token revoked")
+			return t, false, NewErrorUnauthorized(ErrTokenRevoked)
+		}
 	}
 	for _, c := range checks.Checks {
 		if err = c(authCtx); err != nil {
 			a.logger.With("error", err, "user", authCtx.UserID()).Log(ctx, slog.LevelWarn, "permission denied")
-			return t, NewErrorPermissionDenied(err)
+			return authCtx, true, NewErrorPermissionDenied(err)
 		}
 	}
-	authCtx.SetToken(token)
-	return authCtx, nil
+	if err = evaluatePolicies(ctx, authCtx, checks.Policies); err != nil {
+		a.logger.With("error", err, "user", authCtx.UserID()).Log(ctx, slog.LevelWarn, "permission denied")
+		return authCtx, true, NewErrorPermissionDenied(err)
+	}
+	return authCtx, true, nil
 }
 
 // Verifier defines the possible verification checks such as validation of the authorizationToken.
+// Errors are returned as [UnauthorizedErr] to the caller, unless the Verifier already returns a [PermissionDeniedErr]
+// for a valid but insufficient authorizationToken (e.g. missing scopes).
 type Verifier[T Ctx] interface {
 	CheckAuthorization(ctx context.Context, authorizationToken string) (T, error)
 }
@@ -91,6 +135,10 @@ type VerifierInitializer[T Ctx] func(ctx context.Context, zitadel *zitadel.Zitad
 // There will be options, e.g. caching and more in the near future.
 type Check[T Ctx] struct {
 	Checks []func(authCtx T) error
+	// Requirements describe the Checks for the [AuditHook], e.g. `role:admin`.
+	Requirements []string
+	// Policies are evaluated after the Checks ([WithPolicy]).
+	Policies []PolicyEvaluator
 }
 
 // CheckOption allows customization of the [Check] like additional permission requirements (e.g. roles)
@@ -100,6 +148,7 @@ type CheckOption func(*Check[Ctx])
 // If the role is not granted to the user, an [ErrMissingRole] is returned.
 func WithRole(role string) CheckOption {
 	return func(checks *Check[Ctx]) {
+		checks.Requirements = append(checks.Requirements, "role:"+role)
 		checks.Checks = append(checks.Checks, func(authCtx Ctx) error {
 			if authCtx.IsGrantedRole(role) {
 				return nil
@@ -108,3 +157,49 @@ func WithRole(role string) CheckOption {
 		})
 	}
 }
+
+// WithAnyRole requires the authorized user to be granted at least one of the provided roles.
+// If none of the roles is granted to the user, an [ErrMissingRole] is returned.
+func WithAnyRole(roles ...string) CheckOption {
+	return func(checks *Check[Ctx]) {
+		checks.Requirements = append(checks.Requirements, "anyRole:"+strings.Join(roles, ","))
+		checks.Checks = append(checks.Checks, func(authCtx Ctx) error {
+			for _, role := range roles {
+				if authCtx.IsGrantedRole(role) {
+					return nil
+				}
+			}
+			return fmt.Errorf("%w: `%s`", ErrMissingRole, strings.Join(roles, "`, `"))
+		})
+	}
+}
+
+// ScopeHolder is implemented by authorization contexts ([Ctx]) providing the granted scopes of the token,
+// e.g. the IntrospectionContext and JWTContext of the oauth package.
+type ScopeHolder interface {
+	IsGrantedScope(scope string) bool
+}
+
+// WithScope requires the token to be granted the provided scope.
+// If the scope is not granted or the [Ctx] does not implement the [ScopeHolder], an [ErrMissingScope] is returned.
+func WithScope(scope string) CheckOption {
+	return func(checks *Check[Ctx]) {
+		checks.Requirements = append(checks.Requirements, "scope:"+scope)
+		checks.Checks = append(checks.Checks, func(authCtx Ctx) error {
+			if holder, ok := authCtx.(ScopeHolder); ok && holder.IsGrantedScope(scope) {
+				return nil
+			}
+			return fmt.Errorf("%w: `%s`", ErrMissingScope, scope)
+		})
+	}
+}
+
+// WithRequiredScopes requires the token to be granted all the provided scopes (see [WithScope]),
+// e.g. for APIs modelling their permissions as OAuth scopes instead of roles.
+func WithRequiredScopes(scopes ...string) CheckOption {
+	return func(checks *Check[Ctx]) {
+		for _, scope := range scopes {
+			WithScope(scope)(checks)
+		}
+	}
+}
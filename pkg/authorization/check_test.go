@@ -2,6 +2,7 @@ package authorization
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -68,6 +69,22 @@ func TestAuthorizer_CheckAuthorization(t *testing.T) {
 			wantAuthCtx: nil,
 			wantErr:     NewErrorPermissionDenied(ErrMissingRole),
 		},
+		{
+			name: "insufficient token, permissiondenied error",
+			a: Authorizer[*testCtx]{
+				verifier: &testVerifier[*testCtx]{
+					err: NewErrorPermissionDenied(errTest),
+				},
+				logger: slog.Default(),
+			},
+			args: args{
+				ctx:     context.Background(),
+				token:   "token",
+				options: nil,
+			},
+			wantAuthCtx: nil,
+			wantErr:     NewErrorPermissionDenied(errTest),
+		},
 		{
 			name: "authorized",
 			a: Authorizer[*testCtx]{
@@ -115,6 +132,8 @@ func TestAuthorizer_CheckAuthorization(t *testing.T) {
 	}
 }
 
+var errTest = errors.New("test")
+
 type testVerifier[T Ctx] struct {
 	ctx T
 	err error
@@ -0,0 +1,66 @@
+package authorization
+
+import "context"
+
+const (
+	// HeaderUserID is used to propagate the id of the authorized user to downstream services.
+	HeaderUserID = "x-zitadel-user-id"
+	// HeaderOrgID is used to propagate the id of the organization of the authorized user to downstream services.
+	HeaderOrgID = "x-zitadel-user-org-id"
+	// HeaderProjectID is used to propagate the id of the project of the token to downstream services.
+	HeaderProjectID = "x-zitadel-project-id"
+)
+
+// OrganizationHolder is implemented by authorization contexts ([Ctx]) providing the id of the organization
+// of the authorized user, e.g. the IntrospectionContext and JWTContext of the oauth package.
+type OrganizationHolder interface {
+	OrgID() string
+}
+
+// ProjectHolder is implemented by authorization contexts ([Ctx]) providing the id of the project
+// the token was issued for, e.g. the IntrospectionContext and JWTContext of the oauth package.
+type ProjectHolder interface {
+	ProjectID() string
+}
+
+// Identity is the identity of the authorized caller, e.g. to scope the data by tenant
+// or to propagate it to downstream services.
+type Identity struct {
+	UserID    string
+	OrgID     string
+	ProjectID string
+}
+
+// Headers returns the identity as map of the HeaderUserID, HeaderOrgID and HeaderProjectID.
+// Empty values are omitted.
+func (i Identity) Headers() map[string]string {
+	headers := make(map[string]string, 3)
+	for key, value := range map[string]string{
+		HeaderUserID:    i.UserID,
+		HeaderOrgID:     i.OrgID,
+		HeaderProjectID: i.ProjectID,
+	} {
+		if value != "" {
+			headers[key] = value
+		}
+	}
+	return headers
+}
+
+// CallerIdentity returns the [Identity] of the authorized caller of the authorization context ([Ctx]) of the ctx.
+// The organization and project are only set if the [Ctx] implements the [OrganizationHolder], resp. [ProjectHolder].
+// In case of an unauthorized caller, the identity is empty.
+func CallerIdentity(ctx context.Context) Identity {
+	authCtx := Context[Ctx](ctx)
+	if authCtx == nil || !authCtx.IsAuthorized() {
+		return Identity{}
+	}
+	identity := Identity{UserID: authCtx.UserID()}
+	if holder, ok := authCtx.(OrganizationHolder); ok {
+		identity.OrgID = holder.OrgID()
+	}
+	if holder, ok := authCtx.(ProjectHolder); ok {
+		identity.ProjectID = holder.ProjectID()
+	}
+	return identity
+}
@@ -0,0 +1,18 @@
+package authorization
+
+import "context"
+
+// KeyRefresher is implemented by verifiers caching the public keys of ZITADEL, e.g. the JWTVerification
+// of the oauth package, which allows refreshing the keys immediately after a key rotation.
+type KeyRefresher interface {
+	RefreshKeys(ctx context.Context) error
+}
+
+// RefreshKeys refreshes the public keys of the [Verifier], if it implements [KeyRefresher],
+// e.g. triggered by a key rotation event. Otherwise, it's a no-op.
+func (a *Authorizer[T]) RefreshKeys(ctx context.Context) error {
+	if a.keyRefresher == nil {
+		return nil
+	}
+	return a.keyRefresher.RefreshKeys(ctx)
+}
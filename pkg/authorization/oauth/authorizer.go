@@ -2,8 +2,10 @@ package oauth
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/zitadel/oidc/v3/pkg/client"
@@ -17,6 +19,10 @@ import (
 var (
 	ErrInvalidAuthorizationHeader = errors.New("invalid authorization header, must be prefixed with `Bearer`")
 	ErrIntrospectionFailed        = errors.New("token introspection failed")
+	ErrInvalidAudience            = errors.New("token is not issued for the required audience")
+	ErrMissingScope               = authorization.ErrMissingScope
+	ErrNoApplicationKey           = errors.New("key is not an application key (missing clientId)")
+	ErrMissingClientCredentials   = errors.New("client id and client secret are required")
 )
 
 // IntrospectionVerification provides an [authorization.Verifier] implementation
@@ -24,19 +30,54 @@ var (
 // Use [WithIntrospection] for implementation.
 type IntrospectionVerification[T any] struct {
 	rs.ResourceServer
+	audience       string
+	requiredScopes []string
+}
+
+// IntrospectionOption allows customization of the [IntrospectionVerification].
+type IntrospectionOption func(*introspectionOptions)
+
+type introspectionOptions struct {
+	audience       string
+	requiredScopes []string
+}
+
+// WithRequiredAudience requires the introspected token to be issued for the audience,
+// e.g. the id of the project of the API.
+// Tokens of other audiences are invalid for the API (`invalid_token` of RFC 6750), so they are rejected
+// with an [ErrInvalidAudience] as [authorization.UnauthorizedErr], like by the JWT verification.
+func WithRequiredAudience(audience string) IntrospectionOption {
+	return func(o *introspectionOptions) {
+		o.audience = audience
+	}
+}
+
+// WithRequiredScopes requires the introspected token to be granted all the provided scopes.
+// Tokens missing any of them are rejected with an [ErrMissingScope] as [authorization.PermissionDeniedErr].
+func WithRequiredScopes(scopes ...string) IntrospectionOption {
+	return func(o *introspectionOptions) {
+		o.requiredScopes = append(o.requiredScopes, scopes...)
+	}
 }
 
 // WithIntrospection creates the OAuth2 Introspection implementation of the [authorization.Verifier] interface.
 // The introspection endpoint itself requires some [IntrospectionAuthentication] of the client.
 // Possible implementation are [JWTProfileIntrospectionAuthentication] and [ClientIDSecretIntrospectionAuthentication].
-func WithIntrospection[T authorization.Ctx](auth IntrospectionAuthentication) authorization.VerifierInitializer[T] {
+// The verification can be restricted further, e.g. by using [WithRequiredAudience] and [WithRequiredScopes].
+func WithIntrospection[T authorization.Ctx](auth IntrospectionAuthentication, opts ...IntrospectionOption) authorization.VerifierInitializer[T] {
 	return func(ctx context.Context, zitadel *zitadel.Zitadel) (authorization.Verifier[T], error) {
+		o := new(introspectionOptions)
+		for _, opt := range opts {
+			opt(o)
+		}
 		resourceServer, err := auth(ctx, zitadel.Origin())
 		if err != nil {
 			return nil, err
 		}
 		return &IntrospectionVerification[T]{
 			ResourceServer: resourceServer,
+			audience:       o.audience,
+			requiredScopes: o.requiredScopes,
 		}, nil
 	}
 }
@@ -44,17 +85,26 @@ func WithIntrospection[T authorization.Ctx](auth IntrospectionAuthentication) au
 type IntrospectionAuthentication func(ctx context.Context, issuer string) (rs.ResourceServer, error)
 
 // JWTProfileIntrospectionAuthentication allows to authenticate the introspection request with JWT Profile
-// using a key.json provided by ZITADEL.
+// (`private_key_jwt`) using a key.json provided by ZITADEL.
+// The key must be created on the API application (containing the `clientId`), not on a service user,
+// otherwise an [ErrNoApplicationKey] is returned.
 func JWTProfileIntrospectionAuthentication(file *client.KeyFile) IntrospectionAuthentication {
 	return func(ctx context.Context, issuer string) (rs.ResourceServer, error) {
+		if file.ClientID == "" {
+			return nil, ErrNoApplicationKey
+		}
 		return rs.NewResourceServerJWTProfile(ctx, issuer, file.ClientID, file.KeyID, []byte(file.Key))
 	}
 }
 
 // ClientIDSecretIntrospectionAuthentication allows to authenticate the introspection request with
-// the client_id and client_secret provided by ZITADEL.
+// the client_id and client_secret provided by ZITADEL using HTTP Basic authentication (`client_secret_basic`).
+// If either of them is empty, an [ErrMissingClientCredentials] is returned.
 func ClientIDSecretIntrospectionAuthentication(clientID, clientSecret string) IntrospectionAuthentication {
 	return func(ctx context.Context, issuer string) (rs.ResourceServer, error) {
+		if clientID == "" || clientSecret == "" {
+			return nil, ErrMissingClientCredentials
+		}
 		return rs.NewResourceServerClientCredentials(ctx, issuer, clientID, clientSecret)
 	}
 }
@@ -71,6 +121,12 @@ func DefaultAuthorization(path string) authorization.VerifierInitializer[*Intros
 	return WithIntrospection[*IntrospectionContext](JWTProfileIntrospectionAuthentication(c))
 }
 
+// ClientSecretAuthorization is a short version of [WithIntrospection[*IntrospectionContext](ClientIDSecretIntrospectionAuthentication)]
+// for API applications registered with the `Basic` authentication method.
+func ClientSecretAuthorization(clientID, clientSecret string, opts ...IntrospectionOption) authorization.VerifierInitializer[*IntrospectionContext] {
+	return WithIntrospection[*IntrospectionContext](ClientIDSecretIntrospectionAuthentication(clientID, clientSecret), opts...)
+}
+
 // CheckAuthorization implements the [authorization.Verifier] interface by checking the authorizationToken
 // on the OAuth2 introspection endpoint.
 // On success, it will return a generic struct of type [T] of the [IntrospectionVerification].
@@ -79,9 +135,43 @@ func (i *IntrospectionVerification[T]) CheckAuthorization(ctx context.Context, a
 	if !ok {
 		return resp, ErrInvalidAuthorizationHeader
 	}
-	resp, err = rs.Introspect[T](ctx, i.ResourceServer, strings.TrimSpace(accessToken))
+	raw, err := rs.Introspect[json.RawMessage](ctx, i.ResourceServer, strings.TrimSpace(accessToken))
 	if err != nil {
 		return resp, fmt.Errorf("%w: %v", ErrIntrospectionFailed, err)
 	}
+	if err = i.checkClaims(raw); err != nil {
+		return resp, err
+	}
+	if err = json.Unmarshal(raw, &resp); err != nil {
+		return resp, fmt.Errorf("%w: %v", ErrIntrospectionFailed, err)
+	}
 	return resp, nil
 }
+
+// checkClaims checks the audience and scopes of an active token.
+// Inactive tokens are not checked, so the [authorization.Ctx] will report them as unauthorized.
+func (i *IntrospectionVerification[T]) checkClaims(raw json.RawMessage) error {
+	if i.audience == "" && len(i.requiredScopes) == 0 {
+		return nil
+	}
+	claims := new(struct {
+		Active   bool                     `json:"active"`
+		Scope    oidc.SpaceDelimitedArray `json:"scope"`
+		Audience oidc.Audience            `json:"aud"`
+	})
+	if err := json.Unmarshal(raw, claims); err != nil {
+		return fmt.Errorf("%w: %v", ErrIntrospectionFailed, err)
+	}
+	if !claims.Active {
+		return nil
+	}
+	if i.audience != "" && !slices.Contains(claims.Audience, i.audience) {
+		return authorization.NewErrorUnauthorized(fmt.Errorf("%w: `%s`", ErrInvalidAudience, i.audience))
+	}
+	for _, scope := range i.requiredScopes {
+		if !slices.Contains(claims.Scope, scope) {
+			return authorization.NewErrorPermissionDenied(fmt.Errorf("%w: `%s`", ErrMissingScope, scope))
+		}
+	}
+	return nil
+}
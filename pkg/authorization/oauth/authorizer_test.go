@@ -3,11 +3,20 @@ package oauth
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/oidc/v3/pkg/client"
+	"github.com/zitadel/oidc/v3/pkg/client/rs"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authorization"
+	"github.com/zitadel/zitadel-go/v3/pkg/zitadel"
 )
 
 func TestIntrospectionVerification_CheckAuthorization(t *testing.T) {
@@ -130,3 +139,124 @@ func (m *mockTransport) RoundTrip(_ *http.Request) (*http.Response, error) {
 		Body:       responseBody,
 	}, nil
 }
+
+func TestIntrospectionVerification_CheckAuthorization_requirements(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []IntrospectionOption
+		resp       string
+		wantErr    error
+		wantDenied bool
+	}{
+		{
+			name: "no requirements",
+			resp: `{"active": true, "sub": "sub"}`,
+		},
+		{
+			name:    "invalid audience",
+			opts:    []IntrospectionOption{WithRequiredAudience("project")},
+			resp:    `{"active": true, "sub": "sub", "aud": ["other"]}`,
+			wantErr: ErrInvalidAudience,
+		},
+		{
+			name: "valid audience",
+			opts: []IntrospectionOption{WithRequiredAudience("project")},
+			resp: `{"active": true, "sub": "sub", "aud": ["client", "project"]}`,
+		},
+		{
+			name:       "missing scope",
+			opts:       []IntrospectionOption{WithRequiredScopes("openid", "write")},
+			resp:       `{"active": true, "sub": "sub", "scope": "openid read"}`,
+			wantErr:    ErrMissingScope,
+			wantDenied: true,
+		},
+		{
+			name: "granted scopes",
+			opts: []IntrospectionOption{WithRequiredScopes("openid", "write")},
+			resp: `{"active": true, "sub": "sub", "scope": "openid read write"}`,
+		},
+		{
+			name: "inactive token is not checked",
+			opts: []IntrospectionOption{WithRequiredAudience("project"), WithRequiredScopes("write")},
+			resp: `{"active": false}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initVerifier := WithIntrospection[*IntrospectionContext](func(context.Context, string) (rs.ResourceServer, error) {
+				return &resourceServer{client: mockClient([]byte(tt.resp), 200)}, nil
+			}, tt.opts...)
+			verifier, err := initVerifier(context.Background(), zitadel.New("zitadel.example.com"))
+			require.NoError(t, err)
+
+			got, err := verifier.CheckAuthorization(context.Background(), "Bearer token")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, tt.wantDenied, errors.Is(err, &authorization.PermissionDeniedErr{}))
+				assert.Equal(t, !tt.wantDenied, errors.Is(err, &authorization.UnauthorizedErr{}))
+				return
+			}
+			require.NoError(t, err)
+			var want oidc.IntrospectionResponse
+			require.NoError(t, json.Unmarshal([]byte(tt.resp), &want))
+			assert.Equal(t, want, got.IntrospectionResponse)
+		})
+	}
+}
+
+func TestJWTProfileIntrospectionAuthentication(t *testing.T) {
+	_, err := JWTProfileIntrospectionAuthentication(&client.KeyFile{Type: "serviceaccount", UserID: "user", KeyID: "key"})(context.Background(), "https://zitadel.example.com")
+	assert.ErrorIs(t, err, ErrNoApplicationKey)
+}
+
+func TestClientSecretAuthorization(t *testing.T) {
+	server := newJWKSServer(t, "key")
+	tests := []struct {
+		name         string
+		clientID     string
+		clientSecret string
+		wantErr      error
+	}{
+		{
+			name:         "valid",
+			clientID:     "client",
+			clientSecret: "secret",
+		},
+		{
+			name:         "missing client id",
+			clientSecret: "secret",
+			wantErr:      ErrMissingClientCredentials,
+		},
+		{
+			name:     "missing client secret",
+			clientID: "client",
+			wantErr:  ErrMissingClientCredentials,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier, err := ClientSecretAuthorization(tt.clientID, tt.clientSecret, WithRequiredAudience("project"))(context.Background(), server.zitadel(t))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			introspection, ok := verifier.(*IntrospectionVerification[*IntrospectionContext])
+			require.True(t, ok)
+			assert.Equal(t, "project", introspection.audience)
+			assert.Equal(t, server.URL+"/oauth/v2/introspect", introspection.ResourceServer.IntrospectionURL())
+		})
+	}
+}
+
+func TestJWTContext_identity(t *testing.T) {
+	c := &JWTContext{AccessTokenClaims: oidc.AccessTokenClaims{Claims: map[string]any{
+		"urn:zitadel:iam:user:resourceowner:id":      "org",
+		"urn:zitadel:iam:org:project:roles":          map[string]any{},
+		"urn:zitadel:iam:org:project:123456:roles":   map[string]any{},
+		"urn:zitadel:iam:org:project:id:zitadel:aud": "other",
+	}}}
+	assert.Equal(t, "org", c.OrgID())
+	assert.Equal(t, "123456", c.ProjectID())
+	assert.Empty(t, (*JWTContext)(nil).ProjectID())
+}
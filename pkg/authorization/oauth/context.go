@@ -1,6 +1,19 @@
 package oauth
 
-import "github.com/zitadel/oidc/v3/pkg/oidc"
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+)
+
+const (
+	roleClaim          = "urn:zitadel:iam:org:project:roles"
+	resourceOwnerClaim = "urn:zitadel:iam:user:resourceowner:id"
+	projectRolesPrefix = "urn:zitadel:iam:org:project:"
+	projectRolesSuffix = ":roles"
+)
 
 // IntrospectionContext implements the [authorization.Ctx] interface with the [oidc.IntrospectionResponse] as underlying data.
 type IntrospectionContext struct {
@@ -42,6 +55,54 @@ func (c *IntrospectionContext) IsGrantedRoleInOrganization(role, organizationID
 	return ok
 }
 
+// IsGrantedScope implements [authorization.ScopeHolder] by checking the `scope` claim of the [oidc.IntrospectionResponse].
+func (c *IntrospectionContext) IsGrantedScope(scope string) bool {
+	if c == nil {
+		return false
+	}
+	return slices.Contains(c.IntrospectionResponse.Scope, scope)
+}
+
+// OrgID implements [authorization.OrganizationHolder] by returning the `urn:zitadel:iam:user:resourceowner:id` claim.
+func (c *IntrospectionContext) OrgID() string {
+	if c == nil {
+		return ""
+	}
+	return orgIDOf(c.IntrospectionResponse.Claims)
+}
+
+// ProjectID implements [authorization.ProjectHolder] by returning the id of the `urn:zitadel:iam:org:project:{id}:roles` claim.
+func (c *IntrospectionContext) ProjectID() string {
+	if c == nil {
+		return ""
+	}
+	return projectIDOf(c.IntrospectionResponse.Claims)
+}
+
+// TokenID implements [authorization.TokenIDHolder] by returning the `jti` claim of the [oidc.IntrospectionResponse].
+func (c *IntrospectionContext) TokenID() string {
+	if c == nil {
+		return ""
+	}
+	return c.IntrospectionResponse.JWTID
+}
+
+// IssuedAt implements [authorization.IssuedAtHolder] by returning the `iat` claim of the [oidc.IntrospectionResponse].
+func (c *IntrospectionContext) IssuedAt() time.Time {
+	if c == nil {
+		return time.Time{}
+	}
+	return c.IntrospectionResponse.IssuedAt.AsTime()
+}
+
+// ExpiresAt implements [authorization.ExpiresAtHolder] by returning the `exp` claim of the [oidc.IntrospectionResponse].
+func (c *IntrospectionContext) ExpiresAt() time.Time {
+	if c == nil {
+		return time.Time{}
+	}
+	return c.IntrospectionResponse.Expiration.AsTime()
+}
+
 func (c *IntrospectionContext) SetToken(token string) {
 	c.token = token
 }
@@ -51,7 +112,109 @@ func (c *IntrospectionContext) GetToken() string {
 }
 
 func (c *IntrospectionContext) checkRoleClaim(role string) map[string]interface{} {
-	roles, ok := c.IntrospectionResponse.Claims["urn:zitadel:iam:org:project:roles"].(map[string]interface{})
+	return checkRoleClaim(c.IntrospectionResponse.Claims, role)
+}
+
+// JWTContext implements the [authorization.Ctx] interface with the [oidc.AccessTokenClaims] of a JWT access token
+// as underlying data. It's meant to be used with the [JWTVerification].
+type JWTContext struct {
+	oidc.AccessTokenClaims
+	token string
+}
+
+// IsAuthorized implements [authorization.Ctx] by checking the presence of the `sub` claim,
+// since the [JWTVerification] already validated the token.
+func (c *JWTContext) IsAuthorized() bool {
+	if c == nil {
+		return false
+	}
+	return c.AccessTokenClaims.Subject != ""
+}
+
+// UserID implements [authorization.Ctx] by returning the `sub` claim of the [oidc.AccessTokenClaims].
+func (c *JWTContext) UserID() string {
+	if c == nil {
+		return ""
+	}
+	return c.AccessTokenClaims.Subject
+}
+
+// IsGrantedRole implements [authorization.Ctx] by checking if the `urn:zitadel:iam:org:project:roles` claim contains the requested role.
+func (c *JWTContext) IsGrantedRole(role string) bool {
+	if c == nil {
+		return false
+	}
+	return len(checkRoleClaim(c.AccessTokenClaims.Claims, role)) > 0
+}
+
+// IsGrantedRoleInOrganization implements [authorization.Ctx] by checking if the organizationID is part of the list
+// of the `urn:zitadel:iam:org:project:roles` claim requested role.
+func (c *JWTContext) IsGrantedRoleInOrganization(role, organizationID string) bool {
+	if c == nil {
+		return false
+	}
+	_, ok := checkRoleClaim(c.AccessTokenClaims.Claims, role)[organizationID]
+	return ok
+}
+
+// IsGrantedScope implements [authorization.ScopeHolder] by checking the `scope` claim of the [oidc.AccessTokenClaims].
+func (c *JWTContext) IsGrantedScope(scope string) bool {
+	if c == nil {
+		return false
+	}
+	return slices.Contains(c.AccessTokenClaims.Scopes, scope)
+}
+
+// OrgID implements [authorization.OrganizationHolder] by returning the `urn:zitadel:iam:user:resourceowner:id` claim.
+func (c *JWTContext) OrgID() string {
+	if c == nil {
+		return ""
+	}
+	return orgIDOf(c.AccessTokenClaims.Claims)
+}
+
+// ProjectID implements [authorization.ProjectHolder] by returning the id of the `urn:zitadel:iam:org:project:{id}:roles` claim.
+func (c *JWTContext) ProjectID() string {
+	if c == nil {
+		return ""
+	}
+	return projectIDOf(c.AccessTokenClaims.Claims)
+}
+
+// TokenID implements [authorization.TokenIDHolder] by returning the `jti` claim of the [oidc.AccessTokenClaims].
+func (c *JWTContext) TokenID() string {
+	if c == nil {
+		return ""
+	}
+	return c.AccessTokenClaims.JWTID
+}
+
+// IssuedAt implements [authorization.IssuedAtHolder] by returning the `iat` claim of the [oidc.AccessTokenClaims].
+func (c *JWTContext) IssuedAt() time.Time {
+	if c == nil {
+		return time.Time{}
+	}
+	return c.AccessTokenClaims.IssuedAt.AsTime()
+}
+
+// ExpiresAt implements [authorization.ExpiresAtHolder] by returning the `exp` claim of the [oidc.AccessTokenClaims].
+func (c *JWTContext) ExpiresAt() time.Time {
+	if c == nil {
+		return time.Time{}
+	}
+	return c.AccessTokenClaims.Expiration.AsTime()
+}
+
+func (c *JWTContext) SetToken(token string) {
+	c.token = token
+}
+
+func (c *JWTContext) GetToken() string {
+	return c.token
+}
+
+func checkRoleClaim(claims map[string]interface{}, role string) map[string]interface{} {
+	roles, ok := claims[roleClaim].(map[string]interface{})
 	if !ok || len(roles) == 0 {
 		return nil
 	}
@@ -61,3 +224,26 @@ func (c *IntrospectionContext) checkRoleClaim(role string) map[string]interface{
 	}
 	return organisations
 }
+
+func orgIDOf(claims map[string]interface{}) string {
+	orgID, _ := claims[resourceOwnerClaim].(string)
+	return orgID
+}
+
+// projectIDOf returns the id of the project specific role claim (`urn:zitadel:iam:org:project:{id}:roles`),
+// which is only present if the roles were requested.
+func projectIDOf(claims map[string]interface{}) string {
+	for claim := range claims {
+		if claim == roleClaim {
+			continue
+		}
+		projectID, ok := strings.CutPrefix(claim, projectRolesPrefix)
+		if !ok {
+			continue
+		}
+		if projectID, ok = strings.CutSuffix(projectID, projectRolesSuffix); ok && projectID != "" {
+			return projectID
+		}
+	}
+	return ""
+}
@@ -0,0 +1,297 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/zitadel/oidc/v3/pkg/client"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authorization"
+	"github.com/zitadel/zitadel-go/v3/pkg/zitadel"
+)
+
+var (
+	ErrInvalidJWT      = errors.New("invalid jwt access token")
+	ErrMissingAudience = errors.New("audience is required for the jwt verification")
+)
+
+// minKeySetRefreshInterval limits the refreshes of the [keySet] triggered by an unknown key id,
+// so tokens with random key ids cannot be used to flood the JWKS endpoint.
+const minKeySetRefreshInterval = 10 * time.Second
+
+// JWTVerification provides an [authorization.Verifier] implementation
+// by validating the provided JWT access token locally with the public keys (JWKS) of ZITADEL.
+// Compared to the [IntrospectionVerification], no call to ZITADEL is needed per request,
+// but revoked tokens will be accepted until they expire.
+// Use [WithJWT] for implementation.
+type JWTVerification[T authorization.Ctx] struct {
+	issuer            string
+	audience          string
+	clockSkew         time.Duration
+	supportedSignAlgs []string
+	keySet            *keySet
+}
+
+// JWTOption allows customization of the [JWTVerification].
+type JWTOption func(*jwtOptions)
+
+type jwtOptions struct {
+	issuer          string
+	staticKeys      *jose.JSONWebKeySet
+	audience        string
+	clockSkew       time.Duration
+	refreshInterval time.Duration
+	httpClient      *http.Client
+}
+
+// WithAudience requires the token to be issued for the audience, e.g. the id of the project of the API.
+// The audience is required, otherwise any token signed by ZITADEL (e.g. of other projects) would be accepted.
+func WithAudience(audience string) JWTOption {
+	return func(o *jwtOptions) {
+		o.audience = audience
+	}
+}
+
+// WithIssuer requires the token to be issued by the issuer, e.g. if ZITADEL is accessed by another domain
+// than the one of the issuer. By default, the issuer of the discovery endpoint is required.
+func WithIssuer(issuer string) JWTOption {
+	return func(o *jwtOptions) {
+		o.issuer = issuer
+	}
+}
+
+// WithStaticKeys validates the tokens with the provided public keys instead of fetching them from the JWKS endpoint.
+// Combined with [WithIssuer], the verification does not require any network call at all (not even on startup),
+// but the keys need to be updated manually on a key rotation in ZITADEL.
+func WithStaticKeys(keys *jose.JSONWebKeySet) JWTOption {
+	return func(o *jwtOptions) {
+		o.staticKeys = keys
+	}
+}
+
+// WithClockSkew allows a tolerance for the time based claims (exp, iat) other than 10 seconds.
+func WithClockSkew(clockSkew time.Duration) JWTOption {
+	return func(o *jwtOptions) {
+		o.clockSkew = clockSkew
+	}
+}
+
+// WithKeySetRefreshInterval allows an interval of the background refresh of the public keys other than 1 hour.
+// Independent of the interval, the keys are refreshed if a token is signed by an unknown key (key rollover).
+func WithKeySetRefreshInterval(interval time.Duration) JWTOption {
+	return func(o *jwtOptions) {
+		o.refreshInterval = interval
+	}
+}
+
+// WithJWTHTTPClient allows a http.Client other than http.DefaultClient for the discovery and JWKS calls.
+func WithJWTHTTPClient(httpClient *http.Client) JWTOption {
+	return func(o *jwtOptions) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithJWT creates the local JWT validation implementation of the [authorization.Verifier] interface.
+// The public keys are fetched from the JWKS endpoint of ZITADEL and refreshed in the background
+// until the ctx passed to [authorization.New] is done, unless [WithStaticKeys] are used.
+// The audience ([WithAudience]) is required, otherwise an [ErrMissingAudience] is returned.
+func WithJWT[T authorization.Ctx](opts ...JWTOption) authorization.VerifierInitializer[T] {
+	return func(ctx context.Context, zitadel *zitadel.Zitadel) (authorization.Verifier[T], error) {
+		o := &jwtOptions{
+			clockSkew:       10 * time.Second,
+			refreshInterval: time.Hour,
+			httpClient:      http.DefaultClient,
+		}
+		for _, opt := range opts {
+			opt(o)
+		}
+		if o.audience == "" {
+			return nil, ErrMissingAudience
+		}
+		if o.staticKeys != nil && o.issuer != "" {
+			return &JWTVerification[T]{
+				issuer:    o.issuer,
+				audience:  o.audience,
+				clockSkew: o.clockSkew,
+				keySet:    &keySet{keys: o.staticKeys.Keys, static: true},
+			}, nil
+		}
+		discovery, err := client.Discover(ctx, zitadel.Origin(), o.httpClient)
+		if err != nil {
+			return nil, err
+		}
+		issuer := discovery.Issuer
+		if o.issuer != "" {
+			issuer = o.issuer
+		}
+		keys := &keySet{
+			jwksURI:    discovery.JwksURI,
+			httpClient: o.httpClient,
+		}
+		if o.staticKeys != nil {
+			keys.keys = o.staticKeys.Keys
+			keys.static = true
+		} else {
+			if err = keys.refresh(ctx); err != nil {
+				return nil, err
+			}
+			go keys.run(ctx, o.refreshInterval)
+		}
+		return &JWTVerification[T]{
+			issuer:            issuer,
+			audience:          o.audience,
+			clockSkew:         o.clockSkew,
+			supportedSignAlgs: discovery.IDTokenSigningAlgValuesSupported,
+			keySet:            keys,
+		}, nil
+	}
+}
+
+// DefaultJWTAuthorization is a short version of [WithJWT[*JWTContext]] requiring the provided audience.
+func DefaultJWTAuthorization(audience string) authorization.VerifierInitializer[*JWTContext] {
+	return WithJWT[*JWTContext](WithAudience(audience))
+}
+
+// CheckAuthorization implements the [authorization.Verifier] interface by validating the signature
+// and the issuer, audience, exp and iat claims of the authorizationToken.
+// On success, the claims of the token are returned as generic struct of type [T].
+func (j *JWTVerification[T]) CheckAuthorization(ctx context.Context, authorizationToken string) (resp T, err error) {
+	accessToken, ok := strings.CutPrefix(authorizationToken, oidc.BearerToken)
+	if !ok {
+		return resp, ErrInvalidAuthorizationHeader
+	}
+	accessToken = strings.TrimSpace(accessToken)
+	claims := new(oidc.AccessTokenClaims)
+	payload, err := oidc.ParseToken(accessToken, claims)
+	if err != nil {
+		return resp, fmt.Errorf("%w: %w", ErrInvalidJWT, err)
+	}
+	if err = oidc.CheckIssuer(claims, j.issuer); err != nil {
+		return resp, fmt.Errorf("%w: %w", ErrInvalidJWT, err)
+	}
+	if err = oidc.CheckAudience(claims, j.audience); err != nil {
+		return resp, fmt.Errorf("%w: %w", ErrInvalidJWT, err)
+	}
+	if err = oidc.CheckSignature(ctx, accessToken, payload, claims, j.supportedSignAlgs, j.keySet); err != nil {
+		return resp, fmt.Errorf("%w: %w", ErrInvalidJWT, err)
+	}
+	// the offset of the expiration check is added to the current time, so it's negated to allow the skew
+	if err = oidc.CheckExpiration(claims, -j.clockSkew); err != nil {
+		return resp, fmt.Errorf("%w: %w", ErrInvalidJWT, err)
+	}
+	if err = oidc.CheckIssuedAt(claims, 0, j.clockSkew); err != nil {
+		return resp, fmt.Errorf("%w: %w", ErrInvalidJWT, err)
+	}
+	if err = json.Unmarshal(payload, &resp); err != nil {
+		return resp, fmt.Errorf("%w: %w", ErrInvalidJWT, err)
+	}
+	return resp, nil
+}
+
+// RefreshKeys implements the [authorization.KeyRefresher] interface by fetching the public keys
+// from the JWKS endpoint immediately, e.g. after a key rotation. Static keys ([WithStaticKeys]) are kept.
+func (j *JWTVerification[T]) RefreshKeys(ctx context.Context) error {
+	if j.keySet.static {
+		return nil
+	}
+	return j.keySet.refresh(ctx)
+}
+
+// keySet implements the [oidc.KeySet] interface by caching the public keys of the JWKS endpoint.
+type keySet struct {
+	jwksURI    string
+	httpClient *http.Client
+	// static keys ([WithStaticKeys]) are never refreshed.
+	static bool
+
+	mu          sync.RWMutex
+	keys        []jose.JSONWebKey
+	lastRefresh time.Time
+
+	// refreshMu ensures concurrent requests with an unknown key only trigger a single refresh.
+	refreshMu sync.Mutex
+}
+
+// VerifySignature implements the [oidc.KeySet] interface.
+// If the key of the signature is not (yet) known, the keys are refreshed once to support key rollovers.
+func (k *keySet) VerifySignature(ctx context.Context, jws *jose.JSONWebSignature) ([]byte, error) {
+	keyID, alg := oidc.GetKeyIDAndAlg(jws)
+	k.mu.RLock()
+	key, err := oidc.FindMatchingKey(keyID, oidc.KeyUseSignature, alg, k.keys...)
+	k.mu.RUnlock()
+	if errors.Is(err, oidc.ErrKeyNone) {
+		if err = k.refreshUnknown(ctx); err != nil {
+			return nil, err
+		}
+		k.mu.RLock()
+		key, err = oidc.FindMatchingKey(keyID, oidc.KeyUseSignature, alg, k.keys...)
+		k.mu.RUnlock()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return jws.Verify(&key)
+}
+
+// refreshUnknown refreshes the keys, unless they were refreshed in the last [minKeySetRefreshInterval].
+func (k *keySet) refreshUnknown(ctx context.Context) error {
+	if k.static {
+		return nil
+	}
+	k.refreshMu.Lock()
+	defer k.refreshMu.Unlock()
+	k.mu.RLock()
+	recent := time.Since(k.lastRefresh) < minKeySetRefreshInterval
+	k.mu.RUnlock()
+	if recent {
+		return nil
+	}
+	return k.refresh(ctx)
+}
+
+func (k *keySet) refresh(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, k.jwksURI, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := k.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetching keys failed with status %d", resp.StatusCode)
+	}
+	keys := new(jose.JSONWebKeySet)
+	if err = json.NewDecoder(resp.Body).Decode(keys); err != nil {
+		return err
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.keys = keys.Keys
+	k.lastRefresh = time.Now()
+	return nil
+}
+
+// run refreshes the keys in the provided interval until the ctx is done.
+// Failed refreshes keep the current keys and are retried in the next interval.
+func (k *keySet) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = k.refresh(ctx)
+		}
+	}
+}
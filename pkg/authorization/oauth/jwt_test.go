@@ -0,0 +1,312 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/zitadel"
+)
+
+type jwksServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	keys     map[string]*rsa.PrivateKey
+	requests int
+}
+
+func newJWKSServer(t *testing.T, keyIDs ...string) *jwksServer {
+	t.Helper()
+	s := &jwksServer{keys: make(map[string]*rsa.PrivateKey)}
+	for _, keyID := range keyIDs {
+		s.addKey(t, keyID)
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		switch r.URL.Path {
+		case oidc.DiscoveryEndpoint:
+			json.NewEncoder(w).Encode(&oidc.DiscoveryConfiguration{
+				Issuer:                s.URL,
+				JwksURI:               s.URL + "/oauth/v2/keys",
+				TokenEndpoint:         s.URL + "/oauth/v2/token",
+				IntrospectionEndpoint: s.URL + "/oauth/v2/introspect",
+			})
+		case "/oauth/v2/keys":
+			s.requests++
+			keySet := new(jose.JSONWebKeySet)
+			for keyID, key := range s.keys {
+				keySet.Keys = append(keySet.Keys, jose.JSONWebKey{Key: &key.PublicKey, KeyID: keyID, Algorithm: string(jose.RS256), Use: oidc.KeyUseSignature})
+			}
+			json.NewEncoder(w).Encode(keySet)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *jwksServer) addKey(t *testing.T, keyID string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[keyID] = key
+}
+
+func (s *jwksServer) jwksRequests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+func (s *jwksServer) sign(t *testing.T, keyID string, claims *oidc.AccessTokenClaims) string {
+	t.Helper()
+	s.mu.Lock()
+	key, ok := s.keys[keyID]
+	s.mu.Unlock()
+	if !ok {
+		var err error
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+	}
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: jose.JSONWebKey{Key: key, KeyID: keyID}}, nil)
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	signed, err := signer.Sign(payload)
+	require.NoError(t, err)
+	token, err := signed.CompactSerialize()
+	require.NoError(t, err)
+	return token
+}
+
+func (s *jwksServer) zitadel(t *testing.T) *zitadel.Zitadel {
+	t.Helper()
+	u, err := url.Parse(s.URL)
+	require.NoError(t, err)
+	return zitadel.New(u.Hostname(), zitadel.WithInsecure(u.Port()))
+}
+
+func newAccessTokenClaims(issuer string, expiration time.Time) *oidc.AccessTokenClaims {
+	claims := oidc.NewAccessTokenClaims(issuer, "user", []string{"project", "client"}, expiration, "id", "client", 0)
+	claims.Claims = map[string]any{
+		roleClaim: map[string]any{"admin": map[string]any{"org": "example.com"}},
+	}
+	return claims
+}
+
+func TestJWTVerification_CheckAuthorization(t *testing.T) {
+	server := newJWKSServer(t, "key1")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	verifier, err := WithJWT[*JWTContext](WithAudience("project"), WithClockSkew(time.Minute))(ctx, server.zitadel(t))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name               string
+		authorizationToken string
+		wantErr            error
+	}{
+		{
+			name:               "invalid authorizationToken format",
+			authorizationToken: server.sign(t, "key1", newAccessTokenClaims(server.URL, time.Now().Add(time.Hour))),
+			wantErr:            ErrInvalidAuthorizationHeader,
+		},
+		{
+			name:               "opaque token",
+			authorizationToken: "Bearer opaque",
+			wantErr:            ErrInvalidJWT,
+		},
+		{
+			name:               "invalid issuer",
+			authorizationToken: "Bearer " + server.sign(t, "key1", newAccessTokenClaims("https://other.example.com", time.Now().Add(time.Hour))),
+			wantErr:            oidc.ErrIssuerInvalid,
+		},
+		{
+			name: "invalid audience",
+			authorizationToken: func() string {
+				claims := newAccessTokenClaims(server.URL, time.Now().Add(time.Hour))
+				claims.Audience = []string{"other"}
+				return "Bearer " + server.sign(t, "key1", claims)
+			}(),
+			wantErr: oidc.ErrAudience,
+		},
+		{
+			name:               "unknown key",
+			authorizationToken: "Bearer " + server.sign(t, "unknown", newAccessTokenClaims(server.URL, time.Now().Add(time.Hour))),
+			wantErr:            oidc.ErrSignatureInvalid,
+		},
+		{
+			name:               "expired",
+			authorizationToken: "Bearer " + server.sign(t, "key1", newAccessTokenClaims(server.URL, time.Now().Add(-2*time.Minute))),
+			wantErr:            oidc.ErrExpired,
+		},
+		{
+			name:               "expired within clock skew",
+			authorizationToken: "Bearer " + server.sign(t, "key1", newAccessTokenClaims(server.URL, time.Now().Add(-30*time.Second))),
+		},
+		{
+			name:               "valid",
+			authorizationToken: "Bearer " + server.sign(t, "key1", newAccessTokenClaims(server.URL, time.Now().Add(time.Hour))),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := verifier.CheckAuthorization(context.Background(), tt.authorizationToken)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, got.IsAuthorized())
+			assert.Equal(t, "user", got.UserID())
+			assert.True(t, got.IsGrantedRole("admin"))
+			assert.True(t, got.IsGrantedRoleInOrganization("admin", "org"))
+			assert.False(t, got.IsGrantedRole("viewer"))
+		})
+	}
+}
+
+func TestJWTVerification_keyRollover(t *testing.T) {
+	server := newJWKSServer(t, "key1")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	v, err := WithJWT[*JWTContext](WithAudience("project"))(ctx, server.zitadel(t))
+	require.NoError(t, err)
+	verifier := v.(*JWTVerification[*JWTContext])
+	assert.Equal(t, 1, server.jwksRequests())
+
+	// tokens of known keys are validated without any further request
+	for i := 0; i < 10; i++ {
+		_, err = verifier.CheckAuthorization(context.Background(), "Bearer "+server.sign(t, "key1", newAccessTokenClaims(server.URL, time.Now().Add(time.Hour))))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, server.jwksRequests())
+
+	// a new key is only fetched once the last refresh is long enough ago
+	server.addKey(t, "key2")
+	token := "Bearer " + server.sign(t, "key2", newAccessTokenClaims(server.URL, time.Now().Add(time.Hour)))
+	_, err = verifier.CheckAuthorization(context.Background(), token)
+	require.Error(t, err)
+	assert.Equal(t, 1, server.jwksRequests())
+
+	verifier.keySet.mu.Lock()
+	verifier.keySet.lastRefresh = time.Now().Add(-minKeySetRefreshInterval)
+	verifier.keySet.mu.Unlock()
+
+	authCtx, err := verifier.CheckAuthorization(context.Background(), token)
+	require.NoError(t, err)
+	assert.Equal(t, "user", authCtx.UserID())
+	assert.Equal(t, 2, server.jwksRequests())
+}
+
+func TestJWTVerification_RefreshKeys(t *testing.T) {
+	server := newJWKSServer(t, "key1")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	v, err := WithJWT[*JWTContext](WithAudience("project"))(ctx, server.zitadel(t))
+	require.NoError(t, err)
+	verifier := v.(*JWTVerification[*JWTContext])
+
+	// an explicit refresh (e.g. on a key rotation event) is not limited by the minimum refresh interval
+	server.addKey(t, "key2")
+	require.NoError(t, verifier.RefreshKeys(context.Background()))
+	assert.Equal(t, 2, server.jwksRequests())
+	_, err = verifier.CheckAuthorization(context.Background(), "Bearer "+server.sign(t, "key2", newAccessTokenClaims(server.URL, time.Now().Add(time.Hour))))
+	require.NoError(t, err)
+	assert.Equal(t, 2, server.jwksRequests())
+}
+
+func TestJWTVerification_backgroundRefresh(t *testing.T) {
+	server := newJWKSServer(t, "key1")
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err := WithJWT[*JWTContext](WithAudience("project"), WithKeySetRefreshInterval(10*time.Millisecond))(ctx, server.zitadel(t))
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		return server.jwksRequests() >= 3
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+	time.Sleep(20 * time.Millisecond)
+	requests := server.jwksRequests()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, requests, server.jwksRequests(), "refresh must stop once the ctx is done")
+}
+
+func TestJWTVerification_staticKeys(t *testing.T) {
+	// the server is only used to sign the tokens, the verification must not call it
+	server := newJWKSServer(t, "key")
+	issuer := "https://issuer.example.com"
+	keys := &jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+		{Key: &server.keys["key"].PublicKey, KeyID: "key", Algorithm: string(jose.RS256), Use: oidc.KeyUseSignature},
+	}}
+	verifier, err := WithJWT[*JWTContext](WithIssuer(issuer), WithStaticKeys(keys), WithAudience("project"))(context.Background(), zitadel.New("zitadel.invalid"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{
+			name:  "valid",
+			token: server.sign(t, "key", newAccessTokenClaims(issuer, time.Now().Add(time.Hour))),
+		},
+		{
+			name:    "other issuer",
+			token:   server.sign(t, "key", newAccessTokenClaims(server.URL, time.Now().Add(time.Hour))),
+			wantErr: true,
+		},
+		{
+			name:    "unknown key",
+			token:   server.sign(t, "unknown", newAccessTokenClaims(issuer, time.Now().Add(time.Hour))),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authCtx, err := verifier.CheckAuthorization(context.Background(), "Bearer "+tt.token)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidJWT)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, authCtx.IsGrantedRole("admin"))
+		})
+	}
+	assert.Equal(t, 0, server.jwksRequests())
+}
+
+func TestJWTVerification_issuer(t *testing.T) {
+	server := newJWKSServer(t, "key")
+	verifier, err := WithJWT[*JWTContext](WithIssuer("https://custom.example.com"), WithAudience("project"))(context.Background(), server.zitadel(t))
+	require.NoError(t, err)
+
+	_, err = verifier.CheckAuthorization(context.Background(), "Bearer "+server.sign(t, "key", newAccessTokenClaims(server.URL, time.Now().Add(time.Hour))))
+	assert.ErrorIs(t, err, ErrInvalidJWT)
+	_, err = verifier.CheckAuthorization(context.Background(), "Bearer "+server.sign(t, "key", newAccessTokenClaims("https://custom.example.com", time.Now().Add(time.Hour))))
+	assert.NoError(t, err)
+}
+
+func TestWithJWT_missingAudience(t *testing.T) {
+	server := newJWKSServer(t, "key")
+	_, err := WithJWT[*JWTContext]()(context.Background(), server.zitadel(t))
+	assert.ErrorIs(t, err, ErrMissingAudience)
+	assert.Equal(t, 0, server.jwksRequests())
+}
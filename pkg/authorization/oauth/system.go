@@ -0,0 +1,114 @@
+package oauth
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authorization"
+	"github.com/zitadel/zitadel-go/v3/pkg/zitadel"
+)
+
+var (
+	ErrInvalidSystemKey  = errors.New("invalid public key of system user")
+	ErrUnknownSystemUser = errors.New("unknown system user")
+)
+
+// SystemVerification provides an [authorization.Verifier] implementation for JWTs of System API users.
+// Like for the System API of ZITADEL, the tokens are self-signed by the private key of the system user,
+// which is the issuer and subject of the token, and are validated with the configured public keys.
+// Use [WithSystemJWT] for implementation.
+type SystemVerification[T authorization.Ctx] struct {
+	audience  string
+	clockSkew time.Duration
+	keys      map[string]*keySet
+}
+
+// WithSystemJWT creates the System API user JWT validation implementation of the [authorization.Verifier] interface.
+// The keys map the id of each system user to its public key (PEM encoded), the same way as they are configured
+// for the System API in ZITADEL (`SystemAPIUsers`).
+// The tokens are required to be issued for the audience of [WithAudience] or the origin of ZITADEL by default,
+// the tolerance can be changed with [WithClockSkew]. Other [JWTOption] are ignored.
+// No call to ZITADEL is needed at all.
+func WithSystemJWT[T authorization.Ctx](keys map[string][]byte, opts ...JWTOption) authorization.VerifierInitializer[T] {
+	return func(_ context.Context, zitadel *zitadel.Zitadel) (authorization.Verifier[T], error) {
+		o := &jwtOptions{
+			clockSkew: 10 * time.Second,
+		}
+		for _, opt := range opts {
+			opt(o)
+		}
+		if o.audience == "" {
+			o.audience = zitadel.Origin()
+		}
+		v := &SystemVerification[T]{
+			audience:  o.audience,
+			clockSkew: o.clockSkew,
+			keys:      make(map[string]*keySet, len(keys)),
+		}
+		for userID, data := range keys {
+			key, err := parsePublicKey(data)
+			if err != nil {
+				return nil, fmt.Errorf("%w `%s`: %w", ErrInvalidSystemKey, userID, err)
+			}
+			v.keys[userID] = &keySet{
+				keys:   []jose.JSONWebKey{{Key: key, Use: oidc.KeyUseSignature}},
+				static: true,
+			}
+		}
+		return v, nil
+	}
+}
+
+// CheckAuthorization implements the [authorization.Verifier] interface by validating the signature
+// with the public key of the system user (`iss` and `sub` claim) and the audience, exp and iat claims of the authorizationToken.
+// On success, the claims of the token are returned as generic struct of type [T], e.g. the [JWTContext].
+func (s *SystemVerification[T]) CheckAuthorization(ctx context.Context, authorizationToken string) (resp T, err error) {
+	accessToken, ok := strings.CutPrefix(authorizationToken, oidc.BearerToken)
+	if !ok {
+		return resp, ErrInvalidAuthorizationHeader
+	}
+	accessToken = strings.TrimSpace(accessToken)
+	claims := new(oidc.AccessTokenClaims)
+	payload, err := oidc.ParseToken(accessToken, claims)
+	if err != nil {
+		return resp, fmt.Errorf("%w: %w", ErrInvalidJWT, err)
+	}
+	keys, ok := s.keys[claims.Issuer]
+	if !ok || claims.Subject != claims.Issuer {
+		return resp, fmt.Errorf("%w: %w", ErrInvalidJWT, ErrUnknownSystemUser)
+	}
+	if err = oidc.CheckAudience(claims, s.audience); err != nil {
+		return resp, fmt.Errorf("%w: %w", ErrInvalidJWT, err)
+	}
+	if err = oidc.CheckSignature(ctx, accessToken, payload, claims, nil, keys); err != nil {
+		return resp, fmt.Errorf("%w: %w", ErrInvalidJWT, err)
+	}
+	// the offset of the expiration check is added to the current time, so it's negated to allow the skew
+	if err = oidc.CheckExpiration(claims, -s.clockSkew); err != nil {
+		return resp, fmt.Errorf("%w: %w", ErrInvalidJWT, err)
+	}
+	if err = oidc.CheckIssuedAt(claims, 0, s.clockSkew); err != nil {
+		return resp, fmt.Errorf("%w: %w", ErrInvalidJWT, err)
+	}
+	if err = json.Unmarshal(payload, &resp); err != nil {
+		return resp, fmt.Errorf("%w: %w", ErrInvalidJWT, err)
+	}
+	return resp, nil
+}
+
+func parsePublicKey(data []byte) (any, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
@@ -0,0 +1,86 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/zitadel"
+)
+
+func TestSystemVerification_CheckAuthorization(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	signer := &jwksServer{keys: map[string]*rsa.PrivateKey{"": key}}
+
+	verifier, err := WithSystemJWT[*JWTContext](map[string][]byte{
+		"system-user": pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}),
+	})(context.Background(), zitadel.New("zitadel.example.com"))
+	require.NoError(t, err)
+
+	systemClaims := func(issuer, subject, audience string, expiration time.Time) *oidc.AccessTokenClaims {
+		return oidc.NewAccessTokenClaims(issuer, subject, []string{audience}, expiration, "", "", 0)
+	}
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{
+			name:  "valid",
+			token: signer.sign(t, "", systemClaims("system-user", "system-user", "https://zitadel.example.com", time.Now().Add(time.Hour))),
+		},
+		{
+			name:    "unknown user",
+			token:   signer.sign(t, "", systemClaims("other", "other", "https://zitadel.example.com", time.Now().Add(time.Hour))),
+			wantErr: true,
+		},
+		{
+			name:    "subject mismatch",
+			token:   signer.sign(t, "", systemClaims("system-user", "user", "https://zitadel.example.com", time.Now().Add(time.Hour))),
+			wantErr: true,
+		},
+		{
+			name:    "wrong audience",
+			token:   signer.sign(t, "", systemClaims("system-user", "system-user", "https://other.example.com", time.Now().Add(time.Hour))),
+			wantErr: true,
+		},
+		{
+			name:    "expired",
+			token:   signer.sign(t, "", systemClaims("system-user", "system-user", "https://zitadel.example.com", time.Now().Add(-time.Hour))),
+			wantErr: true,
+		},
+		{
+			name:    "wrong key",
+			token:   signer.sign(t, "unknown", systemClaims("system-user", "system-user", "https://zitadel.example.com", time.Now().Add(time.Hour))),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authCtx, err := verifier.CheckAuthorization(context.Background(), "Bearer "+tt.token)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidJWT)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, authCtx.IsAuthorized())
+			assert.Equal(t, "system-user", authCtx.UserID())
+		})
+	}
+}
+
+func TestWithSystemJWT_invalidKey(t *testing.T) {
+	_, err := WithSystemJWT[*JWTContext](map[string][]byte{"system-user": []byte("invalid")})(context.Background(), zitadel.New("zitadel.example.com"))
+	assert.ErrorIs(t, err, ErrInvalidSystemKey)
+}
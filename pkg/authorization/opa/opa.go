@@ -0,0 +1,88 @@
+// Package opa provides an [authorization.PolicyEvaluator] querying the REST API of an Open Policy Agent (OPA):
+//
+//	evaluator := opa.New("http://localhost:8181", "httpapi/authz/allow")
+//	mw.RequireAuthorization(authorization.WithPolicy(evaluator))
+//
+// The [authorization.PolicyInput] is passed as `input` of the query. The decision must be a boolean.
+package opa
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authorization"
+)
+
+var (
+	ErrUndefinedDecision = errors.New("policy decision is undefined")
+)
+
+// Evaluator queries a rule of the Data API (`/v1/data/{path}`) of an OPA.
+type Evaluator struct {
+	url    string
+	client *http.Client
+}
+
+// Option allows customization of the [Evaluator].
+type Option func(*Evaluator)
+
+// WithHTTPClient allows to use a client other than the [http.DefaultClient], e.g. for TLS or timeouts.
+func WithHTTPClient(client *http.Client) Option {
+	return func(e *Evaluator) {
+		e.client = client
+	}
+}
+
+// New creates an [Evaluator] for the rule (e.g. `httpapi/authz/allow`) of the OPA running at the baseURL.
+func New(baseURL, path string, opts ...Option) *Evaluator {
+	e := &Evaluator{
+		url:    strings.TrimSuffix(baseURL, "/") + "/v1/data/" + strings.Trim(path, "/"),
+		client: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+type request struct {
+	Input *authorization.PolicyInput `json:"input"`
+}
+
+type response struct {
+	Result *bool `json:"result"`
+}
+
+// Evaluate implements the [authorization.PolicyEvaluator] interface.
+func (e *Evaluator) Evaluate(ctx context.Context, input *authorization.PolicyInput) (bool, error) {
+	body, err := json.Marshal(&request{Input: input})
+	if err != nil {
+		return false, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("unexpected status from policy agent: %s", resp.Status)
+	}
+	var decision response
+	if err = json.NewDecoder(resp.Body).Decode(&decision); err != nil {
+		return false, err
+	}
+	if decision.Result == nil {
+		return false, ErrUndefinedDecision
+	}
+	return *decision.Result, nil
+}
@@ -0,0 +1,76 @@
+package opa
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authorization"
+)
+
+func TestEvaluator_Evaluate(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:   "allowed",
+			status: http.StatusOK,
+			body:   `{"result": true}`,
+			want:   true,
+		},
+		{
+			name:   "denied",
+			status: http.StatusOK,
+			body:   `{"result": false}`,
+		},
+		{
+			name:    "undefined",
+			status:  http.StatusOK,
+			body:    `{}`,
+			wantErr: true,
+		},
+		{
+			name:    "error",
+			status:  http.StatusInternalServerError,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var input map[string]any
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/v1/data/httpapi/authz/allow", r.URL.Path)
+				var req struct {
+					Input map[string]any `json:"input"`
+				}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				input = req.Input
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			got, err := New(server.URL+"/", "/httpapi/authz/allow").Evaluate(context.Background(), &authorization.PolicyInput{
+				UserID:     "user",
+				Attributes: map[string]any{"method": "GET"},
+			})
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, "user", input["user_id"])
+			assert.Equal(t, map[string]any{"method": "GET"}, input["attributes"])
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package permission
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/middleware"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user"
+)
+
+// Client is the part of the [client.Client] used by the [GrantResolver].
+type Client interface {
+	ManagementService() management.ManagementServiceClient
+}
+
+// GrantResolver implements the [Resolver] interface by using the roles of the active user grants
+// of the project as permissions, e.g. a role `document.edit`. The resource is the id of the organization,
+// in which the user is granted. An empty resource resolves the grants of the organization of the client.
+// The client needs to be authorized to read the user grants, e.g. as service user with the `ORG_USER_GRANT_READER` role.
+type GrantResolver struct {
+	client    Client
+	projectID string
+}
+
+// NewGrantResolver creates a [GrantResolver] for the project.
+func NewGrantResolver(client Client, projectID string) *GrantResolver {
+	return &GrantResolver{
+		client:    client,
+		projectID: projectID,
+	}
+}
+
+// Permissions implements the [Resolver] interface.
+func (g *GrantResolver) Permissions(ctx context.Context, userID, resource string) ([]string, error) {
+	if resource != "" {
+		ctx = middleware.SetOrgID(ctx, resource)
+	}
+	resp, err := g.client.ManagementService().ListUserGrants(ctx, &management.ListUserGrantRequest{
+		Queries: []*user.UserGrantQuery{
+			{Query: &user.UserGrantQuery_UserIdQuery{UserIdQuery: &user.UserGrantUserIDQuery{UserId: userID}}},
+			{Query: &user.UserGrantQuery_ProjectIdQuery{ProjectIdQuery: &user.UserGrantProjectIDQuery{ProjectId: g.projectID}}},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	permissions := make([]string, 0, len(resp.GetResult()))
+	for _, grant := range resp.GetResult() {
+		if grant.GetState() != user.UserGrantState_USER_GRANT_STATE_ACTIVE {
+			continue
+		}
+		if resource != "" && grant.GetOrgId() != resource {
+			continue
+		}
+		permissions = append(permissions, grant.GetRoleKeys()...)
+	}
+	return permissions, nil
+}
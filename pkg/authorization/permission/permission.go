@@ -0,0 +1,147 @@
+// Package permission allows asking whether a user is allowed to perform an action on a resource,
+// e.g. "can user X edit documents of organization Z", independent of the roles contained in the token:
+//
+//	checker := permission.New(permission.NewGrantResolver(client, projectID))
+//	if err := checker.Require(ctx, "document.edit", orgID); err != nil {
+//		return err
+//	}
+//
+// The permissions are resolved by a [Resolver], e.g. the [GrantResolver], which uses the user grants
+// (role assignments) of a project in ZITADEL, and cached per user and resource.
+package permission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authorization"
+)
+
+var (
+	ErrMissingPermission = errors.New("missing required permission")
+)
+
+// Resolver resolves the permissions of the user on the resource.
+// An empty resource stands for the permissions independent of a specific resource.
+type Resolver interface {
+	Permissions(ctx context.Context, userID, resource string) ([]string, error)
+}
+
+// Checker checks the permissions of users using a [Resolver] and caches the resolved permissions.
+type Checker struct {
+	resolver Resolver
+	ttl      time.Duration
+
+	mu        sync.Mutex
+	entries   map[cacheKey]cacheEntry
+	lastSweep time.Time
+}
+
+type cacheKey struct {
+	userID   string
+	resource string
+}
+
+type cacheEntry struct {
+	permissions []string
+	expiresAt   time.Time
+}
+
+// Option allows customization of the [Checker].
+type Option func(*Checker)
+
+// WithTTL allows a cache duration of the resolved permissions other than a minute.
+// A ttl of 0 disables the cache.
+func WithTTL(ttl time.Duration) Option {
+	return func(c *Checker) {
+		c.ttl = ttl
+	}
+}
+
+// New creates a [Checker] resolving the permissions with the resolver.
+func New(resolver Resolver, opts ...Option) *Checker {
+	c := &Checker{
+		resolver: resolver,
+		ttl:      time.Minute,
+		entries:  make(map[cacheKey]cacheEntry),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Can returns if the user is allowed to perform the action (permission) on the resource.
+func (c *Checker) Can(ctx context.Context, userID, permission, resource string) (bool, error) {
+	permissions, err := c.permissions(ctx, userID, resource)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(permissions, permission), nil
+}
+
+// Require checks the permission of the authorized user of the ctx ([authorization.Context]) on the resource.
+// If the caller is not authorized, an [authorization.UnauthorizedErr] is returned.
+// If the permission is not granted, an [authorization.PermissionDeniedErr] wrapping an [ErrMissingPermission] is returned.
+func (c *Checker) Require(ctx context.Context, permission, resource string) error {
+	authCtx := authorization.Context[authorization.Ctx](ctx)
+	if authCtx == nil || !authCtx.IsAuthorized() {
+		return authorization.NewErrorUnauthorized(nil)
+	}
+	ok, err := c.Can(ctx, authCtx.UserID(), permission, resource)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return authorization.NewErrorPermissionDenied(fmt.Errorf("%w: `%s` on `%s`", ErrMissingPermission, permission, resource))
+	}
+	return nil
+}
+
+// Invalidate removes the cached permissions of the user, e.g. after changing their grants.
+func (c *Checker) Invalidate(userID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.entries {
+		if key.userID == userID {
+			delete(c.entries, key)
+		}
+	}
+}
+
+func (c *Checker) permissions(ctx context.Context, userID, resource string) ([]string, error) {
+	key := cacheKey{userID: userID, resource: resource}
+	now := time.Now()
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.permissions, nil
+	}
+	permissions, err := c.resolver.Permissions(ctx, userID, resource)
+	if err != nil || c.ttl <= 0 {
+		return permissions, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sweep(now)
+	c.entries[key] = cacheEntry{permissions: permissions, expiresAt: now.Add(c.ttl)}
+	return permissions, nil
+}
+
+// sweep removes the expired entries at most once per ttl.
+func (c *Checker) sweep(now time.Time) {
+	if now.Sub(c.lastSweep) < c.ttl {
+		return
+	}
+	c.lastSweep = now
+	for key, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+}
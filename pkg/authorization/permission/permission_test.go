@@ -0,0 +1,162 @@
+package permission
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authorization"
+	"github.com/zitadel/zitadel-go/v3/pkg/client"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user"
+)
+
+type countingResolver struct {
+	permissions map[string][]string
+	err         error
+	calls       int
+}
+
+func (r *countingResolver) Permissions(_ context.Context, userID, resource string) ([]string, error) {
+	r.calls++
+	return r.permissions[userID+"@"+resource], r.err
+}
+
+type testCtx struct {
+	userID string
+}
+
+func (t *testCtx) IsAuthorized() bool                              { return t != nil }
+func (t *testCtx) UserID() string                                  { return t.userID }
+func (t *testCtx) IsGrantedRole(string) bool                       { return false }
+func (t *testCtx) IsGrantedRoleInOrganization(string, string) bool { return false }
+func (t *testCtx) SetToken(string)                                 {}
+func (t *testCtx) GetToken() string                                { return "" }
+
+func TestChecker_Can(t *testing.T) {
+	resolver := &countingResolver{permissions: map[string][]string{"user@org": {"document.edit"}}}
+	checker := New(resolver)
+	ctx := context.Background()
+
+	ok, err := checker.Can(ctx, "user", "document.edit", "org")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = checker.Can(ctx, "user", "document.delete", "org")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, 1, resolver.calls, "permissions must be cached")
+
+	ok, err = checker.Can(ctx, "user", "document.edit", "other")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, 2, resolver.calls)
+
+	checker.Invalidate("user")
+	_, err = checker.Can(ctx, "user", "document.edit", "org")
+	require.NoError(t, err)
+	assert.Equal(t, 3, resolver.calls)
+}
+
+func TestChecker_Can_noCache(t *testing.T) {
+	resolver := &countingResolver{err: errors.New("unavailable")}
+	checker := New(resolver, WithTTL(time.Hour))
+	_, err := checker.Can(context.Background(), "user", "document.edit", "org")
+	assert.Error(t, err)
+	_, err = checker.Can(context.Background(), "user", "document.edit", "org")
+	assert.Error(t, err)
+	assert.Equal(t, 2, resolver.calls, "errors must not be cached")
+
+	resolver.err = nil
+	checker = New(resolver, WithTTL(0))
+	_, _ = checker.Can(context.Background(), "user", "document.edit", "org")
+	_, _ = checker.Can(context.Background(), "user", "document.edit", "org")
+	assert.Equal(t, 4, resolver.calls, "cache must be disabled")
+}
+
+func TestChecker_Require(t *testing.T) {
+	checker := New(&countingResolver{permissions: map[string][]string{"user@org": {"document.edit"}}})
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		permission string
+		wantErr    error
+	}{
+		{
+			name:       "not authorized",
+			ctx:        context.Background(),
+			permission: "document.edit",
+			wantErr:    &authorization.UnauthorizedErr{},
+		},
+		{
+			name:       "granted",
+			ctx:        authorization.WithAuthContext(context.Background(), &testCtx{userID: "user"}),
+			permission: "document.edit",
+		},
+		{
+			name:       "missing",
+			ctx:        authorization.WithAuthContext(context.Background(), &testCtx{userID: "user"}),
+			permission: "document.delete",
+			wantErr:    ErrMissingPermission,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checker.Require(tt.ctx, tt.permission, "org")
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+type testManagementClient struct {
+	management.ManagementServiceClient
+	orgID   string
+	request *management.ListUserGrantRequest
+}
+
+func (c *testManagementClient) ListUserGrants(ctx context.Context, req *management.ListUserGrantRequest, _ ...grpc.CallOption) (*management.ListUserGrantResponse, error) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	if values := md.Get(client.OrgHeader); len(values) > 0 {
+		c.orgID = values[0]
+	}
+	c.request = req
+	return &management.ListUserGrantResponse{Result: []*user.UserGrant{
+		{OrgId: "org", State: user.UserGrantState_USER_GRANT_STATE_ACTIVE, RoleKeys: []string{"document.edit", "document.read"}},
+		{OrgId: "org", State: user.UserGrantState_USER_GRANT_STATE_INACTIVE, RoleKeys: []string{"document.delete"}},
+		{OrgId: "other", State: user.UserGrantState_USER_GRANT_STATE_ACTIVE, RoleKeys: []string{"admin"}},
+	}}, nil
+}
+
+type testClient struct {
+	management *testManagementClient
+}
+
+func (c *testClient) ManagementService() management.ManagementServiceClient {
+	return c.management
+}
+
+func TestGrantResolver_Permissions(t *testing.T) {
+	mgmt := new(testManagementClient)
+	resolver := NewGrantResolver(&testClient{management: mgmt}, "project")
+
+	permissions, err := resolver.Permissions(context.Background(), "user", "org")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"document.edit", "document.read"}, permissions)
+	assert.Equal(t, "org", mgmt.orgID)
+	require.Len(t, mgmt.request.GetQueries(), 2)
+	assert.Equal(t, "user", mgmt.request.GetQueries()[0].GetUserIdQuery().GetUserId())
+	assert.Equal(t, "project", mgmt.request.GetQueries()[1].GetProjectIdQuery().GetProjectId())
+
+	permissions, err = resolver.Permissions(context.Background(), "user", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"document.edit", "document.read", "admin"}, permissions)
+}
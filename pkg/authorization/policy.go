@@ -0,0 +1,81 @@
+package authorization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrPolicyDenied     = errors.New("denied by policy")
+	ErrPolicyEvaluation = errors.New("policy evaluation failed")
+)
+
+type policyAttributesKey struct{}
+
+// PolicyInput is passed to the [PolicyEvaluator] and contains the verified identity and the attributes of the request.
+type PolicyInput struct {
+	// UserID is the id of the authorized user.
+	UserID string `json:"user_id"`
+	// AuthContext is the verified authorization context ([Ctx]), e.g. the claims of the token.
+	AuthContext Ctx `json:"auth_context"`
+	// Attributes describe the request, e.g. the method and path of an HTTP request ([WithPolicyAttributes]).
+	Attributes map[string]any `json:"attributes,omitempty"`
+}
+
+// PolicyEvaluator decides if the request is allowed, e.g. by evaluating a central OPA (Rego) policy.
+type PolicyEvaluator interface {
+	Evaluate(ctx context.Context, input *PolicyInput) (allowed bool, err error)
+}
+
+// PolicyFunc allows using a function as [PolicyEvaluator].
+type PolicyFunc func(ctx context.Context, input *PolicyInput) (bool, error)
+
+// Evaluate implements the [PolicyEvaluator] interface.
+func (f PolicyFunc) Evaluate(ctx context.Context, input *PolicyInput) (bool, error) {
+	return f(ctx, input)
+}
+
+// WithPolicy requires the evaluator to allow the request of the authorized user.
+// If the request is denied, an [ErrPolicyDenied] is returned. If the evaluation fails, the request is denied
+// as well with an [ErrPolicyEvaluation].
+func WithPolicy(evaluator PolicyEvaluator) CheckOption {
+	return func(checks *Check[Ctx]) {
+		checks.Requirements = append(checks.Requirements, "policy")
+		checks.Policies = append(checks.Policies, evaluator)
+	}
+}
+
+// WithPolicyAttributes allows to set the attributes of the request passed to the [PolicyEvaluator],
+// which can later be retrieved by calling the [PolicyAttributes] function.
+// The HTTP and gRPC middlewares set them automatically.
+func WithPolicyAttributes(ctx context.Context, attributes map[string]any) context.Context {
+	return context.WithValue(ctx, policyAttributesKey{}, attributes)
+}
+
+// PolicyAttributes returns the attributes of the request set by [WithPolicyAttributes].
+func PolicyAttributes(ctx context.Context) map[string]any {
+	attributes, _ := ctx.Value(policyAttributesKey{}).(map[string]any)
+	return attributes
+}
+
+func evaluatePolicies(ctx context.Context, authCtx Ctx, policies []PolicyEvaluator) error {
+	if len(policies) == 0 {
+		return nil
+	}
+	input := &PolicyInput{
+		UserID:      authCtx.UserID(),
+		AuthContext: authCtx,
+		Attributes:  PolicyAttributes(ctx),
+	}
+	for _, policy := range policies {
+		allowed, err := policy.Evaluate(ctx, input)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrPolicyEvaluation, err)
+		}
+		if !allowed {
+			return ErrPolicyDenied
+		}
+	}
+	return nil
+}
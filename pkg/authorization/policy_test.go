@@ -0,0 +1,74 @@
+package authorization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/exp/slog"
+)
+
+func TestWithPolicy(t *testing.T) {
+	var input *PolicyInput
+	record := func(allowed bool, err error) PolicyEvaluator {
+		return PolicyFunc(func(_ context.Context, i *PolicyInput) (bool, error) {
+			input = i
+			return allowed, err
+		})
+	}
+	tests := []struct {
+		name      string
+		verifier  *testVerifier[*testCtx]
+		evaluator PolicyEvaluator
+		wantErr   error
+		denied    bool
+		wantInput bool
+	}{
+		{
+			name:      "unauthorized, not evaluated",
+			verifier:  &testVerifier[*testCtx]{},
+			evaluator: record(true, nil),
+			wantErr:   NewErrorUnauthorized(nil),
+		},
+		{
+			name:      "denied, permissiondenied error",
+			verifier:  &testVerifier[*testCtx]{ctx: &testCtx{isAuthorized: true, userID: "user"}},
+			evaluator: record(false, nil),
+			wantErr:   ErrPolicyDenied,
+			denied:    true,
+			wantInput: true,
+		},
+		{
+			name:      "evaluation error, permissiondenied error",
+			verifier:  &testVerifier[*testCtx]{ctx: &testCtx{isAuthorized: true, userID: "user"}},
+			evaluator: record(true, errTest),
+			wantErr:   ErrPolicyEvaluation,
+			denied:    true,
+			wantInput: true,
+		},
+		{
+			name:      "allowed",
+			verifier:  &testVerifier[*testCtx]{ctx: &testCtx{isAuthorized: true, userID: "user"}},
+			evaluator: record(true, nil),
+			wantInput: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input = nil
+			a := &Authorizer[*testCtx]{verifier: tt.verifier, logger: slog.Default()}
+			ctx := WithPolicyAttributes(context.Background(), map[string]any{"path": "/documents"})
+			_, err := a.CheckAuthorization(ctx, "token", WithPolicy(tt.evaluator))
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.denied {
+				assert.ErrorIs(t, err, &PermissionDeniedErr{})
+			}
+			if !tt.wantInput {
+				assert.Nil(t, input)
+				return
+			}
+			assert.Equal(t, "user", input.UserID)
+			assert.Equal(t, map[string]any{"path": "/documents"}, input.Attributes)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package authorization
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Requirement defines a permission requirement of [Require], e.g. [Role] or [InOrg].
+type Requirement func(*requirements)
+
+type requirements struct {
+	roles    []string
+	anyRoles [][]string
+	orgID    string
+}
+
+// Role requires the authorized user to be granted the role.
+// Multiple roles are all required.
+func Role(role string) Requirement {
+	return func(r *requirements) {
+		r.roles = append(r.roles, role)
+	}
+}
+
+// AnyRole requires the authorized user to be granted at least one of the roles.
+func AnyRole(roles ...string) Requirement {
+	return func(r *requirements) {
+		r.anyRoles = append(r.anyRoles, roles)
+	}
+}
+
+// InOrg requires the roles of [Role] and [AnyRole] to be granted in the organization with the id.
+func InOrg(orgID string) Requirement {
+	return func(r *requirements) {
+		r.orgID = orgID
+	}
+}
+
+// Require checks the authorization context ([Ctx]) of the ctx against the requirements, e.g.:
+//
+//	if err := authorization.Require(ctx, authorization.Role("admin"), authorization.InOrg(orgID)); err != nil {
+//		return err
+//	}
+//
+// This allows checks deep in the business logic, where the HTTP or gRPC middleware cannot decide
+// (e.g. because the organization is part of the loaded resource).
+// If the caller is not authorized, an [UnauthorizedErr] is returned.
+// If a role is not granted, a [PermissionDeniedErr] wrapping an [ErrMissingRole] is returned.
+func Require(ctx context.Context, reqs ...Requirement) error {
+	authCtx := Context[Ctx](ctx)
+	if authCtx == nil || !authCtx.IsAuthorized() {
+		return NewErrorUnauthorized(nil)
+	}
+	r := new(requirements)
+	for _, req := range reqs {
+		req(r)
+	}
+	for _, role := range r.roles {
+		if !r.isGranted(authCtx, role) {
+			return NewErrorPermissionDenied(r.missingRole(role))
+		}
+	}
+	for _, roles := range r.anyRoles {
+		if !r.isGrantedAny(authCtx, roles) {
+			return NewErrorPermissionDenied(r.missingRole(roles...))
+		}
+	}
+	return nil
+}
+
+// CheckRole is a short version of [Require] with the [Role].
+func CheckRole(ctx context.Context, role string) error {
+	return Require(ctx, Role(role))
+}
+
+// CheckOrgRole is a short version of [Require] with the [Role] in the organization ([InOrg]).
+func CheckOrgRole(ctx context.Context, role, orgID string) error {
+	return Require(ctx, Role(role), InOrg(orgID))
+}
+
+func (r *requirements) isGranted(authCtx Ctx, role string) bool {
+	if r.orgID != "" {
+		return authCtx.IsGrantedRoleInOrganization(role, r.orgID)
+	}
+	return authCtx.IsGrantedRole(role)
+}
+
+func (r *requirements) isGrantedAny(authCtx Ctx, roles []string) bool {
+	for _, role := range roles {
+		if r.isGranted(authCtx, role) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *requirements) missingRole(roles ...string) error {
+	if r.orgID != "" {
+		return fmt.Errorf("%w: `%s` in organization `%s`", ErrMissingRole, strings.Join(roles, "`, `"), r.orgID)
+	}
+	return fmt.Errorf("%w: `%s`", ErrMissingRole, strings.Join(roles, "`, `"))
+}
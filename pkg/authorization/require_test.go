@@ -0,0 +1,108 @@
+package authorization
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// roleCtx grants the roles in the organizations.
+type roleCtx struct {
+	testCtx
+	roles map[string][]string
+}
+
+func (r *roleCtx) IsGrantedRole(role string) bool {
+	_, ok := r.roles[role]
+	return ok
+}
+
+func (r *roleCtx) IsGrantedRoleInOrganization(role, organizationID string) bool {
+	return slices.Contains(r.roles[role], organizationID)
+}
+
+func TestRequire(t *testing.T) {
+	authCtx := &roleCtx{
+		testCtx: testCtx{isAuthorized: true},
+		roles:   map[string][]string{"admin": {"org1"}, "viewer": {"org1", "org2"}},
+	}
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		reqs    []Requirement
+		wantErr error
+	}{
+		{
+			name:    "not authorized",
+			ctx:     context.Background(),
+			reqs:    []Requirement{Role("admin")},
+			wantErr: &UnauthorizedErr{},
+		},
+		{
+			name:    "unauthorized context",
+			ctx:     WithAuthContext(context.Background(), &testCtx{}),
+			wantErr: &UnauthorizedErr{},
+		},
+		{
+			name: "authorized",
+			ctx:  WithAuthContext(context.Background(), authCtx),
+		},
+		{
+			name: "roles",
+			ctx:  WithAuthContext(context.Background(), authCtx),
+			reqs: []Requirement{Role("admin"), Role("viewer")},
+		},
+		{
+			name:    "missing role",
+			ctx:     WithAuthContext(context.Background(), authCtx),
+			reqs:    []Requirement{Role("admin"), Role("owner")},
+			wantErr: ErrMissingRole,
+		},
+		{
+			name: "role in org",
+			ctx:  WithAuthContext(context.Background(), authCtx),
+			reqs: []Requirement{Role("viewer"), InOrg("org2")},
+		},
+		{
+			name:    "missing role in org",
+			ctx:     WithAuthContext(context.Background(), authCtx),
+			reqs:    []Requirement{Role("admin"), InOrg("org2")},
+			wantErr: &PermissionDeniedErr{},
+		},
+		{
+			name: "any role",
+			ctx:  WithAuthContext(context.Background(), authCtx),
+			reqs: []Requirement{AnyRole("owner", "admin")},
+		},
+		{
+			name:    "any role in org",
+			ctx:     WithAuthContext(context.Background(), authCtx),
+			reqs:    []Requirement{AnyRole("owner", "admin"), InOrg("org2")},
+			wantErr: ErrMissingRole,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Require(tt.ctx, tt.reqs...)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCheckOrgRole(t *testing.T) {
+	ctx := WithAuthContext(context.Background(), &roleCtx{
+		testCtx: testCtx{isAuthorized: true},
+		roles:   map[string][]string{"admin": {"org1"}},
+	})
+	assert.NoError(t, CheckRole(ctx, "admin"))
+	assert.NoError(t, CheckOrgRole(ctx, "admin", "org1"))
+	err := CheckOrgRole(ctx, "admin", "org2")
+	assert.ErrorIs(t, err, &PermissionDeniedErr{})
+	assert.EqualError(t, err, "missing required role: `admin` in organization `org2`")
+}
@@ -0,0 +1,228 @@
+package authorization
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/slog"
+)
+
+var (
+	ErrTokenRevoked = errors.New("token has been revoked")
+)
+
+const (
+	// DefaultRevocationLifetime is used for revocations without an Until,
+	// which is the default lifetime of access tokens in ZITADEL.
+	DefaultRevocationLifetime = 12 * time.Hour
+
+	// revocationPollTimeout limits the duration of a single poll of the [RevocationSource].
+	revocationPollTimeout = 30 * time.Second
+	// minRevocationBackoff and maxRevocationBackoff limit the delay of the next poll after a failed one.
+	minRevocationBackoff = time.Second
+	maxRevocationBackoff = 5 * time.Minute
+)
+
+// TokenIDHolder is implemented by authorization contexts ([Ctx]) providing the id of the token (`jti` claim),
+// e.g. the IntrospectionContext and JWTContext of the oauth package.
+type TokenIDHolder interface {
+	TokenID() string
+}
+
+// IssuedAtHolder is implemented by authorization contexts ([Ctx]) providing the issue time of the token (`iat` claim),
+// e.g. the IntrospectionContext and JWTContext of the oauth package.
+type IssuedAtHolder interface {
+	IssuedAt() time.Time
+}
+
+// ExpiresAtHolder is implemented by authorization contexts ([Ctx]) providing the expiration of the token (`exp` claim),
+// e.g. the IntrospectionContext and JWTContext of the oauth package.
+type ExpiresAtHolder interface {
+	ExpiresAt() time.Time
+}
+
+// Revocation marks a single token (by its id) or all tokens of a user as revoked until the provided time,
+// which should be the expiration of the token(s).
+//
+// For a user, only the tokens issued before the revocation (At) are rejected, so the user can sign in again.
+// If At is zero, the time the revocation is first added is used, so a [RevocationSource] should provide it.
+// Tokens without an issue time ([IssuedAtHolder]) are rejected until the expiration.
+// If Until is zero, the revocation is kept for the [DefaultRevocationLifetime].
+type Revocation struct {
+	TokenID string
+	UserID  string
+	At      time.Time
+	Until   time.Time
+}
+
+// RevocationSource provides the current revocations, e.g. by querying a revocation list or the session events.
+type RevocationSource interface {
+	Revocations(ctx context.Context) ([]Revocation, error)
+}
+
+// RevocationSourceFunc allows using a function as [RevocationSource].
+type RevocationSourceFunc func(ctx context.Context) ([]Revocation, error)
+
+// Revocations implements the [RevocationSource] interface.
+func (f RevocationSourceFunc) Revocations(ctx context.Context) ([]Revocation, error) {
+	return f(ctx)
+}
+
+// WithRevocation rejects revoked tokens with an [ErrTokenRevoked] as [UnauthorizedErr], even if they are still valid
+// by themselves (e.g. a JWT or a cached introspection response).
+// The revocations of the source (if not nil) are polled once when the option is applied and afterward in the
+// background, if the last poll of an authorization is longer ago than the interval, so the authorizations never wait
+// for the source. Failed polls are logged, the previous revocations are kept and the poll is retried
+// with an exponential backoff.
+// Additional revocations, e.g. consumed from events, can be added with [Authorizer.Revoke].
+//
+// The revocations are checked after the verification on every call, so cached tokens ([WithCache]) are checked as well.
+func WithRevocation[T Ctx](source RevocationSource, interval time.Duration) Option[T] {
+	return func(a *Authorizer[T]) {
+		a.revocations = &revocationList{
+			source:   source,
+			interval: interval,
+			tokens:   make(map[string]time.Time),
+			users:    make(map[string]userRevocation),
+		}
+		if source != nil {
+			// the initial revocations must be known before the first authorization
+			a.revocations.poll(context.Background(), a.logger)
+		}
+	}
+}
+
+// Revoke adds the revocations, e.g. consumed from events, if [WithRevocation] is set.
+func (a *Authorizer[T]) Revoke(revocations ...Revocation) {
+	if a.revocations == nil {
+		return
+	}
+	a.revocations.add(time.Now(), revocations)
+}
+
+type revocationList struct {
+	source   RevocationSource
+	interval time.Duration
+
+	mu       sync.RWMutex
+	tokens   map[string]time.Time
+	users    map[string]userRevocation
+	nextPoll time.Time
+	backoff  time.Duration
+	polling  bool
+}
+
+// refresh starts a poll of the source in the background, if the next poll is due and none is running yet.
+// The poll is independent of the cancellation of the authorization, but keeps the values of the ctx (e.g. for logging).
+func (r *revocationList) refresh(ctx context.Context, logger *slog.Logger) {
+	if r.source == nil {
+		return
+	}
+	r.mu.Lock()
+	if r.polling || time.Now().Before(r.nextPoll) {
+		r.mu.Unlock()
+		return
+	}
+	r.polling = true
+	r.mu.Unlock()
+	go r.poll(context.WithoutCancel(ctx), logger)
+}
+
+// poll queries the source and schedules the next poll after the interval, resp. the backoff if it failed.
+func (r *revocationList) poll(ctx context.Context, logger *slog.Logger) {
+	ctx, cancel := context.WithTimeout(ctx, revocationPollTimeout)
+	defer cancel()
+	revocations, err := r.source.Revocations(ctx)
+
+	now := time.Now()
+	r.mu.Lock()
+	r.polling = false
+	if err != nil {
+		backoff := min(max(2*r.backoff, minRevocationBackoff), maxRevocationBackoff)
+		r.backoff = backoff
+		r.nextPoll = now.Add(backoff)
+		r.mu.Unlock()
+		logger.With("error", err, "retry", backoff).Log(ctx, slog.LevelWarn, "failed to poll revocations")
+		return
+	}
+	r.backoff = 0
+	r.nextPoll = now.Add(r.interval)
+	r.mu.Unlock()
+	r.add(now, revocations)
+}
+
+func (r *revocationList) add(now time.Time, revocations []Revocation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, revocation := range revocations {
+		if revocation.Until.IsZero() {
+			revocation.Until = now.Add(DefaultRevocationLifetime)
+		}
+		if revocation.TokenID != "" {
+			r.tokens[revocation.TokenID] = revocation.Until
+		}
+		if revocation.UserID != "" {
+			r.addUser(now, revocation)
+		}
+	}
+	for id, until := range r.tokens {
+		if until.Before(now) {
+			delete(r.tokens, id)
+		}
+	}
+	for id, revocation := range r.users {
+		if revocation.until.Before(now) {
+			delete(r.users, id)
+		}
+	}
+}
+
+// addUser keeps the latest revocation of the user, which covers all earlier tokens.
+// A revocation without time does not replace an existing one, since a polled source returns it repeatedly.
+func (r *revocationList) addUser(now time.Time, revocation Revocation) {
+	previous, ok := r.users[revocation.UserID]
+	at := revocation.At
+	if at.IsZero() {
+		if ok {
+			return
+		}
+		at = now
+	}
+	if !ok || previous.at.Before(at) {
+		r.users[revocation.UserID] = userRevocation{at: at, until: revocation.Until}
+	}
+}
+
+func (r *revocationList) isRevoked(authCtx Ctx) bool {
+	now := time.Now()
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if revocation, ok := r.users[authCtx.UserID()]; ok && now.Before(revocation.until) && revocation.issuedBefore(authCtx) {
+		return true
+	}
+	holder, ok := authCtx.(TokenIDHolder)
+	if !ok || holder.TokenID() == "" {
+		return false
+	}
+	until, ok := r.tokens[holder.TokenID()]
+	return ok && now.Before(until)
+}
+
+type userRevocation struct {
+	at    time.Time
+	until time.Time
+}
+
+// issuedBefore returns whether the token was issued before the revocation.
+// Tokens without an issue time are considered to be issued before.
+// The issue time (`iat`) only has a precision of seconds, so tokens issued in the same second as the revocation
+// (e.g. on an immediate re-login) are considered to be issued after it.
+func (u userRevocation) issuedBefore(authCtx Ctx) bool {
+	holder, ok := authCtx.(IssuedAtHolder)
+	if !ok || holder.IssuedAt().IsZero() {
+		return true
+	}
+	return holder.IssuedAt().Before(u.at.Truncate(time.Second))
+}
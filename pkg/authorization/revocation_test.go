@@ -0,0 +1,189 @@
+package authorization
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/exp/slog"
+)
+
+type tokenIDCtx struct {
+	testCtx
+	tokenID  string
+	issuedAt time.Time
+}
+
+func (t *tokenIDCtx) TokenID() string { return t.tokenID }
+
+func (t *tokenIDCtx) IssuedAt() time.Time { return t.issuedAt }
+
+func newRevocationAuthorizer(source RevocationSource, interval time.Duration) *Authorizer[*tokenIDCtx] {
+	verifier := VerifierFunc[*tokenIDCtx](func(_ context.Context, token string) (*tokenIDCtx, error) {
+		return &tokenIDCtx{testCtx: testCtx{isAuthorized: true, userID: "user-" + token}, tokenID: token}, nil
+	})
+	a := &Authorizer[*tokenIDCtx]{verifier: verifier, logger: slog.Default()}
+	WithRevocation[*tokenIDCtx](source, interval)(a)
+	return a
+}
+
+func TestWithRevocation(t *testing.T) {
+	var polls atomic.Int32
+	source := RevocationSourceFunc(func(context.Context) ([]Revocation, error) {
+		polls.Add(1)
+		return []Revocation{
+			{TokenID: "revoked", Until: time.Now().Add(time.Hour)},
+			{TokenID: "expired", Until: time.Now().Add(-time.Second)},
+			{UserID: "user-blocked", Until: time.Now().Add(time.Hour)},
+		}, nil
+	})
+	a := newRevocationAuthorizer(source, time.Hour)
+
+	tests := []struct {
+		token   string
+		wantErr bool
+	}{
+		{token: "valid"},
+		{token: "revoked", wantErr: true},
+		{token: "expired"},
+		{token: "blocked", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			_, err := a.CheckAuthorization(context.Background(), tt.token)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, &UnauthorizedErr{})
+			assert.ErrorIs(t, err, ErrTokenRevoked)
+		})
+	}
+	assert.Equal(t, int32(1), polls.Load())
+}
+
+func TestAuthorizer_Revoke(t *testing.T) {
+	a := newRevocationAuthorizer(RevocationSourceFunc(func(context.Context) ([]Revocation, error) {
+		return nil, errors.New("unavailable")
+	}), 0)
+
+	_, err := a.CheckAuthorization(context.Background(), "token")
+	assert.NoError(t, err)
+
+	a.Revoke(Revocation{TokenID: "token", Until: time.Now().Add(time.Hour)})
+	_, err = a.CheckAuthorization(context.Background(), "token")
+	assert.ErrorIs(t, err, ErrTokenRevoked)
+}
+
+func TestWithRevocation_issuedAt(t *testing.T) {
+	revokedAt := time.Now()
+	verifier := VerifierFunc[*tokenIDCtx](func(_ context.Context, token string) (*tokenIDCtx, error) {
+		issuedAt, err := time.ParseDuration(token)
+		if err != nil {
+			return nil, err
+		}
+		return &tokenIDCtx{testCtx: testCtx{isAuthorized: true, userID: "user"}, tokenID: token, issuedAt: revokedAt.Add(issuedAt)}, nil
+	})
+	a := &Authorizer[*tokenIDCtx]{verifier: verifier, logger: slog.Default()}
+	WithRevocation[*tokenIDCtx](nil, 0)(a)
+	a.Revoke(Revocation{UserID: "user", At: revokedAt, Until: revokedAt.Add(time.Hour)})
+
+	_, err := a.CheckAuthorization(context.Background(), "-1m")
+	assert.ErrorIs(t, err, ErrTokenRevoked)
+	_, err = a.CheckAuthorization(context.Background(), "1m")
+	assert.NoError(t, err)
+
+	// the `iat` claim has no sub-second precision, so a token of the same second is issued after the revocation
+	_, err = a.CheckAuthorization(context.Background(), revokedAt.Truncate(time.Second).Sub(revokedAt).String())
+	assert.NoError(t, err)
+
+	// an earlier revocation does not replace the later one
+	a.Revoke(Revocation{UserID: "user", At: revokedAt.Add(-time.Hour), Until: revokedAt.Add(time.Hour)})
+	_, err = a.CheckAuthorization(context.Background(), "-1m")
+	assert.ErrorIs(t, err, ErrTokenRevoked)
+}
+
+func TestWithRevocation_cache(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []Option[*testCtx]
+	}{
+		{
+			name:    "cache first",
+			options: []Option[*testCtx]{WithCache[*testCtx](time.Hour), WithRevocation[*testCtx](nil, 0)},
+		},
+		{
+			name:    "revocation first",
+			options: []Option[*testCtx]{WithRevocation[*testCtx](nil, 0), WithCache[*testCtx](time.Hour)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := new(countingVerifier)
+			a := &Authorizer[*testCtx]{verifier: verifier, logger: slog.Default()}
+			for _, option := range tt.options {
+				option(a)
+			}
+			_, err := a.CheckAuthorization(context.Background(), "alice.1")
+			assert.NoError(t, err)
+
+			a.Revoke(Revocation{UserID: "alice", Until: time.Now().Add(time.Hour)})
+			_, err = a.CheckAuthorization(context.Background(), "alice.1")
+			assert.ErrorIs(t, err, ErrTokenRevoked)
+			assert.Equal(t, int32(1), verifier.calls.Load())
+		})
+	}
+}
+
+func TestWithRevocation_background(t *testing.T) {
+	var polls atomic.Int32
+	release := make(chan struct{})
+	source := RevocationSourceFunc(func(ctx context.Context) ([]Revocation, error) {
+		if polls.Add(1) == 1 {
+			return nil, nil
+		}
+		<-release
+		return []Revocation{{TokenID: "revoked", Until: time.Now().Add(time.Hour)}}, ctx.Err()
+	})
+	a := newRevocationAuthorizer(source, 0)
+
+	// the authorization does not wait for the poll and its cancellation does not cancel the poll
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err := a.CheckAuthorization(ctx, "revoked")
+	assert.NoError(t, err)
+	cancel()
+	assert.Eventually(t, func() bool { return polls.Load() == 2 }, time.Second, time.Millisecond)
+	close(release)
+	assert.Eventually(t, func() bool {
+		_, err := a.CheckAuthorization(context.Background(), "revoked")
+		return errors.Is(err, ErrTokenRevoked)
+	}, time.Second, time.Millisecond)
+}
+
+func TestWithRevocation_failingSource(t *testing.T) {
+	var polls atomic.Int32
+	a := newRevocationAuthorizer(RevocationSourceFunc(func(context.Context) ([]Revocation, error) {
+		polls.Add(1)
+		return nil, errors.New("unavailable")
+	}), 0)
+
+	for i := 0; i < 3; i++ {
+		_, err := a.CheckAuthorization(context.Background(), "token")
+		assert.NoError(t, err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, int32(1), polls.Load(), "a failed poll must not be retried before the backoff")
+}
+
+func TestAuthorizer_Revoke_defaultLifetime(t *testing.T) {
+	a := newRevocationAuthorizer(nil, 0)
+
+	a.Revoke(Revocation{TokenID: "token"}, Revocation{UserID: "user-blocked"})
+	_, err := a.CheckAuthorization(context.Background(), "token")
+	assert.ErrorIs(t, err, ErrTokenRevoked)
+	_, err = a.CheckAuthorization(context.Background(), "blocked")
+	assert.ErrorIs(t, err, ErrTokenRevoked)
+}
@@ -0,0 +1,50 @@
+package authorization
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/exp/slog"
+)
+
+type scopeCtx struct {
+	testCtx
+	scopes []string
+}
+
+func (s *scopeCtx) IsGrantedScope(scope string) bool {
+	return slices.Contains(s.scopes, scope)
+}
+
+func TestWithRequiredScopes(t *testing.T) {
+	tests := []struct {
+		name    string
+		scopes  []string
+		wantErr error
+	}{
+		{
+			name:   "granted",
+			scopes: []string{"read:orders", "write:orders", "openid"},
+		},
+		{
+			name:    "missing",
+			scopes:  []string{"read:orders"},
+			wantErr: ErrMissingScope,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := VerifierFunc[*scopeCtx](func(context.Context, string) (*scopeCtx, error) {
+				return &scopeCtx{testCtx: testCtx{isAuthorized: true}, scopes: tt.scopes}, nil
+			})
+			a := &Authorizer[*scopeCtx]{verifier: verifier, logger: slog.Default()}
+			_, err := a.CheckAuthorization(context.Background(), "token", WithRequiredScopes("read:orders", "write:orders"))
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, &PermissionDeniedErr{})
+			}
+		})
+	}
+}
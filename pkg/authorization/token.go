@@ -0,0 +1,32 @@
+package authorization
+
+import (
+	"context"
+	"strings"
+)
+
+// bearerPrefix is the prefix of the authorization header expected by the [Verifier].
+const bearerPrefix = "Bearer "
+
+// Authorize verifies the raw access token (without `Bearer` prefix) like [Authorizer.CheckAuthorization]
+// and returns the ctx with the authorization context ([WithAuthContext]). It's meant for tokens outside any HTTP or gRPC request,
+// e.g. embedded in queue messages or passed to background jobs:
+//
+//	ctx, err := authorizer.Authorize(ctx, msg.Token, authorization.WithRole("orders.write"))
+//	if err != nil {
+//		return err
+//	}
+//	orgID := authorization.CallerIdentity(ctx).OrgID
+//
+// The configured cache ([WithCache]) and helpers like [Require] or [IsGrantedRole] work the same as in the middlewares.
+func (a *Authorizer[T]) Authorize(ctx context.Context, accessToken string, options ...CheckOption) (context.Context, error) {
+	token := accessToken
+	if token != "" && !strings.HasPrefix(token, bearerPrefix) {
+		token = bearerPrefix + token
+	}
+	authCtx, err := a.CheckAuthorization(ctx, token, options...)
+	if err != nil {
+		return nil, err
+	}
+	return WithAuthContext(ctx, authCtx), nil
+}
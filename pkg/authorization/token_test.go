@@ -0,0 +1,56 @@
+package authorization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slog"
+)
+
+func TestAuthorizer_Authorize(t *testing.T) {
+	verifier := VerifierFunc[*testCtx](func(_ context.Context, token string) (*testCtx, error) {
+		if token != "Bearer token" {
+			return nil, errTest
+		}
+		return &testCtx{isAuthorized: true, isGrantedRole: true, userID: "user"}, nil
+	})
+	a := &Authorizer[*testCtx]{verifier: verifier, logger: slog.Default()}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{
+			name:  "raw token",
+			token: "token",
+		},
+		{
+			name:  "bearer token",
+			token: "Bearer token",
+		},
+		{
+			name:    "empty token",
+			wantErr: &UnauthorizedErr{},
+		},
+		{
+			name:    "invalid token",
+			token:   "invalid",
+			wantErr: &UnauthorizedErr{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, err := a.Authorize(context.Background(), tt.token, WithRole("admin"))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "user", UserID(ctx))
+			assert.NoError(t, CheckRole(ctx, "admin"))
+		})
+	}
+}
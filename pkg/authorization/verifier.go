@@ -0,0 +1,37 @@
+package authorization
+
+import "context"
+
+// VerifierFunc allows using a function as [Verifier], e.g. for a custom token format:
+//
+//	authorization.New(ctx, z, func(context.Context, *zitadel.Zitadel) (authorization.Verifier[*MyCtx], error) {
+//		return authorization.VerifierFunc[*MyCtx](verify), nil
+//	})
+type VerifierFunc[T Ctx] func(ctx context.Context, authorizationToken string) (T, error)
+
+// CheckAuthorization implements the [Verifier] interface.
+func (f VerifierFunc[T]) CheckAuthorization(ctx context.Context, authorizationToken string) (T, error) {
+	return f(ctx, authorizationToken)
+}
+
+// WithVerification adds the validation to the configured [Verifier] (e.g. the introspection or JWT verification),
+// e.g. to restrict the tokens to a tenant allowlist or to check additional claims.
+// The validation is only called for successfully verified tokens and its result is cached together with the token ([WithCache]),
+// if the option is passed before the [WithCache].
+// An error is returned as [UnauthorizedErr], unless the validation already returns a [PermissionDeniedErr].
+func WithVerification[T Ctx](validate func(ctx context.Context, authCtx T) error) Option[T] {
+	return func(a *Authorizer[T]) {
+		verifier := a.verifier
+		a.verifier = VerifierFunc[T](func(ctx context.Context, authorizationToken string) (authCtx T, err error) {
+			authCtx, err = verifier.CheckAuthorization(ctx, authorizationToken)
+			if err != nil || !authCtx.IsAuthorized() {
+				return authCtx, err
+			}
+			if err = validate(ctx, authCtx); err != nil {
+				var t T
+				return t, err
+			}
+			return authCtx, nil
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package authorization
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/exp/slog"
+)
+
+func TestWithVerification(t *testing.T) {
+	errTenant := errors.New("tenant not allowed")
+	tests := []struct {
+		name    string
+		userID  string
+		err     error
+		wantErr error
+	}{
+		{
+			name:   "allowed",
+			userID: "allowed",
+		},
+		{
+			name:    "unauthorized",
+			userID:  "other",
+			err:     errTenant,
+			wantErr: &UnauthorizedErr{},
+		},
+		{
+			name:    "permission denied",
+			userID:  "other",
+			err:     NewErrorPermissionDenied(errTenant),
+			wantErr: &PermissionDeniedErr{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := VerifierFunc[*testCtx](func(context.Context, string) (*testCtx, error) {
+				return &testCtx{isAuthorized: true, userID: tt.userID}, nil
+			})
+			a := &Authorizer[*testCtx]{verifier: verifier, logger: slog.Default()}
+			WithVerification[*testCtx](func(_ context.Context, authCtx *testCtx) error {
+				if authCtx.UserID() != "allowed" {
+					return tt.err
+				}
+				return nil
+			})(a)
+			authCtx, err := a.CheckAuthorization(context.Background(), "token")
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.userID, authCtx.UserID())
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.ErrorIs(t, err, errTenant)
+		})
+	}
+}
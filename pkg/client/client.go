@@ -26,6 +26,8 @@ import (
 type clientOptions struct {
 	initTokenSource TokenSourceInitializer
 	grpcDialOptions []grpc.DialOption
+	retry           *connectionRetry
+	versionCheck    *versionCheck
 }
 
 type Option func(*clientOptions)
@@ -47,7 +49,10 @@ func WithGRPCDialOptions(opts ...grpc.DialOption) Option {
 }
 
 type Client struct {
-	connection *grpc.ClientConn
+	connection grpc.ClientConnInterface
+	origin     string
+	// credentials authorize the gRPC calls of the connection and the calls of the [Client.HTTPClient].
+	credentials *cred
 
 	systemService         system.SystemServiceClient
 	adminService          admin.AdminServiceClient
@@ -71,29 +76,85 @@ func New(ctx context.Context, zitadel *zitadel.Zitadel, opts ...Option) (*Client
 		o(&options)
 	}
 
-	var source oauth2.TokenSource
-	if options.initTokenSource != nil {
-		var err error
-		source, err = options.initTokenSource(ctx, zitadel.Origin())
-		if err != nil {
+	conn, creds, err := initConnection(ctx, zitadel, &options)
+	if err != nil {
+		return nil, err
+	}
+	c := &Client{
+		connection:  conn,
+		origin:      zitadel.Origin(),
+		credentials: creds,
+	}
+	if options.versionCheck != nil {
+		if err = options.versionCheck.check(ctx, c); err != nil {
+			conn.Close()
 			return nil, err
 		}
 	}
+	return c, nil
+}
 
-	conn, err := newConnection(ctx, zitadel, source, options.grpcDialOptions...)
+func initConnection(ctx context.Context, zitadel *zitadel.Zitadel, options *clientOptions) (conn *grpc.ClientConn, creds *cred, err error) {
+	if options.retry == nil {
+		return connect(ctx, zitadel, options)
+	}
+	err = options.retry.do(ctx, func(attemptCtx context.Context) error {
+		conn, creds, err = connectAndWait(ctx, attemptCtx, zitadel, options)
+		return err
+	})
+	return conn, creds, err
+}
+
+func connect(ctx context.Context, zitadel *zitadel.Zitadel, options *clientOptions) (*grpc.ClientConn, *cred, error) {
+	source, err := tokenSource(ctx, zitadel, options)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	creds := &cred{tls: zitadel.IsTLS(), tokenSource: source}
+	conn, err := newConnection(ctx, zitadel, creds, options.grpcDialOptions...)
+	if err != nil {
+		return nil, nil, err
 	}
+	return conn, creds, nil
+}
 
-	return &Client{
-		connection: conn,
-	}, nil
+// connectAndWait will not only initialize the connection, but also ensure that a token can be retrieved
+// and the connection is ready to be used.
+// The token source and connection are initialized with the long-lived ctx, as token sources (e.g. client credentials)
+// keep it for all subsequent token requests. Only waiting for the connection is bound to the attemptCtx.
+func connectAndWait(ctx, attemptCtx context.Context, zitadel *zitadel.Zitadel, options *clientOptions) (*grpc.ClientConn, *cred, error) {
+	source, err := tokenSource(ctx, zitadel, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	if source != nil {
+		if _, err = source.Token(); err != nil {
+			return nil, nil, err
+		}
+	}
+	creds := &cred{tls: zitadel.IsTLS(), tokenSource: source}
+	conn, err := newConnection(ctx, zitadel, creds, options.grpcDialOptions...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = waitForReady(attemptCtx, conn); err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	return conn, creds, nil
+}
+
+func tokenSource(ctx context.Context, zitadel *zitadel.Zitadel, options *clientOptions) (oauth2.TokenSource, error) {
+	if options.initTokenSource == nil {
+		return nil, nil
+	}
+	return options.initTokenSource(ctx, zitadel.Origin())
 }
 
 func newConnection(
 	ctx context.Context,
 	zitadel *zitadel.Zitadel,
-	tokenSource oauth2.TokenSource,
+	creds *cred,
 	opts ...grpc.DialOption,
 ) (*grpc.ClientConn, error) {
 	transportCreds, err := transportCredentials(zitadel.Domain(), zitadel.IsTLS())
@@ -103,7 +164,7 @@ func newConnection(
 
 	dialOptions := []grpc.DialOption{
 		grpc.WithTransportCredentials(transportCreds),
-		grpc.WithPerRPCCredentials(&cred{tls: zitadel.IsTLS(), tokenSource: tokenSource}),
+		grpc.WithPerRPCCredentials(creds),
 	}
 	dialOptions = append(dialOptions, opts...)
 
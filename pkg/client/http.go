@@ -0,0 +1,89 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// httpServices maps the REST only endpoints to the service they belong to,
+// so a scoped client ([Client.Scoped]) only allows the endpoints of its services.
+var httpServices = []struct {
+	prefix  string
+	service Service
+}{
+	{prefix: "/assets/v1/instance/", service: ServiceAdmin},
+	{prefix: "/assets/v1/org/", service: ServiceManagement},
+	{prefix: "/assets/v1/users/", service: ServiceAuth},
+}
+
+// Origin returns the origin (scheme, host and port) of the connected ZITADEL instance,
+// e.g. to call its REST only endpoints with the [Client.HTTPClient].
+func (c *Client) Origin() string {
+	return c.origin
+}
+
+// HTTPClient returns an [http.Client] authorized the same way as the gRPC calls of the client.
+// It's meant for the few REST only endpoints of ZITADEL, such as the assets API.
+//
+// Like for the gRPC calls, a token set by [BearerTokenCtx] and the organization set by [middleware.SetOrgID]
+// on the context of the request are respected. For a scoped client ([Client.Scoped]), only the endpoints
+// of the allowed services can be called. Endpoints not belonging to a service (e.g. SCIM) are always rejected.
+func (c *Client) HTTPClient() *http.Client {
+	return &http.Client{
+		Transport: &transport{
+			credentials: c.credentials,
+			connection:  c.connection,
+			base:        http.DefaultTransport,
+		},
+	}
+}
+
+type transport struct {
+	credentials *cred
+	connection  grpc.ClientConnInterface
+	base        http.RoundTripper
+}
+
+// RoundTrip implements [http.RoundTripper].
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.checkScope(req); err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	if t.credentials != nil {
+		md, err := t.credentials.GetRequestMetadata(req.Context())
+		if err != nil {
+			return nil, err
+		}
+		if authorization, ok := md["authorization"]; ok {
+			req.Header.Set("Authorization", authorization)
+		}
+	}
+	if md, ok := metadata.FromOutgoingContext(req.Context()); ok {
+		if orgID := md.Get(OrgHeader); len(orgID) > 0 {
+			req.Header.Set(OrgHeader, orgID[len(orgID)-1])
+		}
+	}
+	return t.base.RoundTrip(req)
+}
+
+// checkScope checks the service of the endpoint against the scopes of the client.
+// Endpoints without a known service can only be called by clients without a scope.
+func (t *transport) checkScope(req *http.Request) error {
+	if !isScoped(t.connection) {
+		return nil
+	}
+	for _, endpoint := range httpServices {
+		if strings.HasPrefix(req.URL.Path, endpoint.prefix) {
+			return checkServiceScope(t.connection, endpoint.service)
+		}
+	}
+	return fmt.Errorf("%w: `%s`", ErrServiceNotInScope, req.URL.Path)
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestClient_HTTPClient(t *testing.T) {
+	tests := []struct {
+		name              string
+		ctx               context.Context
+		wantAuthorization string
+		wantOrgID         string
+	}{
+		{
+			name:              "token source",
+			ctx:               context.Background(),
+			wantAuthorization: "Bearer source",
+		},
+		{
+			name:              "token from context",
+			ctx:               BearerTokenCtx(context.Background(), "context"),
+			wantAuthorization: "Bearer context",
+		},
+		{
+			name:              "organization from context",
+			ctx:               metadata.AppendToOutgoingContext(context.Background(), OrgHeader, "orgID"),
+			wantAuthorization: "Bearer source",
+			wantOrgID:         "orgID",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var header http.Header
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				header = r.Header
+			}))
+			defer server.Close()
+			c := &Client{
+				origin:      server.URL,
+				credentials: &cred{tokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "source", TokenType: "Bearer"})},
+			}
+			req, err := http.NewRequestWithContext(tt.ctx, http.MethodGet, c.Origin(), nil)
+			require.NoError(t, err)
+			resp, err := c.HTTPClient().Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, tt.wantAuthorization, header.Get("Authorization"))
+			assert.Equal(t, tt.wantOrgID, header.Get(OrgHeader))
+		})
+	}
+}
+
+func TestClient_HTTPClient_Scoped(t *testing.T) {
+	tests := []struct {
+		name     string
+		scopes   [][]Service
+		path     string
+		wantErr  error
+		wantCall bool
+	}{
+		{
+			name:     "not scoped, unmapped path",
+			path:     "/scim/v2/orgID/Users",
+			wantCall: true,
+		},
+		{
+			name:     "service in scope",
+			scopes:   [][]Service{{ServiceAdmin}},
+			path:     "/assets/v1/instance/policy/label/logo",
+			wantCall: true,
+		},
+		{
+			name:    "service not in scope",
+			scopes:  [][]Service{{ServiceAdmin}},
+			path:    "/assets/v1/org/policy/label/logo",
+			wantErr: ErrServiceNotInScope,
+		},
+		{
+			name:    "nested, service not in both scopes",
+			scopes:  [][]Service{{ServiceAdmin, ServiceManagement}, {ServiceManagement}},
+			path:    "/assets/v1/instance/policy/label/logo",
+			wantErr: ErrServiceNotInScope,
+		},
+		{
+			name:    "unmapped path",
+			scopes:  [][]Service{{ServiceAdmin, ServiceManagement, ServiceAuth, ServiceUserV2}},
+			path:    "/scim/v2/orgID/Users",
+			wantErr: ErrServiceNotInScope,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var called bool
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+			}))
+			defer server.Close()
+			c := &Client{
+				connection: &testConnection{},
+				origin:     server.URL,
+			}
+			for _, scope := range tt.scopes {
+				c = c.Scoped(scope...)
+			}
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, c.Origin()+tt.path, nil)
+			require.NoError(t, err)
+			resp, err := c.HTTPClient().Do(req)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if err == nil {
+				resp.Body.Close()
+			}
+			assert.Equal(t, tt.wantCall, called)
+		})
+	}
+}
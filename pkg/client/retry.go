@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+const (
+	defaultRetryInitialInterval = 500 * time.Millisecond
+	defaultRetryMaxInterval     = 30 * time.Second
+	retryMultiplier             = 2
+)
+
+var (
+	ErrConnectionRetryExceeded = errors.New("connection could not be established within the retry duration")
+	ErrConnectionNotReady      = errors.New("connection is not ready")
+)
+
+// RetryAttempt provides information about a failed attempt of establishing the initial connection
+// when using [WithConnectionRetry].
+type RetryAttempt struct {
+	// Attempt is the number of the failed attempt, starting with 1.
+	Attempt int
+	// Err is the error of the failed attempt.
+	Err error
+	// Elapsed is the time passed since the first attempt.
+	Elapsed time.Duration
+	// Next is the wait time until the next attempt.
+	Next time.Duration
+}
+
+// RetryOption allows customization of the backoff used by [WithConnectionRetry].
+type RetryOption func(*connectionRetry)
+
+// WithRetryInterval allows an initial and maximum backoff interval other than 500ms and 30s.
+// The interval is doubled after every failed attempt until the maximum is reached.
+// Non-positive values will keep the respective default and a maximum lower than the initial interval
+// will be raised to the initial interval.
+func WithRetryInterval(initialInterval, maxInterval time.Duration) RetryOption {
+	return func(r *connectionRetry) {
+		if initialInterval > 0 {
+			r.initialInterval = initialInterval
+		}
+		if maxInterval > 0 {
+			r.maxInterval = maxInterval
+		}
+		r.maxInterval = max(r.maxInterval, r.initialInterval)
+	}
+}
+
+// WithRetryCallback will call the provided function after every failed attempt, e.g. for logging the progress.
+func WithRetryCallback(onAttempt func(RetryAttempt)) RetryOption {
+	return func(r *connectionRetry) {
+		r.onAttempt = onAttempt
+	}
+}
+
+// WithConnectionRetry lets [New] retry the initial token retrieval and connection establishment
+// with an exponential backoff for at most the provided duration, instead of returning on the first failure.
+// This is useful in init containers or jobs, where ZITADEL might not be reachable yet.
+//
+// Note that if enabled, [New] blocks until the connection is ready.
+func WithConnectionRetry(maxDuration time.Duration, options ...RetryOption) Option {
+	return func(c *clientOptions) {
+		c.retry = &connectionRetry{
+			maxDuration:     maxDuration,
+			initialInterval: defaultRetryInitialInterval,
+			maxInterval:     defaultRetryMaxInterval,
+		}
+		for _, option := range options {
+			option(c.retry)
+		}
+	}
+}
+
+type connectionRetry struct {
+	maxDuration     time.Duration
+	initialInterval time.Duration
+	maxInterval     time.Duration
+	onAttempt       func(RetryAttempt)
+}
+
+// do executes the attempt until it succeeds, the maxDuration elapsed or the context is done.
+func (r *connectionRetry) do(ctx context.Context, attempt func(ctx context.Context) error) error {
+	start := time.Now()
+	interval := r.initialInterval
+	for i := 1; ; i++ {
+		attemptCtx, cancel := context.WithDeadline(ctx, start.Add(r.maxDuration))
+		err := attempt(attemptCtx)
+		cancel()
+		if err == nil {
+			return nil
+		}
+		elapsed := time.Since(start)
+		if elapsed+interval > r.maxDuration {
+			return fmt.Errorf("%w: %d attempts: %w", ErrConnectionRetryExceeded, i, err)
+		}
+		if r.onAttempt != nil {
+			r.onAttempt(RetryAttempt{
+				Attempt: i,
+				Err:     err,
+				Elapsed: elapsed,
+				Next:    interval,
+			})
+		}
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w: %w", ctx.Err(), err)
+		case <-timer.C:
+		}
+		interval = min(interval*retryMultiplier, r.maxInterval)
+	}
+}
+
+// waitForReady actively connects and blocks until the connection is ready.
+// It returns an error as soon as the connection fails or the context is done.
+func waitForReady(ctx context.Context, conn *grpc.ClientConn) error {
+	conn.Connect()
+	for {
+		state := conn.GetState()
+		switch state {
+		case connectivity.Ready:
+			return nil
+		case connectivity.TransientFailure, connectivity.Shutdown:
+			return fmt.Errorf("%w: %s", ErrConnectionNotReady, state)
+		}
+		if !conn.WaitForStateChange(ctx, state) {
+			return fmt.Errorf("%w: %w", ErrConnectionNotReady, ctx.Err())
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var errAttempt = errors.New("attempt failed")
+
+func Test_connectionRetry_do(t *testing.T) {
+	type fields struct {
+		maxDuration     time.Duration
+		initialInterval time.Duration
+		maxInterval     time.Duration
+	}
+	tests := []struct {
+		name         string
+		fields       fields
+		ctx          func() context.Context
+		failures     int
+		wantErr      error
+		wantAttempts []int
+		wantNext     []time.Duration
+	}{
+		{
+			name: "success on first attempt",
+			fields: fields{
+				maxDuration:     time.Second,
+				initialInterval: time.Millisecond,
+				maxInterval:     time.Millisecond,
+			},
+			ctx:      context.Background,
+			failures: 0,
+		},
+		{
+			name: "success after failures",
+			fields: fields{
+				maxDuration:     time.Second,
+				initialInterval: time.Millisecond,
+				maxInterval:     10 * time.Millisecond,
+			},
+			ctx:          context.Background,
+			failures:     2,
+			wantAttempts: []int{1, 2},
+			wantNext:     []time.Duration{time.Millisecond, 2 * time.Millisecond},
+		},
+		{
+			name: "backoff capped at max interval",
+			fields: fields{
+				maxDuration:     time.Second,
+				initialInterval: time.Millisecond,
+				maxInterval:     2 * time.Millisecond,
+			},
+			ctx:          context.Background,
+			failures:     4,
+			wantAttempts: []int{1, 2, 3, 4},
+			wantNext:     []time.Duration{time.Millisecond, 2 * time.Millisecond, 2 * time.Millisecond, 2 * time.Millisecond},
+		},
+		{
+			name: "max duration exceeded",
+			fields: fields{
+				maxDuration:     5 * time.Millisecond,
+				initialInterval: 2 * time.Millisecond,
+				maxInterval:     10 * time.Millisecond,
+			},
+			ctx:          context.Background,
+			failures:     -1,
+			wantErr:      ErrConnectionRetryExceeded,
+			wantAttempts: []int{1},
+			wantNext:     []time.Duration{2 * time.Millisecond},
+		},
+		{
+			name: "parent context canceled",
+			fields: fields{
+				maxDuration:     time.Second,
+				initialInterval: time.Millisecond,
+				maxInterval:     time.Millisecond,
+			},
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			},
+			failures:     -1,
+			wantErr:      context.Canceled,
+			wantAttempts: []int{1},
+			wantNext:     []time.Duration{time.Millisecond},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts []RetryAttempt
+			r := &connectionRetry{
+				maxDuration:     tt.fields.maxDuration,
+				initialInterval: tt.fields.initialInterval,
+				maxInterval:     tt.fields.maxInterval,
+				onAttempt: func(attempt RetryAttempt) {
+					attempts = append(attempts, attempt)
+				},
+			}
+			calls := 0
+			err := r.do(tt.ctx(), func(ctx context.Context) error {
+				calls++
+				if tt.failures < 0 || calls <= tt.failures {
+					return errAttempt
+				}
+				return nil
+			})
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, errAttempt)
+			}
+			require.Len(t, attempts, len(tt.wantAttempts))
+			var lastElapsed time.Duration
+			for i, attempt := range attempts {
+				assert.Equal(t, tt.wantAttempts[i], attempt.Attempt)
+				assert.Equal(t, tt.wantNext[i], attempt.Next)
+				assert.ErrorIs(t, attempt.Err, errAttempt)
+				assert.GreaterOrEqual(t, attempt.Elapsed, lastElapsed)
+				lastElapsed = attempt.Elapsed
+			}
+		})
+	}
+}
+
+func TestWithRetryInterval(t *testing.T) {
+	type args struct {
+		initialInterval time.Duration
+		maxInterval     time.Duration
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantInitial time.Duration
+		wantMax     time.Duration
+	}{
+		{
+			name: "custom intervals",
+			args: args{
+				initialInterval: time.Second,
+				maxInterval:     time.Minute,
+			},
+			wantInitial: time.Second,
+			wantMax:     time.Minute,
+		},
+		{
+			name: "non-positive intervals, defaults",
+			args: args{
+				initialInterval: 0,
+				maxInterval:     -1,
+			},
+			wantInitial: defaultRetryInitialInterval,
+			wantMax:     defaultRetryMaxInterval,
+		},
+		{
+			name: "max lower than initial, raised",
+			args: args{
+				initialInterval: time.Minute,
+				maxInterval:     time.Second,
+			},
+			wantInitial: time.Minute,
+			wantMax:     time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var options clientOptions
+			WithConnectionRetry(time.Minute, WithRetryInterval(tt.args.initialInterval, tt.args.maxInterval))(&options)
+			assert.Equal(t, tt.wantInitial, options.retry.initialInterval)
+			assert.Equal(t, tt.wantMax, options.retry.maxInterval)
+		})
+	}
+}
+
+func Test_waitForReady(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closedAddr := closed.Addr().String()
+	require.NoError(t, closed.Close())
+
+	tests := []struct {
+		name    string
+		target  string
+		wantErr error
+	}{
+		{
+			name:    "unreachable, not ready error",
+			target:  closedAddr,
+			wantErr: ErrConnectionNotReady,
+		},
+		{
+			name:   "ready",
+			target: listener.Addr().String(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, err := grpc.NewClient(tt.target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+			require.NoError(t, err)
+			defer conn.Close()
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			err = waitForReady(ctx, conn)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
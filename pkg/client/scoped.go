@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+)
+
+// Service identifies a ZITADEL API service by its fully qualified gRPC service name.
+type Service string
+
+const (
+	ServiceSystem         Service = "zitadel.system.v1.SystemService"
+	ServiceAdmin          Service = "zitadel.admin.v1.AdminService"
+	ServiceManagement     Service = "zitadel.management.v1.ManagementService"
+	ServiceAuth           Service = "zitadel.auth.v1.AuthService"
+	ServiceUser           Service = "zitadel.user.v2beta.UserService"
+	ServiceUserV2         Service = "zitadel.user.v2.UserService"
+	ServiceSettings       Service = "zitadel.settings.v2beta.SettingsService"
+	ServiceSettingsV2     Service = "zitadel.settings.v2.SettingsService"
+	ServiceSession        Service = "zitadel.session.v2beta.SessionService"
+	ServiceSessionV2      Service = "zitadel.session.v2.SessionService"
+	ServiceOrganization   Service = "zitadel.org.v2beta.OrganizationService"
+	ServiceOrganizationV2 Service = "zitadel.org.v2.OrganizationService"
+	ServiceOIDC           Service = "zitadel.oidc.v2beta.OIDCService"
+	ServiceOIDCV2         Service = "zitadel.oidc.v2.OIDCService"
+)
+
+var (
+	ErrServiceNotInScope = errors.New("service is not in the scope of the client")
+)
+
+// Scoped returns a view of the client sharing its connection, but only allowing calls to the provided services.
+// Calls to any other service will fail with an [ErrServiceNotInScope] without reaching ZITADEL.
+// This includes the calls of the [Client.HTTPClient] to the REST only endpoints of the other services.
+// This allows handing narrowly scoped clients to components to enforce least privilege in code.
+//
+// Scoping an already scoped client will only allow the services allowed by both.
+func (c *Client) Scoped(allowed ...Service) *Client {
+	services := make(map[Service]struct{}, len(allowed))
+	for _, service := range allowed {
+		services[service] = struct{}{}
+	}
+	return &Client{
+		connection: &scopedConnection{
+			ClientConnInterface: c.connection,
+			allowed:             services,
+		},
+		origin:      c.origin,
+		credentials: c.credentials,
+	}
+}
+
+// scopedConnection implements the [grpc.ClientConnInterface] and will reject any call
+// to a service not explicitly allowed.
+type scopedConnection struct {
+	grpc.ClientConnInterface
+	allowed map[Service]struct{}
+}
+
+func (s *scopedConnection) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	if err := s.checkScope(method); err != nil {
+		return err
+	}
+	return s.ClientConnInterface.Invoke(ctx, method, args, reply, opts...)
+}
+
+func (s *scopedConnection) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	if err := s.checkScope(method); err != nil {
+		return nil, err
+	}
+	return s.ClientConnInterface.NewStream(ctx, desc, method, opts...)
+}
+
+// checkScope checks the service of the full method name (/package.Service/Method) against the allowed services.
+func (s *scopedConnection) checkScope(method string) error {
+	service, _, _ := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	if _, ok := s.allowed[Service(service)]; !ok {
+		return fmt.Errorf("%w: `%s`", ErrServiceNotInScope, service)
+	}
+	return nil
+}
+
+// checkServiceScope checks the service against all (nested) scopes of the connection.
+func checkServiceScope(conn grpc.ClientConnInterface, service Service) error {
+	for {
+		scoped, ok := conn.(*scopedConnection)
+		if !ok {
+			return nil
+		}
+		if _, ok = scoped.allowed[service]; !ok {
+			return fmt.Errorf("%w: `%s`", ErrServiceNotInScope, service)
+		}
+		conn = scoped.ClientConnInterface
+	}
+}
+
+// isScoped returns whether the calls of the connection are restricted by [Client.Scoped].
+func isScoped(conn grpc.ClientConnInterface) bool {
+	_, ok := conn.(*scopedConnection)
+	return ok
+}
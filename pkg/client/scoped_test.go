@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/admin"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+)
+
+func TestClient_Scoped(t *testing.T) {
+	tests := []struct {
+		name           string
+		scopes         [][]Service
+		wantAdmin      error
+		wantManagement error
+	}{
+		{
+			name:           "admin only",
+			scopes:         [][]Service{{ServiceAdmin}},
+			wantAdmin:      nil,
+			wantManagement: ErrServiceNotInScope,
+		},
+		{
+			name:           "admin and management",
+			scopes:         [][]Service{{ServiceAdmin, ServiceManagement}},
+			wantAdmin:      nil,
+			wantManagement: nil,
+		},
+		{
+			name:           "nested, intersection",
+			scopes:         [][]Service{{ServiceAdmin, ServiceManagement}, {ServiceManagement, ServiceAuth}},
+			wantAdmin:      ErrServiceNotInScope,
+			wantManagement: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := &testConnection{}
+			c := &Client{connection: conn}
+			for _, scope := range tt.scopes {
+				c = c.Scoped(scope...)
+			}
+			_, err := c.AdminService().Healthz(context.Background(), &admin.HealthzRequest{})
+			assert.ErrorIs(t, err, tt.wantAdmin)
+			_, err = c.ManagementService().Healthz(context.Background(), &management.HealthzRequest{})
+			assert.ErrorIs(t, err, tt.wantManagement)
+
+			var wantCalls []string
+			if tt.wantAdmin == nil {
+				wantCalls = append(wantCalls, admin.AdminService_Healthz_FullMethodName)
+			}
+			if tt.wantManagement == nil {
+				wantCalls = append(wantCalls, management.ManagementService_Healthz_FullMethodName)
+			}
+			assert.Equal(t, wantCalls, conn.calls)
+		})
+	}
+}
+
+type testConnection struct {
+	grpc.ClientConnInterface
+	calls []string
+}
+
+func (c *testConnection) Invoke(_ context.Context, method string, _, _ any, _ ...grpc.CallOption) error {
+	c.calls = append(c.calls, method)
+	return nil
+}
@@ -0,0 +1,196 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/slog"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/admin"
+)
+
+const (
+	// MinSupportedVersion is the lowest ZITADEL version (inclusive) this SDK is tested against.
+	MinSupportedVersion = "v2.62.0"
+	// MaxSupportedVersion is the ZITADEL version (exclusive) up to which this SDK is tested against.
+	MaxSupportedVersion = "v3.0.0"
+)
+
+var (
+	ErrUnsupportedVersion = errors.New("unsupported ZITADEL version")
+	ErrInvalidVersion     = errors.New("invalid version")
+)
+
+// VersionCompatibility is the result of the comparison of the ZITADEL server version
+// with the range of versions the SDK is tested against.
+type VersionCompatibility struct {
+	ServerVersion string
+	MinVersion    string
+	MaxVersion    string
+	// TooOld is set if the server version is lower than the MinVersion.
+	TooOld bool
+	// TooNew is set if the server version is equal or higher than the MaxVersion.
+	TooNew bool
+}
+
+// IsSupported returns if the server version is within the supported range.
+func (v *VersionCompatibility) IsSupported() bool {
+	return !v.TooOld && !v.TooNew
+}
+
+// VersionCheckOption allows customization of the version check enabled by [WithVersionCheck].
+type VersionCheckOption func(*versionCheck)
+
+// WithStrictVersionCheck will let [New] return an [ErrUnsupportedVersion] instead of logging a warning,
+// if the server version is not within the supported range or could not be determined.
+func WithStrictVersionCheck() VersionCheckOption {
+	return func(v *versionCheck) {
+		v.strict = true
+	}
+}
+
+// WithSupportedVersions overrides the supported range (min inclusive, max exclusive) of versions,
+// e.g. if you verified the compatibility with a newer ZITADEL version yourself.
+// An empty value will keep the respective default ([MinSupportedVersion], [MaxSupportedVersion]).
+func WithSupportedVersions(min, max string) VersionCheckOption {
+	return func(v *versionCheck) {
+		if min != "" {
+			v.min = min
+		}
+		if max != "" {
+			v.max = max
+		}
+	}
+}
+
+// WithVersionCheckLogger allows a logger other than slog.Default() for the version warnings.
+//
+// EXPERIMENTAL: Will change to log/slog import after we drop support for Go 1.20
+func WithVersionCheckLogger(logger *slog.Logger) VersionCheckOption {
+	return func(v *versionCheck) {
+		v.logger = logger
+	}
+}
+
+// WithVersionCheck will compare the version of the connected ZITADEL server with the range of versions
+// the SDK is tested against when calling [New] and log a warning (or return an error in strict mode)
+// if it's not within that range.
+//
+// Note that the version is retrieved from the Admin API (GetMyInstance) and therefore requires
+// the authorized user to have read permissions on the instance.
+func WithVersionCheck(options ...VersionCheckOption) Option {
+	return func(c *clientOptions) {
+		c.versionCheck = &versionCheck{
+			min:    MinSupportedVersion,
+			max:    MaxSupportedVersion,
+			logger: slog.Default(),
+		}
+		for _, option := range options {
+			option(c.versionCheck)
+		}
+	}
+}
+
+type versionCheck struct {
+	min    string
+	max    string
+	strict bool
+	logger *slog.Logger
+}
+
+func (v *versionCheck) check(ctx context.Context, c *Client) error {
+	compatibility, err := c.checkVersion(ctx, v.min, v.max)
+	if err != nil {
+		if v.strict {
+			return fmt.Errorf("%w: %w", ErrUnsupportedVersion, err)
+		}
+		v.logger.Log(ctx, slog.LevelWarn, "unable to check ZITADEL version", "error", err)
+		return nil
+	}
+	if compatibility.IsSupported() {
+		return nil
+	}
+	if v.strict {
+		return fmt.Errorf("%w: %s is not within [%s, %s)", ErrUnsupportedVersion, compatibility.ServerVersion, compatibility.MinVersion, compatibility.MaxVersion)
+	}
+	v.logger.Log(ctx, slog.LevelWarn, "ZITADEL version is not within the supported range of the SDK",
+		"version", compatibility.ServerVersion,
+		"min", compatibility.MinVersion,
+		"max", compatibility.MaxVersion,
+		"too_old", compatibility.TooOld,
+		"too_new", compatibility.TooNew,
+	)
+	return nil
+}
+
+// CheckVersion retrieves the version of the connected ZITADEL server and compares it
+// with the range of versions the SDK is tested against ([MinSupportedVersion], [MaxSupportedVersion]).
+func (c *Client) CheckVersion(ctx context.Context) (*VersionCompatibility, error) {
+	return c.checkVersion(ctx, MinSupportedVersion, MaxSupportedVersion)
+}
+
+func (c *Client) checkVersion(ctx context.Context, min, max string) (*VersionCompatibility, error) {
+	resp, err := c.AdminService().GetMyInstance(ctx, &admin.GetMyInstanceRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return compareVersion(resp.GetInstance().GetVersion(), min, max)
+}
+
+func compareVersion(version, min, max string) (*VersionCompatibility, error) {
+	server, err := parseVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	lower, err := parseVersion(min)
+	if err != nil {
+		return nil, err
+	}
+	upper, err := parseVersion(max)
+	if err != nil {
+		return nil, err
+	}
+	return &VersionCompatibility{
+		ServerVersion: version,
+		MinVersion:    min,
+		MaxVersion:    max,
+		TooOld:        server.compare(lower) < 0,
+		TooNew:        server.compare(upper) >= 0,
+	}, nil
+}
+
+type semanticVersion [3]int
+
+// parseVersion parses versions in the form of (v)major.minor.patch ignoring any pre-release or build suffix.
+func parseVersion(version string) (v semanticVersion, err error) {
+	core := strings.TrimPrefix(version, "v")
+	if i := strings.IndexAny(core, "-+"); i >= 0 {
+		core = core[:i]
+	}
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return v, fmt.Errorf("%w: `%s`", ErrInvalidVersion, version)
+	}
+	for i, part := range parts {
+		v[i], err = strconv.Atoi(part)
+		if err != nil || v[i] < 0 {
+			return v, fmt.Errorf("%w: `%s`", ErrInvalidVersion, version)
+		}
+	}
+	return v, nil
+}
+
+func (v semanticVersion) compare(other semanticVersion) int {
+	for i := range v {
+		if v[i] != other[i] {
+			if v[i] < other[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_compareVersion(t *testing.T) {
+	type args struct {
+		version string
+		min     string
+		max     string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *VersionCompatibility
+		wantErr error
+	}{
+		{
+			name: "invalid version",
+			args: args{
+				version: "latest",
+				min:     "v2.62.0",
+				max:     "v3.0.0",
+			},
+			wantErr: ErrInvalidVersion,
+		},
+		{
+			name: "too old",
+			args: args{
+				version: "v2.61.9",
+				min:     "v2.62.0",
+				max:     "v3.0.0",
+			},
+			want: &VersionCompatibility{
+				ServerVersion: "v2.61.9",
+				MinVersion:    "v2.62.0",
+				MaxVersion:    "v3.0.0",
+				TooOld:        true,
+			},
+		},
+		{
+			name: "too new",
+			args: args{
+				version: "v3.0.0",
+				min:     "v2.62.0",
+				max:     "v3.0.0",
+			},
+			want: &VersionCompatibility{
+				ServerVersion: "v3.0.0",
+				MinVersion:    "v2.62.0",
+				MaxVersion:    "v3.0.0",
+				TooNew:        true,
+			},
+		},
+		{
+			name: "supported, pre-release",
+			args: args{
+				version: "v2.63.0-rc.1",
+				min:     "v2.62.0",
+				max:     "v3.0.0",
+			},
+			want: &VersionCompatibility{
+				ServerVersion: "v2.63.0-rc.1",
+				MinVersion:    "v2.62.0",
+				MaxVersion:    "v3.0.0",
+			},
+		},
+		{
+			name: "supported, without prefix",
+			args: args{
+				version: "2.62.0",
+				min:     "v2.62.0",
+				max:     "v3.0.0",
+			},
+			want: &VersionCompatibility{
+				ServerVersion: "2.62.0",
+				MinVersion:    "v2.62.0",
+				MaxVersion:    "v3.0.0",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := compareVersion(tt.args.version, tt.args.min, tt.args.max)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -3,6 +3,11 @@ package middleware
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
 	"google.golang.org/grpc"
@@ -12,26 +17,111 @@ import (
 	"github.com/zitadel/zitadel-go/v3/pkg/authorization"
 )
 
+var (
+	ErrUnmappedMethod = errors.New("method is not mapped to any authorization requirement")
+)
+
+// Interceptor provides the [grpc.UnaryServerInterceptor] and [grpc.StreamServerInterceptor], which extract the
+// bearer token from the `authorization` metadata of the incoming call, verify it with the [authorization.Authorizer]
+// and provide the [authorization.Ctx] in the context of the handler.
 type Interceptor[T authorization.Ctx] struct {
-	authorizer *authorization.Authorizer[T]
-	checks     map[string][]authorization.CheckOption
+	authorizer    *authorization.Authorizer[T]
+	checks        map[string][]authorization.CheckOption
+	defaultChecks []authorization.CheckOption
+	requireAll    bool
+	denyUnmapped  bool
+	publicMethods map[string]struct{}
+	revalidate    bool
+	revalidation  time.Duration
+}
+
+// Option allows customization of the [Interceptor].
+type Option func(*options)
+
+type options struct {
+	defaultChecks []authorization.CheckOption
+	requireAll    bool
+	denyUnmapped  bool
+	publicMethods []string
+	revalidate    bool
+	revalidation  time.Duration
+}
+
+// WithDefaultChecks requires an authorization for all methods, which are not configured in the checks
+// of the [Interceptor], using the provided checks (if any).
+// Without it (or [WithDenyByDefault]), such methods are public. Methods without any authorization (e.g. health checks)
+// can be excluded with [WithPublicMethods].
+func WithDefaultChecks(checks ...authorization.CheckOption) Option {
+	return func(o *options) {
+		o.requireAll = true
+		o.defaultChecks = append(o.defaultChecks, checks...)
+	}
+}
+
+// WithDenyByDefault rejects all calls to methods, which are neither configured in the checks
+// of the [Interceptor] nor listed in [WithPublicMethods], with [codes.PermissionDenied].
+// This prevents accidentally exposed methods, e.g. after adding a new method to the service.
+// It takes precedence over [WithDefaultChecks]. Use [Interceptor.Validate] to detect unmapped methods on startup.
+func WithDenyByDefault() Option {
+	return func(o *options) {
+		o.denyUnmapped = true
+	}
+}
+
+// WithPublicMethods allows access to the methods (full method names, e.g. `/grpc.health.v1.Health/Check`)
+// without any authorization, even if [WithDefaultChecks] is set.
+func WithPublicMethods(methods ...string) Option {
+	return func(o *options) {
+		o.publicMethods = append(o.publicMethods, methods...)
+	}
 }
 
-func New[T authorization.Ctx](authorizer *authorization.Authorizer[T], checks map[string][]authorization.CheckOption) *Interceptor[T] {
+// WithStreamRevalidation authorizes the caller of a stream again (with the same token and checks) before receiving
+// or sending a message, if the last authorization is longer ago than the interval. With an interval of 0, every message
+// is checked. This allows terminating long-lived streams (e.g. subscriptions), e.g. after the token expired or was revoked.
+// If the authorization fails, the RecvMsg or SendMsg of the stream returns the [codes.Unauthenticated],
+// resp. [codes.PermissionDenied] error.
+func WithStreamRevalidation(interval time.Duration) Option {
+	return func(o *options) {
+		o.revalidate = true
+		o.revalidation = interval
+	}
+}
+
+// New creates an [Interceptor] with the checks per full method name (e.g. `/example.v1.ExampleService/Get`).
+//
+// Methods without checks are public and called without any authorization, unless [WithDefaultChecks]
+// or [WithDenyByDefault] is passed. Use one of them to protect methods, which are not (yet) mapped.
+func New[T authorization.Ctx](authorizer *authorization.Authorizer[T], checks map[string][]authorization.CheckOption, opts ...Option) *Interceptor[T] {
+	o := new(options)
+	for _, opt := range opts {
+		opt(o)
+	}
+	publicMethods := make(map[string]struct{}, len(o.publicMethods))
+	for _, method := range o.publicMethods {
+		publicMethods[method] = struct{}{}
+	}
 	return &Interceptor[T]{
-		authorizer: authorizer,
-		checks:     checks,
+		authorizer:    authorizer,
+		checks:        checks,
+		defaultChecks: o.defaultChecks,
+		requireAll:    o.requireAll,
+		denyUnmapped:  o.denyUnmapped,
+		publicMethods: publicMethods,
+		revalidate:    o.revalidate,
+		revalidation:  o.revalidation,
 	}
 }
 
 // Unary creates a [grpc.UnaryServerInterceptor].
 // Ensure to configure the [Interceptor] with the required checks.
-// If no checks are provided the interceptor will allow public access to the API.
+// Methods without checks are public, unless [WithDefaultChecks] or [WithDenyByDefault] is set (see [New]).
+// A missing or invalid token results in [codes.Unauthenticated], failed checks in [codes.PermissionDenied].
 func (i *Interceptor[T]) Unary() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
 		ctx, err = i.intercept(ctx, info.FullMethod)
 		if err != nil {
-			return nil, status.Error(codes.PermissionDenied, err.Error())
+			return nil, err
 		}
 		return handler(ctx, req)
 	}
@@ -39,14 +129,25 @@ func (i *Interceptor[T]) Unary() grpc.UnaryServerInterceptor {
 
 // Stream creates a [grpc.StreamServerInterceptor].
 // Ensure to configure the [Interceptor] with the required checks.
-// If no checks are provided the interceptor will allow public access to the API.
+// Methods without checks are public, unless [WithDefaultChecks] or [WithDenyByDefault] is set (see [New]).
+// The caller is authorized when the stream is opened, resp. additionally on received and sent messages
+// if [WithStreamRevalidation] is set.
 func (i *Interceptor[T]) Stream() grpc.StreamServerInterceptor {
 	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
 		ctx, err := i.intercept(stream.Context(), info.FullMethod)
 		if err != nil {
 			return err
 		}
-		return handler(srv, &serverStream{ServerStream: stream, ctx: ctx})
+		wrapped := &serverStream{ServerStream: stream, ctx: ctx}
+		if checks, ok := i.checksOf(info.FullMethod); ok && i.revalidate {
+			wrapped.revalidate = func() error {
+				_, err := i.authorize(stream.Context(), info.FullMethod, checks)
+				return err
+			}
+			wrapped.interval = i.revalidation
+			wrapped.lastCheck = time.Now()
+		}
+		return handler(srv, wrapped)
 	}
 }
 
@@ -55,21 +156,72 @@ func (i *Interceptor[T]) Context(ctx context.Context) T {
 	return authorization.Context[T](ctx)
 }
 
-func (i *Interceptor[T]) intercept(ctx context.Context, method string) (context.Context, error) {
-	for endpoint, checks := range i.checks {
-		if endpoint != method {
-			continue
-		}
-		authCtx, err := i.authorizer.CheckAuthorization(ctx, metautils.ExtractIncoming(ctx).Get(authorization.HeaderName), checks...)
-		if err != nil {
-			if errors.Is(err, &authorization.UnauthorizedErr{}) {
-				return nil, status.Error(codes.Unauthenticated, err.Error())
+// Validate returns an [ErrUnmappedMethod] listing all methods of the services, which are neither configured
+// in the checks of the [Interceptor] nor listed in [WithPublicMethods], e.g.:
+//
+//	if err := interceptor.Validate(server.GetServiceInfo()); err != nil {
+//		log.Fatal(err)
+//	}
+func (i *Interceptor[T]) Validate(services map[string]grpc.ServiceInfo) error {
+	var unmapped []string
+	for service, info := range services {
+		for _, method := range info.Methods {
+			fullMethod := "/" + service + "/" + method.Name
+			if !i.isMapped(fullMethod) {
+				unmapped = append(unmapped, fullMethod)
 			}
-			return nil, status.Error(codes.PermissionDenied, err.Error())
 		}
-		return authorization.WithAuthContext(ctx, authCtx), nil
 	}
-	return ctx, nil
+	if len(unmapped) == 0 {
+		return nil
+	}
+	slices.Sort(unmapped)
+	return fmt.Errorf("%w: `%s`", ErrUnmappedMethod, strings.Join(unmapped, "`, `"))
+}
+
+func (i *Interceptor[T]) intercept(ctx context.Context, method string) (context.Context, error) {
+	if i.denyUnmapped && !i.isMapped(method) {
+		return nil, status.Error(codes.PermissionDenied, ErrUnmappedMethod.Error())
+	}
+	checks, ok := i.checksOf(method)
+	if !ok {
+		return ctx, nil
+	}
+	return i.authorize(ctx, method, checks)
+}
+
+// authorize checks the token of the incoming metadata and returns the context with the [authorization.Ctx].
+// The full method name is passed as attribute to a [authorization.PolicyEvaluator] and as resource to an [authorization.AuditHook].
+func (i *Interceptor[T]) authorize(ctx context.Context, method string, checks []authorization.CheckOption) (context.Context, error) {
+	token := metautils.ExtractIncoming(ctx).Get(authorization.HeaderName)
+	checkCtx := authorization.WithPolicyAttributes(authorization.WithResource(ctx, method), map[string]any{"method": method})
+	authCtx, err := i.authorizer.CheckAuthorization(checkCtx, token, checks...)
+	if err != nil {
+		if errors.Is(err, &authorization.UnauthorizedErr{}) {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	return authorization.WithAuthContext(ctx, authCtx), nil
+}
+
+// checksOf returns the checks of the method and if an authorization is required at all.
+func (i *Interceptor[T]) checksOf(method string) ([]authorization.CheckOption, bool) {
+	if checks, ok := i.checks[method]; ok {
+		return checks, true
+	}
+	if _, ok := i.publicMethods[method]; ok || !i.requireAll {
+		return nil, false
+	}
+	return i.defaultChecks, true
+}
+
+func (i *Interceptor[T]) isMapped(method string) bool {
+	if _, ok := i.checks[method]; ok {
+		return true
+	}
+	_, ok := i.publicMethods[method]
+	return ok
 }
 
 // serverStream is required to be able to intercept and annotate the [context.Context]
@@ -77,8 +229,48 @@ func (i *Interceptor[T]) intercept(ctx context.Context, method string) (context.
 type serverStream struct {
 	grpc.ServerStream
 	ctx context.Context
+
+	// revalidate is only set if [WithStreamRevalidation] is used.
+	revalidate func() error
+	interval   time.Duration
+	// mu guards the lastCheck, as RecvMsg and SendMsg may be called concurrently.
+	mu        sync.Mutex
+	lastCheck time.Time
 }
 
 func (s *serverStream) Context() context.Context {
 	return s.ctx
 }
+
+// RecvMsg authorizes the caller again before receiving the message, if the revalidation interval elapsed.
+func (s *serverStream) RecvMsg(m interface{}) error {
+	if err := s.check(); err != nil {
+		return err
+	}
+	return s.ServerStream.RecvMsg(m)
+}
+
+// SendMsg authorizes the caller again before sending the message, if the revalidation interval elapsed,
+// so server-streaming calls (which only receive the initial request) are revalidated as well.
+func (s *serverStream) SendMsg(m interface{}) error {
+	if err := s.check(); err != nil {
+		return err
+	}
+	return s.ServerStream.SendMsg(m)
+}
+
+func (s *serverStream) check() error {
+	if s.revalidate == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if time.Since(s.lastCheck) < s.interval {
+		return nil
+	}
+	if err := s.revalidate(); err != nil {
+		return err
+	}
+	s.lastCheck = time.Now()
+	return nil
+}
@@ -0,0 +1,314 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authorization"
+	"github.com/zitadel/zitadel-go/v3/pkg/zitadel"
+)
+
+type testCtx struct {
+	token string
+	role  string
+}
+
+func (t *testCtx) IsAuthorized() bool                              { return t != nil }
+func (t *testCtx) UserID() string                                  { return "userID" }
+func (t *testCtx) IsGrantedRole(role string) bool                  { return role == t.role }
+func (t *testCtx) IsGrantedRoleInOrganization(string, string) bool { return false }
+func (t *testCtx) SetToken(token string)                           { t.token = token }
+func (t *testCtx) GetToken() string                                { return t.token }
+
+type tokenVerifier struct {
+	revoked bool
+}
+
+func (v *tokenVerifier) CheckAuthorization(_ context.Context, token string) (*testCtx, error) {
+	if v.revoked {
+		return nil, errors.New("token revoked")
+	}
+	switch token {
+	case "Bearer admin":
+		return &testCtx{role: "admin"}, nil
+	case "Bearer user":
+		return &testCtx{}, nil
+	}
+	return nil, errors.New("invalid token")
+}
+
+func newTestInterceptor(t *testing.T, verifier *tokenVerifier, opts ...Option) *Interceptor[*testCtx] {
+	t.Helper()
+	authZ, err := authorization.New(context.Background(), zitadel.New("zitadel.example.com"),
+		func(context.Context, *zitadel.Zitadel) (authorization.Verifier[*testCtx], error) {
+			return verifier, nil
+		})
+	require.NoError(t, err)
+	return New(authZ, map[string][]authorization.CheckOption{
+		"/test.Service/Admin": {authorization.WithRole("admin")},
+		"/test.Service/User":  nil,
+	}, opts...)
+}
+
+func TestInterceptor_Unary(t *testing.T) {
+	tests := []struct {
+		name           string
+		opts           []Option
+		method         string
+		token          string
+		wantCode       codes.Code
+		wantAuthorized bool
+	}{
+		{
+			name:     "public",
+			method:   "/test.Service/Public",
+			wantCode: codes.OK,
+		},
+		{
+			name:           "authorized",
+			method:         "/test.Service/User",
+			token:          "Bearer user",
+			wantCode:       codes.OK,
+			wantAuthorized: true,
+		},
+		{
+			name:     "missing token",
+			method:   "/test.Service/User",
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "invalid token",
+			method:   "/test.Service/User",
+			token:    "Bearer invalid",
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "missing role",
+			method:   "/test.Service/Admin",
+			token:    "Bearer user",
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:           "role",
+			method:         "/test.Service/Admin",
+			token:          "Bearer admin",
+			wantCode:       codes.OK,
+			wantAuthorized: true,
+		},
+		{
+			name:     "default checks",
+			opts:     []Option{WithDefaultChecks()},
+			method:   "/test.Service/Public",
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "default checks with role",
+			opts:     []Option{WithDefaultChecks(authorization.WithRole("admin"))},
+			method:   "/test.Service/Other",
+			token:    "Bearer user",
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "default checks public method",
+			opts:     []Option{WithDefaultChecks(), WithPublicMethods("/grpc.health.v1.Health/Check")},
+			method:   "/grpc.health.v1.Health/Check",
+			wantCode: codes.OK,
+		},
+		{
+			name:           "allowed by policy",
+			opts:           []Option{WithDefaultChecks(authorization.WithPolicy(methodPolicy("/test.Service/Other")))},
+			method:         "/test.Service/Other",
+			token:          "Bearer user",
+			wantCode:       codes.OK,
+			wantAuthorized: true,
+		},
+		{
+			name:     "denied by policy",
+			opts:     []Option{WithDefaultChecks(authorization.WithPolicy(methodPolicy("/test.Service/Other")))},
+			method:   "/test.Service/Denied",
+			token:    "Bearer user",
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "deny by default, unmapped",
+			opts:     []Option{WithDenyByDefault(), WithDefaultChecks()},
+			method:   "/test.Service/Other",
+			token:    "Bearer admin",
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "deny by default, public",
+			opts:     []Option{WithDenyByDefault(), WithPublicMethods("/test.Service/Public")},
+			method:   "/test.Service/Public",
+			wantCode: codes.OK,
+		},
+		{
+			name:           "deny by default, mapped",
+			opts:           []Option{WithDenyByDefault()},
+			method:         "/test.Service/User",
+			token:          "Bearer user",
+			wantCode:       codes.OK,
+			wantAuthorized: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.token != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tt.token))
+			}
+			var authorized bool
+			_, err := newTestInterceptor(t, new(tokenVerifier), tt.opts...).Unary()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+				authorized = authorization.Context[*testCtx](ctx).IsAuthorized()
+				return nil, nil
+			})
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantAuthorized, authorized)
+		})
+	}
+}
+
+func TestInterceptor_Validate(t *testing.T) {
+	services := map[string]grpc.ServiceInfo{
+		"test.Service": {Methods: []grpc.MethodInfo{{Name: "Admin"}, {Name: "User"}, {Name: "Public"}, {Name: "Other"}}},
+		"test.Health":  {Methods: []grpc.MethodInfo{{Name: "Check"}}},
+	}
+	err := newTestInterceptor(t, new(tokenVerifier), WithPublicMethods("/test.Service/Public")).Validate(services)
+	assert.ErrorIs(t, err, ErrUnmappedMethod)
+	assert.ErrorContains(t, err, "`/test.Health/Check`, `/test.Service/Other`")
+
+	err = newTestInterceptor(t, new(tokenVerifier), WithPublicMethods("/test.Service/Public", "/test.Service/Other", "/test.Health/Check")).Validate(services)
+	assert.NoError(t, err)
+}
+
+func methodPolicy(allowed string) authorization.PolicyEvaluator {
+	return authorization.PolicyFunc(func(_ context.Context, input *authorization.PolicyInput) (bool, error) {
+		return input.Attributes["method"] == allowed, nil
+	})
+}
+
+type testStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	received int
+	sent     int
+}
+
+func (s *testStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testStream) RecvMsg(interface{}) error {
+	s.received++
+	return nil
+}
+
+func (s *testStream) SendMsg(interface{}) error {
+	s.sent++
+	return nil
+}
+
+func TestInterceptor_Stream(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         []Option
+		method       string
+		wantCode     codes.Code
+		wantReceived int
+	}{
+		{
+			name:         "without revalidation",
+			method:       "/test.Service/User",
+			wantCode:     codes.OK,
+			wantReceived: 2,
+		},
+		{
+			name:         "revalidation",
+			opts:         []Option{WithStreamRevalidation(0)},
+			method:       "/test.Service/User",
+			wantCode:     codes.Unauthenticated,
+			wantReceived: 1,
+		},
+		{
+			name:         "revalidation interval not elapsed",
+			opts:         []Option{WithStreamRevalidation(time.Hour)},
+			method:       "/test.Service/User",
+			wantCode:     codes.OK,
+			wantReceived: 2,
+		},
+		{
+			name:         "revalidation of public method",
+			opts:         []Option{WithStreamRevalidation(0)},
+			method:       "/test.Service/Public",
+			wantCode:     codes.OK,
+			wantReceived: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := new(tokenVerifier)
+			stream := &testStream{ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer user"))}
+			err := newTestInterceptor(t, verifier, tt.opts...).Stream()(nil, stream, &grpc.StreamServerInfo{FullMethod: tt.method}, func(_ interface{}, stream grpc.ServerStream) error {
+				if err := stream.RecvMsg(nil); err != nil {
+					return err
+				}
+				verifier.revoked = true
+				return stream.RecvMsg(nil)
+			})
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantReceived, stream.received)
+		})
+	}
+}
+
+func TestInterceptor_Stream_serverStreaming(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		wantCode codes.Code
+		wantSent int
+	}{
+		{
+			name:     "without revalidation",
+			wantCode: codes.OK,
+			wantSent: 3,
+		},
+		{
+			name:     "revalidation",
+			opts:     []Option{WithStreamRevalidation(0)},
+			wantCode: codes.Unauthenticated,
+			wantSent: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := new(tokenVerifier)
+			stream := &testStream{ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer user"))}
+			err := newTestInterceptor(t, verifier, tt.opts...).Stream()(nil, stream, &grpc.StreamServerInfo{FullMethod: "/test.Service/User"}, func(_ interface{}, stream grpc.ServerStream) error {
+				// a subscription receives the request once and sends events afterward
+				if err := stream.RecvMsg(nil); err != nil {
+					return err
+				}
+				for i := 0; i < 3; i++ {
+					if err := stream.SendMsg(nil); err != nil {
+						return err
+					}
+					verifier.revoked = true
+				}
+				return nil
+			})
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, 1, stream.received)
+			assert.Equal(t, tt.wantSent, stream.sent)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authorization"
+)
+
+// IdentityInterceptor forwards the [authorization.Identity] of the authorized caller (user, organization and project id)
+// as metadata of outgoing calls, e.g. for a consistent tenant scoping across services.
+// The metadata is informational only, downstream services must still authorize the calls themselves.
+type IdentityInterceptor struct{}
+
+// NewIdentityInterceptor creates an [IdentityInterceptor] for the [grpc.ClientConn] of a downstream service.
+func NewIdentityInterceptor() *IdentityInterceptor {
+	return &IdentityInterceptor{}
+}
+
+func (interceptor *IdentityInterceptor) Unary() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(SetIdentity(ctx, authorization.CallerIdentity(ctx)), method, req, reply, cc, opts...)
+	}
+}
+
+func (interceptor *IdentityInterceptor) Stream() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(SetIdentity(ctx, authorization.CallerIdentity(ctx)), desc, cc, method, opts...)
+	}
+}
+
+// SetIdentity passes the identity as metadata of the outgoing calls.
+func SetIdentity(ctx context.Context, identity authorization.Identity) context.Context {
+	headers := identity.Headers()
+	if len(headers) == 0 {
+		return ctx
+	}
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok {
+		md = metadata.MD{}
+	} else {
+		md = md.Copy()
+	}
+	for key, value := range headers {
+		md.Set(key, value)
+	}
+	return metadata.NewOutgoingContext(ctx, md)
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authorization"
+)
+
+func TestIdentityInterceptor_Unary(t *testing.T) {
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-existing", "value")
+	ctx = authorization.WithAuthContext(ctx, &testCtx{})
+
+	var md metadata.MD
+	err := NewIdentityInterceptor().Unary()(ctx, "/test.Service/User", nil, nil, nil, func(ctx context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		md, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"userID"}, md.Get(authorization.HeaderUserID))
+	assert.Equal(t, []string{"value"}, md.Get("x-existing"))
+	assert.Empty(t, md.Get(authorization.HeaderOrgID))
+}
@@ -0,0 +1,177 @@
+// Package actions provides typed helpers for the management of actions (v1) and their assignment
+// to the triggers of the flows, so scripts can be deployed from version control.
+// Scripts are checked by [Lint] before they're uploaded to catch common mistakes without a roundtrip to ZITADEL.
+// They can be loaded from files with [LoadDir] and verified against test users with [DeployAndTest].
+//
+// All functions take an optional orgID. If it's empty, the call is executed in the organization
+// of the authorized user.
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/action"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	object "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/org"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/query"
+)
+
+var (
+	ErrDuplicateAction = errors.New("multiple actions with the same name")
+)
+
+// Client is the part of the [client.Client] used by this package.
+type Client interface {
+	ManagementService() management.ManagementServiceClient
+}
+
+// Action is a script executed by ZITADEL on the triggers of the flows it's assigned to.
+// The script must contain a function with the name of the action, which is called by ZITADEL.
+type Action struct {
+	Name   string
+	Script string
+	// Timeout of the execution of the script, at most [MaxTimeout].
+	Timeout time.Duration
+	// AllowedToFail will let the flow continue, even if the script fails or times out.
+	AllowedToFail bool
+}
+
+// ExistingAction is an action as returned by ZITADEL.
+type ExistingAction struct {
+	Action
+	ID     string
+	Active bool
+}
+
+// Create lints and creates the action and returns its id.
+func Create(ctx context.Context, c Client, orgID string, a *Action) (string, error) {
+	if err := Lint(a); err != nil {
+		return "", err
+	}
+	resp, err := c.ManagementService().CreateAction(org.Context(ctx, orgID), &management.CreateActionRequest{
+		Name:          a.Name,
+		Script:        a.Script,
+		Timeout:       durationpb.New(a.Timeout),
+		AllowedToFail: a.AllowedToFail,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.GetId(), nil
+}
+
+// Update lints the action and replaces the existing action (by id) with it.
+func Update(ctx context.Context, c Client, orgID, id string, a *Action) error {
+	if err := Lint(a); err != nil {
+		return err
+	}
+	_, err := c.ManagementService().UpdateAction(org.Context(ctx, orgID), &management.UpdateActionRequest{
+		Id:            id,
+		Name:          a.Name,
+		Script:        a.Script,
+		Timeout:       durationpb.New(a.Timeout),
+		AllowedToFail: a.AllowedToFail,
+	})
+	return err
+}
+
+// Deactivate deactivates the action. It will no longer be executed, but stays assigned to its triggers.
+func Deactivate(ctx context.Context, c Client, orgID, id string) error {
+	_, err := c.ManagementService().DeactivateAction(org.Context(ctx, orgID), &management.DeactivateActionRequest{Id: id})
+	return err
+}
+
+// Reactivate reactivates a previously deactivated action.
+func Reactivate(ctx context.Context, c Client, orgID, id string) error {
+	_, err := c.ManagementService().ReactivateAction(org.Context(ctx, orgID), &management.ReactivateActionRequest{Id: id})
+	return err
+}
+
+// Delete deletes the action and removes it from all triggers.
+func Delete(ctx context.Context, c Client, orgID, id string) error {
+	_, err := c.ManagementService().DeleteAction(org.Context(ctx, orgID), &management.DeleteActionRequest{Id: id})
+	return err
+}
+
+// List returns all actions of the organization.
+func List(ctx context.Context, c Client, orgID string) ([]*ExistingAction, error) {
+	return list(ctx, c, orgID)
+}
+
+// Find returns the action with the name or nil if there is none.
+func Find(ctx context.Context, c Client, orgID, name string) (*ExistingAction, error) {
+	found, err := list(ctx, c, orgID, &management.ActionQuery{
+		Query: &management.ActionQuery_ActionNameQuery{
+			ActionNameQuery: &action.ActionNameQuery{
+				Name:   name,
+				Method: object.TextQueryMethod_TEXT_QUERY_METHOD_EQUALS,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	switch len(found) {
+	case 0:
+		return nil, nil
+	case 1:
+		return found[0], nil
+	default:
+		return nil, fmt.Errorf("%w: `%s`", ErrDuplicateAction, name)
+	}
+}
+
+// Ensure creates the action or updates the existing action with the same name if it differs
+// and returns its id. Use it to deploy actions from version control.
+func Ensure(ctx context.Context, c Client, orgID string, a *Action) (string, error) {
+	if err := Lint(a); err != nil {
+		return "", err
+	}
+	existing, err := Find(ctx, c, orgID, a.Name)
+	if err != nil {
+		return "", err
+	}
+	if existing == nil {
+		return Create(ctx, c, orgID, a)
+	}
+	if existing.Action == *a {
+		return existing.ID, nil
+	}
+	return existing.ID, Update(ctx, c, orgID, existing.ID, a)
+}
+
+func list(ctx context.Context, c Client, orgID string, queries ...*management.ActionQuery) ([]*ExistingAction, error) {
+	actions, err := query.All(org.Context(ctx, orgID), func(ctx context.Context, offset uint64, limit uint32) ([]*action.Action, uint64, error) {
+		resp, err := c.ManagementService().ListActions(ctx, &management.ListActionsRequest{
+			Query:   &object.ListQuery{Offset: offset, Limit: limit, Asc: true},
+			Queries: queries,
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		return resp.GetResult(), resp.GetDetails().GetTotalResult(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*ExistingAction, len(actions))
+	for i, a := range actions {
+		result[i] = &ExistingAction{
+			Action: Action{
+				Name:          a.GetName(),
+				Script:        a.GetScript(),
+				Timeout:       a.GetTimeout().AsDuration(),
+				AllowedToFail: a.GetAllowedToFail(),
+			},
+			ID:     a.GetId(),
+			Active: a.GetState() == action.ActionState_ACTION_STATE_ACTIVE,
+		}
+	}
+	return result, nil
+}
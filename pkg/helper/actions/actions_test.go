@@ -0,0 +1,110 @@
+package actions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/action"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	object "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object"
+)
+
+type testClient struct {
+	management management.ManagementServiceClient
+}
+
+func (c *testClient) ManagementService() management.ManagementServiceClient {
+	return c.management
+}
+
+type managementService struct {
+	management.ManagementServiceClient
+	actions []*action.Action
+	calls   []string
+	trigger *management.SetTriggerActionsRequest
+}
+
+func (m *managementService) ListActions(_ context.Context, req *management.ListActionsRequest, _ ...grpc.CallOption) (*management.ListActionsResponse, error) {
+	var result []*action.Action
+	for _, a := range m.actions {
+		if a.GetName() == req.GetQueries()[0].GetActionNameQuery().GetName() {
+			result = append(result, a)
+		}
+	}
+	return &management.ListActionsResponse{
+		Details: &object.ListDetails{TotalResult: uint64(len(result))},
+		Result:  result,
+	}, nil
+}
+
+func (m *managementService) CreateAction(_ context.Context, req *management.CreateActionRequest, _ ...grpc.CallOption) (*management.CreateActionResponse, error) {
+	m.calls = append(m.calls, "CreateAction "+req.GetName())
+	return &management.CreateActionResponse{Id: "new-" + req.GetName()}, nil
+}
+
+func (m *managementService) UpdateAction(_ context.Context, req *management.UpdateActionRequest, _ ...grpc.CallOption) (*management.UpdateActionResponse, error) {
+	m.calls = append(m.calls, "UpdateAction "+req.GetId())
+	return &management.UpdateActionResponse{}, nil
+}
+
+func (m *managementService) SetTriggerActions(_ context.Context, req *management.SetTriggerActionsRequest, _ ...grpc.CallOption) (*management.SetTriggerActionsResponse, error) {
+	m.calls = append(m.calls, "SetTriggerActions")
+	m.trigger = req
+	return &management.SetTriggerActionsResponse{}, nil
+}
+
+func TestValidateTrigger(t *testing.T) {
+	assert.NoError(t, ValidateTrigger(FlowComplementToken, TriggerPreAccessTokenCreation))
+	assert.ErrorIs(t, ValidateTrigger(FlowComplementToken, TriggerPreCreation), ErrInvalidTrigger)
+	assert.ErrorIs(t, ValidateTrigger("unknown", TriggerPreCreation), ErrInvalidTrigger)
+}
+
+func TestDeploy(t *testing.T) {
+	unchanged := &Action{Name: "unchanged", Script: "function unchanged(ctx, api) {}", Timeout: time.Second}
+	changed := &Action{Name: "changed", Script: "function changed(ctx, api) { api.v1.claims.setClaim('a', 1) }"}
+	added := &Action{Name: "added", Script: "function added(ctx, api) {}"}
+	tests := []struct {
+		name      string
+		actions   []*Action
+		wantIDs   []string
+		wantCalls []string
+		wantErr   error
+	}{
+		{
+			name:    "invalid script, no calls",
+			actions: []*Action{unchanged, {Name: "broken", Script: "function broken(ctx, api) {"}},
+			wantErr: ErrInvalidScript,
+		},
+		{
+			name:      "ensure and set trigger in order",
+			actions:   []*Action{changed, unchanged, added},
+			wantIDs:   []string{"id-changed", "id-unchanged", "new-added"},
+			wantCalls: []string{"UpdateAction id-changed", "CreateAction added", "SetTriggerActions"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgmt := &managementService{
+				actions: []*action.Action{
+					{Id: "id-unchanged", Name: "unchanged", Script: unchanged.Script, Timeout: durationpb.New(time.Second)},
+					{Id: "id-changed", Name: "changed", Script: "function changed(ctx, api) {}"},
+				},
+			}
+			ids, err := Deploy(context.Background(), &testClient{management: mgmt}, "org", FlowComplementToken, TriggerPreAccessTokenCreation, tt.actions...)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantIDs, ids)
+			assert.Equal(t, tt.wantCalls, mgmt.calls)
+			if tt.wantErr == nil {
+				assert.Equal(t, string(FlowComplementToken), mgmt.trigger.GetFlowType())
+				assert.Equal(t, string(TriggerPreAccessTokenCreation), mgmt.trigger.GetTriggerType())
+				assert.Equal(t, tt.wantIDs, mgmt.trigger.GetActionIds())
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package actions
+
+import (
+	"io/fs"
+	"path"
+	"strings"
+	"time"
+)
+
+// scriptExtension is the file extension of the action scripts loaded by [LoadFile] and [LoadDir].
+const scriptExtension = ".js"
+
+// LoadOption allows customization of the actions loaded by [LoadFile] and [LoadDir].
+type LoadOption func(*Action)
+
+// WithTimeout sets the timeout of the loaded actions, at most [MaxTimeout].
+func WithTimeout(timeout time.Duration) LoadOption {
+	return func(a *Action) {
+		a.Timeout = timeout
+	}
+}
+
+// WithAllowedToFail lets the flow continue, even if the loaded actions fail or time out.
+func WithAllowedToFail() LoadOption {
+	return func(a *Action) {
+		a.AllowedToFail = true
+	}
+}
+
+// LoadFile reads the script of an action from the file, e.g. of an [os.DirFS] or an [embed.FS].
+// The action is named after the file without its extension, so `addGroups.js` must declare the function `addGroups`.
+func LoadFile(fsys fs.FS, name string, opts ...LoadOption) (*Action, error) {
+	script, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	a := &Action{
+		Name:   strings.TrimSuffix(path.Base(name), path.Ext(name)),
+		Script: string(script),
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a, nil
+}
+
+// LoadDir reads the scripts of all `.js` files of the directory (see [LoadFile]), sorted by their file name.
+func LoadDir(fsys fs.FS, dir string, opts ...LoadOption) ([]*Action, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	var actions []*Action
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != scriptExtension {
+			continue
+		}
+		a, err := LoadFile(fsys, path.Join(dir, entry.Name()), opts...)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, a)
+	}
+	return actions, nil
+}
@@ -0,0 +1,120 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/org"
+)
+
+var (
+	ErrInvalidTrigger = errors.New("trigger type is not part of the flow")
+)
+
+// FlowType identifies a flow, which executes the actions assigned to its triggers.
+type FlowType string
+
+const (
+	FlowExternalAuthentication FlowType = "1"
+	FlowComplementToken        FlowType = "2"
+	FlowInternalAuthentication FlowType = "3"
+	FlowComplementSAMLResponse FlowType = "4"
+)
+
+// TriggerType identifies the point in a flow, where the assigned actions are executed.
+type TriggerType string
+
+const (
+	TriggerPostAuthentication      TriggerType = "1"
+	TriggerPreCreation             TriggerType = "2"
+	TriggerPostCreation            TriggerType = "3"
+	TriggerPreUserinfoCreation     TriggerType = "4"
+	TriggerPreAccessTokenCreation  TriggerType = "5"
+	TriggerPreSAMLResponseCreation TriggerType = "6"
+)
+
+var flowTriggers = map[FlowType][]TriggerType{
+	FlowExternalAuthentication: {TriggerPostAuthentication, TriggerPreCreation, TriggerPostCreation},
+	FlowInternalAuthentication: {TriggerPostAuthentication, TriggerPreCreation, TriggerPostCreation},
+	FlowComplementToken:        {TriggerPreUserinfoCreation, TriggerPreAccessTokenCreation},
+	FlowComplementSAMLResponse: {TriggerPreSAMLResponseCreation},
+}
+
+// Flow contains the ids of the actions assigned to the triggers of a flow in order of their execution.
+type Flow map[TriggerType][]string
+
+// ValidateTrigger checks if the trigger is part of the flow.
+func ValidateTrigger(flow FlowType, trigger TriggerType) error {
+	for _, t := range flowTriggers[flow] {
+		if t == trigger {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: flow `%s`, trigger `%s`", ErrInvalidTrigger, flow, trigger)
+}
+
+// GetFlow returns the actions assigned to the triggers of the flow.
+func GetFlow(ctx context.Context, c Client, orgID string, flow FlowType) (Flow, error) {
+	resp, err := c.ManagementService().GetFlow(org.Context(ctx, orgID), &management.GetFlowRequest{Type: string(flow)})
+	if err != nil {
+		return nil, err
+	}
+	result := make(Flow, len(resp.GetFlow().GetTriggerActions()))
+	for _, trigger := range resp.GetFlow().GetTriggerActions() {
+		ids := make([]string, len(trigger.GetActions()))
+		for i, a := range trigger.GetActions() {
+			ids[i] = a.GetId()
+		}
+		result[TriggerType(trigger.GetTriggerType().GetId())] = ids
+	}
+	return result, nil
+}
+
+// SetTrigger replaces the actions assigned to the trigger of the flow. The actions are executed in the provided order.
+// Passing no actionIDs removes all actions from the trigger.
+func SetTrigger(ctx context.Context, c Client, orgID string, flow FlowType, trigger TriggerType, actionIDs ...string) error {
+	if err := ValidateTrigger(flow, trigger); err != nil {
+		return err
+	}
+	_, err := c.ManagementService().SetTriggerActions(org.Context(ctx, orgID), &management.SetTriggerActionsRequest{
+		FlowType:    string(flow),
+		TriggerType: string(trigger),
+		ActionIds:   actionIDs,
+	})
+	return err
+}
+
+// ClearFlow removes all actions from all triggers of the flow.
+func ClearFlow(ctx context.Context, c Client, orgID string, flow FlowType) error {
+	_, err := c.ManagementService().ClearFlow(org.Context(ctx, orgID), &management.ClearFlowRequest{Type: string(flow)})
+	return err
+}
+
+// Deploy ensures the actions (see [Ensure]) and assigns them to the trigger of the flow in the provided order,
+// replacing any previously assigned actions. It returns the ids of the actions.
+//
+// All actions are linted before any call is made, so a broken script will not result in a partial deployment.
+func Deploy(ctx context.Context, c Client, orgID string, flow FlowType, trigger TriggerType, actions ...*Action) ([]string, error) {
+	if err := ValidateTrigger(flow, trigger); err != nil {
+		return nil, err
+	}
+	for _, a := range actions {
+		if err := Lint(a); err != nil {
+			return nil, err
+		}
+	}
+	ids := make([]string, len(actions))
+	for i, a := range actions {
+		id, err := Ensure(ctx, c, orgID, a)
+		if err != nil {
+			return nil, fmt.Errorf("unable to deploy action `%s`: %w", a.Name, err)
+		}
+		ids[i] = id
+	}
+	if err := SetTrigger(ctx, c, orgID, flow, trigger, ids...); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
@@ -0,0 +1,214 @@
+package actions
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	ErrTestFailed = errors.New("action test failed")
+)
+
+// userinfoPath is the path of the userinfo endpoint of ZITADEL.
+const userinfoPath = "/oidc/v1/userinfo"
+
+// ClaimsFunc returns the claims of the test user, e.g. [UserinfoClaims] or [AccessTokenClaims].
+type ClaimsFunc func(ctx context.Context) (map[string]any, error)
+
+// UserinfoClaims returns the claims of the userinfo endpoint of ZITADEL, which executes the actions of the
+// [TriggerPreUserinfoCreation]. The httpClient must be authorized with an access token of the test user.
+func UserinfoClaims(httpClient *http.Client, origin string) ClaimsFunc {
+	return func(ctx context.Context) (map[string]any, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(origin, "/")+userinfoPath, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("userinfo failed with status %d", resp.StatusCode)
+		}
+		claims := make(map[string]any)
+		if err = json.NewDecoder(resp.Body).Decode(&claims); err != nil {
+			return nil, err
+		}
+		return claims, nil
+	}
+}
+
+// AccessTokenClaims returns the claims of a JWT access token of the test user, which contains the claims
+// added by the actions of the [TriggerPreAccessTokenCreation]. The token must be issued after the deployment,
+// so the token func is called for every attempt, e.g. to execute a client credentials grant of a machine user.
+// The signature is not verified, the claims are only used for the test.
+func AccessTokenClaims(token func(ctx context.Context) (string, error)) ClaimsFunc {
+	return func(ctx context.Context) (map[string]any, error) {
+		accessToken, err := token(ctx)
+		if err != nil {
+			return nil, err
+		}
+		parts := strings.Split(accessToken, ".")
+		if len(parts) != 3 {
+			return nil, errors.New("access token is not a JWT")
+		}
+		payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		claims := make(map[string]any)
+		if err = json.Unmarshal(payload, &claims); err != nil {
+			return nil, err
+		}
+		return claims, nil
+	}
+}
+
+// ClaimTest verifies the claims of a test user after the actions are deployed.
+type ClaimTest struct {
+	Name   string
+	Claims ClaimsFunc
+	// Want contains the expected claims, other claims are ignored.
+	Want map[string]any
+	// Absent contains the claims which must not be set, e.g. if the action removes them.
+	Absent []string
+}
+
+// TestResult is the result of a [ClaimTest] of the last attempt.
+type TestResult struct {
+	Name     string
+	Claims   map[string]any
+	Failures []string
+}
+
+// Passed returns whether the claims matched the expectations.
+func (r *TestResult) Passed() bool {
+	return len(r.Failures) == 0
+}
+
+// TestOption allows customization of [DeployAndTest].
+type TestOption func(*testOptions)
+
+type testOptions struct {
+	attempts int
+	interval time.Duration
+}
+
+// WithAttempts sets how often a failing test is repeated and the interval in between (default 5 attempts, every second).
+// ZITADEL applies changed actions and flows asynchronously, so the first attempt might still use the previous scripts.
+func WithAttempts(attempts int, interval time.Duration) TestOption {
+	return func(o *testOptions) {
+		o.attempts = attempts
+		o.interval = interval
+	}
+}
+
+// DeployAndTest deploys the actions to the trigger of the flow (see [Deploy]) and verifies their effect
+// on the claims of test users, e.g. in a CI pipeline before the scripts are deployed to production:
+//
+//	scripts, err := actions.LoadDir(os.DirFS("actions"), "complement-token")
+//	results, err := actions.DeployAndTest(ctx, api, testOrgID, actions.FlowComplementToken, actions.TriggerPreUserinfoCreation, scripts,
+//		actions.ClaimTest{
+//			Name:   "groups",
+//			Claims: actions.UserinfoClaims(testUserClient, api.Origin()),
+//			Want:   map[string]any{"groups": []string{"admins"}},
+//		},
+//	)
+//
+// ZITADEL has no dry-run of actions, so the actions are executed for real in the organization,
+// which should therefore be a dedicated test organization (or instance) holding the test users.
+// The results of all tests are returned, if any of them failed, together with an error wrapping [ErrTestFailed].
+func DeployAndTest(ctx context.Context, c Client, orgID string, flow FlowType, trigger TriggerType, actions []*Action, tests []ClaimTest, opts ...TestOption) ([]*TestResult, error) {
+	o := &testOptions{attempts: 5, interval: time.Second}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if _, err := Deploy(ctx, c, orgID, flow, trigger, actions...); err != nil {
+		return nil, err
+	}
+	results := make([]*TestResult, len(tests))
+	var failed []string
+	for i, test := range tests {
+		result, err := runTest(ctx, test, o)
+		if err != nil {
+			return nil, fmt.Errorf("test `%s`: %w", test.Name, err)
+		}
+		results[i] = result
+		if !result.Passed() {
+			failed = append(failed, fmt.Sprintf("%s: %s", test.Name, strings.Join(result.Failures, ", ")))
+		}
+	}
+	if len(failed) > 0 {
+		return results, fmt.Errorf("%w: %s", ErrTestFailed, strings.Join(failed, "; "))
+	}
+	return results, nil
+}
+
+// runTest executes the test until it passes or the attempts are exhausted.
+// Errors fetching the claims are returned immediately, as they are not caused by the actions.
+func runTest(ctx context.Context, test ClaimTest, o *testOptions) (*TestResult, error) {
+	want, err := normalizeClaims(test.Want)
+	if err != nil {
+		return nil, err
+	}
+	result := &TestResult{Name: test.Name}
+	for attempt := 1; ; attempt++ {
+		result.Claims, err = test.Claims(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result.Failures = compareClaims(result.Claims, want, test.Absent)
+		if result.Passed() || attempt >= o.attempts {
+			return result, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(o.interval):
+		}
+	}
+}
+
+// normalizeClaims converts the expected claims into their JSON representation (e.g. numbers into float64),
+// so they can be compared with the decoded claims.
+func normalizeClaims(claims map[string]any) (map[string]any, error) {
+	data, err := json.Marshal(claims)
+	if err != nil {
+		return nil, err
+	}
+	normalized := make(map[string]any)
+	if err = json.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+func compareClaims(claims, want map[string]any, absent []string) []string {
+	var failures []string
+	for key, value := range want {
+		got, ok := claims[key]
+		switch {
+		case !ok:
+			failures = append(failures, fmt.Sprintf("missing claim `%s`", key))
+		case !reflect.DeepEqual(got, value):
+			failures = append(failures, fmt.Sprintf("claim `%s` is %v, want %v", key, got, value))
+		}
+	}
+	for _, key := range absent {
+		if _, ok := claims[key]; ok {
+			failures = append(failures, fmt.Sprintf("unexpected claim `%s`", key))
+		}
+	}
+	// map iteration is random, so the failures are sorted for a stable output
+	sort.Strings(failures)
+	return failures
+}
@@ -0,0 +1,91 @@
+package actions
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadDir(t *testing.T) {
+	fsys := fstest.MapFS{
+		"flows/token/b.js":     {Data: []byte("function b(ctx, api) {}")},
+		"flows/token/a.js":     {Data: []byte("function a(ctx, api) {}")},
+		"flows/token/README":   {Data: []byte("docs")},
+		"flows/token/lib/c.js": {Data: []byte("function c(ctx, api) {}")},
+	}
+	actions, err := LoadDir(fsys, "flows/token", WithTimeout(time.Second), WithAllowedToFail())
+	require.NoError(t, err)
+	assert.Equal(t, []*Action{
+		{Name: "a", Script: "function a(ctx, api) {}", Timeout: time.Second, AllowedToFail: true},
+		{Name: "b", Script: "function b(ctx, api) {}", Timeout: time.Second, AllowedToFail: true},
+	}, actions)
+
+	_, err = LoadFile(fsys, "flows/token/missing.js")
+	assert.Error(t, err)
+}
+
+func TestDeployAndTest(t *testing.T) {
+	// the first userinfo response does not yet contain the claim of the deployed action
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, userinfoPath, r.URL.Path)
+		requests++
+		if requests == 1 {
+			_, _ = w.Write([]byte(`{"sub":"user","legacy":true}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"sub":"user","groups":["admins"],"level":2}`))
+	}))
+	defer server.Close()
+	jwt := func(payload string) func(context.Context) (string, error) {
+		return func(context.Context) (string, error) {
+			return "header." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".signature", nil
+		}
+	}
+	a := &Action{Name: "groups", Script: "function groups(ctx, api) {}"}
+	tests := []struct {
+		name     string
+		test     ClaimTest
+		wantErr  error
+		failures []string
+	}{
+		{
+			name: "userinfo, retried until passed",
+			test: ClaimTest{
+				Name:   "userinfo",
+				Claims: UserinfoClaims(server.Client(), server.URL+"/"),
+				Want:   map[string]any{"groups": []string{"admins"}, "level": 2},
+				Absent: []string{"legacy"},
+			},
+		},
+		{
+			name: "access token, failed",
+			test: ClaimTest{
+				Name:   "access token",
+				Claims: AccessTokenClaims(jwt(`{"sub":"user","groups":["users"],"legacy":true}`)),
+				Want:   map[string]any{"groups": []string{"admins"}, "level": 2},
+				Absent: []string{"legacy"},
+			},
+			wantErr:  ErrTestFailed,
+			failures: []string{"claim `groups` is [users], want [admins]", "missing claim `level`", "unexpected claim `legacy`"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgmt := new(managementService)
+			results, err := DeployAndTest(context.Background(), &testClient{management: mgmt}, "org", FlowComplementToken, TriggerPreUserinfoCreation,
+				[]*Action{a}, []ClaimTest{tt.test}, WithAttempts(2, time.Millisecond))
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, []string{"CreateAction groups", "SetTriggerActions"}, mgmt.calls)
+			require.Len(t, results, 1)
+			assert.Equal(t, tt.failures, results[0].Failures)
+		})
+	}
+}
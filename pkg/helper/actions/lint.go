@@ -0,0 +1,287 @@
+package actions
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// MaxTimeout is the maximum execution time of an action allowed by ZITADEL.
+const MaxTimeout = 20 * time.Second
+
+var (
+	ErrInvalidScript = errors.New("invalid action script")
+)
+
+var identifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// unsupported contains keywords of language features not supported by the JavaScript runtime of ZITADEL.
+var unsupported = map[string]string{
+	"import": "modules are not supported, use require() for the provided modules",
+	"export": "modules are not supported, the function with the name of the action is called",
+	"async":  "async functions are not supported",
+	"await":  "async functions are not supported",
+}
+
+// regexpPrecedingKeywords are keywords after which a `/` starts a regular expression instead of a division.
+var regexpPrecedingKeywords = map[string]struct{}{
+	"return": {}, "typeof": {}, "instanceof": {}, "in": {}, "of": {}, "new": {},
+	"delete": {}, "void": {}, "throw": {}, "case": {}, "do": {}, "else": {},
+}
+
+// Lint checks the action for common mistakes before it is uploaded to ZITADEL:
+//   - the name must be a valid JavaScript identifier
+//   - the script must declare a function with the name of the action, which is called by ZITADEL
+//   - brackets, strings, template literals and comments must be closed
+//   - modules (import / export) and async functions are not supported by the runtime
+//   - the timeout must not exceed [MaxTimeout]
+//
+// It is not a full parser, so a successful lint does not guarantee the script to execute.
+// All findings are returned as a single error wrapping [ErrInvalidScript].
+func Lint(a *Action) error {
+	var findings []string
+	if !identifier.MatchString(a.Name) {
+		findings = append(findings, fmt.Sprintf("name `%s` is not a valid function name", a.Name))
+	}
+	if a.Timeout < 0 || a.Timeout > MaxTimeout {
+		findings = append(findings, fmt.Sprintf("timeout %s must be between 0 and %s", a.Timeout, MaxTimeout))
+	}
+	tokens, err := scan(a.Script)
+	if err != nil {
+		findings = append(findings, err.Error())
+	}
+	declared := false
+	for i, token := range tokens {
+		if reason, ok := unsupported[token.value]; ok {
+			findings = append(findings, fmt.Sprintf("line %d: `%s`: %s", token.line, token.value, reason))
+		}
+		if token.value == "function" && i+1 < len(tokens) && tokens[i+1].value == a.Name {
+			declared = true
+		}
+	}
+	if !declared {
+		findings = append(findings, fmt.Sprintf("missing function `%s`", a.Name))
+	}
+	if len(findings) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w `%s`: %s", ErrInvalidScript, a.Name, strings.Join(findings, "; "))
+}
+
+// token is an identifier (or keyword) of the script outside of strings and comments.
+type token struct {
+	value string
+	line  int
+}
+
+// scan tokenizes the identifiers of the script and checks that brackets, strings,
+// template literals, regular expressions and comments are closed.
+func scan(script string) ([]token, error) {
+	s := &scanner{src: []rune(script), line: 1}
+	return s.run()
+}
+
+type scanner struct {
+	src  []rune
+	pos  int
+	line int
+	// brackets contains the open brackets with their line; a '`' marks a `${` of a template literal.
+	brackets []rune
+	lines    []int
+	tokens   []token
+	// regexpAllowed is whether a `/` at the current position would start a regular expression.
+	regexpAllowed bool
+}
+
+func (s *scanner) run() ([]token, error) {
+	s.regexpAllowed = true
+	for s.pos < len(s.src) {
+		r := s.src[s.pos]
+		switch {
+		case r == '\n':
+			s.line++
+			s.pos++
+		case unicode.IsSpace(r):
+			s.pos++
+		case r == '/' && s.peek(1) == '/':
+			for s.pos < len(s.src) && s.src[s.pos] != '\n' {
+				s.pos++
+			}
+		case r == '/' && s.peek(1) == '*':
+			start := s.line
+			s.pos += 2
+			if !s.skipUntil("*/") {
+				return s.tokens, fmt.Errorf("line %d: unclosed comment", start)
+			}
+		case r == '\'' || r == '"':
+			if err := s.string(r); err != nil {
+				return s.tokens, err
+			}
+			s.regexpAllowed = false
+		case r == '`':
+			s.pos++
+			if err := s.template(); err != nil {
+				return s.tokens, err
+			}
+		case r == '/' && s.regexpAllowed:
+			if err := s.regexp(); err != nil {
+				return s.tokens, err
+			}
+			s.regexpAllowed = false
+		case r == '(' || r == '[' || r == '{':
+			s.brackets = append(s.brackets, r)
+			s.lines = append(s.lines, s.line)
+			s.pos++
+			s.regexpAllowed = true
+		case r == ')' || r == ']' || r == '}':
+			if err := s.close(r); err != nil {
+				return s.tokens, err
+			}
+		case r == '_' || r == '$' || unicode.IsLetter(r):
+			start := s.pos
+			for s.pos < len(s.src) && (s.src[s.pos] == '_' || s.src[s.pos] == '$' || unicode.IsLetter(s.src[s.pos]) || unicode.IsDigit(s.src[s.pos])) {
+				s.pos++
+			}
+			value := string(s.src[start:s.pos])
+			s.tokens = append(s.tokens, token{value: value, line: s.line})
+			_, s.regexpAllowed = regexpPrecedingKeywords[value]
+		case unicode.IsDigit(r):
+			for s.pos < len(s.src) && (unicode.IsDigit(s.src[s.pos]) || unicode.IsLetter(s.src[s.pos]) || s.src[s.pos] == '.') {
+				s.pos++
+			}
+			s.regexpAllowed = false
+		default:
+			s.pos++
+			s.regexpAllowed = true
+		}
+	}
+	if len(s.brackets) > 0 {
+		last := len(s.brackets) - 1
+		if s.brackets[last] == '`' {
+			return s.tokens, fmt.Errorf("line %d: unclosed template literal", s.lines[last])
+		}
+		return s.tokens, fmt.Errorf("line %d: unclosed `%c`", s.lines[last], s.brackets[last])
+	}
+	return s.tokens, nil
+}
+
+func (s *scanner) peek(n int) rune {
+	if s.pos+n >= len(s.src) {
+		return 0
+	}
+	return s.src[s.pos+n]
+}
+
+// skipUntil moves the position after the next occurrence of the end and returns whether it was found.
+func (s *scanner) skipUntil(end string) bool {
+	for s.pos < len(s.src) {
+		if strings.HasPrefix(string(s.src[s.pos:min(s.pos+len(end), len(s.src))]), end) {
+			s.pos += len(end)
+			return true
+		}
+		if s.src[s.pos] == '\n' {
+			s.line++
+		}
+		s.pos++
+	}
+	return false
+}
+
+func (s *scanner) close(r rune) error {
+	open := map[rune]rune{')': '(', ']': '[', '}': '{'}[r]
+	if len(s.brackets) == 0 {
+		return fmt.Errorf("line %d: unexpected `%c`", s.line, r)
+	}
+	last := len(s.brackets) - 1
+	top := s.brackets[last]
+	s.brackets, s.lines = s.brackets[:last], s.lines[:last]
+	s.pos++
+	if top == '`' && r == '}' {
+		// end of a template literal expression, continue with the literal itself
+		return s.template()
+	}
+	if top != open {
+		return fmt.Errorf("line %d: unexpected `%c`", s.line, r)
+	}
+	s.regexpAllowed = r == '}'
+	return nil
+}
+
+func (s *scanner) string(quote rune) error {
+	start := s.line
+	s.pos++
+	for s.pos < len(s.src) {
+		switch s.src[s.pos] {
+		case '\\':
+			s.pos += 2
+			continue
+		case '\n':
+			return fmt.Errorf("line %d: unclosed string", start)
+		case quote:
+			s.pos++
+			return nil
+		}
+		s.pos++
+	}
+	return fmt.Errorf("line %d: unclosed string", start)
+}
+
+// template scans a template literal (after the opening backtick) until its end or the start of an expression.
+func (s *scanner) template() error {
+	start := s.line
+	for s.pos < len(s.src) {
+		switch s.src[s.pos] {
+		case '\\':
+			s.pos += 2
+			continue
+		case '\n':
+			s.line++
+		case '`':
+			s.pos++
+			s.regexpAllowed = false
+			return nil
+		case '$':
+			if s.peek(1) == '{' {
+				s.brackets = append(s.brackets, '`')
+				s.lines = append(s.lines, start)
+				s.pos += 2
+				s.regexpAllowed = true
+				return nil
+			}
+		}
+		s.pos++
+	}
+	return fmt.Errorf("line %d: unclosed template literal", start)
+}
+
+func (s *scanner) regexp() error {
+	start := s.line
+	s.pos++
+	inClass := false
+	for s.pos < len(s.src) {
+		switch s.src[s.pos] {
+		case '\\':
+			s.pos += 2
+			continue
+		case '\n':
+			return fmt.Errorf("line %d: unclosed regular expression", start)
+		case '[':
+			inClass = true
+		case ']':
+			inClass = false
+		case '/':
+			if !inClass {
+				s.pos++
+				for s.pos < len(s.src) && unicode.IsLetter(s.src[s.pos]) {
+					s.pos++
+				}
+				return nil
+			}
+		}
+		s.pos++
+	}
+	return fmt.Errorf("line %d: unclosed regular expression", start)
+}
@@ -0,0 +1,129 @@
+package actions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name        string
+		action      *Action
+		wantErr     bool
+		wantMessage string
+	}{
+		{
+			name: "valid",
+			action: &Action{
+				Name: "addGroupsClaim",
+				Script: `/**
+ * sets the groups claim {of the user}
+ */
+function addGroupsClaim(ctx, api) {
+	let metadata = ctx.v1.user.getMetadata();
+	// ignore (unbalanced in comments
+	let groups = metadata.metadata
+		.filter(md => /^group[:_]/i.test(md.key))
+		.map(md => ` + "`${md.key.replace(/[/]/g, '')}: ${md.value}`" + `);
+	api.v1.claims.setClaim('groups', groups.length / 2 > 0 ? groups : ["none)"]);
+}`,
+				Timeout: 10 * time.Second,
+			},
+		},
+		{
+			name: "invalid name",
+			action: &Action{
+				Name:   "add-groups",
+				Script: "function add-groups(ctx, api) {}",
+			},
+			wantErr:     true,
+			wantMessage: "name `add-groups` is not a valid function name",
+		},
+		{
+			name: "missing function",
+			action: &Action{
+				Name:   "addGroups",
+				Script: "function addGroupsClaim(ctx, api) {}",
+			},
+			wantErr:     true,
+			wantMessage: "missing function `addGroups`",
+		},
+		{
+			name: "timeout too long",
+			action: &Action{
+				Name:    "addGroups",
+				Script:  "function addGroups(ctx, api) {}",
+				Timeout: time.Minute,
+			},
+			wantErr:     true,
+			wantMessage: "timeout 1m0s must be between 0 and 20s",
+		},
+		{
+			name: "unclosed bracket",
+			action: &Action{
+				Name:   "addGroups",
+				Script: "function addGroups(ctx, api) {\n\tif (ctx) {\n}",
+			},
+			wantErr:     true,
+			wantMessage: "line 1: unclosed `{`",
+		},
+		{
+			name: "mismatched bracket",
+			action: &Action{
+				Name:   "addGroups",
+				Script: "function addGroups(ctx, api) {\n\tapi.set([1, 2)];\n}",
+			},
+			wantErr:     true,
+			wantMessage: "line 2: unexpected `)`",
+		},
+		{
+			name: "unclosed string",
+			action: &Action{
+				Name:   "addGroups",
+				Script: "function addGroups(ctx, api) {\n\tapi.set('groups);\n}",
+			},
+			wantErr:     true,
+			wantMessage: "line 2: unclosed string",
+		},
+		{
+			name: "unclosed template literal",
+			action: &Action{
+				Name:   "addGroups",
+				Script: "function addGroups(ctx, api) {\n\tapi.set(`${ctx.v1}`);\n\tapi.set(`${ctx.v1);\n}",
+			},
+			wantErr:     true,
+			wantMessage: "line 3: unexpected `)`",
+		},
+		{
+			name: "unclosed comment",
+			action: &Action{
+				Name:   "addGroups",
+				Script: "function addGroups(ctx, api) {}\n/* end",
+			},
+			wantErr:     true,
+			wantMessage: "line 2: unclosed comment",
+		},
+		{
+			name: "unsupported features",
+			action: &Action{
+				Name:   "addGroups",
+				Script: "import http from 'http';\nexport async function addGroups(ctx, api) {\n\tawait http.fetch('url');\n}",
+			},
+			wantErr:     true,
+			wantMessage: "line 1: `import`: modules are not supported",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Lint(tt.action)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrInvalidScript)
+			assert.ErrorContains(t, err, tt.wantMessage)
+		})
+	}
+}
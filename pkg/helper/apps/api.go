@@ -0,0 +1,133 @@
+package apps
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/oidc/v3/pkg/client"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/app"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/authn"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	object "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/org"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/query"
+)
+
+// APIAuthMethod defines how an API application authenticates, e.g. when calling the introspection endpoint.
+type APIAuthMethod = app.APIAuthMethodType
+
+const (
+	// APIAuthBasic authenticates with the client_id and client_secret as basic auth.
+	APIAuthBasic = app.APIAuthMethodType_API_AUTH_METHOD_TYPE_BASIC
+	// APIAuthPrivateKeyJWT authenticates with a JWT signed with an application key (key.json).
+	APIAuthPrivateKeyJWT = app.APIAuthMethodType_API_AUTH_METHOD_TYPE_PRIVATE_KEY_JWT
+)
+
+// APIApp contains the information about a newly created API application.
+// The ClientSecret is only returned for the [APIAuthBasic] method.
+// Note that ZITADEL does not allow to retrieve the secret later on, use [RegenerateAPIClientSecret] instead.
+type APIApp struct {
+	AppID        string
+	ClientID     string
+	ClientSecret string
+}
+
+// CreateAPI creates a new API (machine-to-machine) application in the project using the provided auth method.
+func CreateAPI(ctx context.Context, c Client, orgID, projectID, name string, authMethod APIAuthMethod) (*APIApp, error) {
+	resp, err := c.ManagementService().AddAPIApp(org.Context(ctx, orgID), &management.AddAPIAppRequest{
+		ProjectId:      projectID,
+		Name:           name,
+		AuthMethodType: authMethod,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &APIApp{
+		AppID:        resp.GetAppId(),
+		ClientID:     resp.GetClientId(),
+		ClientSecret: resp.GetClientSecret(),
+	}, nil
+}
+
+// UpdateAPIAuthMethod changes the auth method of an existing API application.
+func UpdateAPIAuthMethod(ctx context.Context, c Client, orgID, projectID, appID string, authMethod APIAuthMethod) error {
+	_, err := c.ManagementService().UpdateAPIAppConfig(org.Context(ctx, orgID), &management.UpdateAPIAppConfigRequest{
+		ProjectId:      projectID,
+		AppId:          appID,
+		AuthMethodType: authMethod,
+	})
+	return err
+}
+
+// RegenerateAPIClientSecret generates a new client_secret for an API application using [APIAuthBasic].
+// The previous secret is invalidated immediately.
+func RegenerateAPIClientSecret(ctx context.Context, c Client, orgID, projectID, appID string) (string, error) {
+	resp, err := c.ManagementService().RegenerateAPIClientSecret(org.Context(ctx, orgID), &management.RegenerateAPIClientSecretRequest{
+		ProjectId: projectID,
+		AppId:     appID,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.GetClientSecret(), nil
+}
+
+// Key is a newly created application key.
+type Key struct {
+	ID string
+	// JSON is the content of the key.json as provided by ZITADEL. It's only returned on creation.
+	JSON []byte
+}
+
+// KeyFile parses the JSON of the key, e.g. to be used for [oauth.JWTProfileIntrospectionAuthentication].
+func (k *Key) KeyFile() (*client.KeyFile, error) {
+	return client.ConfigFromKeyFileData(k.JSON)
+}
+
+// AddKey creates a new key for an application using the private_key_jwt auth method (API or OIDC).
+// If the expiration is zero, ZITADEL will set the maximum possible expiration.
+func AddKey(ctx context.Context, c Client, orgID, projectID, appID string, expiration time.Time) (*Key, error) {
+	req := &management.AddAppKeyRequest{
+		ProjectId: projectID,
+		AppId:     appID,
+		Type:      authn.KeyType_KEY_TYPE_JSON,
+	}
+	if !expiration.IsZero() {
+		req.ExpirationDate = timestamppb.New(expiration)
+	}
+	resp, err := c.ManagementService().AddAppKey(org.Context(ctx, orgID), req)
+	if err != nil {
+		return nil, err
+	}
+	return &Key{
+		ID:   resp.GetId(),
+		JSON: resp.GetKeyDetails(),
+	}, nil
+}
+
+// ListKeys returns all keys of the application. The key material itself is not returned.
+func ListKeys(ctx context.Context, c Client, orgID, projectID, appID string) ([]*authn.Key, error) {
+	return query.All(org.Context(ctx, orgID), func(ctx context.Context, offset uint64, limit uint32) ([]*authn.Key, uint64, error) {
+		resp, err := c.ManagementService().ListAppKeys(ctx, &management.ListAppKeysRequest{
+			Query:     &object.ListQuery{Offset: offset, Limit: limit, Asc: true},
+			ProjectId: projectID,
+			AppId:     appID,
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		return resp.GetResult(), resp.GetDetails().GetTotalResult(), nil
+	})
+}
+
+// RemoveKey removes the key from the application. It can no longer be used for authentication.
+func RemoveKey(ctx context.Context, c Client, orgID, projectID, appID, keyID string) error {
+	_, err := c.ManagementService().RemoveAppKey(org.Context(ctx, orgID), &management.RemoveAppKeyRequest{
+		ProjectId: projectID,
+		AppId:     appID,
+		KeyId:     keyID,
+	})
+	return err
+}
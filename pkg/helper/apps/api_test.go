@@ -0,0 +1,81 @@
+package apps
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+)
+
+func TestAddKey(t *testing.T) {
+	expiration := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name           string
+		expiration     time.Time
+		wantExpiration bool
+	}{
+		{
+			name:           "without expiration",
+			expiration:     time.Time{},
+			wantExpiration: false,
+		},
+		{
+			name:           "with expiration",
+			expiration:     expiration,
+			wantExpiration: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &managementService{}
+			key, err := AddKey(context.Background(), &testClient{service}, "", "projectID", "appID", tt.expiration)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantExpiration, service.addKeyReq.GetExpirationDate() != nil)
+			if tt.wantExpiration {
+				assert.Equal(t, expiration, service.addKeyReq.GetExpirationDate().AsTime())
+			}
+			keyFile, err := key.KeyFile()
+			require.NoError(t, err)
+			assert.Equal(t, "keyID", keyFile.KeyID)
+			assert.Equal(t, "clientID", keyFile.ClientID)
+		})
+	}
+}
+
+type testClient struct {
+	management management.ManagementServiceClient
+}
+
+func (c *testClient) ManagementService() management.ManagementServiceClient {
+	return c.management
+}
+
+type managementService struct {
+	management.ManagementServiceClient
+	addKeyReq     *management.AddAppKeyRequest
+	addOIDCReq    *management.AddOIDCAppRequest
+	updateOIDCReq *management.UpdateOIDCAppConfigRequest
+}
+
+func (s *managementService) AddOIDCApp(_ context.Context, req *management.AddOIDCAppRequest, _ ...grpc.CallOption) (*management.AddOIDCAppResponse, error) {
+	s.addOIDCReq = req
+	return &management.AddOIDCAppResponse{AppId: "appID", ClientId: "clientID"}, nil
+}
+
+func (s *managementService) UpdateOIDCAppConfig(_ context.Context, req *management.UpdateOIDCAppConfigRequest, _ ...grpc.CallOption) (*management.UpdateOIDCAppConfigResponse, error) {
+	s.updateOIDCReq = req
+	return &management.UpdateOIDCAppConfigResponse{}, nil
+}
+
+func (s *managementService) AddAppKey(_ context.Context, req *management.AddAppKeyRequest, _ ...grpc.CallOption) (*management.AddAppKeyResponse, error) {
+	s.addKeyReq = req
+	return &management.AddAppKeyResponse{
+		Id:         "keyID",
+		KeyDetails: []byte(`{"type":"application","keyId":"keyID","key":"key","appId":"appID","clientId":"clientID"}`),
+	}, nil
+}
@@ -0,0 +1,68 @@
+// Package apps provides typed helpers for the creation and management of applications (OIDC, API and SAML)
+// of a project.
+//
+// All functions take an optional orgID. If it's empty, the call is executed in the organization
+// of the authorized user.
+package apps
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/app"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/org"
+)
+
+// Client is the part of the [client.Client] used by this package.
+type Client interface {
+	ManagementService() management.ManagementServiceClient
+}
+
+// Get returns the application by its id.
+func Get(ctx context.Context, c Client, orgID, projectID, appID string) (*app.App, error) {
+	resp, err := c.ManagementService().GetAppByID(org.Context(ctx, orgID), &management.GetAppByIDRequest{
+		ProjectId: projectID,
+		AppId:     appID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetApp(), nil
+}
+
+// Rename changes the name of the application.
+func Rename(ctx context.Context, c Client, orgID, projectID, appID, name string) error {
+	_, err := c.ManagementService().UpdateApp(org.Context(ctx, orgID), &management.UpdateAppRequest{
+		ProjectId: projectID,
+		AppId:     appID,
+		Name:      name,
+	})
+	return err
+}
+
+// Deactivate deactivates the application. Users will not be able to authenticate on it.
+func Deactivate(ctx context.Context, c Client, orgID, projectID, appID string) error {
+	_, err := c.ManagementService().DeactivateApp(org.Context(ctx, orgID), &management.DeactivateAppRequest{
+		ProjectId: projectID,
+		AppId:     appID,
+	})
+	return err
+}
+
+// Reactivate reactivates a previously deactivated application.
+func Reactivate(ctx context.Context, c Client, orgID, projectID, appID string) error {
+	_, err := c.ManagementService().ReactivateApp(org.Context(ctx, orgID), &management.ReactivateAppRequest{
+		ProjectId: projectID,
+		AppId:     appID,
+	})
+	return err
+}
+
+// Remove removes the application.
+func Remove(ctx context.Context, c Client, orgID, projectID, appID string) error {
+	_, err := c.ManagementService().RemoveApp(org.Context(ctx, orgID), &management.RemoveAppRequest{
+		ProjectId: projectID,
+		AppId:     appID,
+	})
+	return err
+}
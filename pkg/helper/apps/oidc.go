@@ -0,0 +1,266 @@
+package apps
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/app"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/org"
+)
+
+var (
+	ErrMissingRedirectURI = errors.New("at least one redirect uri is required for the authorization code and implicit flow")
+)
+
+// OIDCConfig is the configuration of an OIDC application.
+// Use one of the presets ([WebPreset], [SPAPreset], [NativePreset]) and modify it with [OIDCOption] if required.
+type OIDCConfig struct {
+	AppType                  app.OIDCAppType
+	AuthMethod               app.OIDCAuthMethodType
+	ResponseTypes            []app.OIDCResponseType
+	GrantTypes               []app.OIDCGrantType
+	RedirectURIs             []string
+	PostLogoutRedirectURIs   []string
+	AdditionalOrigins        []string
+	AccessTokenType          app.OIDCTokenType
+	AccessTokenRoleAssertion bool
+	IDTokenRoleAssertion     bool
+	IDTokenUserinfoAssertion bool
+	ClockSkew                time.Duration
+	DevMode                  bool
+	SkipNativeAppSuccessPage bool
+}
+
+// OIDCOption allows modifications of an [OIDCConfig] preset.
+type OIDCOption func(*OIDCConfig)
+
+// WebPreset returns the configuration for a server side rendered web application (confidential client)
+// using the authorization code flow with refresh tokens and the client_secret as basic auth.
+func WebPreset(redirectURIs ...string) OIDCOption {
+	return func(c *OIDCConfig) {
+		c.AppType = app.OIDCAppType_OIDC_APP_TYPE_WEB
+		c.AuthMethod = app.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_BASIC
+		c.ResponseTypes = []app.OIDCResponseType{app.OIDCResponseType_OIDC_RESPONSE_TYPE_CODE}
+		c.GrantTypes = []app.OIDCGrantType{app.OIDCGrantType_OIDC_GRANT_TYPE_AUTHORIZATION_CODE, app.OIDCGrantType_OIDC_GRANT_TYPE_REFRESH_TOKEN}
+		c.RedirectURIs = redirectURIs
+	}
+}
+
+// SPAPreset returns the configuration for a single page application (public client)
+// using the authorization code flow with PKCE and JWT access tokens.
+func SPAPreset(redirectURIs ...string) OIDCOption {
+	return func(c *OIDCConfig) {
+		c.AppType = app.OIDCAppType_OIDC_APP_TYPE_USER_AGENT
+		c.AuthMethod = app.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_NONE
+		c.ResponseTypes = []app.OIDCResponseType{app.OIDCResponseType_OIDC_RESPONSE_TYPE_CODE}
+		c.GrantTypes = []app.OIDCGrantType{app.OIDCGrantType_OIDC_GRANT_TYPE_AUTHORIZATION_CODE}
+		c.AccessTokenType = app.OIDCTokenType_OIDC_TOKEN_TYPE_JWT
+		c.RedirectURIs = redirectURIs
+	}
+}
+
+// NativePreset returns the configuration for a native (mobile / desktop) application (public client)
+// using the authorization code flow with PKCE and refresh tokens.
+func NativePreset(redirectURIs ...string) OIDCOption {
+	return func(c *OIDCConfig) {
+		c.AppType = app.OIDCAppType_OIDC_APP_TYPE_NATIVE
+		c.AuthMethod = app.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_NONE
+		c.ResponseTypes = []app.OIDCResponseType{app.OIDCResponseType_OIDC_RESPONSE_TYPE_CODE}
+		c.GrantTypes = []app.OIDCGrantType{app.OIDCGrantType_OIDC_GRANT_TYPE_AUTHORIZATION_CODE, app.OIDCGrantType_OIDC_GRANT_TYPE_REFRESH_TOKEN}
+		c.RedirectURIs = redirectURIs
+	}
+}
+
+// WithPostLogoutRedirectURIs sets the allowed redirect targets after a logout.
+func WithPostLogoutRedirectURIs(uris ...string) OIDCOption {
+	return func(c *OIDCConfig) {
+		c.PostLogoutRedirectURIs = uris
+	}
+}
+
+// WithAdditionalOrigins allows additional origins (CORS) for the application, e.g. for SPAs hosted on a different domain.
+func WithAdditionalOrigins(origins ...string) OIDCOption {
+	return func(c *OIDCConfig) {
+		c.AdditionalOrigins = origins
+	}
+}
+
+// WithPrivateKeyJWT lets the (confidential) client authenticate using a JWT signed with an application key
+// instead of the client_secret.
+func WithPrivateKeyJWT() OIDCOption {
+	return func(c *OIDCConfig) {
+		c.AuthMethod = app.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_PRIVATE_KEY_JWT
+	}
+}
+
+// WithJWTAccessToken lets ZITADEL issue JWT instead of opaque (bearer) access tokens.
+func WithJWTAccessToken() OIDCOption {
+	return func(c *OIDCConfig) {
+		c.AccessTokenType = app.OIDCTokenType_OIDC_TOKEN_TYPE_JWT
+	}
+}
+
+// WithRoleAssertion adds the roles of the user to the access and id token.
+func WithRoleAssertion() OIDCOption {
+	return func(c *OIDCConfig) {
+		c.AccessTokenRoleAssertion = true
+		c.IDTokenRoleAssertion = true
+	}
+}
+
+// WithUserinfoInIDToken adds the user information (profile, email, ...) to the id token.
+func WithUserinfoInIDToken() OIDCOption {
+	return func(c *OIDCConfig) {
+		c.IDTokenUserinfoAssertion = true
+	}
+}
+
+// WithClockSkew allows a clock skew of the client, which will be added to the validity of the tokens.
+func WithClockSkew(skew time.Duration) OIDCOption {
+	return func(c *OIDCConfig) {
+		c.ClockSkew = skew
+	}
+}
+
+// WithDevMode allows insecure (http) and non-compliant redirect uris, e.g. for local development.
+func WithDevMode() OIDCOption {
+	return func(c *OIDCConfig) {
+		c.DevMode = true
+	}
+}
+
+// WithSkipNativeAppSuccessPage redirects users of a native application directly to the app after the login,
+// instead of showing the success page of the Login UI first.
+func WithSkipNativeAppSuccessPage() OIDCOption {
+	return func(c *OIDCConfig) {
+		c.SkipNativeAppSuccessPage = true
+	}
+}
+
+// OIDCApp contains the information about a newly created OIDC application.
+// The ClientSecret is only returned for confidential clients using basic or post authentication.
+type OIDCApp struct {
+	AppID              string
+	ClientID           string
+	ClientSecret       string
+	ComplianceProblems []string
+}
+
+// NewOIDCConfig returns the [OIDCConfig] resulting of the preset and options.
+// It will return an [ErrMissingRedirectURI] if the configuration uses the authorization code or implicit flow
+// without a redirect uri.
+func NewOIDCConfig(preset OIDCOption, options ...OIDCOption) (*OIDCConfig, error) {
+	config := new(OIDCConfig)
+	preset(config)
+	for _, option := range options {
+		option(config)
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func (c *OIDCConfig) validate() error {
+	if len(c.RedirectURIs) > 0 {
+		return nil
+	}
+	for _, grantType := range c.GrantTypes {
+		if grantType == app.OIDCGrantType_OIDC_GRANT_TYPE_AUTHORIZATION_CODE || grantType == app.OIDCGrantType_OIDC_GRANT_TYPE_IMPLICIT {
+			return ErrMissingRedirectURI
+		}
+	}
+	return nil
+}
+
+func (c *OIDCConfig) clockSkew() *durationpb.Duration {
+	if c.ClockSkew == 0 {
+		return nil
+	}
+	return durationpb.New(c.ClockSkew)
+}
+
+// CreateOIDC creates a new OIDC application in the project using the provided preset and options,
+// e.g. apps.CreateOIDC(ctx, c, "", projectID, "portal", apps.SPAPreset("https://portal.example.com/callback"))
+func CreateOIDC(ctx context.Context, c Client, orgID, projectID, name string, preset OIDCOption, options ...OIDCOption) (*OIDCApp, error) {
+	config, err := NewOIDCConfig(preset, options...)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.ManagementService().AddOIDCApp(org.Context(ctx, orgID), &management.AddOIDCAppRequest{
+		ProjectId:                projectID,
+		Name:                     name,
+		RedirectUris:             config.RedirectURIs,
+		ResponseTypes:            config.ResponseTypes,
+		GrantTypes:               config.GrantTypes,
+		AppType:                  config.AppType,
+		AuthMethodType:           config.AuthMethod,
+		PostLogoutRedirectUris:   config.PostLogoutRedirectURIs,
+		Version:                  app.OIDCVersion_OIDC_VERSION_1_0,
+		DevMode:                  config.DevMode,
+		AccessTokenType:          config.AccessTokenType,
+		AccessTokenRoleAssertion: config.AccessTokenRoleAssertion,
+		IdTokenRoleAssertion:     config.IDTokenRoleAssertion,
+		IdTokenUserinfoAssertion: config.IDTokenUserinfoAssertion,
+		ClockSkew:                config.clockSkew(),
+		AdditionalOrigins:        config.AdditionalOrigins,
+		SkipNativeAppSuccessPage: config.SkipNativeAppSuccessPage,
+	})
+	if err != nil {
+		return nil, err
+	}
+	problems := make([]string, len(resp.GetComplianceProblems()))
+	for i, problem := range resp.GetComplianceProblems() {
+		problems[i] = problem.GetKey()
+	}
+	return &OIDCApp{
+		AppID:              resp.GetAppId(),
+		ClientID:           resp.GetClientId(),
+		ClientSecret:       resp.GetClientSecret(),
+		ComplianceProblems: problems,
+	}, nil
+}
+
+// UpdateOIDC replaces the configuration of an existing OIDC application with the provided preset and options.
+func UpdateOIDC(ctx context.Context, c Client, orgID, projectID, appID string, preset OIDCOption, options ...OIDCOption) error {
+	config, err := NewOIDCConfig(preset, options...)
+	if err != nil {
+		return err
+	}
+	_, err = c.ManagementService().UpdateOIDCAppConfig(org.Context(ctx, orgID), &management.UpdateOIDCAppConfigRequest{
+		ProjectId:                projectID,
+		AppId:                    appID,
+		RedirectUris:             config.RedirectURIs,
+		ResponseTypes:            config.ResponseTypes,
+		GrantTypes:               config.GrantTypes,
+		AppType:                  config.AppType,
+		AuthMethodType:           config.AuthMethod,
+		PostLogoutRedirectUris:   config.PostLogoutRedirectURIs,
+		DevMode:                  config.DevMode,
+		AccessTokenType:          config.AccessTokenType,
+		AccessTokenRoleAssertion: config.AccessTokenRoleAssertion,
+		IdTokenRoleAssertion:     config.IDTokenRoleAssertion,
+		IdTokenUserinfoAssertion: config.IDTokenUserinfoAssertion,
+		ClockSkew:                config.clockSkew(),
+		AdditionalOrigins:        config.AdditionalOrigins,
+		SkipNativeAppSuccessPage: config.SkipNativeAppSuccessPage,
+	})
+	return err
+}
+
+// RegenerateOIDCClientSecret generates a new client_secret for a confidential OIDC application.
+// The previous secret is invalidated immediately.
+func RegenerateOIDCClientSecret(ctx context.Context, c Client, orgID, projectID, appID string) (string, error) {
+	resp, err := c.ManagementService().RegenerateOIDCClientSecret(org.Context(ctx, orgID), &management.RegenerateOIDCClientSecretRequest{
+		ProjectId: projectID,
+		AppId:     appID,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.GetClientSecret(), nil
+}
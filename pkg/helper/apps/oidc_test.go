@@ -0,0 +1,95 @@
+package apps
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/app"
+)
+
+func TestNewOIDCConfig(t *testing.T) {
+	type args struct {
+		preset  OIDCOption
+		options []OIDCOption
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *OIDCConfig
+		wantErr error
+	}{
+		{
+			name: "missing redirect uri, error",
+			args: args{
+				preset: SPAPreset(),
+			},
+			wantErr: ErrMissingRedirectURI,
+		},
+		{
+			name: "spa",
+			args: args{
+				preset: SPAPreset("https://app.example.com/callback"),
+			},
+			want: &OIDCConfig{
+				AppType:         app.OIDCAppType_OIDC_APP_TYPE_USER_AGENT,
+				AuthMethod:      app.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_NONE,
+				ResponseTypes:   []app.OIDCResponseType{app.OIDCResponseType_OIDC_RESPONSE_TYPE_CODE},
+				GrantTypes:      []app.OIDCGrantType{app.OIDCGrantType_OIDC_GRANT_TYPE_AUTHORIZATION_CODE},
+				AccessTokenType: app.OIDCTokenType_OIDC_TOKEN_TYPE_JWT,
+				RedirectURIs:    []string{"https://app.example.com/callback"},
+			},
+		},
+		{
+			name: "web with options",
+			args: args{
+				preset: WebPreset("https://app.example.com/callback"),
+				options: []OIDCOption{
+					WithPrivateKeyJWT(),
+					WithRoleAssertion(),
+					WithPostLogoutRedirectURIs("https://app.example.com"),
+					WithClockSkew(time.Second),
+				},
+			},
+			want: &OIDCConfig{
+				AppType:                  app.OIDCAppType_OIDC_APP_TYPE_WEB,
+				AuthMethod:               app.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_PRIVATE_KEY_JWT,
+				ResponseTypes:            []app.OIDCResponseType{app.OIDCResponseType_OIDC_RESPONSE_TYPE_CODE},
+				GrantTypes:               []app.OIDCGrantType{app.OIDCGrantType_OIDC_GRANT_TYPE_AUTHORIZATION_CODE, app.OIDCGrantType_OIDC_GRANT_TYPE_REFRESH_TOKEN},
+				RedirectURIs:             []string{"https://app.example.com/callback"},
+				PostLogoutRedirectURIs:   []string{"https://app.example.com"},
+				AccessTokenRoleAssertion: true,
+				IDTokenRoleAssertion:     true,
+				ClockSkew:                time.Second,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewOIDCConfig(tt.args.preset, tt.args.options...)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCreateOIDC_skipNativeAppSuccessPage(t *testing.T) {
+	for _, skip := range []bool{false, true} {
+		var options []OIDCOption
+		if skip {
+			options = append(options, WithSkipNativeAppSuccessPage())
+		}
+		service := &managementService{}
+		created, err := CreateOIDC(context.Background(), &testClient{service}, "", "projectID", "cli", NativePreset("http://localhost:8080/callback"), options...)
+		require.NoError(t, err)
+		assert.Equal(t, "clientID", created.ClientID)
+		assert.Equal(t, skip, service.addOIDCReq.GetSkipNativeAppSuccessPage())
+
+		err = UpdateOIDC(context.Background(), &testClient{service}, "", "projectID", "appID", NativePreset("http://localhost:8080/callback"), options...)
+		require.NoError(t, err)
+		assert.Equal(t, skip, service.updateOIDCReq.GetSkipNativeAppSuccessPage())
+	}
+}
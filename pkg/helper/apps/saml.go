@@ -0,0 +1,177 @@
+package apps
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/org"
+	"github.com/zitadel/zitadel-go/v3/pkg/zitadel"
+)
+
+const (
+	samlMetadataPath = "/saml/v2/metadata"
+)
+
+var (
+	ErrInvalidSAMLMetadata = errors.New("invalid SAML metadata")
+	ErrMissingEntityID     = errors.New("SAML metadata is missing the entityID")
+	ErrInvalidCertificate  = errors.New("invalid certificate in SAML metadata")
+	ErrExpiredCertificate  = errors.New("expired certificate in SAML metadata")
+	ErrMissingSAMLConfig   = errors.New("application is not a SAML application")
+)
+
+// SAMLMetadata is the metadata of the service provider of a SAML application,
+// either provided directly as XML or as URL where ZITADEL will retrieve it.
+// Use [SAMLMetadataXML] or [SAMLMetadataURL] to create it.
+type SAMLMetadata struct {
+	XML []byte
+	URL string
+}
+
+// SAMLMetadataXML provides the metadata of the service provider as XML.
+func SAMLMetadataXML(xml []byte) SAMLMetadata {
+	return SAMLMetadata{XML: xml}
+}
+
+// SAMLMetadataURL provides the URL where ZITADEL will retrieve the metadata of the service provider.
+func SAMLMetadataURL(url string) SAMLMetadata {
+	return SAMLMetadata{URL: url}
+}
+
+// CreateSAML creates a new SAML application in the project. XML metadata is validated using [ValidateSAMLMetadata]
+// before it's sent to ZITADEL.
+func CreateSAML(ctx context.Context, c Client, orgID, projectID, name string, metadata SAMLMetadata) (string, error) {
+	req := &management.AddSAMLAppRequest{
+		ProjectId: projectID,
+		Name:      name,
+	}
+	if metadata.URL != "" {
+		req.Metadata = &management.AddSAMLAppRequest_MetadataUrl{MetadataUrl: metadata.URL}
+	} else {
+		if _, err := ValidateSAMLMetadata(metadata.XML, time.Now()); err != nil {
+			return "", err
+		}
+		req.Metadata = &management.AddSAMLAppRequest_MetadataXml{MetadataXml: metadata.XML}
+	}
+	resp, err := c.ManagementService().AddSAMLApp(org.Context(ctx, orgID), req)
+	if err != nil {
+		return "", err
+	}
+	return resp.GetAppId(), nil
+}
+
+// UpdateSAML replaces the metadata of an existing SAML application. XML metadata is validated using [ValidateSAMLMetadata]
+// before it's sent to ZITADEL.
+func UpdateSAML(ctx context.Context, c Client, orgID, projectID, appID string, metadata SAMLMetadata) error {
+	req := &management.UpdateSAMLAppConfigRequest{
+		ProjectId: projectID,
+		AppId:     appID,
+	}
+	if metadata.URL != "" {
+		req.Metadata = &management.UpdateSAMLAppConfigRequest_MetadataUrl{MetadataUrl: metadata.URL}
+	} else {
+		if _, err := ValidateSAMLMetadata(metadata.XML, time.Now()); err != nil {
+			return err
+		}
+		req.Metadata = &management.UpdateSAMLAppConfigRequest_MetadataXml{MetadataXml: metadata.XML}
+	}
+	_, err := c.ManagementService().UpdateSAMLAppConfig(org.Context(ctx, orgID), req)
+	return err
+}
+
+// GetSAMLMetadata returns the configured service provider metadata of the SAML application.
+func GetSAMLMetadata(ctx context.Context, c Client, orgID, projectID, appID string) (SAMLMetadata, error) {
+	a, err := Get(ctx, c, orgID, projectID, appID)
+	if err != nil {
+		return SAMLMetadata{}, err
+	}
+	config := a.GetSamlConfig()
+	if config == nil {
+		return SAMLMetadata{}, ErrMissingSAMLConfig
+	}
+	return SAMLMetadata{
+		XML: config.GetMetadataXml(),
+		URL: config.GetMetadataUrl(),
+	}, nil
+}
+
+// IdentityProviderMetadata retrieves the SAML metadata of ZITADEL as identity provider,
+// which needs to be configured in the service provider.
+// If no httpClient is provided, the [http.DefaultClient] is used.
+func IdentityProviderMetadata(ctx context.Context, httpClient *http.Client, z *zitadel.Zitadel) ([]byte, error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, z.Origin()+samlMetadataPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status retrieving SAML metadata: %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// ServiceProvider contains the information parsed from the service provider metadata.
+type ServiceProvider struct {
+	EntityID     string
+	Certificates []*x509.Certificate
+}
+
+type entityDescriptor struct {
+	EntityID       string          `xml:"entityID,attr"`
+	KeyDescriptors []keyDescriptor `xml:"SPSSODescriptor>KeyDescriptor"`
+}
+
+type keyDescriptor struct {
+	Use          string   `xml:"use,attr"`
+	Certificates []string `xml:"KeyInfo>X509Data>X509Certificate"`
+}
+
+// ValidateSAMLMetadata parses the XML metadata of a service provider and checks for an entityID
+// as well as for valid and (at the provided time) not expired certificates.
+func ValidateSAMLMetadata(metadata []byte, now time.Time) (*ServiceProvider, error) {
+	descriptor := new(entityDescriptor)
+	if err := xml.Unmarshal(metadata, descriptor); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSAMLMetadata, err)
+	}
+	if descriptor.EntityID == "" {
+		return nil, ErrMissingEntityID
+	}
+	sp := &ServiceProvider{EntityID: descriptor.EntityID}
+	for _, key := range descriptor.KeyDescriptors {
+		for _, encoded := range key.Certificates {
+			certificate, err := parseCertificate(encoded)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidCertificate, err)
+			}
+			if now.Before(certificate.NotBefore) || now.After(certificate.NotAfter) {
+				return nil, fmt.Errorf("%w: `%s` valid from %s until %s", ErrExpiredCertificate, certificate.Subject, certificate.NotBefore, certificate.NotAfter)
+			}
+			sp.Certificates = append(sp.Certificates, certificate)
+		}
+	}
+	return sp, nil
+}
+
+func parseCertificate(encoded string) (*x509.Certificate, error) {
+	der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(encoded), ""))
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(der)
+}
@@ -0,0 +1,99 @@
+package apps
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSAMLMetadata(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	certificate := testCertificate(t, now.Add(-time.Hour), now.Add(time.Hour))
+	tests := []struct {
+		name         string
+		metadata     []byte
+		now          time.Time
+		wantEntityID string
+		wantCerts    int
+		wantErr      error
+	}{
+		{
+			name:     "invalid xml",
+			metadata: []byte(`<EntityDescriptor`),
+			now:      now,
+			wantErr:  ErrInvalidSAMLMetadata,
+		},
+		{
+			name:     "missing entityID",
+			metadata: []byte(`<EntityDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata"></EntityDescriptor>`),
+			now:      now,
+			wantErr:  ErrMissingEntityID,
+		},
+		{
+			name:     "invalid certificate",
+			metadata: testMetadata("invalid"),
+			now:      now,
+			wantErr:  ErrInvalidCertificate,
+		},
+		{
+			name:     "expired certificate",
+			metadata: testMetadata(certificate),
+			now:      now.Add(2 * time.Hour),
+			wantErr:  ErrExpiredCertificate,
+		},
+		{
+			name:         "valid",
+			metadata:     testMetadata(certificate),
+			now:          now,
+			wantEntityID: "https://sp.example.com",
+			wantCerts:    1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateSAMLMetadata(tt.metadata, tt.now)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr != nil {
+				return
+			}
+			assert.Equal(t, tt.wantEntityID, got.EntityID)
+			assert.Len(t, got.Certificates, tt.wantCerts)
+		})
+	}
+}
+
+func testMetadata(certificate string) []byte {
+	return []byte(fmt.Sprintf(`<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" xmlns:ds="http://www.w3.org/2000/09/xmldsig#" entityID="https://sp.example.com">
+	<md:SPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+		<md:KeyDescriptor use="signing">
+			<ds:KeyInfo><ds:X509Data><ds:X509Certificate>
+				%s
+			</ds:X509Certificate></ds:X509Data></ds:KeyInfo>
+		</md:KeyDescriptor>
+	</md:SPSSODescriptor>
+</md:EntityDescriptor>`, certificate))
+}
+
+func testCertificate(t *testing.T, notBefore, notAfter time.Time) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sp.example.com"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(der)
+}
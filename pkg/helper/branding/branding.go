@@ -0,0 +1,364 @@
+// Package branding provides typed helpers for the label policy (branding) of the instance and organizations,
+// including the upload of the logos, icons and font through the assets API.
+//
+// Changes to the label policy and its assets are first applied to a preview and need to be activated
+// with [Activate] to be shown to the users. [Apply] will do all three steps at once.
+package branding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/admin"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/policy"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/org"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/policies"
+)
+
+var (
+	ErrInvalidColor = errors.New("invalid color, expected hex value such as #5469d4")
+	ErrAssetUpload  = errors.New("unable to upload asset")
+	ErrAssetType    = errors.New("unknown asset type")
+)
+
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// Client is the part of the [client.Client] used by this package.
+type Client interface {
+	AdminService() admin.AdminServiceClient
+	ManagementService() management.ManagementServiceClient
+	HTTPClient() *http.Client
+	Origin() string
+}
+
+// ThemeMode defines which themes (light and / or dark) are available in the login UI.
+type ThemeMode = policy.ThemeMode
+
+const (
+	ThemeModeAuto  = policy.ThemeMode_THEME_MODE_AUTO
+	ThemeModeDark  = policy.ThemeMode_THEME_MODE_DARK
+	ThemeModeLight = policy.ThemeMode_THEME_MODE_LIGHT
+)
+
+// Colors of a theme as hex values (e.g. #5469d4).
+type Colors struct {
+	Primary    string
+	Warn       string
+	Background string
+	Font       string
+}
+
+func (c Colors) validate() error {
+	for _, color := range []string{c.Primary, c.Warn, c.Background, c.Font} {
+		if color != "" && !hexColor.MatchString(color) {
+			return fmt.Errorf("%w: `%s`", ErrInvalidColor, color)
+		}
+	}
+	return nil
+}
+
+// LabelPolicy is the branding shown in the login UI and emails.
+type LabelPolicy struct {
+	Light               Colors
+	Dark                Colors
+	ThemeMode           ThemeMode
+	HideLoginNameSuffix bool
+	DisableWatermark    bool
+	// The asset URLs are set when reading the policy and ignored on updates.
+	// Use [Upload] and [RemoveAsset] to change them.
+	LogoURL     string
+	LogoURLDark string
+	IconURL     string
+	IconURLDark string
+	FontURL     string
+	// IsDefault is set if an organization has no own policy and therefore uses the default of the instance.
+	// It's ignored on updates.
+	IsDefault bool
+}
+
+// Validate checks the colors of the policy, so misconfigurations are detected before calling the API.
+func (p *LabelPolicy) Validate() error {
+	if err := p.Light.validate(); err != nil {
+		return err
+	}
+	return p.Dark.validate()
+}
+
+// Get returns the active (effective) label policy of the level.
+func Get(ctx context.Context, c Client, level policies.Level) (*LabelPolicy, error) {
+	if level.IsInstance() {
+		resp, err := c.AdminService().GetLabelPolicy(ctx, &admin.GetLabelPolicyRequest{})
+		if err != nil {
+			return nil, err
+		}
+		return labelPolicyFromProto(resp.GetPolicy()), nil
+	}
+	resp, err := c.ManagementService().GetLabelPolicy(org.Context(ctx, level.OrgID()), &management.GetLabelPolicyRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return labelPolicyFromProto(resp.GetPolicy()), nil
+}
+
+// GetPreview returns the preview label policy of the level including changes not activated yet.
+func GetPreview(ctx context.Context, c Client, level policies.Level) (*LabelPolicy, error) {
+	if level.IsInstance() {
+		resp, err := c.AdminService().GetPreviewLabelPolicy(ctx, &admin.GetPreviewLabelPolicyRequest{})
+		if err != nil {
+			return nil, err
+		}
+		return labelPolicyFromProto(resp.GetPolicy()), nil
+	}
+	resp, err := c.ManagementService().GetPreviewLabelPolicy(org.Context(ctx, level.OrgID()), &management.GetPreviewLabelPolicyRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return labelPolicyFromProto(resp.GetPolicy()), nil
+}
+
+// Set validates and updates the preview label policy of the level.
+// For an organization without own policy, the override is created.
+func Set(ctx context.Context, c Client, level policies.Level, p *LabelPolicy) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	if level.IsInstance() {
+		_, err := c.AdminService().UpdateLabelPolicy(ctx, &admin.UpdateLabelPolicyRequest{
+			PrimaryColor:        p.Light.Primary,
+			WarnColor:           p.Light.Warn,
+			BackgroundColor:     p.Light.Background,
+			FontColor:           p.Light.Font,
+			PrimaryColorDark:    p.Dark.Primary,
+			WarnColorDark:       p.Dark.Warn,
+			BackgroundColorDark: p.Dark.Background,
+			FontColorDark:       p.Dark.Font,
+			HideLoginNameSuffix: p.HideLoginNameSuffix,
+			DisableWatermark:    p.DisableWatermark,
+			ThemeMode:           p.ThemeMode,
+		})
+		return err
+	}
+	current, err := GetPreview(ctx, c, level)
+	if err != nil {
+		return err
+	}
+	ctx = org.Context(ctx, level.OrgID())
+	if current.IsDefault {
+		_, err = c.ManagementService().AddCustomLabelPolicy(ctx, &management.AddCustomLabelPolicyRequest{
+			PrimaryColor:        p.Light.Primary,
+			WarnColor:           p.Light.Warn,
+			BackgroundColor:     p.Light.Background,
+			FontColor:           p.Light.Font,
+			PrimaryColorDark:    p.Dark.Primary,
+			WarnColorDark:       p.Dark.Warn,
+			BackgroundColorDark: p.Dark.Background,
+			FontColorDark:       p.Dark.Font,
+			HideLoginNameSuffix: p.HideLoginNameSuffix,
+			DisableWatermark:    p.DisableWatermark,
+			ThemeMode:           p.ThemeMode,
+		})
+		return err
+	}
+	_, err = c.ManagementService().UpdateCustomLabelPolicy(ctx, &management.UpdateCustomLabelPolicyRequest{
+		PrimaryColor:        p.Light.Primary,
+		WarnColor:           p.Light.Warn,
+		BackgroundColor:     p.Light.Background,
+		FontColor:           p.Light.Font,
+		PrimaryColorDark:    p.Dark.Primary,
+		WarnColorDark:       p.Dark.Warn,
+		BackgroundColorDark: p.Dark.Background,
+		FontColorDark:       p.Dark.Font,
+		HideLoginNameSuffix: p.HideLoginNameSuffix,
+		DisableWatermark:    p.DisableWatermark,
+		ThemeMode:           p.ThemeMode,
+	})
+	return err
+}
+
+// Activate activates the preview label policy of the level, so it's shown to the users.
+func Activate(ctx context.Context, c Client, level policies.Level) error {
+	if level.IsInstance() {
+		_, err := c.AdminService().ActivateLabelPolicy(ctx, &admin.ActivateLabelPolicyRequest{})
+		return err
+	}
+	_, err := c.ManagementService().ActivateCustomLabelPolicy(org.Context(ctx, level.OrgID()), &management.ActivateCustomLabelPolicyRequest{})
+	return err
+}
+
+// Reset removes the label policy of the organization, so the instance default is used again.
+func Reset(ctx context.Context, c Client, orgID string) error {
+	_, err := c.ManagementService().ResetLabelPolicyToDefault(org.Context(ctx, orgID), &management.ResetLabelPolicyToDefaultRequest{})
+	return err
+}
+
+// Apply updates the preview label policy, uploads the provided assets and activates the policy.
+func Apply(ctx context.Context, c Client, level policies.Level, p *LabelPolicy, assets ...Asset) error {
+	if err := Set(ctx, c, level, p); err != nil {
+		return err
+	}
+	for _, asset := range assets {
+		if err := Upload(ctx, c, level, asset); err != nil {
+			return err
+		}
+	}
+	return Activate(ctx, c, level)
+}
+
+func labelPolicyFromProto(p *policy.LabelPolicy) *LabelPolicy {
+	return &LabelPolicy{
+		Light: Colors{
+			Primary:    p.GetPrimaryColor(),
+			Warn:       p.GetWarnColor(),
+			Background: p.GetBackgroundColor(),
+			Font:       p.GetFontColor(),
+		},
+		Dark: Colors{
+			Primary:    p.GetPrimaryColorDark(),
+			Warn:       p.GetWarnColorDark(),
+			Background: p.GetBackgroundColorDark(),
+			Font:       p.GetFontColorDark(),
+		},
+		ThemeMode:           p.GetThemeMode(),
+		HideLoginNameSuffix: p.GetHideLoginNameSuffix(),
+		DisableWatermark:    p.GetDisableWatermark(),
+		LogoURL:             p.GetLogoUrl(),
+		LogoURLDark:         p.GetLogoUrlDark(),
+		IconURL:             p.GetIconUrl(),
+		IconURLDark:         p.GetIconUrlDark(),
+		FontURL:             p.GetFontUrl(),
+		IsDefault:           p.GetIsDefault(),
+	}
+}
+
+// AssetType defines which asset of the label policy is uploaded.
+type AssetType string
+
+const (
+	AssetLogo     AssetType = "logo"
+	AssetLogoDark AssetType = "logo/dark"
+	AssetIcon     AssetType = "icon"
+	AssetIconDark AssetType = "icon/dark"
+	AssetFont     AssetType = "font"
+)
+
+func (t AssetType) validate() error {
+	switch t {
+	case AssetLogo, AssetLogoDark, AssetIcon, AssetIconDark, AssetFont:
+		return nil
+	default:
+		return fmt.Errorf("%w `%s`", ErrAssetType, t)
+	}
+}
+
+// Asset is a file (image or font) of the label policy.
+type Asset struct {
+	Type     AssetType
+	Filename string
+	Content  io.Reader
+}
+
+// Upload uploads the asset to the preview label policy of the level using the assets (REST) API.
+// For an organization, the label policy override must already exist (see [Set]).
+func Upload(ctx context.Context, c Client, level policies.Level, asset Asset) error {
+	if err := asset.Type.validate(); err != nil {
+		return err
+	}
+	body, contentType := multipartBody(asset)
+	req, err := http.NewRequestWithContext(org.Context(ctx, level.OrgID()), http.MethodPost, assetURL(c.Origin(), level, asset.Type), body)
+	if err != nil {
+		// closing the reader stops the goroutine writing the body
+		body.Close()
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := c.HTTPClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("%w `%s`: %w", ErrAssetUpload, asset.Type, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%w `%s`: %s: %s", ErrAssetUpload, asset.Type, resp.Status, msg)
+	}
+	return nil
+}
+
+// RemoveAsset removes the asset from the preview label policy of the level.
+func RemoveAsset(ctx context.Context, c Client, level policies.Level, assetType AssetType) (err error) {
+	if level.IsInstance() {
+		switch assetType {
+		case AssetLogo:
+			_, err = c.AdminService().RemoveLabelPolicyLogo(ctx, &admin.RemoveLabelPolicyLogoRequest{})
+		case AssetLogoDark:
+			_, err = c.AdminService().RemoveLabelPolicyLogoDark(ctx, &admin.RemoveLabelPolicyLogoDarkRequest{})
+		case AssetIcon:
+			_, err = c.AdminService().RemoveLabelPolicyIcon(ctx, &admin.RemoveLabelPolicyIconRequest{})
+		case AssetIconDark:
+			_, err = c.AdminService().RemoveLabelPolicyIconDark(ctx, &admin.RemoveLabelPolicyIconDarkRequest{})
+		case AssetFont:
+			_, err = c.AdminService().RemoveLabelPolicyFont(ctx, &admin.RemoveLabelPolicyFontRequest{})
+		default:
+			return fmt.Errorf("%w `%s`", ErrAssetType, assetType)
+		}
+		return err
+	}
+	ctx = org.Context(ctx, level.OrgID())
+	switch assetType {
+	case AssetLogo:
+		_, err = c.ManagementService().RemoveCustomLabelPolicyLogo(ctx, &management.RemoveCustomLabelPolicyLogoRequest{})
+	case AssetLogoDark:
+		_, err = c.ManagementService().RemoveCustomLabelPolicyLogoDark(ctx, &management.RemoveCustomLabelPolicyLogoDarkRequest{})
+	case AssetIcon:
+		_, err = c.ManagementService().RemoveCustomLabelPolicyIcon(ctx, &management.RemoveCustomLabelPolicyIconRequest{})
+	case AssetIconDark:
+		_, err = c.ManagementService().RemoveCustomLabelPolicyIconDark(ctx, &management.RemoveCustomLabelPolicyIconDarkRequest{})
+	case AssetFont:
+		_, err = c.ManagementService().RemoveCustomLabelPolicyFont(ctx, &management.RemoveCustomLabelPolicyFontRequest{})
+	default:
+		return fmt.Errorf("%w `%s`", ErrAssetType, assetType)
+	}
+	return err
+}
+
+func assetURL(origin string, level policies.Level, assetType AssetType) string {
+	resource := "org"
+	if level.IsInstance() {
+		resource = "instance"
+	}
+	return origin + "/assets/v1/" + resource + "/policy/label/" + string(assetType)
+}
+
+// multipartBody streams the asset as multipart form with the content type derived from the file extension.
+// The returned reader must be closed, if it's not consumed (e.g. by the [http.Client]).
+func multipartBody(asset Asset) (io.ReadCloser, string) {
+	body, writer := io.Pipe()
+	form := multipart.NewWriter(writer)
+	go func() {
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, strings.ReplaceAll(asset.Filename, `"`, "")))
+		contentType := mime.TypeByExtension(path.Ext(asset.Filename))
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		header.Set("Content-Type", contentType)
+		part, err := form.CreatePart(header)
+		if err == nil {
+			_, err = io.Copy(part, asset.Content)
+		}
+		if err == nil {
+			err = form.Close()
+		}
+		writer.CloseWithError(err)
+	}()
+	return body, form.FormDataContentType()
+}
@@ -0,0 +1,152 @@
+package branding
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/admin"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/policies"
+)
+
+func TestLabelPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  LabelPolicy
+		wantErr error
+	}{
+		{
+			name:    "invalid light color, error",
+			policy:  LabelPolicy{Light: Colors{Primary: "blue"}},
+			wantErr: ErrInvalidColor,
+		},
+		{
+			name:    "invalid dark color, error",
+			policy:  LabelPolicy{Dark: Colors{Font: "#12345"}},
+			wantErr: ErrInvalidColor,
+		},
+		{
+			name: "valid",
+			policy: LabelPolicy{
+				Light: Colors{Primary: "#5469d4", Warn: "#CD3D56", Background: "#fff"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.policy.Validate(), tt.wantErr)
+		})
+	}
+}
+
+func TestUpload(t *testing.T) {
+	tests := []struct {
+		name            string
+		level           policies.Level
+		asset           Asset
+		status          int
+		wantPath        string
+		wantOrgID       string
+		wantContentType string
+		wantErr         error
+	}{
+		{
+			name:            "instance logo",
+			level:           policies.Instance(),
+			asset:           Asset{Type: AssetLogo, Filename: "logo.png", Content: strings.NewReader("png")},
+			status:          http.StatusOK,
+			wantPath:        "/assets/v1/instance/policy/label/logo",
+			wantContentType: "image/png",
+		},
+		{
+			name:            "organization dark icon",
+			level:           policies.Org("orgID"),
+			asset:           Asset{Type: AssetIconDark, Filename: "icon.svg", Content: strings.NewReader("<svg/>")},
+			status:          http.StatusOK,
+			wantPath:        "/assets/v1/org/policy/label/icon/dark",
+			wantOrgID:       "orgID",
+			wantContentType: "image/svg+xml",
+		},
+		{
+			name:            "failed, error",
+			level:           policies.Instance(),
+			asset:           Asset{Type: AssetFont, Filename: "font", Content: strings.NewReader("ttf")},
+			status:          http.StatusBadRequest,
+			wantPath:        "/assets/v1/instance/policy/label/font",
+			wantContentType: "application/octet-stream",
+			wantErr:         ErrAssetUpload,
+		},
+		{
+			name:    "unknown asset type, no call",
+			level:   policies.Instance(),
+			asset:   Asset{Type: "background", Filename: "bg.png", Content: strings.NewReader("")},
+			wantErr: ErrAssetType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var path, orgID, contentType, content string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+				orgID = r.Header.Get("x-zitadel-orgid")
+				file, header, err := r.FormFile("file")
+				if err == nil {
+					contentType = header.Header.Get("Content-Type")
+					b, _ := io.ReadAll(file)
+					content = string(b)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+			want, _ := io.ReadAll(tt.asset.Content)
+			tt.asset.Content = strings.NewReader(string(want))
+
+			err := Upload(context.Background(), &testClient{server: server}, tt.level, tt.asset)
+			assert.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.wantPath, path)
+			assert.Equal(t, tt.wantOrgID, orgID)
+			assert.Equal(t, tt.wantContentType, contentType)
+			assert.Equal(t, string(want), content)
+		})
+	}
+}
+
+type testClient struct {
+	server *httptest.Server
+}
+
+func (c *testClient) AdminService() admin.AdminServiceClient {
+	return nil
+}
+
+func (c *testClient) ManagementService() management.ManagementServiceClient {
+	return nil
+}
+
+// HTTPClient adds the org header from the outgoing context like the [client.Client].
+func (c *testClient) HTTPClient() *http.Client {
+	return &http.Client{Transport: orgTransport{}}
+}
+
+func (c *testClient) Origin() string {
+	return c.server.URL
+}
+
+type orgTransport struct{}
+
+func (orgTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if md, ok := metadata.FromOutgoingContext(req.Context()); ok {
+		if orgID := md.Get("x-zitadel-orgid"); len(orgID) > 0 {
+			req.Header.Set("x-zitadel-orgid", orgID[0])
+		}
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
@@ -0,0 +1,286 @@
+// Package events provides a watcher on the events of the instance (Admin API `ListEvents`),
+// e.g. to build projections of ZITADEL resources without reimplementing the cursor handling.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/admin"
+	eventV1 "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/event"
+)
+
+// Client is the part of the [client.Client] used by this package.
+type Client interface {
+	AdminService() admin.AdminServiceClient
+}
+
+// Filter restricts the events returned by [Watch]. Empty fields do not restrict the events.
+type Filter struct {
+	// EventTypes, e.g. `user.human.added`
+	EventTypes []string
+	// AggregateTypes, e.g. `user` or `org`
+	AggregateTypes []string
+	AggregateID    string
+	ResourceOwner  string
+	EditorUserID   string
+	// From is the creation date of the first event to return.
+	// The zero value watches all events since the creation of the instance.
+	From time.Time
+}
+
+// Event is an event of the instance.
+type Event struct {
+	Type          string
+	AggregateID   string
+	AggregateType string
+	ResourceOwner string
+	Sequence      uint64
+	CreatedAt     time.Time
+	EditorUserID  string
+	EditorService string
+	// Payload is the raw JSON payload of the event, it's empty if the event has none.
+	Payload json.RawMessage
+}
+
+// DecodePayload unmarshals the payload of the event into v.
+func (e *Event) DecodePayload(v any) error {
+	if len(e.Payload) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(e.Payload, v); err != nil {
+		return fmt.Errorf("decode payload of event `%s`: %w", e.Type, err)
+	}
+	return nil
+}
+
+// Decode unmarshals the payload into the typed struct of the event type, e.g. [*UserHumanAdded]
+// for `user.human.added`. The payload of unknown event types is returned as map[string]any.
+func (e *Event) Decode() (any, error) {
+	var v any = &map[string]any{}
+	if newPayload, ok := payloads[e.Type]; ok {
+		v = newPayload()
+	}
+	if err := e.DecodePayload(v); err != nil {
+		return nil, err
+	}
+	if m, ok := v.(*map[string]any); ok {
+		return *m, nil
+	}
+	return v, nil
+}
+
+func eventFromProto(e *eventV1.Event) (Event, error) {
+	event := Event{
+		Type:          e.GetType().GetType(),
+		AggregateID:   e.GetAggregate().GetId(),
+		AggregateType: e.GetAggregate().GetType().GetType(),
+		ResourceOwner: e.GetAggregate().GetResourceOwner(),
+		Sequence:      e.GetSequence(),
+		CreatedAt:     e.GetCreationDate().AsTime(),
+		EditorUserID:  e.GetEditor().GetUserId(),
+		EditorService: e.GetEditor().GetService(),
+	}
+	if e.GetPayload() != nil {
+		payload, err := e.GetPayload().MarshalJSON()
+		if err != nil {
+			return Event{}, fmt.Errorf("encode payload of event `%s`: %w", event.Type, err)
+		}
+		event.Payload = payload
+	}
+	return event, nil
+}
+
+// WatchOption allows customization of [Watch].
+type WatchOption func(*watchOptions)
+
+type watchOptions struct {
+	interval     time.Duration
+	maxBackoff   time.Duration
+	batchSize    uint32
+	lag          time.Duration
+	errorHandler func(error)
+}
+
+// WithPollInterval sets the interval between two requests once all events have been received (default 5s).
+func WithPollInterval(interval time.Duration) WatchOption {
+	return func(o *watchOptions) {
+		o.interval = interval
+	}
+}
+
+// WithMaxBackoff sets the maximum interval between two requests after failures (default 1m).
+// The interval starts at the poll interval and doubles on every consecutive failure.
+func WithMaxBackoff(backoff time.Duration) WatchOption {
+	return func(o *watchOptions) {
+		o.maxBackoff = backoff
+	}
+}
+
+// WithBatchSize sets the maximum number of events requested at once (default 100).
+func WithBatchSize(size uint32) WatchOption {
+	return func(o *watchOptions) {
+		o.batchSize = size
+	}
+}
+
+// DefaultLagWindow is the default of [WithLagWindow].
+const DefaultLagWindow = 10 * time.Second
+
+// WithLagWindow sets how long before the last received event the events are requested again ([DefaultLagWindow]),
+// so events of transactions committed after a later event was already received are not skipped.
+// The creation date of an event is set when its transaction starts, so the window should cover the longest transactions.
+func WithLagWindow(lag time.Duration) WatchOption {
+	return func(o *watchOptions) {
+		o.lag = lag
+	}
+}
+
+// WithErrorHandler is called with every failed request, e.g. for logging. The request is retried with backoff.
+func WithErrorHandler(handler func(error)) WatchOption {
+	return func(o *watchOptions) {
+		o.errorHandler = handler
+	}
+}
+
+// Watch polls the events matching the filter in order of their creation and sends them to the returned channel,
+// until the context is done. The channel is closed afterward.
+//
+// The events are requested from the creation date of the last received event minus the lag window ([WithLagWindow]),
+// events already sent are skipped. So every event is sent at most once and an event committed after later ones is still
+// sent, if it was created within the lag window before the last received one. Events of transactions committed later
+// than that are skipped. Failed requests are retried with an exponential backoff.
+func Watch(ctx context.Context, c Client, filter Filter, options ...WatchOption) <-chan Event {
+	return watch(ctx, c, filter, newWatchOptions(options))
+}
+
+func newWatchOptions(options []WatchOption) *watchOptions {
+	opts := &watchOptions{
+		interval:     5 * time.Second,
+		maxBackoff:   time.Minute,
+		batchSize:    100,
+		lag:          DefaultLagWindow,
+		errorHandler: func(error) {},
+	}
+	for _, option := range options {
+		option(opts)
+	}
+	return opts
+}
+
+func watch(ctx context.Context, c Client, filter Filter, opts *watchOptions) <-chan Event {
+	w := &watcher{
+		client: c,
+		filter: filter,
+		opts:   opts,
+		limit:  opts.batchSize,
+		cursor: filter.From,
+		seen:   make(map[eventKey]time.Time),
+	}
+	events := make(chan Event)
+	go w.run(ctx, events)
+	return events
+}
+
+type eventKey struct {
+	aggregateID string
+	sequence    uint64
+}
+
+type watcher struct {
+	client Client
+	filter Filter
+	opts   *watchOptions
+	// limit is the batch size plus the number of events already sent within the lag window,
+	// it's increased further if a full batch only contains events already sent.
+	limit uint32
+	// cursor is the latest creation date of the sent events,
+	// seen contains the events sent within the lag window before it (with their creation date).
+	cursor time.Time
+	seen   map[eventKey]time.Time
+}
+
+func (w *watcher) run(ctx context.Context, events chan<- Event) {
+	defer close(events)
+	wait := time.Duration(0)
+	backoff := w.opts.interval
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+		more, err := w.poll(ctx, events)
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err != nil:
+			w.opts.errorHandler(err)
+			wait = backoff
+			backoff = min(2*backoff, w.opts.maxBackoff)
+		case more:
+			wait = 0
+			backoff = w.opts.interval
+		default:
+			wait = w.opts.interval
+			backoff = w.opts.interval
+		}
+	}
+}
+
+// poll requests the next batch of events (starting at the lag window before the cursor) and sends all unseen ones.
+// It reports whether more events are expected, i.e. the batch was full.
+func (w *watcher) poll(ctx context.Context, events chan<- Event) (bool, error) {
+	req := &admin.ListEventsRequest{
+		Limit:          w.limit,
+		Asc:            true,
+		EditorUserId:   w.filter.EditorUserID,
+		EventTypes:     w.filter.EventTypes,
+		AggregateId:    w.filter.AggregateID,
+		AggregateTypes: w.filter.AggregateTypes,
+		ResourceOwner:  w.filter.ResourceOwner,
+	}
+	if !w.cursor.IsZero() {
+		req.CreationDateFilter = &admin.ListEventsRequest_From{From: timestamppb.New(w.cursor.Add(-w.opts.lag))}
+	}
+	resp, err := w.client.AdminService().ListEvents(ctx, req)
+	if err != nil {
+		return false, fmt.Errorf("list events: %w", err)
+	}
+	sent := false
+	for _, e := range resp.GetEvents() {
+		event, err := eventFromProto(e)
+		if err != nil {
+			return false, err
+		}
+		key := eventKey{aggregateID: event.AggregateID, sequence: event.Sequence}
+		if _, ok := w.seen[key]; ok || event.CreatedAt.Before(w.filter.From) {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case events <- event:
+		}
+		if event.CreatedAt.After(w.cursor) {
+			w.cursor = event.CreatedAt
+		}
+		w.seen[key] = event.CreatedAt
+		sent = true
+	}
+	for key, createdAt := range w.seen {
+		if createdAt.Before(w.cursor.Add(-w.opts.lag)) {
+			delete(w.seen, key)
+		}
+	}
+	full := len(resp.GetEvents()) >= int(w.limit)
+	w.limit = w.opts.batchSize + uint32(len(w.seen))
+	if full && !sent {
+		w.limit = 2 * uint32(len(resp.GetEvents()))
+	}
+	return full, nil
+}
@@ -0,0 +1,219 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/admin"
+	eventV1 "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/event"
+)
+
+func TestWatch(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	service := &adminService{
+		failures: 1,
+		events: []*eventV1.Event{
+			testEvent(t, "1", 1, start, TypeUserHumanAdded, map[string]any{"userName": "alice"}),
+			testEvent(t, "2", 1, start.Add(time.Second), TypeUserHumanAdded, map[string]any{"userName": "bob"}),
+			testEvent(t, "2", 2, start.Add(time.Second), TypeUserRemoved, nil),
+			testEvent(t, "3", 1, start.Add(time.Second), TypeOrgAdded, map[string]any{"name": "org"}),
+			testEvent(t, "1", 2, start.Add(2*time.Second), TypeUserRemoved, map[string]any{"userName": "alice"}),
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var errs []error
+	events := Watch(ctx, &testClient{service}, Filter{},
+		WithBatchSize(2),
+		WithPollInterval(time.Millisecond),
+		WithErrorHandler(func(err error) { errs = append(errs, err) }),
+	)
+
+	var got []eventKey
+	for len(got) < 5 {
+		select {
+		case e := <-events:
+			got = append(got, eventKey{e.AggregateID, e.Sequence})
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out, received %v", got)
+		}
+	}
+	assert.Equal(t, []eventKey{{"1", 1}, {"2", 1}, {"2", 2}, {"3", 1}, {"1", 2}}, got)
+
+	service.add(testEvent(t, "4", 1, start.Add(2*time.Second), TypeOrgAdded, map[string]any{"name": "new"}))
+	select {
+	case e := <-events:
+		assert.Equal(t, eventKey{"4", 1}, eventKey{e.AggregateID, e.Sequence})
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for new event")
+	}
+
+	// an event of a transaction committed after later events were received
+	service.add(testEvent(t, "5", 1, start.Add(1500*time.Millisecond), TypeOrgAdded, map[string]any{"name": "late"}))
+	select {
+	case e := <-events:
+		assert.Equal(t, eventKey{"5", 1}, eventKey{e.AggregateID, e.Sequence})
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for late event")
+	}
+	select {
+	case e := <-events:
+		t.Fatalf("event %v sent twice", eventKey{e.AggregateID, e.Sequence})
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	cancel()
+	for range events {
+	}
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], errTest)
+}
+
+func TestWatch_lagWindow(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	service := &adminService{
+		events: []*eventV1.Event{
+			testEvent(t, "1", 1, start, TypeOrgAdded, nil),
+			testEvent(t, "2", 1, start.Add(time.Minute), TypeOrgAdded, nil),
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := Watch(ctx, &testClient{service}, Filter{From: start.Add(time.Second)},
+		WithPollInterval(time.Millisecond),
+		WithLagWindow(10*time.Second),
+	)
+	receive := func() eventKey {
+		select {
+		case e := <-events:
+			return eventKey{e.AggregateID, e.Sequence}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out")
+			return eventKey{}
+		}
+	}
+	assert.Equal(t, eventKey{"2", 1}, receive(), "events before the filter must not be sent")
+
+	// committed late, but within the lag window
+	service.add(testEvent(t, "3", 1, start.Add(55*time.Second), TypeOrgAdded, nil))
+	// committed later than the lag window
+	service.add(testEvent(t, "4", 1, start.Add(30*time.Second), TypeOrgAdded, nil))
+	service.add(testEvent(t, "5", 1, start.Add(2*time.Minute), TypeOrgAdded, nil))
+	assert.Equal(t, eventKey{"3", 1}, receive())
+	assert.Equal(t, eventKey{"5", 1}, receive())
+}
+
+func TestEvent_Decode(t *testing.T) {
+	tests := []struct {
+		name  string
+		event *eventV1.Event
+		want  any
+	}{
+		{
+			name:  "typed",
+			event: testEvent(t, "1", 1, time.Now(), TypeUserHumanAdded, map[string]any{"userName": "alice", "isEmailVerified": true}),
+			want:  &UserHumanAdded{UserName: "alice", EmailVerified: true},
+		},
+		{
+			name:  "unknown",
+			event: testEvent(t, "1", 1, time.Now(), "custom.event", map[string]any{"key": "value"}),
+			want:  map[string]any{"key": "value"},
+		},
+		{
+			name:  "no payload",
+			event: testEvent(t, "1", 1, time.Now(), TypeOrgRemoved, nil),
+			want:  &OrgRemoved{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := eventFromProto(tt.event)
+			require.NoError(t, err)
+			got, err := event.Decode()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+var errTest = errors.New("test")
+
+func testEvent(t *testing.T, aggregateID string, sequence uint64, createdAt time.Time, typ string, payload map[string]any) *eventV1.Event {
+	e := &eventV1.Event{
+		Aggregate:    &eventV1.Aggregate{Id: aggregateID},
+		Sequence:     sequence,
+		CreationDate: timestamppb.New(createdAt),
+		Type:         &eventV1.EventType{Type: typ},
+	}
+	if payload != nil {
+		p, err := structpb.NewStruct(payload)
+		require.NoError(t, err)
+		e.Payload = p
+	}
+	return e
+}
+
+type testClient struct {
+	admin admin.AdminServiceClient
+}
+
+func (c *testClient) AdminService() admin.AdminServiceClient {
+	return c.admin
+}
+
+type adminService struct {
+	admin.AdminServiceClient
+	mu         sync.Mutex
+	failures   int
+	events     []*eventV1.Event
+	eventTypes []string
+}
+
+func (s *adminService) add(e *eventV1.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, e)
+}
+
+func (s *adminService) ListEvents(_ context.Context, req *admin.ListEventsRequest, _ ...grpc.CallOption) (*admin.ListEventsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return nil, errTest
+	}
+	// only the types of the watcher are recorded, not the ones of a lookup of a single aggregate
+	if req.GetAggregateId() == "" {
+		s.eventTypes = req.GetEventTypes()
+	}
+	var result []*eventV1.Event
+	for _, e := range s.events {
+		if from := req.GetFrom(); from != nil && e.GetCreationDate().AsTime().Before(from.AsTime()) {
+			continue
+		}
+		if id := req.GetAggregateId(); id != "" && e.GetAggregate().GetId() != id {
+			continue
+		}
+		if types := req.GetEventTypes(); len(types) > 0 && !slices.Contains(types, e.GetType().GetType()) {
+			continue
+		}
+		result = append(result, e)
+	}
+	slices.SortStableFunc(result, func(a, b *eventV1.Event) int {
+		return a.GetCreationDate().AsTime().Compare(b.GetCreationDate().AsTime())
+	})
+	if len(result) > int(req.GetLimit()) {
+		result = result[:req.GetLimit()]
+	}
+	return &admin.ListEventsResponse{Events: result}, nil
+}
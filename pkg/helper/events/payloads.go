@@ -0,0 +1,118 @@
+package events
+
+// Types of common events with a typed payload, see [Event.Decode].
+const (
+	TypeUserHumanAdded        = "user.human.added"
+	TypeUserHumanRegistered   = "user.human.selfregistered"
+	TypeUserMachineAdded      = "user.machine.added"
+	TypeUserHumanEmailChanged = "user.human.email.changed"
+	TypeUserRemoved           = "user.removed"
+	TypeUserGrantAdded        = "user.grant.added"
+	TypeUserGrantChanged      = "user.grant.changed"
+	TypeUserGrantRemoved      = "user.grant.removed"
+	TypeOrgAdded              = "org.added"
+	TypeOrgChanged            = "org.changed"
+	TypeOrgRemoved            = "org.removed"
+	TypeProjectAdded          = "project.added"
+	TypeProjectRoleAdded      = "project.role.added"
+	TypeProjectRoleRemoved    = "project.role.removed"
+)
+
+var payloads = map[string]func() any{
+	TypeUserHumanAdded:        func() any { return new(UserHumanAdded) },
+	TypeUserHumanRegistered:   func() any { return new(UserHumanAdded) },
+	TypeUserMachineAdded:      func() any { return new(UserMachineAdded) },
+	TypeUserHumanEmailChanged: func() any { return new(UserHumanEmailChanged) },
+	TypeUserRemoved:           func() any { return new(UserRemoved) },
+	TypeUserGrantAdded:        func() any { return new(UserGrantAdded) },
+	TypeUserGrantChanged:      func() any { return new(UserGrantChanged) },
+	TypeUserGrantRemoved:      func() any { return new(UserGrantRemoved) },
+	TypeOrgAdded:              func() any { return new(OrgAdded) },
+	TypeOrgChanged:            func() any { return new(OrgChanged) },
+	TypeOrgRemoved:            func() any { return new(OrgRemoved) },
+	TypeProjectAdded:          func() any { return new(ProjectAdded) },
+	TypeProjectRoleAdded:      func() any { return new(ProjectRoleAdded) },
+	TypeProjectRoleRemoved:    func() any { return new(ProjectRoleRemoved) },
+}
+
+// UserHumanAdded is the payload of [TypeUserHumanAdded] and [TypeUserHumanRegistered].
+type UserHumanAdded struct {
+	UserName          string `json:"userName"`
+	FirstName         string `json:"firstName"`
+	LastName          string `json:"lastName"`
+	NickName          string `json:"nickName,omitempty"`
+	DisplayName       string `json:"displayName,omitempty"`
+	PreferredLanguage string `json:"preferredLanguage,omitempty"`
+	Email             string `json:"email,omitempty"`
+	EmailVerified     bool   `json:"isEmailVerified,omitempty"`
+	Phone             string `json:"phone,omitempty"`
+}
+
+// UserMachineAdded is the payload of [TypeUserMachineAdded].
+type UserMachineAdded struct {
+	UserName    string `json:"userName"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// UserHumanEmailChanged is the payload of [TypeUserHumanEmailChanged].
+type UserHumanEmailChanged struct {
+	Email string `json:"email"`
+}
+
+// UserRemoved is the payload of [TypeUserRemoved].
+type UserRemoved struct {
+	UserName string `json:"userName"`
+}
+
+// UserGrantAdded is the payload of [TypeUserGrantAdded].
+type UserGrantAdded struct {
+	UserID         string   `json:"userId"`
+	ProjectID      string   `json:"projectId"`
+	ProjectGrantID string   `json:"grantId,omitempty"`
+	RoleKeys       []string `json:"roleKeys,omitempty"`
+}
+
+// UserGrantChanged is the payload of [TypeUserGrantChanged].
+type UserGrantChanged struct {
+	UserID   string   `json:"userId"`
+	RoleKeys []string `json:"roleKeys,omitempty"`
+}
+
+// UserGrantRemoved is the payload of [TypeUserGrantRemoved].
+type UserGrantRemoved struct {
+	UserID    string `json:"userId"`
+	ProjectID string `json:"projectId"`
+}
+
+// OrgAdded is the payload of [TypeOrgAdded].
+type OrgAdded struct {
+	Name string `json:"name"`
+}
+
+// OrgChanged is the payload of [TypeOrgChanged].
+type OrgChanged struct {
+	Name string `json:"name"`
+}
+
+// OrgRemoved is the payload of [TypeOrgRemoved].
+type OrgRemoved struct {
+	Name string `json:"name"`
+}
+
+// ProjectAdded is the payload of [TypeProjectAdded].
+type ProjectAdded struct {
+	Name string `json:"name"`
+}
+
+// ProjectRoleAdded is the payload of [TypeProjectRoleAdded].
+type ProjectRoleAdded struct {
+	Key         string `json:"key"`
+	DisplayName string `json:"displayName,omitempty"`
+	Group       string `json:"group,omitempty"`
+}
+
+// ProjectRoleRemoved is the payload of [TypeProjectRoleRemoved].
+type ProjectRoleRemoved struct {
+	Key string `json:"key"`
+}
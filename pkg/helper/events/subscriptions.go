@@ -0,0 +1,213 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/admin"
+)
+
+// Handler handles an event delivered by [Subscriptions]. Errors are passed to the handler of [WithErrorHandler].
+type Handler func(ctx context.Context, e Event) error
+
+// Subscriptions dispatches the events of the instance to the handlers subscribed to their type,
+// e.g. to invalidate the caches of the SDK as soon as something changed in ZITADEL:
+//
+//	err := events.NewSubscriptions().
+//		InvalidateUsers(authorizer, events.UserCacheFunc(permissions.Invalidate)).
+//		RefreshKeys(authorizer).
+//		Run(ctx, api)
+//
+// Handlers must be subscribed before [Subscriptions.Run] is called.
+type Subscriptions struct {
+	subscriptions []subscription
+	// client is set by [Subscriptions.Run], e.g. to resolve the user of a grant.
+	client Client
+}
+
+type subscription struct {
+	pattern string
+	handle  Handler
+}
+
+// NewSubscriptions creates an empty [Subscriptions].
+func NewSubscriptions() *Subscriptions {
+	return new(Subscriptions)
+}
+
+// On subscribes the handler to the event type, e.g. `user.deactivated`.
+// A trailing `*` matches all event types with the prefix, e.g. `user.grant.*`.
+func (s *Subscriptions) On(eventType string, handle Handler) *Subscriptions {
+	s.subscriptions = append(s.subscriptions, subscription{pattern: eventType, handle: handle})
+	return s
+}
+
+// UserCache is a cache holding data per user, e.g. the authorization.Authorizer with a cache.
+type UserCache interface {
+	InvalidateUser(userID string)
+}
+
+// UserCacheFunc allows using a function as [UserCache], e.g. the Invalidate method of the permission.Checker.
+type UserCacheFunc func(userID string)
+
+// InvalidateUser implements [UserCache].
+func (f UserCacheFunc) InvalidateUser(userID string) {
+	f(userID)
+}
+
+// userEvents invalidate the cached data of the user (aggregate) of the event.
+var userEvents = []string{
+	"user.deactivated",
+	"user.reactivated",
+	"user.locked",
+	"user.unlocked",
+	"user.removed",
+	"user.token.removed",
+	"user.refresh.token.removed",
+	"user.human.signed.out",
+}
+
+// grantEvents invalidate the cached data of the user in the payload of the event, the aggregate is the grant.
+// The payload of the state changes (deactivated, reactivated) does not contain the user,
+// so it's resolved from the `user.grant.added` event of the grant.
+var grantEvents = []string{
+	"user.grant.added",
+	"user.grant.changed",
+	"user.grant.cascade.changed",
+	"user.grant.deactivated",
+	"user.grant.reactivated",
+	"user.grant.removed",
+	"user.grant.cascade.removed",
+}
+
+// keyEvents are the events of a key rotation, of the signing keys (`key_pair`) and the web keys (`web_key`).
+var keyEvents = []string{
+	"key_pair.added",
+	"web_key.added",
+	"web_key.activated",
+	"web_key.deactivated",
+	"web_key.removed",
+}
+
+// InvalidateUsers subscribes the caches to the events changing the authorization of a user,
+// i.e. the (re)activation, (un)locking or removal of the user, the removal of their tokens and changes of their grants.
+// Reactivations are needed as well, since caches might hold negative results (e.g. authorization.WithNegativeTTL).
+func (s *Subscriptions) InvalidateUsers(caches ...UserCache) *Subscriptions {
+	invalidate := func(userID string) {
+		for _, cache := range caches {
+			cache.InvalidateUser(userID)
+		}
+	}
+	for _, eventType := range userEvents {
+		s.On(eventType, func(_ context.Context, e Event) error {
+			invalidate(e.AggregateID)
+			return nil
+		})
+	}
+	for _, eventType := range grantEvents {
+		s.On(eventType, func(ctx context.Context, e Event) error {
+			userID, err := s.grantUserID(ctx, e)
+			if err != nil {
+				return err
+			}
+			if userID != "" {
+				invalidate(userID)
+			}
+			return nil
+		})
+	}
+	return s
+}
+
+// grantUserID returns the user of the grant event from its payload or else from the `user.grant.added` event of the grant.
+func (s *Subscriptions) grantUserID(ctx context.Context, e Event) (string, error) {
+	var grant struct {
+		UserID string `json:"userId"`
+	}
+	if err := e.DecodePayload(&grant); err != nil {
+		return "", err
+	}
+	if grant.UserID != "" || s.client == nil {
+		return grant.UserID, nil
+	}
+	resp, err := s.client.AdminService().ListEvents(ctx, &admin.ListEventsRequest{
+		Limit:       1,
+		Asc:         true,
+		AggregateId: e.AggregateID,
+		EventTypes:  []string{TypeUserGrantAdded},
+	})
+	if err != nil {
+		return "", fmt.Errorf("list events of grant: %w", err)
+	}
+	if len(resp.GetEvents()) == 0 {
+		return "", nil
+	}
+	added, err := eventFromProto(resp.GetEvents()[0])
+	if err != nil {
+		return "", err
+	}
+	if err = added.DecodePayload(&grant); err != nil {
+		return "", err
+	}
+	return grant.UserID, nil
+}
+
+// KeyRefresher caches the public keys of ZITADEL, e.g. the authorization.Authorizer with JWT verification.
+type KeyRefresher interface {
+	RefreshKeys(ctx context.Context) error
+}
+
+// RefreshKeys subscribes the key sets to the events of a key rotation,
+// so tokens signed by a new key are accepted immediately.
+func (s *Subscriptions) RefreshKeys(keySets ...KeyRefresher) *Subscriptions {
+	refresh := func(ctx context.Context, _ Event) error {
+		for _, keySet := range keySets {
+			if err := keySet.RefreshKeys(ctx); err != nil {
+				return fmt.Errorf("refresh keys: %w", err)
+			}
+		}
+		return nil
+	}
+	for _, eventType := range keyEvents {
+		s.On(eventType, refresh)
+	}
+	return s
+}
+
+// Run watches the events created from now on (see [Watch]) and dispatches them to the subscribed handlers,
+// until the context is done and returns its error. The [WatchOption] are passed to the watcher,
+// the handler of [WithErrorHandler] receives the errors of the handlers as well.
+// Only the subscribed event types are requested from ZITADEL, unless a prefix (`*`) is subscribed.
+func (s *Subscriptions) Run(ctx context.Context, c Client, options ...WatchOption) error {
+	s.client = c
+	opts := newWatchOptions(options)
+	filter := Filter{From: time.Now()}
+	for _, sub := range s.subscriptions {
+		if strings.HasSuffix(sub.pattern, "*") {
+			// prefixes cannot be filtered by ZITADEL, so all events are watched
+			filter.EventTypes = nil
+			break
+		}
+		filter.EventTypes = append(filter.EventTypes, sub.pattern)
+	}
+	for e := range watch(ctx, c, filter, opts) {
+		for _, sub := range s.subscriptions {
+			if !matchEventType(sub.pattern, e.Type) {
+				continue
+			}
+			if err := sub.handle(ctx, e); err != nil {
+				opts.errorHandler(fmt.Errorf("handle event `%s` of %s: %w", e.Type, e.AggregateID, err))
+			}
+		}
+	}
+	return ctx.Err()
+}
+
+func matchEventType(pattern, eventType string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(eventType, prefix)
+	}
+	return pattern == eventType
+}
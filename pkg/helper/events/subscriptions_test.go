@@ -0,0 +1,104 @@
+package events
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscriptions_Run(t *testing.T) {
+	service := new(adminService)
+	// the grant is added before the subscriptions are started, so the user of its deactivation is looked up
+	service.add(testEvent(t, "grant2", 1, time.Now().Add(-time.Hour), TypeUserGrantAdded, map[string]any{"userId": "4"}))
+	var mu sync.Mutex
+	var invalidated []string
+	keys := new(testKeyRefresher)
+	var errs []error
+	subscriptions := NewSubscriptions().
+		InvalidateUsers(UserCacheFunc(func(userID string) {
+			mu.Lock()
+			defer mu.Unlock()
+			invalidated = append(invalidated, userID)
+		})).
+		RefreshKeys(keys)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- subscriptions.Run(ctx, &testClient{service},
+			WithPollInterval(time.Millisecond),
+			WithErrorHandler(func(err error) {
+				mu.Lock()
+				defer mu.Unlock()
+				errs = append(errs, err)
+			}),
+		)
+	}()
+
+	later := time.Now().Add(time.Hour)
+	service.add(testEvent(t, "1", 1, later, TypeUserHumanAdded, nil))
+	service.add(testEvent(t, "1", 2, later, "user.deactivated", nil))
+	service.add(testEvent(t, "grant", 1, later, "user.grant.removed", map[string]any{"userId": "2"}))
+	service.add(testEvent(t, "key", 1, later, "web_key.activated", nil))
+	service.add(testEvent(t, "grant", 2, later, "user.grant.changed", map[string]any{"userId": 3}))
+	service.add(testEvent(t, "grant2", 2, later, "user.grant.deactivated", nil))
+	service.add(testEvent(t, "5", 1, later, "user.reactivated", nil))
+	service.add(testEvent(t, "6", 1, later, "user.unlocked", nil))
+	service.add(testEvent(t, "grant2", 3, later, "user.grant.reactivated", nil))
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(invalidated) == 6 && keys.count() == 1 && len(errs) == 1
+	}, 5*time.Second, time.Millisecond)
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+
+	assert.Equal(t, []string{"1", "2", "4", "5", "6", "4"}, invalidated)
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	assert.Contains(t, service.eventTypes, "user.deactivated")
+	assert.Contains(t, service.eventTypes, "user.grant.deactivated")
+	assert.NotContains(t, service.eventTypes, TypeUserHumanAdded)
+}
+
+func Test_matchEventType(t *testing.T) {
+	tests := []struct {
+		pattern   string
+		eventType string
+		want      bool
+	}{
+		{"user.deactivated", "user.deactivated", true},
+		{"user.deactivated", "user.reactivated", false},
+		{"user.grant.*", "user.grant.added", true},
+		{"user.grant.*", "user.human.added", false},
+		{"*", "org.added", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.eventType, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchEventType(tt.pattern, tt.eventType))
+		})
+	}
+}
+
+type testKeyRefresher struct {
+	mu        sync.Mutex
+	refreshes int
+}
+
+func (k *testKeyRefresher) RefreshKeys(context.Context) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.refreshes++
+	return nil
+}
+
+func (k *testKeyRefresher) count() int {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.refreshes
+}
@@ -0,0 +1,102 @@
+package grants
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	object "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/project"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/org"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/query"
+)
+
+// GrantProject grants the project of the organization to another organization (grantedOrgID).
+// The roleKeys restrict the roles the granted organization can assign to its users and must be a subset
+// of the roles of the project. The id of the created project grant is returned.
+func GrantProject(ctx context.Context, c Client, orgID, projectID, grantedOrgID string, roleKeys ...string) (string, error) {
+	resp, err := c.ManagementService().AddProjectGrant(org.Context(ctx, orgID), &management.AddProjectGrantRequest{
+		ProjectId:    projectID,
+		GrantedOrgId: grantedOrgID,
+		RoleKeys:     roleKeys,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.GetGrantId(), nil
+}
+
+// UpdateProjectGrant replaces the roles of the project grant.
+// Roles removed from the grant will also be removed from the user grants of the granted organization.
+func UpdateProjectGrant(ctx context.Context, c Client, orgID, projectID, grantID string, roleKeys ...string) error {
+	_, err := c.ManagementService().UpdateProjectGrant(org.Context(ctx, orgID), &management.UpdateProjectGrantRequest{
+		ProjectId: projectID,
+		GrantId:   grantID,
+		RoleKeys:  roleKeys,
+	})
+	return err
+}
+
+// EnsureProjectGrant ensures the project is granted to the other organization (grantedOrgID) with exactly
+// the provided roles. If there's no grant for the organization yet, a new one is created; if the roles
+// of an existing grant differ, they're replaced. The id of the (created or existing) project grant is returned.
+func EnsureProjectGrant(ctx context.Context, c Client, orgID, projectID, grantedOrgID string, roleKeys ...string) (string, error) {
+	existing, err := GrantedOrgs(ctx, c, orgID, projectID)
+	if err != nil {
+		return "", err
+	}
+	for _, grant := range existing {
+		if grant.GetGrantedOrgId() != grantedOrgID {
+			continue
+		}
+		if sameRoles(grant.GetGrantedRoleKeys(), roleKeys) {
+			return grant.GetGrantId(), nil
+		}
+		if err = UpdateProjectGrant(ctx, c, orgID, projectID, grant.GetGrantId(), roleKeys...); err != nil {
+			return "", err
+		}
+		return grant.GetGrantId(), nil
+	}
+	return GrantProject(ctx, c, orgID, projectID, grantedOrgID, roleKeys...)
+}
+
+// GrantedOrgs returns all grants of the project to other organizations.
+func GrantedOrgs(ctx context.Context, c Client, orgID, projectID string) ([]*project.GrantedProject, error) {
+	return query.All(org.Context(ctx, orgID), func(ctx context.Context, offset uint64, limit uint32) ([]*project.GrantedProject, uint64, error) {
+		resp, err := c.ManagementService().ListProjectGrants(ctx, &management.ListProjectGrantsRequest{
+			ProjectId: projectID,
+			Query:     &object.ListQuery{Offset: offset, Limit: limit, Asc: true},
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		return resp.GetResult(), resp.GetDetails().GetTotalResult(), nil
+	})
+}
+
+// DeactivateProjectGrant deactivates the project grant. Users of the granted organization
+// will not be able to use the project until it's reactivated.
+func DeactivateProjectGrant(ctx context.Context, c Client, orgID, projectID, grantID string) error {
+	_, err := c.ManagementService().DeactivateProjectGrant(org.Context(ctx, orgID), &management.DeactivateProjectGrantRequest{
+		ProjectId: projectID,
+		GrantId:   grantID,
+	})
+	return err
+}
+
+// ReactivateProjectGrant reactivates a previously deactivated project grant.
+func ReactivateProjectGrant(ctx context.Context, c Client, orgID, projectID, grantID string) error {
+	_, err := c.ManagementService().ReactivateProjectGrant(org.Context(ctx, orgID), &management.ReactivateProjectGrantRequest{
+		ProjectId: projectID,
+		GrantId:   grantID,
+	})
+	return err
+}
+
+// RevokeProjectGrant removes the project grant including all user grants of the granted organization on the project.
+func RevokeProjectGrant(ctx context.Context, c Client, orgID, projectID, grantID string) error {
+	_, err := c.ManagementService().RemoveProjectGrant(org.Context(ctx, orgID), &management.RemoveProjectGrantRequest{
+		ProjectId: projectID,
+		GrantId:   grantID,
+	})
+	return err
+}
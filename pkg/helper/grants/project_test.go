@@ -0,0 +1,86 @@
+package grants
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	object "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/project"
+)
+
+func TestEnsureProjectGrant(t *testing.T) {
+	tests := []struct {
+		name       string
+		existing   []*project.GrantedProject
+		roleKeys   []string
+		wantID     string
+		wantAdd    *management.AddProjectGrantRequest
+		wantUpdate *management.UpdateProjectGrantRequest
+	}{
+		{
+			name: "granted to other org only, created",
+			existing: []*project.GrantedProject{
+				{GrantId: "grantID", GrantedOrgId: "otherOrgID", GrantedRoleKeys: []string{"admin"}},
+			},
+			roleKeys: []string{"admin"},
+			wantID:   "newGrantID",
+			wantAdd: &management.AddProjectGrantRequest{
+				ProjectId:    "projectID",
+				GrantedOrgId: "grantedOrgID",
+				RoleKeys:     []string{"admin"},
+			},
+		},
+		{
+			name: "same roles, unchanged",
+			existing: []*project.GrantedProject{
+				{GrantId: "grantID", GrantedOrgId: "grantedOrgID", GrantedRoleKeys: []string{"viewer", "admin"}},
+			},
+			roleKeys: []string{"admin", "viewer"},
+			wantID:   "grantID",
+		},
+		{
+			name: "role subset changed, updated",
+			existing: []*project.GrantedProject{
+				{GrantId: "grantID", GrantedOrgId: "grantedOrgID", GrantedRoleKeys: []string{"viewer", "admin"}},
+			},
+			roleKeys: []string{"viewer"},
+			wantID:   "grantID",
+			wantUpdate: &management.UpdateProjectGrantRequest{
+				ProjectId: "projectID",
+				GrantId:   "grantID",
+				RoleKeys:  []string{"viewer"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &managementService{projectGrants: tt.existing}
+			got, err := EnsureProjectGrant(context.Background(), &testClient{service}, "", "projectID", "grantedOrgID", tt.roleKeys...)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantID, got)
+			assert.Equal(t, tt.wantAdd, service.addProjectGrant)
+			assert.Equal(t, tt.wantUpdate, service.updateProjectGrant)
+		})
+	}
+}
+
+func (s *managementService) ListProjectGrants(_ context.Context, _ *management.ListProjectGrantsRequest, _ ...grpc.CallOption) (*management.ListProjectGrantsResponse, error) {
+	return &management.ListProjectGrantsResponse{
+		Details: &object.ListDetails{TotalResult: uint64(len(s.projectGrants))},
+		Result:  s.projectGrants,
+	}, nil
+}
+
+func (s *managementService) AddProjectGrant(_ context.Context, req *management.AddProjectGrantRequest, _ ...grpc.CallOption) (*management.AddProjectGrantResponse, error) {
+	s.addProjectGrant = req
+	return &management.AddProjectGrantResponse{GrantId: "newGrantID"}, nil
+}
+
+func (s *managementService) UpdateProjectGrant(_ context.Context, req *management.UpdateProjectGrantRequest, _ ...grpc.CallOption) (*management.UpdateProjectGrantResponse, error) {
+	s.updateProjectGrant = req
+	return &management.UpdateProjectGrantResponse{}, nil
+}
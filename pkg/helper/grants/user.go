@@ -0,0 +1,109 @@
+// Package grants provides typed helpers for the management of user grants (roles of a user on a project)
+// and project grants (delegation of a project to another organization).
+//
+// All functions take an optional orgID. If it's empty, the call is executed in the organization
+// of the authorized user.
+package grants
+
+import (
+	"context"
+	"slices"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	object "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/org"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/query"
+)
+
+// Client is the part of the [client.Client] used by this package.
+type Client interface {
+	ManagementService() management.ManagementServiceClient
+}
+
+// EnsureUserGrant ensures the user is granted exactly the provided roles on the project.
+// If the user has no grant on the project yet, a new one is created; if the roles of an existing grant
+// differ, they're replaced. The id of the (created or existing) grant is returned.
+func EnsureUserGrant(ctx context.Context, c Client, orgID, userID, projectID string, roleKeys ...string) (string, error) {
+	existing, err := listUserGrants(ctx, c, orgID,
+		&user.UserGrantQuery{Query: &user.UserGrantQuery_UserIdQuery{UserIdQuery: &user.UserGrantUserIDQuery{UserId: userID}}},
+		&user.UserGrantQuery{Query: &user.UserGrantQuery_ProjectIdQuery{ProjectIdQuery: &user.UserGrantProjectIDQuery{ProjectId: projectID}}},
+	)
+	if err != nil {
+		return "", err
+	}
+	for _, grant := range existing {
+		if grant.GetProjectGrantId() != "" {
+			continue
+		}
+		if sameRoles(grant.GetRoleKeys(), roleKeys) {
+			return grant.GetId(), nil
+		}
+		_, err = c.ManagementService().UpdateUserGrant(org.Context(ctx, orgID), &management.UpdateUserGrantRequest{
+			UserId:   userID,
+			GrantId:  grant.GetId(),
+			RoleKeys: roleKeys,
+		})
+		if err != nil {
+			return "", err
+		}
+		return grant.GetId(), nil
+	}
+	resp, err := c.ManagementService().AddUserGrant(org.Context(ctx, orgID), &management.AddUserGrantRequest{
+		UserId:    userID,
+		ProjectId: projectID,
+		RoleKeys:  roleKeys,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.GetUserGrantId(), nil
+}
+
+// GrantsForUser returns all grants of the user, including the ones on projects granted to the organization.
+func GrantsForUser(ctx context.Context, c Client, orgID, userID string) ([]*user.UserGrant, error) {
+	return listUserGrants(ctx, c, orgID,
+		&user.UserGrantQuery{Query: &user.UserGrantQuery_UserIdQuery{UserIdQuery: &user.UserGrantUserIDQuery{UserId: userID}}},
+		&user.UserGrantQuery{Query: &user.UserGrantQuery_WithGrantedQuery{WithGrantedQuery: &user.UserGrantWithGrantedQuery{WithGranted: true}}},
+	)
+}
+
+// GrantsForProject returns all user grants of the project.
+func GrantsForProject(ctx context.Context, c Client, orgID, projectID string) ([]*user.UserGrant, error) {
+	return listUserGrants(ctx, c, orgID,
+		&user.UserGrantQuery{Query: &user.UserGrantQuery_ProjectIdQuery{ProjectIdQuery: &user.UserGrantProjectIDQuery{ProjectId: projectID}}},
+	)
+}
+
+// RemoveUserGrant removes the grant of the user.
+func RemoveUserGrant(ctx context.Context, c Client, orgID, userID, grantID string) error {
+	_, err := c.ManagementService().RemoveUserGrant(org.Context(ctx, orgID), &management.RemoveUserGrantRequest{
+		UserId:  userID,
+		GrantId: grantID,
+	})
+	return err
+}
+
+func listUserGrants(ctx context.Context, c Client, orgID string, queries ...*user.UserGrantQuery) ([]*user.UserGrant, error) {
+	return query.All(org.Context(ctx, orgID), func(ctx context.Context, offset uint64, limit uint32) ([]*user.UserGrant, uint64, error) {
+		resp, err := c.ManagementService().ListUserGrants(ctx, &management.ListUserGrantRequest{
+			Query:   &object.ListQuery{Offset: offset, Limit: limit, Asc: true},
+			Queries: queries,
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		return resp.GetResult(), resp.GetDetails().GetTotalResult(), nil
+	})
+}
+
+// sameRoles compares the role keys ignoring their order.
+func sameRoles(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
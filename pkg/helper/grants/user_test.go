@@ -0,0 +1,116 @@
+package grants
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	object "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/project"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user"
+)
+
+func TestEnsureUserGrant(t *testing.T) {
+	tests := []struct {
+		name       string
+		existing   []*user.UserGrant
+		roleKeys   []string
+		wantID     string
+		wantAdd    *management.AddUserGrantRequest
+		wantUpdate *management.UpdateUserGrantRequest
+	}{
+		{
+			name:     "no grant, created",
+			roleKeys: []string{"admin"},
+			wantID:   "newGrantID",
+			wantAdd: &management.AddUserGrantRequest{
+				UserId:    "userID",
+				ProjectId: "projectID",
+				RoleKeys:  []string{"admin"},
+			},
+		},
+		{
+			name: "only project grant, created",
+			existing: []*user.UserGrant{
+				{Id: "grantID", ProjectGrantId: "projectGrantID", RoleKeys: []string{"admin"}},
+			},
+			roleKeys: []string{"admin"},
+			wantID:   "newGrantID",
+			wantAdd: &management.AddUserGrantRequest{
+				UserId:    "userID",
+				ProjectId: "projectID",
+				RoleKeys:  []string{"admin"},
+			},
+		},
+		{
+			name: "same roles, unchanged",
+			existing: []*user.UserGrant{
+				{Id: "grantID", RoleKeys: []string{"viewer", "admin"}},
+			},
+			roleKeys: []string{"admin", "viewer"},
+			wantID:   "grantID",
+		},
+		{
+			name: "different roles, updated",
+			existing: []*user.UserGrant{
+				{Id: "grantID", RoleKeys: []string{"viewer"}},
+			},
+			roleKeys: []string{"admin"},
+			wantID:   "grantID",
+			wantUpdate: &management.UpdateUserGrantRequest{
+				UserId:   "userID",
+				GrantId:  "grantID",
+				RoleKeys: []string{"admin"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &managementService{userGrants: tt.existing}
+			got, err := EnsureUserGrant(context.Background(), &testClient{service}, "", "userID", "projectID", tt.roleKeys...)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantID, got)
+			assert.Equal(t, tt.wantAdd, service.addUserGrant)
+			assert.Equal(t, tt.wantUpdate, service.updateUserGrant)
+		})
+	}
+}
+
+type testClient struct {
+	management management.ManagementServiceClient
+}
+
+func (c *testClient) ManagementService() management.ManagementServiceClient {
+	return c.management
+}
+
+type managementService struct {
+	management.ManagementServiceClient
+	userGrants      []*user.UserGrant
+	addUserGrant    *management.AddUserGrantRequest
+	updateUserGrant *management.UpdateUserGrantRequest
+
+	projectGrants      []*project.GrantedProject
+	addProjectGrant    *management.AddProjectGrantRequest
+	updateProjectGrant *management.UpdateProjectGrantRequest
+}
+
+func (s *managementService) ListUserGrants(_ context.Context, _ *management.ListUserGrantRequest, _ ...grpc.CallOption) (*management.ListUserGrantResponse, error) {
+	return &management.ListUserGrantResponse{
+		Details: &object.ListDetails{TotalResult: uint64(len(s.userGrants))},
+		Result:  s.userGrants,
+	}, nil
+}
+
+func (s *managementService) AddUserGrant(_ context.Context, req *management.AddUserGrantRequest, _ ...grpc.CallOption) (*management.AddUserGrantResponse, error) {
+	s.addUserGrant = req
+	return &management.AddUserGrantResponse{UserGrantId: "newGrantID"}, nil
+}
+
+func (s *managementService) UpdateUserGrant(_ context.Context, req *management.UpdateUserGrantRequest, _ ...grpc.CallOption) (*management.UpdateUserGrantResponse, error) {
+	s.updateUserGrant = req
+	return &management.UpdateUserGrantResponse{}, nil
+}
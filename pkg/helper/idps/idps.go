@@ -0,0 +1,303 @@
+// Package idps provides typed templates for common identity providers (Google, Azure AD, GitHub and generic OIDC),
+// which can be added to the instance ([AddToInstance]) or to an organization ([AddToOrg]).
+// The returned id can then be added to the respective login policy.
+package idps
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/admin"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/idp"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/org"
+)
+
+// Client is the part of the [client.Client] used by this package.
+type Client interface {
+	AdminService() admin.AdminServiceClient
+	ManagementService() management.ManagementServiceClient
+}
+
+// AutoLinking defines if and how an existing user is proposed to be linked on the first login with the provider.
+type AutoLinking = idp.AutoLinkingOption
+
+const (
+	AutoLinkingDisabled = idp.AutoLinkingOption_AUTO_LINKING_OPTION_UNSPECIFIED
+	AutoLinkingUsername = idp.AutoLinkingOption_AUTO_LINKING_OPTION_USERNAME
+	AutoLinkingEmail    = idp.AutoLinkingOption_AUTO_LINKING_OPTION_EMAIL
+)
+
+// AzureADTenantType defines which accounts can log in if no specific tenant id is set with [WithAzureADTenantID].
+type AzureADTenantType = idp.AzureADTenantType
+
+const (
+	AzureADTenantCommon        = idp.AzureADTenantType_AZURE_AD_TENANT_TYPE_COMMON
+	AzureADTenantOrganizations = idp.AzureADTenantType_AZURE_AD_TENANT_TYPE_ORGANISATIONS
+	AzureADTenantConsumers     = idp.AzureADTenantType_AZURE_AD_TENANT_TYPE_CONSUMERS
+)
+
+// Template is the typed configuration of an identity provider created by one of the constructors
+// ([Google], [AzureAD], [GitHub], [GenericOIDC]).
+type Template interface {
+	addToInstance(ctx context.Context, c admin.AdminServiceClient) (string, error)
+	addToOrg(ctx context.Context, c management.ManagementServiceClient) (string, error)
+}
+
+// AddToInstance adds the identity provider to the instance, so it can be used in the default login policy
+// and the login policies of all organizations.
+func AddToInstance(ctx context.Context, c Client, template Template) (string, error) {
+	return template.addToInstance(ctx, c.AdminService())
+}
+
+// AddToOrg adds the identity provider to the organization, so it can only be used in its login policy.
+// If orgID is empty, the organization of the authorized user is used.
+func AddToOrg(ctx context.Context, c Client, orgID string, template Template) (string, error) {
+	return template.addToOrg(org.Context(ctx, orgID), c.ManagementService())
+}
+
+// Option allows customization of the provider templates.
+type Option func(*config)
+
+type config struct {
+	name           string
+	scopes         []string
+	options        *idp.Options
+	tenant         *idp.AzureADTenant
+	emailVerified  bool
+	idTokenMapping bool
+}
+
+func newConfig(options []Option) *config {
+	c := &config{
+		options: &idp.Options{
+			IsLinkingAllowed:  true,
+			IsCreationAllowed: true,
+		},
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// WithName sets the name displayed on the login button. Google, Azure AD and GitHub will use their name by default.
+func WithName(name string) Option {
+	return func(c *config) {
+		c.name = name
+	}
+}
+
+// WithScopes sets the scopes requested from the provider instead of the provider's defaults.
+func WithScopes(scopes ...string) Option {
+	return func(c *config) {
+		c.scopes = scopes
+	}
+}
+
+// WithAutoCreation will automatically create a new user on the first login with the provider,
+// without the user having to confirm the registration form.
+func WithAutoCreation() Option {
+	return func(c *config) {
+		c.options.IsAutoCreation = true
+	}
+}
+
+// WithAutoUpdate will update the user's information from the provider on every login.
+func WithAutoUpdate() Option {
+	return func(c *config) {
+		c.options.IsAutoUpdate = true
+	}
+}
+
+// WithAutoLinking proposes to link an existing user found by the provided [AutoLinking] method.
+func WithAutoLinking(linking AutoLinking) Option {
+	return func(c *config) {
+		c.options.AutoLinking = linking
+	}
+}
+
+// WithoutLinking prevents users from linking the provider to their existing account.
+func WithoutLinking() Option {
+	return func(c *config) {
+		c.options.IsLinkingAllowed = false
+	}
+}
+
+// WithoutCreation prevents the creation of new users through the provider (only linking is possible).
+func WithoutCreation() Option {
+	return func(c *config) {
+		c.options.IsCreationAllowed = false
+	}
+}
+
+// WithAzureADTenantID restricts the login to the provided Azure AD tenant. Only applies to [AzureAD].
+func WithAzureADTenantID(tenantID string) Option {
+	return func(c *config) {
+		c.tenant = &idp.AzureADTenant{Type: &idp.AzureADTenant_TenantId{TenantId: tenantID}}
+	}
+}
+
+// WithAzureADTenantType restricts the login to the provided type of accounts. Only applies to [AzureAD].
+func WithAzureADTenantType(tenantType AzureADTenantType) Option {
+	return func(c *config) {
+		c.tenant = &idp.AzureADTenant{Type: &idp.AzureADTenant_TenantType{TenantType: tenantType}}
+	}
+}
+
+// WithEmailVerified trusts the email addresses of the provider as verified. Only applies to [AzureAD].
+func WithEmailVerified() Option {
+	return func(c *config) {
+		c.emailVerified = true
+	}
+}
+
+// WithIDTokenMapping maps the user information from the id_token instead of the userinfo endpoint.
+// Only applies to [GenericOIDC].
+func WithIDTokenMapping() Option {
+	return func(c *config) {
+		c.idTokenMapping = true
+	}
+}
+
+type googleTemplate struct {
+	clientID     string
+	clientSecret string
+	*config
+}
+
+// Google returns the template for a Google provider using the OAuth client of the Google Cloud Console.
+func Google(clientID, clientSecret string, options ...Option) Template {
+	return &googleTemplate{clientID: clientID, clientSecret: clientSecret, config: newConfig(options)}
+}
+
+func (t *googleTemplate) addToInstance(ctx context.Context, c admin.AdminServiceClient) (string, error) {
+	resp, err := c.AddGoogleProvider(ctx, &admin.AddGoogleProviderRequest{
+		Name:            t.name,
+		ClientId:        t.clientID,
+		ClientSecret:    t.clientSecret,
+		Scopes:          t.scopes,
+		ProviderOptions: t.options,
+	})
+	return resp.GetId(), err
+}
+
+func (t *googleTemplate) addToOrg(ctx context.Context, c management.ManagementServiceClient) (string, error) {
+	resp, err := c.AddGoogleProvider(ctx, &management.AddGoogleProviderRequest{
+		Name:            t.name,
+		ClientId:        t.clientID,
+		ClientSecret:    t.clientSecret,
+		Scopes:          t.scopes,
+		ProviderOptions: t.options,
+	})
+	return resp.GetId(), err
+}
+
+type azureADTemplate struct {
+	clientID     string
+	clientSecret string
+	*config
+}
+
+// AzureAD returns the template for an Azure AD (Microsoft Entra ID) provider using an app registration.
+// Use [WithAzureADTenantID] or [WithAzureADTenantType] to restrict the accounts (defaults to common).
+func AzureAD(clientID, clientSecret string, options ...Option) Template {
+	return &azureADTemplate{clientID: clientID, clientSecret: clientSecret, config: newConfig(options)}
+}
+
+func (t *azureADTemplate) addToInstance(ctx context.Context, c admin.AdminServiceClient) (string, error) {
+	resp, err := c.AddAzureADProvider(ctx, &admin.AddAzureADProviderRequest{
+		Name:            t.name,
+		ClientId:        t.clientID,
+		ClientSecret:    t.clientSecret,
+		Tenant:          t.tenant,
+		EmailVerified:   t.emailVerified,
+		Scopes:          t.scopes,
+		ProviderOptions: t.options,
+	})
+	return resp.GetId(), err
+}
+
+func (t *azureADTemplate) addToOrg(ctx context.Context, c management.ManagementServiceClient) (string, error) {
+	resp, err := c.AddAzureADProvider(ctx, &management.AddAzureADProviderRequest{
+		Name:            t.name,
+		ClientId:        t.clientID,
+		ClientSecret:    t.clientSecret,
+		Tenant:          t.tenant,
+		EmailVerified:   t.emailVerified,
+		Scopes:          t.scopes,
+		ProviderOptions: t.options,
+	})
+	return resp.GetId(), err
+}
+
+type gitHubTemplate struct {
+	clientID     string
+	clientSecret string
+	*config
+}
+
+// GitHub returns the template for a GitHub provider using an OAuth app of github.com.
+func GitHub(clientID, clientSecret string, options ...Option) Template {
+	return &gitHubTemplate{clientID: clientID, clientSecret: clientSecret, config: newConfig(options)}
+}
+
+func (t *gitHubTemplate) addToInstance(ctx context.Context, c admin.AdminServiceClient) (string, error) {
+	resp, err := c.AddGitHubProvider(ctx, &admin.AddGitHubProviderRequest{
+		Name:            t.name,
+		ClientId:        t.clientID,
+		ClientSecret:    t.clientSecret,
+		Scopes:          t.scopes,
+		ProviderOptions: t.options,
+	})
+	return resp.GetId(), err
+}
+
+func (t *gitHubTemplate) addToOrg(ctx context.Context, c management.ManagementServiceClient) (string, error) {
+	resp, err := c.AddGitHubProvider(ctx, &management.AddGitHubProviderRequest{
+		Name:            t.name,
+		ClientId:        t.clientID,
+		ClientSecret:    t.clientSecret,
+		Scopes:          t.scopes,
+		ProviderOptions: t.options,
+	})
+	return resp.GetId(), err
+}
+
+type genericOIDCTemplate struct {
+	issuer       string
+	clientID     string
+	clientSecret string
+	*config
+}
+
+// GenericOIDC returns the template for any OpenID Connect provider supporting the discovery on the issuer.
+// The name is required as it can't be derived from the provider.
+func GenericOIDC(name, issuer, clientID, clientSecret string, options ...Option) Template {
+	return &genericOIDCTemplate{issuer: issuer, clientID: clientID, clientSecret: clientSecret, config: newConfig(append([]Option{WithName(name)}, options...))}
+}
+
+func (t *genericOIDCTemplate) addToInstance(ctx context.Context, c admin.AdminServiceClient) (string, error) {
+	resp, err := c.AddGenericOIDCProvider(ctx, &admin.AddGenericOIDCProviderRequest{
+		Name:             t.name,
+		Issuer:           t.issuer,
+		ClientId:         t.clientID,
+		ClientSecret:     t.clientSecret,
+		Scopes:           t.scopes,
+		ProviderOptions:  t.options,
+		IsIdTokenMapping: t.idTokenMapping,
+	})
+	return resp.GetId(), err
+}
+
+func (t *genericOIDCTemplate) addToOrg(ctx context.Context, c management.ManagementServiceClient) (string, error) {
+	resp, err := c.AddGenericOIDCProvider(ctx, &management.AddGenericOIDCProviderRequest{
+		Name:             t.name,
+		Issuer:           t.issuer,
+		ClientId:         t.clientID,
+		ClientSecret:     t.clientSecret,
+		Scopes:           t.scopes,
+		ProviderOptions:  t.options,
+		IsIdTokenMapping: t.idTokenMapping,
+	})
+	return resp.GetId(), err
+}
@@ -0,0 +1,102 @@
+package idps
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/admin"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/idp"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+)
+
+func TestAddToInstance(t *testing.T) {
+	service := &adminService{}
+	id, err := AddToInstance(context.Background(), &testClient{admin: service},
+		AzureAD("clientID", "clientSecret",
+			WithAzureADTenantID("tenantID"),
+			WithEmailVerified(),
+			WithAutoCreation(),
+			WithAutoLinking(AutoLinkingEmail),
+		),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "idpID", id)
+	assert.Equal(t, &admin.AddAzureADProviderRequest{
+		ClientId:      "clientID",
+		ClientSecret:  "clientSecret",
+		Tenant:        &idp.AzureADTenant{Type: &idp.AzureADTenant_TenantId{TenantId: "tenantID"}},
+		EmailVerified: true,
+		ProviderOptions: &idp.Options{
+			IsLinkingAllowed:  true,
+			IsCreationAllowed: true,
+			IsAutoCreation:    true,
+			AutoLinking:       AutoLinkingEmail,
+		},
+	}, service.azureAD)
+}
+
+func TestAddToOrg(t *testing.T) {
+	service := &managementService{}
+	id, err := AddToOrg(context.Background(), &testClient{management: service}, "orgID",
+		GenericOIDC("Keycloak", "https://keycloak.example.com/realms/acme", "clientID", "clientSecret",
+			WithScopes("openid", "profile", "email"),
+			WithIDTokenMapping(),
+			WithoutCreation(),
+		),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "idpID", id)
+	assert.Equal(t, []string{"orgID"}, service.orgIDs)
+	assert.Equal(t, &management.AddGenericOIDCProviderRequest{
+		Name:             "Keycloak",
+		Issuer:           "https://keycloak.example.com/realms/acme",
+		ClientId:         "clientID",
+		ClientSecret:     "clientSecret",
+		Scopes:           []string{"openid", "profile", "email"},
+		IsIdTokenMapping: true,
+		ProviderOptions: &idp.Options{
+			IsLinkingAllowed: true,
+		},
+	}, service.genericOIDC)
+}
+
+type testClient struct {
+	admin      admin.AdminServiceClient
+	management management.ManagementServiceClient
+}
+
+func (c *testClient) AdminService() admin.AdminServiceClient {
+	return c.admin
+}
+
+func (c *testClient) ManagementService() management.ManagementServiceClient {
+	return c.management
+}
+
+type adminService struct {
+	admin.AdminServiceClient
+	azureAD *admin.AddAzureADProviderRequest
+}
+
+func (s *adminService) AddAzureADProvider(_ context.Context, req *admin.AddAzureADProviderRequest, _ ...grpc.CallOption) (*admin.AddAzureADProviderResponse, error) {
+	s.azureAD = req
+	return &admin.AddAzureADProviderResponse{Id: "idpID"}, nil
+}
+
+type managementService struct {
+	management.ManagementServiceClient
+	orgIDs      []string
+	genericOIDC *management.AddGenericOIDCProviderRequest
+}
+
+func (s *managementService) AddGenericOIDCProvider(ctx context.Context, req *management.AddGenericOIDCProviderRequest, _ ...grpc.CallOption) (*management.AddGenericOIDCProviderResponse, error) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	s.orgIDs = md.Get("x-zitadel-orgid")
+	s.genericOIDC = req
+	return &management.AddGenericOIDCProviderResponse{Id: "idpID"}, nil
+}
@@ -0,0 +1,130 @@
+// Package instance provides typed helpers for the configuration of an instance, which is not covered
+// by its policies: the restrictions and secret generators (Admin API) as well as the limits and quotas (System API).
+package instance
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/admin"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/system"
+)
+
+var (
+	ErrInvalidRetention = errors.New("audit log retention must be positive")
+)
+
+// Client is the part of the [client.Client] used for the restrictions and secret generators of the instance.
+type Client interface {
+	AdminService() admin.AdminServiceClient
+}
+
+// SystemClient is the part of the [client.Client] used for the limits and quotas of instances.
+// It requires a client authorized for the System API.
+type SystemClient interface {
+	SystemService() system.SystemServiceClient
+}
+
+// Restrictions restrict the functionality of the instance.
+type Restrictions struct {
+	// DisallowPublicOrgRegistration prevents the self-registration of organizations by users.
+	DisallowPublicOrgRegistration bool
+	// AllowedLanguages are the languages the Login UI, Console and messages can be used in.
+	// If empty, all languages supported by ZITADEL are allowed.
+	AllowedLanguages []string
+}
+
+// GetRestrictions returns the restrictions of the instance.
+func GetRestrictions(ctx context.Context, c Client) (*Restrictions, error) {
+	resp, err := c.AdminService().GetRestrictions(ctx, &admin.GetRestrictionsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return &Restrictions{
+		DisallowPublicOrgRegistration: resp.GetDisallowPublicOrgRegistration(),
+		AllowedLanguages:              resp.GetAllowedLanguages(),
+	}, nil
+}
+
+// RestrictionOption changes a single restriction in [SetRestrictions].
+type RestrictionOption func(*admin.SetRestrictionsRequest)
+
+// WithDisallowPublicOrgRegistration allows or disallows the self-registration of organizations.
+func WithDisallowPublicOrgRegistration(disallow bool) RestrictionOption {
+	return func(req *admin.SetRestrictionsRequest) {
+		req.DisallowPublicOrgRegistration = &disallow
+	}
+}
+
+// WithAllowedLanguages restricts the languages to the provided ones (e.g. "en", "de").
+// The default language of the instance must be part of them.
+func WithAllowedLanguages(languages ...string) RestrictionOption {
+	return func(req *admin.SetRestrictionsRequest) {
+		req.AllowedLanguages = &admin.SelectLanguages{List: languages}
+	}
+}
+
+// WithAllLanguages removes the restriction of the languages.
+func WithAllLanguages() RestrictionOption {
+	return WithAllowedLanguages()
+}
+
+// SetRestrictions changes the restrictions of the provided options, any other restriction is kept as is.
+func SetRestrictions(ctx context.Context, c Client, options ...RestrictionOption) error {
+	if len(options) == 0 {
+		return nil
+	}
+	req := new(admin.SetRestrictionsRequest)
+	for _, option := range options {
+		option(req)
+	}
+	_, err := c.AdminService().SetRestrictions(ctx, req)
+	return err
+}
+
+// LimitOption changes a single limit in [SetLimits].
+type LimitOption func(*system.SetLimitsRequest) error
+
+// WithAuditLogRetention limits the time events are returned by the audit log (e.g. events API and Console).
+// The events are not removed from the storage.
+func WithAuditLogRetention(retention time.Duration) LimitOption {
+	return func(req *system.SetLimitsRequest) error {
+		if retention <= 0 {
+			return ErrInvalidRetention
+		}
+		req.AuditLogRetention = durationpb.New(retention)
+		return nil
+	}
+}
+
+// WithBlock blocks (or unblocks) all requests to the instance, e.g. for unpaid or abusive tenants.
+func WithBlock(block bool) LimitOption {
+	return func(req *system.SetLimitsRequest) error {
+		req.Block = &block
+		return nil
+	}
+}
+
+// SetLimits changes the limits of the provided options for the instance, any other limit is kept as is.
+func SetLimits(ctx context.Context, c SystemClient, instanceID string, options ...LimitOption) error {
+	if len(options) == 0 {
+		return nil
+	}
+	req := &system.SetLimitsRequest{InstanceId: instanceID}
+	for _, option := range options {
+		if err := option(req); err != nil {
+			return err
+		}
+	}
+	_, err := c.SystemService().SetLimits(ctx, req)
+	return err
+}
+
+// ResetLimits removes all limits of the instance.
+func ResetLimits(ctx context.Context, c SystemClient, instanceID string) error {
+	_, err := c.SystemService().ResetLimits(ctx, &system.ResetLimitsRequest{InstanceId: instanceID})
+	return err
+}
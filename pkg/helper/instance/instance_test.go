@@ -0,0 +1,94 @@
+package instance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/admin"
+	object "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/settings"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/system"
+)
+
+type adminService struct {
+	admin.AdminServiceClient
+	requests   []*admin.SetRestrictionsRequest
+	generators []*settings.SecretGenerator
+	updates    []*admin.UpdateSecretGeneratorRequest
+}
+
+func (s *adminService) AdminService() admin.AdminServiceClient {
+	return s
+}
+
+func (s *adminService) SetRestrictions(_ context.Context, req *admin.SetRestrictionsRequest, _ ...grpc.CallOption) (*admin.SetRestrictionsResponse, error) {
+	s.requests = append(s.requests, req)
+	return &admin.SetRestrictionsResponse{}, nil
+}
+
+func (s *adminService) ListSecretGenerators(context.Context, *admin.ListSecretGeneratorsRequest, ...grpc.CallOption) (*admin.ListSecretGeneratorsResponse, error) {
+	return &admin.ListSecretGeneratorsResponse{
+		Details: &object.ListDetails{TotalResult: uint64(len(s.generators))},
+		Result:  s.generators,
+	}, nil
+}
+
+func (s *adminService) UpdateSecretGenerator(_ context.Context, req *admin.UpdateSecretGeneratorRequest, _ ...grpc.CallOption) (*admin.UpdateSecretGeneratorResponse, error) {
+	s.updates = append(s.updates, req)
+	return &admin.UpdateSecretGeneratorResponse{}, nil
+}
+
+type systemService struct {
+	system.SystemServiceClient
+	requests []*system.SetLimitsRequest
+	quotas   []*system.SetQuotaRequest
+}
+
+func (s *systemService) SystemService() system.SystemServiceClient {
+	return s
+}
+
+func (s *systemService) SetLimits(_ context.Context, req *system.SetLimitsRequest, _ ...grpc.CallOption) (*system.SetLimitsResponse, error) {
+	s.requests = append(s.requests, req)
+	return &system.SetLimitsResponse{}, nil
+}
+
+func (s *systemService) SetQuota(_ context.Context, req *system.SetQuotaRequest, _ ...grpc.CallOption) (*system.SetQuotaResponse, error) {
+	s.quotas = append(s.quotas, req)
+	return &system.SetQuotaResponse{}, nil
+}
+
+func TestSetRestrictions(t *testing.T) {
+	c := new(adminService)
+	require.NoError(t, SetRestrictions(context.Background(), c))
+	assert.Empty(t, c.requests)
+
+	require.NoError(t, SetRestrictions(context.Background(), c, WithDisallowPublicOrgRegistration(true)))
+	require.Len(t, c.requests, 1)
+	assert.True(t, c.requests[0].GetDisallowPublicOrgRegistration())
+	assert.Nil(t, c.requests[0].AllowedLanguages)
+
+	require.NoError(t, SetRestrictions(context.Background(), c, WithAllLanguages()))
+	require.Len(t, c.requests, 2)
+	assert.Nil(t, c.requests[1].DisallowPublicOrgRegistration)
+	assert.NotNil(t, c.requests[1].GetAllowedLanguages())
+	assert.Empty(t, c.requests[1].GetAllowedLanguages().GetList())
+}
+
+func TestSetLimits(t *testing.T) {
+	c := new(systemService)
+	err := SetLimits(context.Background(), c, "instance", WithAuditLogRetention(0))
+	assert.ErrorIs(t, err, ErrInvalidRetention)
+	assert.Empty(t, c.requests)
+
+	require.NoError(t, SetLimits(context.Background(), c, "instance", WithAuditLogRetention(90*24*time.Hour)))
+	require.Len(t, c.requests, 1)
+	assert.Equal(t, "instance", c.requests[0].GetInstanceId())
+	assert.Equal(t, 90*24*time.Hour, c.requests[0].GetAuditLogRetention().AsDuration())
+	assert.Nil(t, c.requests[0].Block)
+}
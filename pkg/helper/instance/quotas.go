@@ -0,0 +1,150 @@
+package instance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/quota"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/system"
+)
+
+var (
+	ErrInvalidQuota = errors.New("invalid quota")
+)
+
+// QuotaUnit is the unit a quota is imposed on.
+type QuotaUnit = quota.Unit
+
+const (
+	// QuotaAuthenticatedRequests counts all authenticated requests to the APIs of the instance.
+	QuotaAuthenticatedRequests = quota.Unit_UNIT_REQUESTS_ALL_AUTHENTICATED
+	// QuotaActionRunSeconds counts the seconds all actions of the instance ran.
+	QuotaActionRunSeconds = quota.Unit_UNIT_ACTIONS_ALL_RUN_SECONDS
+)
+
+// Quota is the amount of units an instance can use per period.
+//
+// ZITADEL provides no API to query the configured quotas or the current usage.
+// Use the Notifications to get informed about the usage, see [QuotaNotificationHandler].
+type Quota struct {
+	Unit QuotaUnit
+	// From is the start of the first period, the following periods are calculated from it.
+	From time.Time
+	// ResetInterval is the duration of a period, e.g. 30 days.
+	ResetInterval time.Duration
+	Amount        uint64
+	// Limit will block any further usage once the amount is reached in the current period.
+	Limit         bool
+	Notifications []QuotaNotification
+}
+
+// QuotaNotification lets ZITADEL call the URL once the usage reaches the percentage of the amount.
+type QuotaNotification struct {
+	Percent uint32
+	// Repeat calls the URL every time a multiple of the percentage is reached.
+	Repeat bool
+	URL    string
+}
+
+// Validate checks the quota for missing or invalid values before it is sent to ZITADEL.
+func (q *Quota) Validate() error {
+	if _, ok := quota.Unit_name[int32(q.Unit)]; !ok || q.Unit == quota.Unit_UNIT_UNIMPLEMENTED {
+		return fmt.Errorf("%w: unknown unit `%d`", ErrInvalidQuota, q.Unit)
+	}
+	if q.Amount == 0 {
+		return fmt.Errorf("%w: amount must be positive", ErrInvalidQuota)
+	}
+	if q.ResetInterval <= 0 {
+		return fmt.Errorf("%w: reset interval must be positive", ErrInvalidQuota)
+	}
+	for _, notification := range q.Notifications {
+		if notification.Percent == 0 {
+			return fmt.Errorf("%w: notification percent must be positive", ErrInvalidQuota)
+		}
+		if u, err := url.Parse(notification.URL); err != nil || !u.IsAbs() {
+			return fmt.Errorf("%w: notification url `%s` must be absolute", ErrInvalidQuota, notification.URL)
+		}
+	}
+	return nil
+}
+
+func (q *Quota) notifications() []*quota.Notification {
+	notifications := make([]*quota.Notification, len(q.Notifications))
+	for i, notification := range q.Notifications {
+		notifications[i] = &quota.Notification{
+			Percent: notification.Percent,
+			Repeat:  notification.Repeat,
+			CallUrl: notification.URL,
+		}
+	}
+	return notifications
+}
+
+// SetQuota creates or replaces the quota of the unit of the instance.
+func SetQuota(ctx context.Context, c SystemClient, instanceID string, q *Quota) error {
+	if err := q.Validate(); err != nil {
+		return err
+	}
+	_, err := c.SystemService().SetQuota(ctx, &system.SetQuotaRequest{
+		InstanceId:    instanceID,
+		Unit:          q.Unit,
+		From:          timestamppb.New(q.From),
+		ResetInterval: durationpb.New(q.ResetInterval),
+		Amount:        q.Amount,
+		Limit:         q.Limit,
+		Notifications: q.notifications(),
+	})
+	return err
+}
+
+// RemoveQuota removes the quota of the unit of the instance.
+func RemoveQuota(ctx context.Context, c SystemClient, instanceID string, unit QuotaUnit) error {
+	_, err := c.SystemService().RemoveQuota(ctx, &system.RemoveQuotaRequest{
+		InstanceId: instanceID,
+		Unit:       unit,
+	})
+	return err
+}
+
+// QuotaUsage is the payload ZITADEL posts to the URL of a [QuotaNotification].
+type QuotaUsage struct {
+	// ID is the id of the notification.
+	ID          string    `json:"id"`
+	Unit        QuotaUnit `json:"unit"`
+	CallURL     string    `json:"callURL"`
+	PeriodStart time.Time `json:"periodStart"`
+	// Threshold is the amount of units which triggered the notification.
+	Threshold uint64 `json:"threshold"`
+	// Usage is the amount of units used in the current period.
+	Usage uint64 `json:"usage"`
+}
+
+// QuotaNotificationHandler returns an [http.Handler] to be served on the URL of a [QuotaNotification].
+// It decodes the usage posted by ZITADEL and passes it to the callback.
+// If the callback returns an error, ZITADEL will be answered with a 500 status.
+func QuotaNotificationHandler(callback func(ctx context.Context, usage *QuotaUsage) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		usage := new(QuotaUsage)
+		if err := json.NewDecoder(r.Body).Decode(usage); err != nil {
+			http.Error(w, "invalid quota notification", http.StatusBadRequest)
+			return
+		}
+		if err := callback(r.Context(), usage); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
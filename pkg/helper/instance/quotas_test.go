@@ -0,0 +1,154 @@
+package instance
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuota_Validate(t *testing.T) {
+	valid := func() *Quota {
+		return &Quota{
+			Unit:          QuotaAuthenticatedRequests,
+			ResetInterval: 30 * 24 * time.Hour,
+			Amount:        1000,
+			Notifications: []QuotaNotification{{Percent: 80, URL: "https://hooks.example.com/quota"}},
+		}
+	}
+	tests := []struct {
+		name    string
+		modify  func(q *Quota)
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			modify: func(*Quota) {},
+		},
+		{
+			name:    "unknown unit",
+			modify:  func(q *Quota) { q.Unit = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "missing amount",
+			modify:  func(q *Quota) { q.Amount = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "missing reset interval",
+			modify:  func(q *Quota) { q.ResetInterval = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "notification without percent",
+			modify:  func(q *Quota) { q.Notifications[0].Percent = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "notification with relative url",
+			modify:  func(q *Quota) { q.Notifications[0].URL = "/quota" },
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := valid()
+			tt.modify(q)
+			err := q.Validate()
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidQuota)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSetQuota(t *testing.T) {
+	c := new(systemService)
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	err := SetQuota(context.Background(), c, "instance", &Quota{
+		Unit:          QuotaActionRunSeconds,
+		From:          from,
+		ResetInterval: time.Hour,
+		Amount:        3600,
+		Limit:         true,
+		Notifications: []QuotaNotification{{Percent: 50, Repeat: true, URL: "https://hooks.example.com/quota"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, c.quotas, 1)
+	req := c.quotas[0]
+	assert.Equal(t, "instance", req.GetInstanceId())
+	assert.Equal(t, QuotaActionRunSeconds, req.GetUnit())
+	assert.Equal(t, from, req.GetFrom().AsTime())
+	assert.Equal(t, time.Hour, req.GetResetInterval().AsDuration())
+	assert.True(t, req.GetLimit())
+	require.Len(t, req.GetNotifications(), 1)
+	assert.Equal(t, "https://hooks.example.com/quota", req.GetNotifications()[0].GetCallUrl())
+	assert.True(t, req.GetNotifications()[0].GetRepeat())
+}
+
+func TestQuotaNotificationHandler(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		body        string
+		callbackErr error
+		wantStatus  int
+		wantUsage   *QuotaUsage
+	}{
+		{
+			name:       "wrong method",
+			method:     http.MethodGet,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "invalid body",
+			method:     http.MethodPost,
+			body:       "{",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "callback error",
+			method:      http.MethodPost,
+			body:        `{"id":"n1","unit":1,"threshold":800,"usage":801}`,
+			callbackErr: errors.New("failed"),
+			wantStatus:  http.StatusInternalServerError,
+			wantUsage:   &QuotaUsage{ID: "n1", Unit: QuotaAuthenticatedRequests, Threshold: 800, Usage: 801},
+		},
+		{
+			name:       "ok",
+			method:     http.MethodPost,
+			body:       `{"id":"n1","unit":2,"callURL":"https://hooks.example.com/quota","periodStart":"2024-01-01T00:00:00Z","threshold":1800,"usage":1850}`,
+			wantStatus: http.StatusOK,
+			wantUsage: &QuotaUsage{
+				ID:          "n1",
+				Unit:        QuotaActionRunSeconds,
+				CallURL:     "https://hooks.example.com/quota",
+				PeriodStart: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				Threshold:   1800,
+				Usage:       1850,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *QuotaUsage
+			handler := QuotaNotificationHandler(func(_ context.Context, usage *QuotaUsage) error {
+				got = usage
+				return tt.callbackErr
+			})
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(tt.method, "/quota", strings.NewReader(tt.body)))
+			assert.Equal(t, tt.wantStatus, recorder.Code)
+			assert.Equal(t, tt.wantUsage, got)
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package instance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/admin"
+	object "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/settings"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/query"
+)
+
+var (
+	ErrInvalidSecretGenerator = errors.New("invalid secret generator")
+)
+
+// SecretGeneratorType is the type of secret (code) generated by a generator.
+type SecretGeneratorType = settings.SecretGeneratorType
+
+const (
+	SecretInitCode             = settings.SecretGeneratorType_SECRET_GENERATOR_TYPE_INIT_CODE
+	SecretVerifyEmailCode      = settings.SecretGeneratorType_SECRET_GENERATOR_TYPE_VERIFY_EMAIL_CODE
+	SecretVerifyPhoneCode      = settings.SecretGeneratorType_SECRET_GENERATOR_TYPE_VERIFY_PHONE_CODE
+	SecretPasswordResetCode    = settings.SecretGeneratorType_SECRET_GENERATOR_TYPE_PASSWORD_RESET_CODE
+	SecretPasswordlessInitCode = settings.SecretGeneratorType_SECRET_GENERATOR_TYPE_PASSWORDLESS_INIT_CODE
+	SecretAppSecret            = settings.SecretGeneratorType_SECRET_GENERATOR_TYPE_APP_SECRET
+	SecretOTPSMS               = settings.SecretGeneratorType_SECRET_GENERATOR_TYPE_OTP_SMS
+	SecretOTPEmail             = settings.SecretGeneratorType_SECRET_GENERATOR_TYPE_OTP_EMAIL
+)
+
+// SecretGenerator defines the length, expiry and character sets of the secrets of a type.
+type SecretGenerator struct {
+	Type   SecretGeneratorType
+	Length uint32
+	// Expiry is the duration a generated secret is valid.
+	Expiry       time.Duration
+	LowerLetters bool
+	UpperLetters bool
+	Digits       bool
+	Symbols      bool
+}
+
+// Validate checks the generator for a length and at least one character set.
+func (g *SecretGenerator) Validate() error {
+	if g.Type == settings.SecretGeneratorType_SECRET_GENERATOR_TYPE_UNSPECIFIED {
+		return fmt.Errorf("%w: missing type", ErrInvalidSecretGenerator)
+	}
+	if g.Length == 0 {
+		return fmt.Errorf("%w: `%s`: length must be positive", ErrInvalidSecretGenerator, g.Type)
+	}
+	if !g.LowerLetters && !g.UpperLetters && !g.Digits && !g.Symbols {
+		return fmt.Errorf("%w: `%s`: at least one character set must be included", ErrInvalidSecretGenerator, g.Type)
+	}
+	return nil
+}
+
+func secretGeneratorFromProto(g *settings.SecretGenerator) *SecretGenerator {
+	return &SecretGenerator{
+		Type:         g.GetGeneratorType(),
+		Length:       g.GetLength(),
+		Expiry:       g.GetExpiry().AsDuration(),
+		LowerLetters: g.GetIncludeLowerLetters(),
+		UpperLetters: g.GetIncludeUpperLetters(),
+		Digits:       g.GetIncludeDigits(),
+		Symbols:      g.GetIncludeSymbols(),
+	}
+}
+
+// GetSecretGenerator returns the generator of the type.
+func GetSecretGenerator(ctx context.Context, c Client, generatorType SecretGeneratorType) (*SecretGenerator, error) {
+	resp, err := c.AdminService().GetSecretGenerator(ctx, &admin.GetSecretGeneratorRequest{GeneratorType: generatorType})
+	if err != nil {
+		return nil, err
+	}
+	return secretGeneratorFromProto(resp.GetSecretGenerator()), nil
+}
+
+// ListSecretGenerators returns the generators of all types.
+func ListSecretGenerators(ctx context.Context, c Client) ([]*SecretGenerator, error) {
+	generators, err := query.All(ctx, func(ctx context.Context, offset uint64, limit uint32) ([]*settings.SecretGenerator, uint64, error) {
+		resp, err := c.AdminService().ListSecretGenerators(ctx, &admin.ListSecretGeneratorsRequest{
+			Query: &object.ListQuery{Offset: offset, Limit: limit, Asc: true},
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		return resp.GetResult(), resp.GetDetails().GetTotalResult(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*SecretGenerator, len(generators))
+	for i, generator := range generators {
+		result[i] = secretGeneratorFromProto(generator)
+	}
+	return result, nil
+}
+
+// UpdateSecretGenerator replaces the generator of its type.
+func UpdateSecretGenerator(ctx context.Context, c Client, generator *SecretGenerator) error {
+	if err := generator.Validate(); err != nil {
+		return err
+	}
+	_, err := c.AdminService().UpdateSecretGenerator(ctx, &admin.UpdateSecretGeneratorRequest{
+		GeneratorType:       generator.Type,
+		Length:              generator.Length,
+		Expiry:              durationpb.New(generator.Expiry),
+		IncludeLowerLetters: generator.LowerLetters,
+		IncludeUpperLetters: generator.UpperLetters,
+		IncludeDigits:       generator.Digits,
+		IncludeSymbols:      generator.Symbols,
+	})
+	return err
+}
+
+// EnsureSecretGenerators updates the generators differing from the desired ones and returns their types.
+// All generators are validated before any update is made.
+func EnsureSecretGenerators(ctx context.Context, c Client, desired ...*SecretGenerator) ([]SecretGeneratorType, error) {
+	for _, generator := range desired {
+		if err := generator.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	existing, err := ListSecretGenerators(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	current := make(map[SecretGeneratorType]SecretGenerator, len(existing))
+	for _, generator := range existing {
+		current[generator.Type] = *generator
+	}
+	var updated []SecretGeneratorType
+	for _, generator := range desired {
+		if existing, ok := current[generator.Type]; ok && existing == *generator {
+			continue
+		}
+		if err = UpdateSecretGenerator(ctx, c, generator); err != nil {
+			return updated, fmt.Errorf("unable to update secret generator `%s`: %w", generator.Type, err)
+		}
+		updated = append(updated, generator.Type)
+	}
+	return updated, nil
+}
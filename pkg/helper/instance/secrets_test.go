@@ -0,0 +1,42 @@
+package instance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/settings"
+)
+
+func TestEnsureSecretGenerators(t *testing.T) {
+	c := &adminService{
+		generators: []*settings.SecretGenerator{
+			{GeneratorType: SecretInitCode, Length: 6, Expiry: durationpb.New(72 * time.Hour), IncludeUpperLetters: true, IncludeDigits: true},
+			{GeneratorType: SecretPasswordResetCode, Length: 6, Expiry: durationpb.New(time.Hour), IncludeDigits: true},
+		},
+	}
+	updated, err := EnsureSecretGenerators(context.Background(), c,
+		&SecretGenerator{Type: SecretInitCode, Length: 6, Expiry: 72 * time.Hour, UpperLetters: true, Digits: true},
+		&SecretGenerator{Type: SecretPasswordResetCode, Length: 8, Expiry: 15 * time.Minute, Digits: true},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []SecretGeneratorType{SecretPasswordResetCode}, updated)
+	require.Len(t, c.updates, 1)
+	assert.Equal(t, uint32(8), c.updates[0].GetLength())
+	assert.Equal(t, 15*time.Minute, c.updates[0].GetExpiry().AsDuration())
+	assert.True(t, c.updates[0].GetIncludeDigits())
+}
+
+func TestEnsureSecretGenerators_invalid(t *testing.T) {
+	c := new(adminService)
+	_, err := EnsureSecretGenerators(context.Background(), c,
+		&SecretGenerator{Type: SecretInitCode, Length: 6, Digits: true},
+		&SecretGenerator{Type: SecretOTPSMS, Length: 6},
+	)
+	assert.ErrorIs(t, err, ErrInvalidSecretGenerator)
+	assert.Empty(t, c.updates)
+}
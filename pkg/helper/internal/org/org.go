@@ -0,0 +1,18 @@
+// Package org provides shared functionality for calls executed in the context of an organization.
+package org
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/middleware"
+)
+
+// Context sets the organization context of the subsequent call.
+// If the orgID is empty, the context is returned unchanged and the call will be executed
+// in the organization of the authorized user.
+func Context(ctx context.Context, orgID string) context.Context {
+	if orgID == "" {
+		return ctx
+	}
+	return middleware.SetOrgID(ctx, orgID)
+}
@@ -0,0 +1,26 @@
+// Package query provides shared functionality for the list endpoints used by the helper packages.
+package query
+
+import "context"
+
+// PageSize is the number of results requested per call when listing all results.
+const PageSize uint32 = 100
+
+// Page retrieves a single page of results starting at the provided offset
+// and returns the results as well as the total number of results.
+type Page[T any] func(ctx context.Context, offset uint64, limit uint32) (results []T, total uint64, err error)
+
+// All will call the provided [Page] until all results are retrieved.
+func All[T any](ctx context.Context, page Page[T]) ([]T, error) {
+	var all []T
+	for {
+		results, total, err := page(ctx, uint64(len(all)), PageSize)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, results...)
+		if len(results) == 0 || uint64(len(all)) >= total {
+			return all, nil
+		}
+	}
+}
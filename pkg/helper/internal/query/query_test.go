@@ -0,0 +1,77 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errPage = errors.New("page failed")
+
+func TestAll(t *testing.T) {
+	type page struct {
+		results []int
+		total   uint64
+		err     error
+	}
+	tests := []struct {
+		name        string
+		pages       []page
+		want        []int
+		wantOffsets []uint64
+		wantErr     error
+	}{
+		{
+			name: "single page",
+			pages: []page{
+				{results: []int{1, 2}, total: 2},
+			},
+			want:        []int{1, 2},
+			wantOffsets: []uint64{0},
+		},
+		{
+			name: "multiple pages",
+			pages: []page{
+				{results: []int{1, 2}, total: 5},
+				{results: []int{3, 4}, total: 5},
+				{results: []int{5}, total: 5},
+			},
+			want:        []int{1, 2, 3, 4, 5},
+			wantOffsets: []uint64{0, 2, 4},
+		},
+		{
+			name: "empty page, stops",
+			pages: []page{
+				{results: []int{1, 2}, total: 5},
+				{results: nil, total: 5},
+			},
+			want:        []int{1, 2},
+			wantOffsets: []uint64{0, 2},
+		},
+		{
+			name: "error, propagated",
+			pages: []page{
+				{results: []int{1, 2}, total: 5},
+				{err: errPage},
+			},
+			wantOffsets: []uint64{0, 2},
+			wantErr:     errPage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var offsets []uint64
+			got, err := All(context.Background(), func(_ context.Context, offset uint64, limit uint32) ([]int, uint64, error) {
+				assert.Equal(t, PageSize, limit)
+				p := tt.pages[len(offsets)]
+				offsets = append(offsets, offset)
+				return p.results, p.total, p.err
+			})
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOffsets, offsets)
+		})
+	}
+}
@@ -0,0 +1,223 @@
+// Package machinekeys provides typed helpers for the lifecycle of the keys of machine users (service accounts),
+// including a [Rotate] to replace the keys of a machine user by a new verified key.
+//
+// All functions take an optional orgID. If it's empty, the call is executed in the organization
+// of the authorized user.
+package machinekeys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/zitadel/oidc/v3/pkg/client"
+	"github.com/zitadel/oidc/v3/pkg/client/profile"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/authn"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	object "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/org"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/query"
+)
+
+var (
+	ErrVerificationFailed = errors.New("new machine key could not be verified")
+)
+
+// Client is the part of the [client.Client] used by this package.
+type Client interface {
+	ManagementService() management.ManagementServiceClient
+	Origin() string
+}
+
+// Key is an existing key of a machine user. The private key is only returned on creation, see [KeyFile].
+type Key struct {
+	ID         string
+	Created    time.Time
+	Expiration time.Time
+}
+
+// KeyFile is the key.json of a newly created key, as downloadable in the Console.
+// Use [KeyFile.Config] for the JWTAuthentication of the client or [KeyFile.Save] for the DefaultServiceUserAuthentication.
+type KeyFile struct {
+	KeyID  string
+	UserID string
+	// Data is the content of the key.json containing the private key.
+	Data []byte
+}
+
+// Config parses the key file for the authentication of the machine user.
+func (k *KeyFile) Config() (*client.KeyFile, error) {
+	return client.ConfigFromKeyFileData(k.Data)
+}
+
+// Save writes the key file to the path, only readable by the current user.
+func (k *KeyFile) Save(path string) error {
+	return os.WriteFile(path, k.Data, 0600)
+}
+
+// List returns all keys of the machine user.
+func List(ctx context.Context, c Client, orgID, userID string) ([]*Key, error) {
+	keys, err := query.All(org.Context(ctx, orgID), func(ctx context.Context, offset uint64, limit uint32) ([]*authn.Key, uint64, error) {
+		resp, err := c.ManagementService().ListMachineKeys(ctx, &management.ListMachineKeysRequest{
+			UserId: userID,
+			Query:  &object.ListQuery{Offset: offset, Limit: limit, Asc: true},
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		return resp.GetResult(), resp.GetDetails().GetTotalResult(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*Key, len(keys))
+	for i, key := range keys {
+		result[i] = &Key{
+			ID:         key.GetId(),
+			Created:    key.GetDetails().GetCreationDate().AsTime(),
+			Expiration: key.GetExpirationDate().AsTime(),
+		}
+	}
+	return result, nil
+}
+
+// Create creates a new (JSON) key for the machine user and returns its key file.
+// A zero expiration creates a key without expiration.
+func Create(ctx context.Context, c Client, orgID, userID string, expiration time.Time) (*KeyFile, error) {
+	req := &management.AddMachineKeyRequest{
+		UserId: userID,
+		Type:   authn.KeyType_KEY_TYPE_JSON,
+	}
+	if !expiration.IsZero() {
+		req.ExpirationDate = timestamppb.New(expiration)
+	}
+	resp, err := c.ManagementService().AddMachineKey(org.Context(ctx, orgID), req)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyFile{
+		KeyID:  resp.GetKeyId(),
+		UserID: userID,
+		Data:   resp.GetKeyDetails(),
+	}, nil
+}
+
+// Delete deletes the key of the machine user. Tokens issued with the key stay valid until they expire.
+func Delete(ctx context.Context, c Client, orgID, userID, keyID string) error {
+	_, err := c.ManagementService().RemoveMachineKey(org.Context(ctx, orgID), &management.RemoveMachineKeyRequest{
+		UserId: userID,
+		KeyId:  keyID,
+	})
+	return err
+}
+
+// Verifier checks that the key file can be used for the authentication of the machine user.
+type Verifier func(ctx context.Context, key *KeyFile) error
+
+// TokenVerifier returns a [Verifier] requesting a token from the issuer using the JWT Profile Grant.
+func TokenVerifier(issuer string) Verifier {
+	return func(ctx context.Context, key *KeyFile) error {
+		config, err := key.Config()
+		if err != nil {
+			return err
+		}
+		tokenSource, err := profile.NewJWTProfileTokenSource(ctx, issuer, config.UserID, config.KeyID, []byte(config.Key), []string{oidc.ScopeOpenID})
+		if err != nil {
+			return err
+		}
+		_, err = tokenSource.Token()
+		return err
+	}
+}
+
+// RotateOption allows customization of the [Rotate].
+type RotateOption func(*rotateOptions)
+
+type rotateOptions struct {
+	expiration    time.Time
+	verifier      Verifier
+	verifyTimeout time.Duration
+	verifyBackoff time.Duration
+}
+
+// WithExpiration sets the expiration of the new key. By default, the key does not expire.
+func WithExpiration(expiration time.Time) RotateOption {
+	return func(o *rotateOptions) {
+		o.expiration = expiration
+	}
+}
+
+// WithVerifier replaces the default [TokenVerifier] (using the origin of the client as issuer).
+func WithVerifier(verifier Verifier) RotateOption {
+	return func(o *rotateOptions) {
+		o.verifier = verifier
+	}
+}
+
+// WithVerifyTimeout sets the time to retry the verification of the new key (default 30s),
+// as the key might not be usable immediately after its creation.
+func WithVerifyTimeout(timeout time.Duration) RotateOption {
+	return func(o *rotateOptions) {
+		o.verifyTimeout = timeout
+	}
+}
+
+// Rotate replaces the keys of the machine user: it creates a new key, verifies it can be used for authentication
+// and only then removes the previously existing keys.
+//
+// If the new key cannot be created or verified, it's removed again and the existing keys are kept,
+// so the machine user is either left unchanged or only has the new key.
+// If the removal of an old key fails, the new key file is returned together with the error,
+// so it doesn't get lost and the removal can be repeated with [Delete].
+func Rotate(ctx context.Context, c Client, orgID, userID string, options ...RotateOption) (*KeyFile, error) {
+	opts := &rotateOptions{
+		verifier:      TokenVerifier(c.Origin()),
+		verifyTimeout: 30 * time.Second,
+		verifyBackoff: time.Second,
+	}
+	for _, option := range options {
+		option(opts)
+	}
+	existing, err := List(ctx, c, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	key, err := Create(ctx, c, orgID, userID, opts.expiration)
+	if err != nil {
+		return nil, err
+	}
+	if err = verify(ctx, key, opts); err != nil {
+		if deleteErr := Delete(ctx, c, orgID, userID, key.KeyID); deleteErr != nil {
+			return nil, fmt.Errorf("%w: %w (unable to remove new key `%s`: %w)", ErrVerificationFailed, err, key.KeyID, deleteErr)
+		}
+		return nil, fmt.Errorf("%w: %w", ErrVerificationFailed, err)
+	}
+	for _, old := range existing {
+		if err = Delete(ctx, c, orgID, userID, old.ID); err != nil {
+			return key, fmt.Errorf("unable to remove old key `%s`: %w", old.ID, err)
+		}
+	}
+	return key, nil
+}
+
+// verify calls the verifier until it succeeds or the verify timeout is reached.
+func verify(ctx context.Context, key *KeyFile, opts *rotateOptions) error {
+	ctx, cancel := context.WithTimeout(ctx, opts.verifyTimeout)
+	defer cancel()
+	for {
+		err := opts.verifier(ctx, key)
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(opts.verifyBackoff):
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package machinekeys
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/authn"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	object "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object"
+)
+
+type testClient struct {
+	management management.ManagementServiceClient
+}
+
+func (c *testClient) ManagementService() management.ManagementServiceClient {
+	return c.management
+}
+
+func (c *testClient) Origin() string {
+	return "https://zitadel.example.com"
+}
+
+type managementService struct {
+	management.ManagementServiceClient
+	keys      []string
+	removeErr error
+	calls     []string
+}
+
+func (m *managementService) ListMachineKeys(context.Context, *management.ListMachineKeysRequest, ...grpc.CallOption) (*management.ListMachineKeysResponse, error) {
+	result := make([]*authn.Key, len(m.keys))
+	for i, id := range m.keys {
+		result[i] = &authn.Key{Id: id}
+	}
+	return &management.ListMachineKeysResponse{
+		Details: &object.ListDetails{TotalResult: uint64(len(result))},
+		Result:  result,
+	}, nil
+}
+
+func (m *managementService) AddMachineKey(_ context.Context, req *management.AddMachineKeyRequest, _ ...grpc.CallOption) (*management.AddMachineKeyResponse, error) {
+	m.calls = append(m.calls, "AddMachineKey")
+	return &management.AddMachineKeyResponse{KeyId: "new", KeyDetails: []byte(`{"keyId":"new"}`)}, nil
+}
+
+func (m *managementService) RemoveMachineKey(_ context.Context, req *management.RemoveMachineKeyRequest, _ ...grpc.CallOption) (*management.RemoveMachineKeyResponse, error) {
+	m.calls = append(m.calls, "RemoveMachineKey "+req.GetKeyId())
+	if m.removeErr != nil {
+		return nil, m.removeErr
+	}
+	return &management.RemoveMachineKeyResponse{}, nil
+}
+
+func TestRotate(t *testing.T) {
+	errVerify := errors.New("invalid key")
+	tests := []struct {
+		name      string
+		verifier  Verifier
+		removeErr error
+		wantKey   bool
+		wantErr   error
+		wantCalls []string
+	}{
+		{
+			name:      "verified, old keys removed",
+			verifier:  func(context.Context, *KeyFile) error { return nil },
+			wantKey:   true,
+			wantCalls: []string{"AddMachineKey", "RemoveMachineKey old1", "RemoveMachineKey old2"},
+		},
+		{
+			name:      "verification failed, new key removed",
+			verifier:  func(context.Context, *KeyFile) error { return errVerify },
+			wantErr:   ErrVerificationFailed,
+			wantCalls: []string{"AddMachineKey", "RemoveMachineKey new"},
+		},
+		{
+			name:      "removal of old key failed, new key returned",
+			verifier:  func(context.Context, *KeyFile) error { return nil },
+			removeErr: errors.New("remove failed"),
+			wantKey:   true,
+			wantErr:   errors.New("unable to remove old key `old1`: remove failed"),
+			wantCalls: []string{"AddMachineKey", "RemoveMachineKey old1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgmt := &managementService{keys: []string{"old1", "old2"}, removeErr: tt.removeErr}
+			key, err := Rotate(context.Background(), &testClient{management: mgmt}, "org", "machine",
+				WithVerifier(tt.verifier),
+				WithVerifyTimeout(time.Millisecond),
+			)
+			switch {
+			case tt.wantErr == nil:
+				require.NoError(t, err)
+			case errors.Is(tt.wantErr, ErrVerificationFailed):
+				assert.ErrorIs(t, err, ErrVerificationFailed)
+				assert.ErrorIs(t, err, errVerify)
+			default:
+				assert.EqualError(t, err, tt.wantErr.Error())
+			}
+			if tt.wantKey {
+				require.NotNil(t, key)
+				assert.Equal(t, "new", key.KeyID)
+				assert.Equal(t, "machine", key.UserID)
+			} else {
+				assert.Nil(t, key)
+			}
+			assert.Equal(t, tt.wantCalls, mgmt.calls)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package members
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/admin"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/member"
+	object "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/org"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/query"
+)
+
+// Roles which can be granted to members of the instance.
+const (
+	RoleIAMOwner               = "IAM_OWNER"
+	RoleIAMOwnerViewer         = "IAM_OWNER_VIEWER"
+	RoleIAMOrgManager          = "IAM_ORG_MANAGER"
+	RoleIAMUserManager         = "IAM_USER_MANAGER"
+	RoleIAMAdminImpersonator   = "IAM_ADMIN_IMPERSONATOR"
+	RoleIAMEndUserImpersonator = "IAM_END_USER_IMPERSONATOR"
+	RoleIAMLoginClient         = "IAM_LOGIN_CLIENT"
+)
+
+// AddInstanceMember adds the user as member with the provided roles to the instance.
+func AddInstanceMember(ctx context.Context, c InstanceClient, m Member) error {
+	_, err := c.AdminService().AddIAMMember(ctx, &admin.AddIAMMemberRequest{
+		UserId: m.UserID,
+		Roles:  m.Roles,
+	})
+	return err
+}
+
+// UpdateInstanceMember replaces the roles of an existing member of the instance.
+func UpdateInstanceMember(ctx context.Context, c InstanceClient, m Member) error {
+	_, err := c.AdminService().UpdateIAMMember(ctx, &admin.UpdateIAMMemberRequest{
+		UserId: m.UserID,
+		Roles:  m.Roles,
+	})
+	return err
+}
+
+// RemoveInstanceMember removes the user as member from the instance.
+func RemoveInstanceMember(ctx context.Context, c InstanceClient, userID string) error {
+	_, err := c.AdminService().RemoveIAMMember(ctx, &admin.RemoveIAMMemberRequest{
+		UserId: userID,
+	})
+	return err
+}
+
+// ListInstanceMembers returns all members of the instance.
+func ListInstanceMembers(ctx context.Context, c InstanceClient) ([]*member.Member, error) {
+	return query.All(ctx, func(ctx context.Context, offset uint64, limit uint32) ([]*member.Member, uint64, error) {
+		resp, err := c.AdminService().ListIAMMembers(ctx, &admin.ListIAMMembersRequest{
+			Query: &object.ListQuery{Offset: offset, Limit: limit, Asc: true},
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		return resp.GetResult(), resp.GetDetails().GetTotalResult(), nil
+	})
+}
+
+// EnsureInstanceMembers converges the members of the instance to the desired members by adding missing members,
+// updating changed roles and removing members not desired (unless [WithKeepUnknown] is used).
+// An empty list of desired members returns [ErrNoDesiredMembers], unless [WithRemoveAll] is used.
+//
+// Be aware that removing all [RoleIAMOwner] members (including the authorized user) will lock you out of the instance.
+func EnsureInstanceMembers(ctx context.Context, c InstanceClient, desired []Member, options ...EnsureOption) (*EnsureResult, error) {
+	return ensure(ctx, memberStore{
+		list: func(ctx context.Context) ([]*member.Member, error) {
+			return ListInstanceMembers(ctx, c)
+		},
+		add: func(ctx context.Context, m Member) error {
+			return AddInstanceMember(ctx, c, m)
+		},
+		update: func(ctx context.Context, m Member) error {
+			return UpdateInstanceMember(ctx, c, m)
+		},
+		remove: func(ctx context.Context, userID string) error {
+			return RemoveInstanceMember(ctx, c, userID)
+		},
+	}, desired, options...)
+}
+
+// MembershipType defines on which resource a user is a member.
+type MembershipType int
+
+const (
+	MembershipTypeUnspecified MembershipType = iota
+	MembershipTypeInstance
+	MembershipTypeOrganization
+	MembershipTypeProject
+	MembershipTypeProjectGrant
+)
+
+// Membership is an effective membership of a user on an instance, organization, project or project grant.
+type Membership struct {
+	Type MembershipType
+	// ResourceID is the id of the organization, project or project grant. It's empty for the instance.
+	ResourceID string
+	// OrgID is the organization owning the resource.
+	OrgID       string
+	DisplayName string
+	Roles       []string
+}
+
+// Memberships returns all effective memberships of the user across the instance, all organizations,
+// projects and project grants, e.g. for an access review.
+// The orgID is the organization of the user. If it's empty, the organization of the authorized user is used.
+func Memberships(ctx context.Context, c Client, orgID, userID string) ([]Membership, error) {
+	memberships, err := query.All(org.Context(ctx, orgID), func(ctx context.Context, offset uint64, limit uint32) ([]*user.Membership, uint64, error) {
+		resp, err := c.ManagementService().ListUserMemberships(ctx, &management.ListUserMembershipsRequest{
+			UserId: userID,
+			Query:  &object.ListQuery{Offset: offset, Limit: limit, Asc: true},
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		return resp.GetResult(), resp.GetDetails().GetTotalResult(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Membership, len(memberships))
+	for i, m := range memberships {
+		result[i] = membershipFromProto(m)
+	}
+	return result, nil
+}
+
+func membershipFromProto(m *user.Membership) Membership {
+	membership := Membership{
+		OrgID:       m.GetDetails().GetResourceOwner(),
+		DisplayName: m.GetDisplayName(),
+		Roles:       m.GetRoles(),
+	}
+	switch t := m.GetType().(type) {
+	case *user.Membership_Iam:
+		membership.Type = MembershipTypeInstance
+	case *user.Membership_OrgId:
+		membership.Type = MembershipTypeOrganization
+		membership.ResourceID = t.OrgId
+	case *user.Membership_ProjectId:
+		membership.Type = MembershipTypeProject
+		membership.ResourceID = t.ProjectId
+	case *user.Membership_ProjectGrantId:
+		membership.Type = MembershipTypeProjectGrant
+		membership.ResourceID = t.ProjectGrantId
+	}
+	return membership
+}
@@ -0,0 +1,67 @@
+package members
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	object "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user"
+)
+
+func Test_membershipFromProto(t *testing.T) {
+	tests := []struct {
+		name       string
+		membership *user.Membership
+		want       Membership
+	}{
+		{
+			name: "instance",
+			membership: &user.Membership{
+				Details: &object.ObjectDetails{ResourceOwner: "instanceID"},
+				Roles:   []string{RoleIAMOwner},
+				Type:    &user.Membership_Iam{Iam: true},
+			},
+			want: Membership{
+				Type:  MembershipTypeInstance,
+				OrgID: "instanceID",
+				Roles: []string{RoleIAMOwner},
+			},
+		},
+		{
+			name: "organization",
+			membership: &user.Membership{
+				Details:     &object.ObjectDetails{ResourceOwner: "orgID"},
+				DisplayName: "ACME",
+				Roles:       []string{RoleOrgOwner},
+				Type:        &user.Membership_OrgId{OrgId: "orgID"},
+			},
+			want: Membership{
+				Type:        MembershipTypeOrganization,
+				ResourceID:  "orgID",
+				OrgID:       "orgID",
+				DisplayName: "ACME",
+				Roles:       []string{RoleOrgOwner},
+			},
+		},
+		{
+			name: "project grant",
+			membership: &user.Membership{
+				Details: &object.ObjectDetails{ResourceOwner: "orgID"},
+				Roles:   []string{"PROJECT_GRANT_OWNER"},
+				Type:    &user.Membership_ProjectGrantId{ProjectGrantId: "grantID"},
+			},
+			want: Membership{
+				Type:       MembershipTypeProjectGrant,
+				ResourceID: "grantID",
+				OrgID:      "orgID",
+				Roles:      []string{"PROJECT_GRANT_OWNER"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, membershipFromProto(tt.membership))
+		})
+	}
+}
@@ -0,0 +1,262 @@
+// Package members provides typed helpers for the management of the members (administrators) of organizations
+// and the instance, including [EnsureMembers] and [EnsureInstanceMembers] to converge the memberships
+// to a desired state, e.g. synced from an external IdM.
+//
+// All organization functions take an optional orgID. If it's empty, the call is executed in the organization
+// of the authorized user.
+package members
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/admin"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/member"
+	object "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/org"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/query"
+)
+
+var (
+	ErrDuplicateMember  = errors.New("duplicate member")
+	ErrMissingRoles     = errors.New("member requires at least one role")
+	ErrNoDesiredMembers = errors.New("no desired members, use WithRemoveAll to remove all members")
+)
+
+// Client is the part of the [client.Client] used by this package.
+type Client interface {
+	ManagementService() management.ManagementServiceClient
+}
+
+// InstanceClient is the part of the [client.Client] used for the instance members.
+type InstanceClient interface {
+	AdminService() admin.AdminServiceClient
+}
+
+// Roles which can be granted to members of an organization.
+const (
+	RoleOrgOwner                   = "ORG_OWNER"
+	RoleOrgOwnerViewer             = "ORG_OWNER_VIEWER"
+	RoleOrgSettingsManager         = "ORG_SETTINGS_MANAGER"
+	RoleOrgUserManager             = "ORG_USER_MANAGER"
+	RoleOrgUserPermissionEditor    = "ORG_USER_PERMISSION_EDITOR"
+	RoleOrgUserSelfManager         = "ORG_USER_SELF_MANAGER"
+	RoleOrgProjectCreator          = "ORG_PROJECT_CREATOR"
+	RoleOrgProjectPermissionEditor = "ORG_PROJECT_PERMISSION_EDITOR"
+	RoleOrgAdminImpersonator       = "ORG_ADMIN_IMPERSONATOR"
+	RoleOrgEndUserImpersonator     = "ORG_END_USER_IMPERSONATOR"
+)
+
+// Member is a user with its (administrative) roles.
+type Member struct {
+	UserID string
+	Roles  []string
+}
+
+// Add adds the user as member with the provided roles to the organization.
+func Add(ctx context.Context, c Client, orgID string, m Member) error {
+	_, err := c.ManagementService().AddOrgMember(org.Context(ctx, orgID), &management.AddOrgMemberRequest{
+		UserId: m.UserID,
+		Roles:  m.Roles,
+	})
+	return err
+}
+
+// Update replaces the roles of an existing member of the organization.
+func Update(ctx context.Context, c Client, orgID string, m Member) error {
+	_, err := c.ManagementService().UpdateOrgMember(org.Context(ctx, orgID), &management.UpdateOrgMemberRequest{
+		UserId: m.UserID,
+		Roles:  m.Roles,
+	})
+	return err
+}
+
+// Remove removes the user as member from the organization.
+func Remove(ctx context.Context, c Client, orgID, userID string) error {
+	_, err := c.ManagementService().RemoveOrgMember(org.Context(ctx, orgID), &management.RemoveOrgMemberRequest{
+		UserId: userID,
+	})
+	return err
+}
+
+// List returns all members of the organization.
+func List(ctx context.Context, c Client, orgID string) ([]*member.Member, error) {
+	return query.All(org.Context(ctx, orgID), func(ctx context.Context, offset uint64, limit uint32) ([]*member.Member, uint64, error) {
+		resp, err := c.ManagementService().ListOrgMembers(ctx, &management.ListOrgMembersRequest{
+			Query: &object.ListQuery{Offset: offset, Limit: limit, Asc: true},
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		return resp.GetResult(), resp.GetDetails().GetTotalResult(), nil
+	})
+}
+
+// EnsureMembers converges the members of the organization to the desired members by adding missing members,
+// updating changed roles and removing members not desired (unless [WithKeepUnknown] is used).
+// An empty list of desired members returns [ErrNoDesiredMembers] (e.g. on a failed sync), unless [WithRemoveAll] is used.
+func EnsureMembers(ctx context.Context, c Client, orgID string, desired []Member, options ...EnsureOption) (*EnsureResult, error) {
+	return ensure(ctx, memberStore{
+		list: func(ctx context.Context) ([]*member.Member, error) {
+			return List(ctx, c, orgID)
+		},
+		add: func(ctx context.Context, m Member) error {
+			return Add(ctx, c, orgID, m)
+		},
+		update: func(ctx context.Context, m Member) error {
+			return Update(ctx, c, orgID, m)
+		},
+		remove: func(ctx context.Context, userID string) error {
+			return Remove(ctx, c, orgID, userID)
+		},
+	}, desired, options...)
+}
+
+// EnsureResult contains the user ids of the members changed by [EnsureMembers] or [EnsureInstanceMembers].
+type EnsureResult struct {
+	Added   []string
+	Updated []string
+	Removed []string
+}
+
+// EnsureOption allows customization of the [EnsureMembers] and [EnsureInstanceMembers].
+type EnsureOption func(*ensureOptions)
+
+type ensureOptions struct {
+	keepUnknown bool
+	removeAll   bool
+	dryRun      bool
+}
+
+// WithKeepUnknown will not remove existing members which are not part of the desired members.
+func WithKeepUnknown() EnsureOption {
+	return func(o *ensureOptions) {
+		o.keepUnknown = true
+	}
+}
+
+// WithRemoveAll allows an empty list of desired members, which removes all existing members.
+func WithRemoveAll() EnsureOption {
+	return func(o *ensureOptions) {
+		o.removeAll = true
+	}
+}
+
+// WithDryRun will only compute the changes without applying them.
+func WithDryRun() EnsureOption {
+	return func(o *ensureOptions) {
+		o.dryRun = true
+	}
+}
+
+// memberStore abstracts the calls of the organization and instance members.
+type memberStore struct {
+	list   func(ctx context.Context) ([]*member.Member, error)
+	add    func(ctx context.Context, m Member) error
+	update func(ctx context.Context, m Member) error
+	remove func(ctx context.Context, userID string) error
+}
+
+func ensure(ctx context.Context, store memberStore, desired []Member, options ...EnsureOption) (*EnsureResult, error) {
+	opts := new(ensureOptions)
+	for _, option := range options {
+		option(opts)
+	}
+	if len(desired) == 0 && !opts.keepUnknown && !opts.removeAll {
+		return nil, ErrNoDesiredMembers
+	}
+	existing, err := store.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	add, update, remove, err := diff(existing, desired)
+	if err != nil {
+		return nil, err
+	}
+	if opts.keepUnknown {
+		remove = nil
+	}
+	result := &EnsureResult{
+		Added:   userIDs(add),
+		Updated: userIDs(update),
+		Removed: remove,
+	}
+	if opts.dryRun {
+		return result, nil
+	}
+	for _, m := range add {
+		if err = store.add(ctx, m); err != nil {
+			return nil, fmt.Errorf("unable to add member `%s`: %w", m.UserID, err)
+		}
+	}
+	for _, m := range update {
+		if err = store.update(ctx, m); err != nil {
+			return nil, fmt.Errorf("unable to update member `%s`: %w", m.UserID, err)
+		}
+	}
+	for _, userID := range remove {
+		if err = store.remove(ctx, userID); err != nil {
+			return nil, fmt.Errorf("unable to remove member `%s`: %w", userID, err)
+		}
+	}
+	return result, nil
+}
+
+// diff computes the members to be added, updated and removed (user ids) to get from the existing to the desired members.
+// The order of the roles is ignored.
+func diff(existing []*member.Member, desired []Member) (add, update []Member, remove []string, err error) {
+	current := make(map[string][]string, len(existing))
+	for _, m := range existing {
+		current[m.GetUserId()] = m.GetRoles()
+	}
+	wanted := make(map[string]struct{}, len(desired))
+	for _, m := range desired {
+		if _, ok := wanted[m.UserID]; ok {
+			return nil, nil, nil, fmt.Errorf("%w: `%s`", ErrDuplicateMember, m.UserID)
+		}
+		if len(m.Roles) == 0 {
+			return nil, nil, nil, fmt.Errorf("%w: `%s`", ErrMissingRoles, m.UserID)
+		}
+		wanted[m.UserID] = struct{}{}
+		roles, ok := current[m.UserID]
+		if !ok {
+			add = append(add, m)
+			continue
+		}
+		if !sameRoles(roles, m.Roles) {
+			update = append(update, m)
+		}
+	}
+	for userID := range current {
+		if _, ok := wanted[userID]; !ok {
+			remove = append(remove, userID)
+		}
+	}
+	sort.Strings(remove)
+	return add, update, remove, nil
+}
+
+func sameRoles(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
+
+func userIDs(members []Member) []string {
+	if len(members) == 0 {
+		return nil
+	}
+	ids := make([]string, len(members))
+	for i, m := range members {
+		ids[i] = m.UserID
+	}
+	return ids
+}
@@ -0,0 +1,172 @@
+package members
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/member"
+	object "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object"
+)
+
+func Test_diff(t *testing.T) {
+	type args struct {
+		existing []*member.Member
+		desired  []Member
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantAdd    []Member
+		wantUpdate []Member
+		wantRemove []string
+		wantErr    error
+	}{
+		{
+			name: "duplicate, error",
+			args: args{
+				desired: []Member{
+					{UserID: "user1", Roles: []string{RoleOrgOwner}},
+					{UserID: "user1", Roles: []string{RoleOrgOwnerViewer}},
+				},
+			},
+			wantErr: ErrDuplicateMember,
+		},
+		{
+			name: "missing roles, error",
+			args: args{
+				desired: []Member{{UserID: "user1"}},
+			},
+			wantErr: ErrMissingRoles,
+		},
+		{
+			name: "roles in different order, unchanged",
+			args: args{
+				existing: []*member.Member{{UserId: "user1", Roles: []string{RoleOrgOwner, RoleOrgUserManager}}},
+				desired:  []Member{{UserID: "user1", Roles: []string{RoleOrgUserManager, RoleOrgOwner}}},
+			},
+		},
+		{
+			name: "add, update and remove",
+			args: args{
+				existing: []*member.Member{
+					{UserId: "user1", Roles: []string{RoleOrgOwner}},
+					{UserId: "user2", Roles: []string{RoleOrgOwnerViewer}},
+					{UserId: "user4", Roles: []string{RoleOrgOwnerViewer}},
+					{UserId: "user3", Roles: []string{RoleOrgOwnerViewer}},
+				},
+				desired: []Member{
+					{UserID: "user1", Roles: []string{RoleOrgOwner}},
+					{UserID: "user2", Roles: []string{RoleOrgUserManager}},
+					{UserID: "user5", Roles: []string{RoleOrgOwner}},
+				},
+			},
+			wantAdd:    []Member{{UserID: "user5", Roles: []string{RoleOrgOwner}}},
+			wantUpdate: []Member{{UserID: "user2", Roles: []string{RoleOrgUserManager}}},
+			wantRemove: []string{"user3", "user4"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			add, update, remove, err := diff(tt.args.existing, tt.args.desired)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantAdd, add)
+			assert.Equal(t, tt.wantUpdate, update)
+			assert.Equal(t, tt.wantRemove, remove)
+		})
+	}
+}
+
+func TestEnsureMembers(t *testing.T) {
+	tests := []struct {
+		name        string
+		options     []EnsureOption
+		want        *EnsureResult
+		wantAdded   []string
+		wantRemoved []string
+	}{
+		{
+			name: "applied",
+			want: &EnsureResult{
+				Added:   []string{"user2"},
+				Removed: []string{"user1"},
+			},
+			wantAdded:   []string{"user2"},
+			wantRemoved: []string{"user1"},
+		},
+		{
+			name:    "keep unknown",
+			options: []EnsureOption{WithKeepUnknown()},
+			want: &EnsureResult{
+				Added: []string{"user2"},
+			},
+			wantAdded: []string{"user2"},
+		},
+		{
+			name:    "dry run",
+			options: []EnsureOption{WithDryRun()},
+			want: &EnsureResult{
+				Added:   []string{"user2"},
+				Removed: []string{"user1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &managementService{members: []*member.Member{{UserId: "user1", Roles: []string{RoleOrgOwner}}}}
+			got, err := EnsureMembers(context.Background(), &testClient{service}, "orgID", []Member{{UserID: "user2", Roles: []string{RoleOrgOwner}}}, tt.options...)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantAdded, service.added)
+			assert.Equal(t, tt.wantRemoved, service.removed)
+		})
+	}
+}
+
+func TestEnsureMembers_noDesiredMembers(t *testing.T) {
+	service := &managementService{members: []*member.Member{{UserId: "user1", Roles: []string{RoleOrgOwner}}}}
+	_, err := EnsureMembers(context.Background(), &testClient{service}, "orgID", nil)
+	assert.ErrorIs(t, err, ErrNoDesiredMembers)
+	assert.Empty(t, service.removed)
+
+	got, err := EnsureMembers(context.Background(), &testClient{service}, "orgID", nil, WithRemoveAll())
+	require.NoError(t, err)
+	assert.Equal(t, &EnsureResult{Removed: []string{"user1"}}, got)
+	assert.Equal(t, []string{"user1"}, service.removed)
+}
+
+type testClient struct {
+	management management.ManagementServiceClient
+}
+
+func (c *testClient) ManagementService() management.ManagementServiceClient {
+	return c.management
+}
+
+type managementService struct {
+	management.ManagementServiceClient
+	members []*member.Member
+	added   []string
+	removed []string
+}
+
+func (s *managementService) ListOrgMembers(_ context.Context, _ *management.ListOrgMembersRequest, _ ...grpc.CallOption) (*management.ListOrgMembersResponse, error) {
+	return &management.ListOrgMembersResponse{
+		Details: &object.ListDetails{TotalResult: uint64(len(s.members))},
+		Result:  s.members,
+	}, nil
+}
+
+func (s *managementService) AddOrgMember(_ context.Context, req *management.AddOrgMemberRequest, _ ...grpc.CallOption) (*management.AddOrgMemberResponse, error) {
+	s.added = append(s.added, req.GetUserId())
+	return &management.AddOrgMemberResponse{}, nil
+}
+
+func (s *managementService) RemoveOrgMember(_ context.Context, req *management.RemoveOrgMemberRequest, _ ...grpc.CallOption) (*management.RemoveOrgMemberResponse, error) {
+	s.removed = append(s.removed, req.GetUserId())
+	return &management.RemoveOrgMemberResponse{}, nil
+}
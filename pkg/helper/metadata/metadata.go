@@ -0,0 +1,113 @@
+// Package metadata provides typed helpers for the metadata of users and organizations.
+// Values are encoded to the bytes of the API by [Encode] and decoded by [Decode]:
+// strings and byte slices are stored as is, any other type as JSON.
+//
+// The user functions are executed in the organization of the authorized user. For users of another
+// organization, set the organization of the call using [middleware.SetOrgID].
+//
+// [middleware.SetOrgID]: https://pkg.go.dev/github.com/zitadel/zitadel-go/v3/pkg/client/middleware#SetOrgID
+package metadata
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+)
+
+var (
+	ErrNotFound = errors.New("metadata not found")
+)
+
+// Client is the part of the [client.Client] used by this package.
+type Client interface {
+	ManagementService() management.ManagementServiceClient
+}
+
+// Values are the encoded metadata values by their key. Use [Lookup] to decode a single value.
+type Values map[string][]byte
+
+// Keys returns the sorted keys of the values.
+func (v Values) Keys() []string {
+	keys := make([]string, 0, len(v))
+	for key := range v {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Lookup decodes the value of the key and returns whether the key exists.
+func Lookup[T any](values Values, key string) (T, bool, error) {
+	data, ok := values[key]
+	if !ok {
+		var zero T
+		return zero, false, nil
+	}
+	value, err := Decode[T](data)
+	if err != nil {
+		return value, true, fmt.Errorf("unable to decode metadata `%s`: %w", key, err)
+	}
+	return value, true, nil
+}
+
+// Encode converts the value to the bytes stored in ZITADEL.
+// Strings and byte slices are stored as is, so they can be easily used in actions, any other type is encoded as JSON.
+func Encode(value any) ([]byte, error) {
+	switch v := value.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		return []byte(v), nil
+	default:
+		return json.Marshal(v)
+	}
+}
+
+// Decode converts the bytes stored in ZITADEL to the value. It's the counterpart of [Encode].
+func Decode[T any](data []byte) (T, error) {
+	var value T
+	switch v := any(&value).(type) {
+	case *[]byte:
+		*v = data
+	case *string:
+		*v = string(data)
+	default:
+		if err := json.Unmarshal(data, &value); err != nil {
+			return value, err
+		}
+	}
+	return value, nil
+}
+
+// encodeAll encodes the values in the order of their keys.
+func encodeAll(values map[string]any) (keys []string, encoded [][]byte, err error) {
+	keys = make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	encoded = make([][]byte, len(keys))
+	for i, key := range keys {
+		if encoded[i], err = Encode(values[key]); err != nil {
+			return nil, nil, fmt.Errorf("unable to encode metadata `%s`: %w", key, err)
+		}
+	}
+	return keys, encoded, nil
+}
+
+// filter returns only the values of the keys or all values if no keys are provided.
+func filter(values Values, keys []string) Values {
+	if len(keys) == 0 {
+		return values
+	}
+	filtered := make(Values, len(keys))
+	for _, key := range keys {
+		if value, ok := values[key]; ok {
+			filtered[key] = value
+		}
+	}
+	return filtered
+}
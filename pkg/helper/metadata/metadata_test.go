@@ -0,0 +1,127 @@
+package metadata
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	metadatapb "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/metadata"
+	object "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object"
+)
+
+type testClient struct {
+	management management.ManagementServiceClient
+}
+
+func (c *testClient) ManagementService() management.ManagementServiceClient {
+	return c.management
+}
+
+type managementService struct {
+	management.ManagementServiceClient
+	metadata map[string][]byte
+}
+
+func (m *managementService) SetUserMetadata(_ context.Context, req *management.SetUserMetadataRequest, _ ...grpc.CallOption) (*management.SetUserMetadataResponse, error) {
+	m.metadata[req.GetKey()] = req.GetValue()
+	return &management.SetUserMetadataResponse{}, nil
+}
+
+func (m *managementService) GetUserMetadata(_ context.Context, req *management.GetUserMetadataRequest, _ ...grpc.CallOption) (*management.GetUserMetadataResponse, error) {
+	value, ok := m.metadata[req.GetKey()]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	return &management.GetUserMetadataResponse{Metadata: &metadatapb.Metadata{Key: req.GetKey(), Value: value}}, nil
+}
+
+func (m *managementService) BulkSetUserMetadata(_ context.Context, req *management.BulkSetUserMetadataRequest, _ ...grpc.CallOption) (*management.BulkSetUserMetadataResponse, error) {
+	for _, md := range req.GetMetadata() {
+		m.metadata[md.GetKey()] = md.GetValue()
+	}
+	return &management.BulkSetUserMetadataResponse{}, nil
+}
+
+func (m *managementService) ListUserMetadata(context.Context, *management.ListUserMetadataRequest, ...grpc.CallOption) (*management.ListUserMetadataResponse, error) {
+	result := make([]*metadatapb.Metadata, 0, len(m.metadata))
+	for key, value := range m.metadata {
+		result = append(result, &metadatapb.Metadata{Key: key, Value: value})
+	}
+	return &management.ListUserMetadataResponse{
+		Details: &object.ListDetails{TotalResult: uint64(len(result))},
+		Result:  result,
+	}, nil
+}
+
+type settings struct {
+	Theme string `json:"theme"`
+	Beta  bool   `json:"beta"`
+}
+
+func TestEncode_Decode(t *testing.T) {
+	data, err := Encode("plain")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("plain"), data)
+	s, err := Decode[string](data)
+	require.NoError(t, err)
+	assert.Equal(t, "plain", s)
+
+	data, err = Encode(settings{Theme: "dark", Beta: true})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"theme":"dark","beta":true}`, string(data))
+	decoded, err := Decode[settings](data)
+	require.NoError(t, err)
+	assert.Equal(t, settings{Theme: "dark", Beta: true}, decoded)
+
+	_, err = Decode[int]([]byte("no number"))
+	assert.Error(t, err)
+}
+
+func TestSet_Get(t *testing.T) {
+	c := &testClient{management: &managementService{metadata: make(map[string][]byte)}}
+	ctx := context.Background()
+
+	require.NoError(t, Set(ctx, c, "user", "settings", &settings{Theme: "dark"}))
+	got, err := Get[settings](ctx, c, "user", "settings")
+	require.NoError(t, err)
+	assert.Equal(t, settings{Theme: "dark"}, got)
+
+	_, err = Get[settings](ctx, c, "user", "unknown")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestSetBulk_GetBulk(t *testing.T) {
+	c := &testClient{management: &managementService{metadata: make(map[string][]byte)}}
+	ctx := context.Background()
+
+	require.NoError(t, SetBulk(ctx, c, "user", map[string]any{
+		"department": "engineering",
+		"level":      3,
+		"settings":   settings{Beta: true},
+	}))
+	values, err := GetBulk(ctx, c, "user", "level", "settings", "unknown")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"level", "settings"}, values.Keys())
+
+	level, ok, err := Lookup[int](values, "level")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 3, level)
+
+	_, ok, err = Lookup[string](values, "department")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, err = Lookup[int](values, "settings")
+	assert.Error(t, err)
+
+	all, err := GetBulk(ctx, c, "user")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"department", "level", "settings"}, all.Keys())
+}
@@ -0,0 +1,93 @@
+package metadata
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	metadatapb "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/metadata"
+	object "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/org"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/query"
+)
+
+// SetOrg encodes the value (see [Encode]) and sets it as metadata of the organization.
+// If the orgID is empty, the organization of the authorized user is used.
+func SetOrg[T any](ctx context.Context, c Client, orgID, key string, value T) error {
+	data, err := Encode(value)
+	if err != nil {
+		return fmt.Errorf("unable to encode metadata `%s`: %w", key, err)
+	}
+	_, err = c.ManagementService().SetOrgMetadata(org.Context(ctx, orgID), &management.SetOrgMetadataRequest{
+		Key:   key,
+		Value: data,
+	})
+	return err
+}
+
+// GetOrg returns the decoded (see [Decode]) metadata of the organization.
+// If the organization has no metadata with the key, an [ErrNotFound] is returned.
+func GetOrg[T any](ctx context.Context, c Client, orgID, key string) (T, error) {
+	resp, err := c.ManagementService().GetOrgMetadata(org.Context(ctx, orgID), &management.GetOrgMetadataRequest{
+		Key: key,
+	})
+	if err != nil {
+		var zero T
+		if status.Code(err) == codes.NotFound {
+			return zero, fmt.Errorf("%w: `%s`", ErrNotFound, key)
+		}
+		return zero, err
+	}
+	return Decode[T](resp.GetMetadata().GetValue())
+}
+
+// RemoveOrg removes the metadata of the organization.
+func RemoveOrg(ctx context.Context, c Client, orgID string, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	_, err := c.ManagementService().BulkRemoveOrgMetadata(org.Context(ctx, orgID), &management.BulkRemoveOrgMetadataRequest{
+		Keys: keys,
+	})
+	return err
+}
+
+// SetOrgBulk encodes the values (see [Encode]) and sets them as metadata of the organization in a single call.
+func SetOrgBulk(ctx context.Context, c Client, orgID string, values map[string]any) error {
+	if len(values) == 0 {
+		return nil
+	}
+	keys, encoded, err := encodeAll(values)
+	if err != nil {
+		return err
+	}
+	req := &management.BulkSetOrgMetadataRequest{
+		Metadata: make([]*management.BulkSetOrgMetadataRequest_Metadata, len(keys)),
+	}
+	for i, key := range keys {
+		req.Metadata[i] = &management.BulkSetOrgMetadataRequest_Metadata{Key: key, Value: encoded[i]}
+	}
+	_, err = c.ManagementService().BulkSetOrgMetadata(org.Context(ctx, orgID), req)
+	return err
+}
+
+// GetOrgBulk returns the metadata of the organization with the keys or all metadata if no keys are provided.
+// Keys without metadata are not part of the result.
+func GetOrgBulk(ctx context.Context, c Client, orgID string, keys ...string) (Values, error) {
+	metadata, err := query.All(org.Context(ctx, orgID), func(ctx context.Context, offset uint64, limit uint32) ([]*metadatapb.Metadata, uint64, error) {
+		resp, err := c.ManagementService().ListOrgMetadata(ctx, &management.ListOrgMetadataRequest{
+			Query: &object.ListQuery{Offset: offset, Limit: limit, Asc: true},
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		return resp.GetResult(), resp.GetDetails().GetTotalResult(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return filter(values(metadata), keys), nil
+}
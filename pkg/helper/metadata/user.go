@@ -0,0 +1,104 @@
+package metadata
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	metadatapb "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/metadata"
+	object "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/query"
+)
+
+// Set encodes the value (see [Encode]) and sets it as metadata of the user.
+func Set[T any](ctx context.Context, c Client, userID, key string, value T) error {
+	data, err := Encode(value)
+	if err != nil {
+		return fmt.Errorf("unable to encode metadata `%s`: %w", key, err)
+	}
+	_, err = c.ManagementService().SetUserMetadata(ctx, &management.SetUserMetadataRequest{
+		Id:    userID,
+		Key:   key,
+		Value: data,
+	})
+	return err
+}
+
+// Get returns the decoded (see [Decode]) metadata of the user.
+// If the user has no metadata with the key, an [ErrNotFound] is returned.
+func Get[T any](ctx context.Context, c Client, userID, key string) (T, error) {
+	resp, err := c.ManagementService().GetUserMetadata(ctx, &management.GetUserMetadataRequest{
+		Id:  userID,
+		Key: key,
+	})
+	if err != nil {
+		var zero T
+		if status.Code(err) == codes.NotFound {
+			return zero, fmt.Errorf("%w: `%s`", ErrNotFound, key)
+		}
+		return zero, err
+	}
+	return Decode[T](resp.GetMetadata().GetValue())
+}
+
+// Remove removes the metadata of the user.
+func Remove(ctx context.Context, c Client, userID string, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	_, err := c.ManagementService().BulkRemoveUserMetadata(ctx, &management.BulkRemoveUserMetadataRequest{
+		Id:   userID,
+		Keys: keys,
+	})
+	return err
+}
+
+// SetBulk encodes the values (see [Encode]) and sets them as metadata of the user in a single call.
+func SetBulk(ctx context.Context, c Client, userID string, values map[string]any) error {
+	if len(values) == 0 {
+		return nil
+	}
+	keys, encoded, err := encodeAll(values)
+	if err != nil {
+		return err
+	}
+	req := &management.BulkSetUserMetadataRequest{
+		Id:       userID,
+		Metadata: make([]*management.BulkSetUserMetadataRequest_Metadata, len(keys)),
+	}
+	for i, key := range keys {
+		req.Metadata[i] = &management.BulkSetUserMetadataRequest_Metadata{Key: key, Value: encoded[i]}
+	}
+	_, err = c.ManagementService().BulkSetUserMetadata(ctx, req)
+	return err
+}
+
+// GetBulk returns the metadata of the user with the keys or all metadata if no keys are provided.
+// Keys without metadata are not part of the result.
+func GetBulk(ctx context.Context, c Client, userID string, keys ...string) (Values, error) {
+	metadata, err := query.All(ctx, func(ctx context.Context, offset uint64, limit uint32) ([]*metadatapb.Metadata, uint64, error) {
+		resp, err := c.ManagementService().ListUserMetadata(ctx, &management.ListUserMetadataRequest{
+			Id:    userID,
+			Query: &object.ListQuery{Offset: offset, Limit: limit, Asc: true},
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		return resp.GetResult(), resp.GetDetails().GetTotalResult(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return filter(values(metadata), keys), nil
+}
+
+func values(metadata []*metadatapb.Metadata) Values {
+	result := make(Values, len(metadata))
+	for _, md := range metadata {
+		result[md.GetKey()] = md.GetValue()
+	}
+	return result
+}
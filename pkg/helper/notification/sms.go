@@ -0,0 +1,162 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/admin"
+	object "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/settings"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/query"
+)
+
+var (
+	ErrInvalidSMSEndpoint = errors.New("sms http endpoint must be an absolute url")
+)
+
+// SMSState is the state of an SMS provider. Only one provider can be active at a time.
+type SMSState = settings.SMSProviderConfigState
+
+const (
+	SMSStateActive   = settings.SMSProviderConfigState_SMS_PROVIDER_CONFIG_ACTIVE
+	SMSStateInactive = settings.SMSProviderConfigState_SMS_PROVIDER_CONFIG_INACTIVE
+)
+
+// TwilioConfig is the configuration of a Twilio SMS provider.
+type TwilioConfig struct {
+	Description string
+	SID         string
+	// Token is only used when adding the provider. Use [RotateTwilioToken] to change it.
+	Token        string
+	SenderNumber string
+	// VerifyServiceSID will let Twilio generate and verify the OTP codes (Twilio Verify) instead of ZITADEL.
+	VerifyServiceSID string
+}
+
+// AddTwilio adds the (inactive) Twilio SMS provider and returns its id.
+// Use [ActivateSMS] to use it for sending SMS.
+func AddTwilio(ctx context.Context, c Client, config *TwilioConfig) (string, error) {
+	resp, err := c.AdminService().AddSMSProviderTwilio(ctx, &admin.AddSMSProviderTwilioRequest{
+		Description:      config.Description,
+		Sid:              config.SID,
+		Token:            config.Token,
+		SenderNumber:     config.SenderNumber,
+		VerifyServiceSid: config.VerifyServiceSID,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.GetId(), nil
+}
+
+// UpdateTwilio updates the Twilio SMS provider. The Token is ignored, use [RotateTwilioToken] to change it.
+func UpdateTwilio(ctx context.Context, c Client, id string, config *TwilioConfig) error {
+	_, err := c.AdminService().UpdateSMSProviderTwilio(ctx, &admin.UpdateSMSProviderTwilioRequest{
+		Id:               id,
+		Description:      config.Description,
+		Sid:              config.SID,
+		SenderNumber:     config.SenderNumber,
+		VerifyServiceSid: config.VerifyServiceSID,
+	})
+	return err
+}
+
+// RotateTwilioToken replaces the auth token of the Twilio SMS provider, e.g. after it was rotated in Twilio.
+func RotateTwilioToken(ctx context.Context, c Client, id, token string) error {
+	_, err := c.AdminService().UpdateSMSProviderTwilioToken(ctx, &admin.UpdateSMSProviderTwilioTokenRequest{
+		Id:    id,
+		Token: token,
+	})
+	return err
+}
+
+// HTTPConfig is the configuration of an SMS provider, where ZITADEL calls an HTTP endpoint
+// for every SMS to be sent, e.g. to use a provider not natively supported.
+type HTTPConfig struct {
+	Description string
+	Endpoint    string
+}
+
+// Validate checks the configuration, so misconfigurations are detected before calling the API.
+func (c *HTTPConfig) Validate() error {
+	u, err := url.Parse(c.Endpoint)
+	if err != nil || !u.IsAbs() || u.Host == "" {
+		return ErrInvalidSMSEndpoint
+	}
+	return nil
+}
+
+// AddHTTP validates and adds the (inactive) HTTP SMS provider and returns its id.
+// Use [ActivateSMS] to use it for sending SMS.
+func AddHTTP(ctx context.Context, c Client, config *HTTPConfig) (string, error) {
+	if err := config.Validate(); err != nil {
+		return "", err
+	}
+	resp, err := c.AdminService().AddSMSProviderHTTP(ctx, &admin.AddSMSProviderHTTPRequest{
+		Description: config.Description,
+		Endpoint:    config.Endpoint,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.GetId(), nil
+}
+
+// UpdateHTTP validates and updates the HTTP SMS provider.
+func UpdateHTTP(ctx context.Context, c Client, id string, config *HTTPConfig) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	_, err := c.AdminService().UpdateSMSProviderHTTP(ctx, &admin.UpdateSMSProviderHTTPRequest{
+		Id:          id,
+		Description: config.Description,
+		Endpoint:    config.Endpoint,
+	})
+	return err
+}
+
+// ActivateSMS activates the SMS provider. Any other active provider is deactivated.
+func ActivateSMS(ctx context.Context, c Client, id string) error {
+	_, err := c.AdminService().ActivateSMSProvider(ctx, &admin.ActivateSMSProviderRequest{Id: id})
+	return err
+}
+
+// DeactivateSMS deactivates the SMS provider. No SMS will be sent until another one is activated.
+func DeactivateSMS(ctx context.Context, c Client, id string) error {
+	_, err := c.AdminService().DeactivateSMSProvider(ctx, &admin.DeactivateSMSProviderRequest{Id: id})
+	return err
+}
+
+// RemoveSMS removes the SMS provider.
+func RemoveSMS(ctx context.Context, c Client, id string) error {
+	_, err := c.AdminService().RemoveSMSProvider(ctx, &admin.RemoveSMSProviderRequest{Id: id})
+	return err
+}
+
+// ListSMS returns all SMS providers of the instance.
+func ListSMS(ctx context.Context, c Client) ([]*settings.SMSProvider, error) {
+	return query.All(ctx, func(ctx context.Context, offset uint64, limit uint32) ([]*settings.SMSProvider, uint64, error) {
+		resp, err := c.AdminService().ListSMSProviders(ctx, &admin.ListSMSProvidersRequest{
+			Query: &object.ListQuery{Offset: offset, Limit: limit, Asc: true},
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		return resp.GetResult(), resp.GetDetails().GetTotalResult(), nil
+	})
+}
+
+// ActiveSMS returns the active SMS provider or nil if there's none.
+func ActiveSMS(ctx context.Context, c Client) (*settings.SMSProvider, error) {
+	providers, err := ListSMS(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	for _, provider := range providers {
+		if provider.GetState() == SMSStateActive {
+			return provider, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,93 @@
+package notification
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/admin"
+	object "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/settings"
+)
+
+func TestHTTPConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint string
+		wantErr  error
+	}{
+		{
+			name:     "relative, error",
+			endpoint: "/sms",
+			wantErr:  ErrInvalidSMSEndpoint,
+		},
+		{
+			name:     "missing host, error",
+			endpoint: "https://",
+			wantErr:  ErrInvalidSMSEndpoint,
+		},
+		{
+			name:     "valid",
+			endpoint: "https://sms.example.com/send",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &HTTPConfig{Endpoint: tt.endpoint}
+			assert.ErrorIs(t, config.Validate(), tt.wantErr)
+		})
+	}
+}
+
+func TestActiveSMS(t *testing.T) {
+	tests := []struct {
+		name      string
+		providers []*settings.SMSProvider
+		want      *settings.SMSProvider
+	}{
+		{
+			name: "none active",
+			providers: []*settings.SMSProvider{
+				{Id: "1", State: SMSStateInactive},
+			},
+		},
+		{
+			name: "active",
+			providers: []*settings.SMSProvider{
+				{Id: "1", State: SMSStateInactive},
+				{Id: "2", State: SMSStateActive},
+			},
+			want: &settings.SMSProvider{Id: "2", State: SMSStateActive},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ActiveSMS(context.Background(), &testClient{&adminService{smsProviders: tt.providers}})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+type testClient struct {
+	admin admin.AdminServiceClient
+}
+
+func (c *testClient) AdminService() admin.AdminServiceClient {
+	return c.admin
+}
+
+type adminService struct {
+	admin.AdminServiceClient
+	smsProviders []*settings.SMSProvider
+}
+
+func (s *adminService) ListSMSProviders(context.Context, *admin.ListSMSProvidersRequest, ...grpc.CallOption) (*admin.ListSMSProvidersResponse, error) {
+	return &admin.ListSMSProvidersResponse{
+		Details: &object.ListDetails{TotalResult: uint64(len(s.smsProviders))},
+		Result:  s.smsProviders,
+	}, nil
+}
@@ -0,0 +1,166 @@
+// Package notification provides typed helpers for the configuration of the notification providers
+// (SMTP and SMS) of the instance, e.g. to bootstrap an environment end to end.
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/admin"
+	object "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/settings"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/query"
+)
+
+var (
+	ErrInvalidSMTPHost      = errors.New("smtp host must be in the form of host:port")
+	ErrMissingSenderAddress = errors.New("smtp sender address is required")
+)
+
+// Client is the part of the [client.Client] used by this package.
+type Client interface {
+	AdminService() admin.AdminServiceClient
+}
+
+// SMTPState is the state of an SMTP configuration. Only one configuration can be active at a time.
+type SMTPState = settings.SMTPConfigState
+
+const (
+	SMTPStateActive   = settings.SMTPConfigState_SMTP_CONFIG_ACTIVE
+	SMTPStateInactive = settings.SMTPConfigState_SMTP_CONFIG_INACTIVE
+)
+
+// SMTPConfig is the configuration of an SMTP provider.
+type SMTPConfig struct {
+	Description string
+	// Host including the port, e.g. smtp.example.com:587
+	Host           string
+	TLS            bool
+	User           string
+	Password       string
+	SenderAddress  string
+	SenderName     string
+	ReplyToAddress string
+}
+
+// Validate checks the configuration, so misconfigurations are detected before calling the API.
+func (c *SMTPConfig) Validate() error {
+	if _, port, err := net.SplitHostPort(c.Host); err != nil || port == "" {
+		return fmt.Errorf("%w: `%s`", ErrInvalidSMTPHost, c.Host)
+	}
+	if c.SenderAddress == "" {
+		return ErrMissingSenderAddress
+	}
+	return nil
+}
+
+// AddSMTP validates and adds the (inactive) SMTP configuration and returns its id.
+// Use [ActivateSMTP] to use it for sending emails.
+func AddSMTP(ctx context.Context, c Client, config *SMTPConfig) (string, error) {
+	if err := config.Validate(); err != nil {
+		return "", err
+	}
+	resp, err := c.AdminService().AddSMTPConfig(ctx, &admin.AddSMTPConfigRequest{
+		Description:    config.Description,
+		Host:           config.Host,
+		Tls:            config.TLS,
+		User:           config.User,
+		Password:       config.Password,
+		SenderAddress:  config.SenderAddress,
+		SenderName:     config.SenderName,
+		ReplyToAddress: config.ReplyToAddress,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.GetId(), nil
+}
+
+// UpdateSMTP validates and updates the SMTP configuration. If the Password is empty, the existing one is kept.
+func UpdateSMTP(ctx context.Context, c Client, id string, config *SMTPConfig) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	_, err := c.AdminService().UpdateSMTPConfig(ctx, &admin.UpdateSMTPConfigRequest{
+		Id:             id,
+		Description:    config.Description,
+		Host:           config.Host,
+		Tls:            config.TLS,
+		User:           config.User,
+		Password:       config.Password,
+		SenderAddress:  config.SenderAddress,
+		SenderName:     config.SenderName,
+		ReplyToAddress: config.ReplyToAddress,
+	})
+	return err
+}
+
+// SetSMTPPassword changes only the password of the SMTP configuration.
+func SetSMTPPassword(ctx context.Context, c Client, id, password string) error {
+	_, err := c.AdminService().UpdateSMTPConfigPassword(ctx, &admin.UpdateSMTPConfigPasswordRequest{
+		Id:       id,
+		Password: password,
+	})
+	return err
+}
+
+// ActivateSMTP activates the SMTP configuration. Any other active configuration is deactivated.
+func ActivateSMTP(ctx context.Context, c Client, id string) error {
+	_, err := c.AdminService().ActivateSMTPConfig(ctx, &admin.ActivateSMTPConfigRequest{Id: id})
+	return err
+}
+
+// DeactivateSMTP deactivates the SMTP configuration. No emails will be sent until another one is activated.
+func DeactivateSMTP(ctx context.Context, c Client, id string) error {
+	_, err := c.AdminService().DeactivateSMTPConfig(ctx, &admin.DeactivateSMTPConfigRequest{Id: id})
+	return err
+}
+
+// RemoveSMTP removes the SMTP configuration.
+func RemoveSMTP(ctx context.Context, c Client, id string) error {
+	_, err := c.AdminService().RemoveSMTPConfig(ctx, &admin.RemoveSMTPConfigRequest{Id: id})
+	return err
+}
+
+// ListSMTP returns all SMTP configurations of the instance.
+func ListSMTP(ctx context.Context, c Client) ([]*settings.SMTPConfig, error) {
+	return query.All(ctx, func(ctx context.Context, offset uint64, limit uint32) ([]*settings.SMTPConfig, uint64, error) {
+		resp, err := c.AdminService().ListSMTPConfigs(ctx, &admin.ListSMTPConfigsRequest{
+			Query: &object.ListQuery{Offset: offset, Limit: limit, Asc: true},
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		return resp.GetResult(), resp.GetDetails().GetTotalResult(), nil
+	})
+}
+
+// TestSMTP sends a test email to the receiver using the provided (not yet stored) configuration.
+// If the id of an existing configuration is provided and the Password is empty, its stored password is used.
+func TestSMTP(ctx context.Context, c Client, id string, config *SMTPConfig, receiver string) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	_, err := c.AdminService().TestSMTPConfig(ctx, &admin.TestSMTPConfigRequest{
+		Id:              id,
+		Host:            config.Host,
+		Tls:             config.TLS,
+		User:            config.User,
+		Password:        config.Password,
+		SenderAddress:   config.SenderAddress,
+		SenderName:      config.SenderName,
+		ReceiverAddress: receiver,
+	})
+	return err
+}
+
+// TestSMTPByID sends a test email to the receiver using the stored configuration.
+func TestSMTPByID(ctx context.Context, c Client, id, receiver string) error {
+	_, err := c.AdminService().TestSMTPConfigById(ctx, &admin.TestSMTPConfigByIdRequest{
+		Id:              id,
+		ReceiverAddress: receiver,
+	})
+	return err
+}
@@ -0,0 +1,40 @@
+package notification
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSMTPConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  SMTPConfig
+		wantErr error
+	}{
+		{
+			name:    "missing port, error",
+			config:  SMTPConfig{Host: "smtp.example.com", SenderAddress: "noreply@example.com"},
+			wantErr: ErrInvalidSMTPHost,
+		},
+		{
+			name:    "empty port, error",
+			config:  SMTPConfig{Host: "smtp.example.com:", SenderAddress: "noreply@example.com"},
+			wantErr: ErrInvalidSMTPHost,
+		},
+		{
+			name:    "missing sender, error",
+			config:  SMTPConfig{Host: "smtp.example.com:587"},
+			wantErr: ErrMissingSenderAddress,
+		},
+		{
+			name:   "valid",
+			config: SMTPConfig{Host: "smtp.example.com:587", SenderAddress: "noreply@example.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.config.Validate(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package orgs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/middleware"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	objectV1 "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/query"
+)
+
+var (
+	ErrDomainNotVerified = errors.New("domain could not be verified")
+)
+
+// Domain is a domain of an organization.
+type Domain struct {
+	Name           string
+	Verified       bool
+	Primary        bool
+	ValidationType DomainValidationType
+}
+
+// ListDomains returns all domains of the organization.
+func ListDomains(ctx context.Context, c Client, orgID string) ([]*Domain, error) {
+	domains, err := query.All(middleware.SetOrgID(ctx, orgID), func(ctx context.Context, offset uint64, limit uint32) ([]*Domain, uint64, error) {
+		resp, err := c.ManagementService().ListOrgDomains(ctx, &management.ListOrgDomainsRequest{
+			Query: &objectV1.ListQuery{Offset: offset, Limit: limit, Asc: true},
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		result := make([]*Domain, len(resp.GetResult()))
+		for i, domain := range resp.GetResult() {
+			result[i] = &Domain{
+				Name:           domain.GetDomainName(),
+				Verified:       domain.GetIsVerified(),
+				Primary:        domain.GetIsPrimary(),
+				ValidationType: domain.GetValidationType(),
+			}
+		}
+		return result, resp.GetDetails().GetTotalResult(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return domains, nil
+}
+
+// Publisher publishes the challenge of the domain validation, e.g. by creating the TXT record at the DNS provider
+// or by deploying the file to the web server. It's called by [OnboardDomain] before the verification is polled.
+type Publisher func(ctx context.Context, domain string, validation *DomainValidation) error
+
+// VerifyOption allows customization of [WaitForDomainVerification] and [OnboardDomain].
+type VerifyOption func(*verifyOptions)
+
+type verifyOptions struct {
+	interval   time.Duration
+	resolver   *net.Resolver
+	httpClient *http.Client
+	primary    bool
+}
+
+// WithPollInterval sets the interval between two verification attempts (default 10s).
+func WithPollInterval(interval time.Duration) VerifyOption {
+	return func(o *verifyOptions) {
+		o.interval = interval
+	}
+}
+
+// WithResolver sets the resolver used to check the DNS challenge locally before asking ZITADEL to verify it.
+func WithResolver(resolver *net.Resolver) VerifyOption {
+	return func(o *verifyOptions) {
+		o.resolver = resolver
+	}
+}
+
+// WithHTTPClient sets the client used to check the HTTP challenge locally before asking ZITADEL to verify it.
+func WithHTTPClient(httpClient *http.Client) VerifyOption {
+	return func(o *verifyOptions) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithPrimary will let [OnboardDomain] set the domain as primary domain of the organization once it's verified.
+func WithPrimary() VerifyOption {
+	return func(o *verifyOptions) {
+		o.primary = true
+	}
+}
+
+func newVerifyOptions(options []VerifyOption) *verifyOptions {
+	opts := &verifyOptions{
+		interval:   10 * time.Second,
+		resolver:   net.DefaultResolver,
+		httpClient: http.DefaultClient,
+	}
+	for _, option := range options {
+		option(opts)
+	}
+	return opts
+}
+
+// WaitForDomainVerification polls until the challenge is published and ZITADEL verified the domain
+// or the context is done. Since every failed verification is recorded by ZITADEL, the challenge is checked
+// locally first (TXT record, resp. HTTP response) and the verification is only requested once it's visible.
+//
+// Use a context with timeout or deadline to limit the time waiting, e.g. for DNS propagation.
+// If the context is done, an [ErrDomainNotVerified] with the last reason is returned.
+func WaitForDomainVerification(ctx context.Context, c Client, orgID, domain string, validation *DomainValidation, options ...VerifyOption) error {
+	return waitForDomainVerification(ctx, c, orgID, domain, validation, newVerifyOptions(options))
+}
+
+func waitForDomainVerification(ctx context.Context, c Client, orgID, domain string, validation *DomainValidation, opts *verifyOptions) error {
+	for {
+		err := opts.checkChallenge(ctx, validation)
+		if err == nil {
+			err = VerifyDomain(ctx, c, orgID, domain)
+			if err == nil {
+				return nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: `%s`: %w", ErrDomainNotVerified, domain, err)
+		case <-time.After(opts.interval):
+		}
+	}
+}
+
+// OnboardDomain adds the domain to the organization (if not already present), generates the challenge
+// of the validation type, publishes it and waits for the verification (see [WaitForDomainVerification]).
+// Already verified domains are not verified again. Use [WithPrimary] to set the domain as primary domain afterwards.
+func OnboardDomain(ctx context.Context, c Client, orgID, domain string, validationType DomainValidationType, publish Publisher, options ...VerifyOption) error {
+	opts := newVerifyOptions(options)
+	domains, err := ListDomains(ctx, c, orgID)
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(domains, func(d *Domain) bool { return d.Name == domain })
+	if i < 0 {
+		if err = AddDomain(ctx, c, orgID, domain); err != nil {
+			return err
+		}
+	}
+	if i < 0 || !domains[i].Verified {
+		validation, err := GenerateDomainValidation(ctx, c, orgID, domain, validationType)
+		if err != nil {
+			return err
+		}
+		if err = publish(ctx, domain, validation); err != nil {
+			return fmt.Errorf("unable to publish domain validation of `%s`: %w", domain, err)
+		}
+		if err = waitForDomainVerification(ctx, c, orgID, domain, validation, opts); err != nil {
+			return err
+		}
+	}
+	if !opts.primary || (i >= 0 && domains[i].Primary) {
+		return nil
+	}
+	return SetPrimaryDomain(ctx, c, orgID, domain)
+}
+
+// checkChallenge checks if the challenge is visible, so ZITADEL is able to verify it.
+func (o *verifyOptions) checkChallenge(ctx context.Context, validation *DomainValidation) error {
+	switch validation.Type {
+	case DomainValidationDNS:
+		records, err := o.resolver.LookupTXT(ctx, validation.URL)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(records, validation.Token) {
+			return fmt.Errorf("TXT record `%s` does not contain the token", validation.URL)
+		}
+		return nil
+	case DomainValidationHTTP:
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, validation.URL, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := o.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), validation.Token) {
+			return fmt.Errorf("`%s` does not serve the token", validation.URL)
+		}
+		return nil
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,140 @@
+package orgs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	objectV1 "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object"
+	orgV1 "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/org"
+)
+
+type domainService struct {
+	management.ManagementServiceClient
+	domains        []*orgV1.Domain
+	url            string
+	validateErrors []error
+	calls          []string
+}
+
+func (s *domainService) ListOrgDomains(context.Context, *management.ListOrgDomainsRequest, ...grpc.CallOption) (*management.ListOrgDomainsResponse, error) {
+	return &management.ListOrgDomainsResponse{
+		Details: &objectV1.ListDetails{TotalResult: uint64(len(s.domains))},
+		Result:  s.domains,
+	}, nil
+}
+
+func (s *domainService) AddOrgDomain(context.Context, *management.AddOrgDomainRequest, ...grpc.CallOption) (*management.AddOrgDomainResponse, error) {
+	s.calls = append(s.calls, "AddOrgDomain")
+	return &management.AddOrgDomainResponse{}, nil
+}
+
+func (s *domainService) GenerateOrgDomainValidation(context.Context, *management.GenerateOrgDomainValidationRequest, ...grpc.CallOption) (*management.GenerateOrgDomainValidationResponse, error) {
+	s.calls = append(s.calls, "GenerateOrgDomainValidation")
+	return &management.GenerateOrgDomainValidationResponse{Token: "token", Url: s.url}, nil
+}
+
+func (s *domainService) ValidateOrgDomain(context.Context, *management.ValidateOrgDomainRequest, ...grpc.CallOption) (*management.ValidateOrgDomainResponse, error) {
+	s.calls = append(s.calls, "ValidateOrgDomain")
+	if len(s.validateErrors) > 0 {
+		err := s.validateErrors[0]
+		s.validateErrors = s.validateErrors[1:]
+		return nil, err
+	}
+	return &management.ValidateOrgDomainResponse{}, nil
+}
+
+func (s *domainService) SetPrimaryOrgDomain(context.Context, *management.SetPrimaryOrgDomainRequest, ...grpc.CallOption) (*management.SetPrimaryOrgDomainResponse, error) {
+	s.calls = append(s.calls, "SetPrimaryOrgDomain")
+	return &management.SetPrimaryOrgDomainResponse{}, nil
+}
+
+func TestOnboardDomain(t *testing.T) {
+	served := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !served {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("token"))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name           string
+		domains        []*orgV1.Domain
+		validateErrors []error
+		publishErr     error
+		timeout        time.Duration
+		wantErr        error
+		wantCalls      []string
+	}{
+		{
+			name:           "new domain, verified and set primary",
+			validateErrors: []error{status.Error(codes.FailedPrecondition, "not yet")},
+			timeout:        time.Second,
+			wantCalls:      []string{"AddOrgDomain", "GenerateOrgDomainValidation", "ValidateOrgDomain", "ValidateOrgDomain", "SetPrimaryOrgDomain"},
+		},
+		{
+			name:      "existing unverified domain",
+			domains:   []*orgV1.Domain{{DomainName: "example.com"}},
+			timeout:   time.Second,
+			wantCalls: []string{"GenerateOrgDomainValidation", "ValidateOrgDomain", "SetPrimaryOrgDomain"},
+		},
+		{
+			name:    "already verified and primary",
+			domains: []*orgV1.Domain{{DomainName: "example.com", IsVerified: true, IsPrimary: true}},
+			timeout: time.Second,
+		},
+		{
+			name:       "publish failed",
+			publishErr: errors.New("dns provider unavailable"),
+			timeout:    time.Second,
+			wantErr:    errors.New("dns provider unavailable"),
+			wantCalls:  []string{"AddOrgDomain", "GenerateOrgDomainValidation"},
+		},
+		{
+			name:           "verification timeout",
+			validateErrors: []error{status.Error(codes.FailedPrecondition, "not yet"), status.Error(codes.FailedPrecondition, "not yet")},
+			timeout:        15 * time.Millisecond,
+			wantErr:        ErrDomainNotVerified,
+			wantCalls:      []string{"AddOrgDomain", "GenerateOrgDomainValidation", "ValidateOrgDomain", "ValidateOrgDomain"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			served = false
+			mgmt := &domainService{domains: tt.domains, url: server.URL, validateErrors: tt.validateErrors}
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+			err := OnboardDomain(ctx, &testClient{management: mgmt}, "org", "example.com", DomainValidationHTTP,
+				func(_ context.Context, domain string, validation *DomainValidation) error {
+					assert.Equal(t, "example.com", domain)
+					assert.Equal(t, &DomainValidation{Type: DomainValidationHTTP, Token: "token", URL: server.URL}, validation)
+					served = true
+					return tt.publishErr
+				},
+				WithPollInterval(10*time.Millisecond),
+				WithPrimary(),
+			)
+			switch {
+			case tt.wantErr == nil:
+				assert.NoError(t, err)
+			case errors.Is(tt.wantErr, ErrDomainNotVerified):
+				assert.ErrorIs(t, err, ErrDomainNotVerified)
+			default:
+				assert.ErrorContains(t, err, tt.wantErr.Error())
+			}
+			assert.Equal(t, tt.wantCalls, mgmt.calls)
+		})
+	}
+}
@@ -0,0 +1,264 @@
+// Package orgs provides typed helpers for the lifecycle of organizations,
+// such as creating them with their first admin, managing their domains and state as well as listing them.
+package orgs
+
+import (
+	"context"
+	"errors"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/middleware"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	object "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object/v2"
+	orgV1 "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/org"
+	org "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/org/v2"
+	user "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user/v2"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/query"
+)
+
+var (
+	ErrUnsupportedState = errors.New("unsupported organization state")
+)
+
+// Client is the part of the [client.Client] used by this package.
+type Client interface {
+	OrganizationServiceV2() org.OrganizationServiceClient
+	ManagementService() management.ManagementServiceClient
+}
+
+// State represents the state of an organization.
+type State = org.OrganizationState
+
+const (
+	StateActive   = org.OrganizationState_ORGANIZATION_STATE_ACTIVE
+	StateInactive = org.OrganizationState_ORGANIZATION_STATE_INACTIVE
+	StateRemoved  = org.OrganizationState_ORGANIZATION_STATE_REMOVED
+)
+
+// Created contains the information about a newly created organization and its admins.
+type Created struct {
+	ID     string
+	Admins []CreatedAdmin
+}
+
+// CreatedAdmin contains the information about an admin added during the creation of an organization.
+// In case a new human user was created, the verification codes might be returned as well.
+type CreatedAdmin struct {
+	UserID    string
+	EmailCode string
+	PhoneCode string
+}
+
+// CreateOption allows customization of the organization creation, e.g. by adding admins.
+type CreateOption func(*org.AddOrganizationRequest)
+
+// WithAdmin adds an existing user as admin of the new organization.
+// If no roles are provided, ZITADEL will grant the ORG_OWNER role.
+func WithAdmin(userID string, roles ...string) CreateOption {
+	return func(req *org.AddOrganizationRequest) {
+		req.Admins = append(req.Admins, &org.AddOrganizationRequest_Admin{
+			UserType: &org.AddOrganizationRequest_Admin_UserId{UserId: userID},
+			Roles:    roles,
+		})
+	}
+}
+
+// WithHumanAdmin creates a new human user in the new organization and adds it as admin.
+// If no roles are provided, ZITADEL will grant the ORG_OWNER role.
+func WithHumanAdmin(human *user.AddHumanUserRequest, roles ...string) CreateOption {
+	return func(req *org.AddOrganizationRequest) {
+		req.Admins = append(req.Admins, &org.AddOrganizationRequest_Admin{
+			UserType: &org.AddOrganizationRequest_Admin_Human{Human: human},
+			Roles:    roles,
+		})
+	}
+}
+
+// Create creates a new organization with the provided name and optional admins.
+func Create(ctx context.Context, c Client, name string, options ...CreateOption) (*Created, error) {
+	req := &org.AddOrganizationRequest{Name: name}
+	for _, option := range options {
+		option(req)
+	}
+	resp, err := c.OrganizationServiceV2().AddOrganization(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	created := &Created{
+		ID:     resp.GetOrganizationId(),
+		Admins: make([]CreatedAdmin, len(resp.GetCreatedAdmins())),
+	}
+	for i, admin := range resp.GetCreatedAdmins() {
+		created.Admins[i] = CreatedAdmin{
+			UserID:    admin.GetUserId(),
+			EmailCode: admin.GetEmailCode(),
+			PhoneCode: admin.GetPhoneCode(),
+		}
+	}
+	return created, nil
+}
+
+// Rename changes the name of the organization.
+func Rename(ctx context.Context, c Client, orgID, name string) error {
+	_, err := c.ManagementService().UpdateOrg(middleware.SetOrgID(ctx, orgID), &management.UpdateOrgRequest{Name: name})
+	return err
+}
+
+// SetState changes the state of the organization by either reactivating ([StateActive]),
+// deactivating ([StateInactive]) or removing ([StateRemoved]) it.
+func SetState(ctx context.Context, c Client, orgID string, state State) error {
+	switch state {
+	case StateActive:
+		return Reactivate(ctx, c, orgID)
+	case StateInactive:
+		return Deactivate(ctx, c, orgID)
+	case StateRemoved:
+		return Remove(ctx, c, orgID)
+	default:
+		return ErrUnsupportedState
+	}
+}
+
+// Deactivate deactivates the organization. Users of a deactivated organization will not be able to log in.
+func Deactivate(ctx context.Context, c Client, orgID string) error {
+	_, err := c.ManagementService().DeactivateOrg(middleware.SetOrgID(ctx, orgID), &management.DeactivateOrgRequest{})
+	return err
+}
+
+// Reactivate reactivates a previously deactivated organization.
+func Reactivate(ctx context.Context, c Client, orgID string) error {
+	_, err := c.ManagementService().ReactivateOrg(middleware.SetOrgID(ctx, orgID), &management.ReactivateOrgRequest{})
+	return err
+}
+
+// Remove removes the organization including all its resources such as users and projects.
+func Remove(ctx context.Context, c Client, orgID string) error {
+	_, err := c.ManagementService().RemoveOrg(middleware.SetOrgID(ctx, orgID), &management.RemoveOrgRequest{})
+	return err
+}
+
+// AddDomain adds a (not yet verified) domain to the organization.
+func AddDomain(ctx context.Context, c Client, orgID, domain string) error {
+	_, err := c.ManagementService().AddOrgDomain(middleware.SetOrgID(ctx, orgID), &management.AddOrgDomainRequest{Domain: domain})
+	return err
+}
+
+// DomainValidationType defines how the ownership of a domain is proven.
+type DomainValidationType = orgV1.DomainValidationType
+
+const (
+	// DomainValidationHTTP requires the token to be served on the returned URL of the domain.
+	DomainValidationHTTP = orgV1.DomainValidationType_DOMAIN_VALIDATION_TYPE_HTTP
+	// DomainValidationDNS requires the token to be set as TXT record on the returned (sub)domain.
+	DomainValidationDNS = orgV1.DomainValidationType_DOMAIN_VALIDATION_TYPE_DNS
+)
+
+// DomainValidation contains the challenge which needs to be fulfilled before calling [VerifyDomain].
+type DomainValidation struct {
+	Type DomainValidationType
+	// Token is the value which needs to be served (HTTP), resp. set as TXT record (DNS).
+	Token string
+	// URL is the location where the Token is expected, resp. the name of the TXT record.
+	URL string
+}
+
+// GenerateDomainValidation generates the challenge of the provided type to prove the ownership
+// of a domain previously added with [AddDomain].
+func GenerateDomainValidation(ctx context.Context, c Client, orgID, domain string, validationType DomainValidationType) (*DomainValidation, error) {
+	resp, err := c.ManagementService().GenerateOrgDomainValidation(middleware.SetOrgID(ctx, orgID), &management.GenerateOrgDomainValidationRequest{
+		Domain: domain,
+		Type:   validationType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &DomainValidation{
+		Type:  validationType,
+		Token: resp.GetToken(),
+		URL:   resp.GetUrl(),
+	}, nil
+}
+
+// VerifyDomain validates the challenge generated by [GenerateDomainValidation] and marks the domain as verified.
+func VerifyDomain(ctx context.Context, c Client, orgID, domain string) error {
+	_, err := c.ManagementService().ValidateOrgDomain(middleware.SetOrgID(ctx, orgID), &management.ValidateOrgDomainRequest{Domain: domain})
+	return err
+}
+
+// SetPrimaryDomain sets the (verified) domain as primary domain of the organization.
+func SetPrimaryDomain(ctx context.Context, c Client, orgID, domain string) error {
+	_, err := c.ManagementService().SetPrimaryOrgDomain(middleware.SetOrgID(ctx, orgID), &management.SetPrimaryOrgDomainRequest{Domain: domain})
+	return err
+}
+
+// RemoveDomain removes the domain from the organization.
+func RemoveDomain(ctx context.Context, c Client, orgID, domain string) error {
+	_, err := c.ManagementService().RemoveOrgDomain(middleware.SetOrgID(ctx, orgID), &management.RemoveOrgDomainRequest{Domain: domain})
+	return err
+}
+
+// Filter restricts the organizations returned by [List].
+type Filter func() *org.SearchQuery
+
+// WithID filters the organizations by their id.
+func WithID(id string) Filter {
+	return func() *org.SearchQuery {
+		return &org.SearchQuery{Query: &org.SearchQuery_IdQuery{IdQuery: &org.OrganizationIDQuery{Id: id}}}
+	}
+}
+
+// WithName filters the organizations by their exact name.
+func WithName(name string) Filter {
+	return WithNameMethod(name, object.TextQueryMethod_TEXT_QUERY_METHOD_EQUALS)
+}
+
+// WithNameMethod filters the organizations by their name with the provided text comparison method.
+func WithNameMethod(name string, method object.TextQueryMethod) Filter {
+	return func() *org.SearchQuery {
+		return &org.SearchQuery{Query: &org.SearchQuery_NameQuery{NameQuery: &org.OrganizationNameQuery{Name: name, Method: method}}}
+	}
+}
+
+// WithDomain filters the organizations by one of their domains (not only the primary).
+func WithDomain(domain string) Filter {
+	return WithDomainMethod(domain, object.TextQueryMethod_TEXT_QUERY_METHOD_EQUALS)
+}
+
+// WithDomainMethod filters the organizations by one of their domains with the provided text comparison method.
+func WithDomainMethod(domain string, method object.TextQueryMethod) Filter {
+	return func() *org.SearchQuery {
+		return &org.SearchQuery{Query: &org.SearchQuery_DomainQuery{DomainQuery: &org.OrganizationDomainQuery{Domain: domain, Method: method}}}
+	}
+}
+
+// WithState filters the organizations by their state.
+func WithState(state State) Filter {
+	return func() *org.SearchQuery {
+		return &org.SearchQuery{Query: &org.SearchQuery_StateQuery{StateQuery: &org.OrganizationStateQuery{State: state}}}
+	}
+}
+
+// IsDefault filters for the default organization of the instance.
+func IsDefault() Filter {
+	return func() *org.SearchQuery {
+		return &org.SearchQuery{Query: &org.SearchQuery_DefaultQuery{DefaultQuery: &org.DefaultOrganizationQuery{}}}
+	}
+}
+
+// List returns all organizations matching all the provided filters.
+func List(ctx context.Context, c Client, filters ...Filter) ([]*org.Organization, error) {
+	queries := make([]*org.SearchQuery, len(filters))
+	for i, filter := range filters {
+		queries[i] = filter()
+	}
+	return query.All(ctx, func(ctx context.Context, offset uint64, limit uint32) ([]*org.Organization, uint64, error) {
+		resp, err := c.OrganizationServiceV2().ListOrganizations(ctx, &org.ListOrganizationsRequest{
+			Query:         &object.ListQuery{Offset: offset, Limit: limit, Asc: true},
+			SortingColumn: org.OrganizationFieldName_ORGANIZATION_FIELD_NAME_NAME,
+			Queries:       queries,
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		return resp.GetResult(), resp.GetDetails().GetTotalResult(), nil
+	})
+}
@@ -0,0 +1,162 @@
+package orgs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	object "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object/v2"
+	org "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/org/v2"
+)
+
+var errList = errors.New("list failed")
+
+func TestList(t *testing.T) {
+	tests := []struct {
+		name        string
+		pages       [][]*org.Organization
+		total       uint64
+		err         error
+		filters     []Filter
+		want        []*org.Organization
+		wantQueries int
+		wantErr     error
+	}{
+		{
+			name:    "error",
+			err:     errList,
+			wantErr: errList,
+		},
+		{
+			name: "multiple pages with filters",
+			pages: [][]*org.Organization{
+				{{Id: "1"}, {Id: "2"}},
+				{{Id: "3"}},
+			},
+			total:       3,
+			filters:     []Filter{WithName("name"), WithState(StateActive)},
+			want:        []*org.Organization{{Id: "1"}, {Id: "2"}, {Id: "3"}},
+			wantQueries: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &orgService{pages: tt.pages, total: tt.total, err: tt.err}
+			got, err := List(context.Background(), &testClient{org: service}, tt.filters...)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+			for _, req := range service.requests {
+				assert.Len(t, req.GetQueries(), tt.wantQueries)
+			}
+		})
+	}
+}
+
+func TestSetState(t *testing.T) {
+	tests := []struct {
+		name       string
+		state      State
+		wantMethod string
+		wantErr    error
+	}{
+		{
+			name:       "active, reactivated",
+			state:      StateActive,
+			wantMethod: "ReactivateOrg",
+		},
+		{
+			name:       "inactive, deactivated",
+			state:      StateInactive,
+			wantMethod: "DeactivateOrg",
+		},
+		{
+			name:       "removed, removed",
+			state:      StateRemoved,
+			wantMethod: "RemoveOrg",
+		},
+		{
+			name:    "unspecified, unsupported error",
+			state:   org.OrganizationState_ORGANIZATION_STATE_UNSPECIFIED,
+			wantErr: ErrUnsupportedState,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &managementService{}
+			err := SetState(context.Background(), &testClient{management: service}, "orgID", tt.state)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantMethod, service.method)
+			if tt.wantMethod != "" {
+				assert.Equal(t, "orgID", service.orgID)
+			}
+		})
+	}
+}
+
+type testClient struct {
+	org        org.OrganizationServiceClient
+	management management.ManagementServiceClient
+}
+
+func (c *testClient) OrganizationServiceV2() org.OrganizationServiceClient {
+	return c.org
+}
+
+func (c *testClient) ManagementService() management.ManagementServiceClient {
+	return c.management
+}
+
+type orgService struct {
+	org.OrganizationServiceClient
+	pages    [][]*org.Organization
+	total    uint64
+	err      error
+	requests []*org.ListOrganizationsRequest
+}
+
+func (s *orgService) ListOrganizations(_ context.Context, req *org.ListOrganizationsRequest, _ ...grpc.CallOption) (*org.ListOrganizationsResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	page := s.pages[len(s.requests)]
+	s.requests = append(s.requests, req)
+	return &org.ListOrganizationsResponse{
+		Details: &object.ListDetails{TotalResult: s.total},
+		Result:  page,
+	}, nil
+}
+
+type managementService struct {
+	management.ManagementServiceClient
+	method string
+	orgID  string
+}
+
+func (s *managementService) called(ctx context.Context, method string) {
+	s.method = method
+	md, _ := metadata.FromOutgoingContext(ctx)
+	if ids := md.Get(client.OrgHeader); len(ids) > 0 {
+		s.orgID = ids[0]
+	}
+}
+
+func (s *managementService) ReactivateOrg(ctx context.Context, _ *management.ReactivateOrgRequest, _ ...grpc.CallOption) (*management.ReactivateOrgResponse, error) {
+	s.called(ctx, "ReactivateOrg")
+	return &management.ReactivateOrgResponse{}, nil
+}
+
+func (s *managementService) DeactivateOrg(ctx context.Context, _ *management.DeactivateOrgRequest, _ ...grpc.CallOption) (*management.DeactivateOrgResponse, error) {
+	s.called(ctx, "DeactivateOrg")
+	return &management.DeactivateOrgResponse{}, nil
+}
+
+func (s *managementService) RemoveOrg(ctx context.Context, _ *management.RemoveOrgRequest, _ ...grpc.CallOption) (*management.RemoveOrgResponse, error) {
+	s.called(ctx, "RemoveOrg")
+	return &management.RemoveOrgResponse{}, nil
+}
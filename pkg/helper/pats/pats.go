@@ -0,0 +1,122 @@
+// Package pats provides typed helpers for the personal access tokens (PATs) of machine users.
+//
+// All functions take an optional orgID. If it's empty, the call is executed in the organization
+// of the authorized user.
+package pats
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	object "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/org"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/query"
+)
+
+// Client is the part of the [client.Client] used by this package.
+type Client interface {
+	ManagementService() management.ManagementServiceClient
+}
+
+// PAT is an existing personal access token of a machine user. The token itself is only returned on creation.
+type PAT struct {
+	ID      string
+	Created time.Time
+	// Expiration is zero for tokens without expiration.
+	Expiration time.Time
+}
+
+// Token is a newly created personal access token.
+type Token struct {
+	ID     string
+	UserID string
+	// Token is the secret to be used as bearer token.
+	Token string
+}
+
+// Authentication returns the initializer for a [client.Client] authenticated by the token.
+func (t *Token) Authentication() client.TokenSourceInitializer {
+	return client.PAT(t.Token)
+}
+
+// Create creates a new personal access token for the machine user.
+// A zero expiration creates a token without expiration.
+func Create(ctx context.Context, c Client, orgID, userID string, expiration time.Time) (*Token, error) {
+	req := &management.AddPersonalAccessTokenRequest{
+		UserId: userID,
+	}
+	if !expiration.IsZero() {
+		req.ExpirationDate = timestamppb.New(expiration)
+	}
+	resp, err := c.ManagementService().AddPersonalAccessToken(org.Context(ctx, orgID), req)
+	if err != nil {
+		return nil, err
+	}
+	return &Token{
+		ID:     resp.GetTokenId(),
+		UserID: userID,
+		Token:  resp.GetToken(),
+	}, nil
+}
+
+// List returns all personal access tokens of the machine user.
+func List(ctx context.Context, c Client, orgID, userID string) ([]*PAT, error) {
+	tokens, err := query.All(org.Context(ctx, orgID), func(ctx context.Context, offset uint64, limit uint32) ([]*user.PersonalAccessToken, uint64, error) {
+		resp, err := c.ManagementService().ListPersonalAccessTokens(ctx, &management.ListPersonalAccessTokensRequest{
+			UserId: userID,
+			Query:  &object.ListQuery{Offset: offset, Limit: limit, Asc: true},
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		return resp.GetResult(), resp.GetDetails().GetTotalResult(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*PAT, len(tokens))
+	for i, token := range tokens {
+		result[i] = &PAT{
+			ID:      token.GetId(),
+			Created: token.GetDetails().GetCreationDate().AsTime(),
+		}
+		if token.GetExpirationDate() != nil {
+			result[i].Expiration = token.GetExpirationDate().AsTime()
+		}
+	}
+	return result, nil
+}
+
+// Revoke removes the personal access token of the machine user. It can no longer be used immediately.
+func Revoke(ctx context.Context, c Client, orgID, userID, tokenID string) error {
+	_, err := c.ManagementService().RemovePersonalAccessToken(org.Context(ctx, orgID), &management.RemovePersonalAccessTokenRequest{
+		UserId:  userID,
+		TokenId: tokenID,
+	})
+	return err
+}
+
+// RevokeExpiring revokes all personal access tokens of the machine user expiring before the provided time
+// (e.g. time.Now() for already expired ones) and returns their ids. Tokens without expiration are kept.
+func RevokeExpiring(ctx context.Context, c Client, orgID, userID string, before time.Time) ([]string, error) {
+	tokens, err := List(ctx, c, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	var revoked []string
+	for _, token := range tokens {
+		if token.Expiration.IsZero() || !token.Expiration.Before(before) {
+			continue
+		}
+		if err = Revoke(ctx, c, orgID, userID, token.ID); err != nil {
+			return revoked, err
+		}
+		revoked = append(revoked, token.ID)
+	}
+	return revoked, nil
+}
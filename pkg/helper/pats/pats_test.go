@@ -0,0 +1,98 @@
+package pats
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	object "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user"
+)
+
+type testClient struct {
+	management management.ManagementServiceClient
+}
+
+func (c *testClient) ManagementService() management.ManagementServiceClient {
+	return c.management
+}
+
+type managementService struct {
+	management.ManagementServiceClient
+	tokens  []*user.PersonalAccessToken
+	add     *management.AddPersonalAccessTokenRequest
+	revoked []string
+	orgIDs  []string
+}
+
+func (m *managementService) AddPersonalAccessToken(ctx context.Context, req *management.AddPersonalAccessTokenRequest, _ ...grpc.CallOption) (*management.AddPersonalAccessTokenResponse, error) {
+	m.add = req
+	m.orgIDs = append(m.orgIDs, orgID(ctx))
+	return &management.AddPersonalAccessTokenResponse{TokenId: "id", Token: "secret"}, nil
+}
+
+func (m *managementService) ListPersonalAccessTokens(ctx context.Context, _ *management.ListPersonalAccessTokensRequest, _ ...grpc.CallOption) (*management.ListPersonalAccessTokensResponse, error) {
+	m.orgIDs = append(m.orgIDs, orgID(ctx))
+	return &management.ListPersonalAccessTokensResponse{
+		Details: &object.ListDetails{TotalResult: uint64(len(m.tokens))},
+		Result:  m.tokens,
+	}, nil
+}
+
+func (m *managementService) RemovePersonalAccessToken(ctx context.Context, req *management.RemovePersonalAccessTokenRequest, _ ...grpc.CallOption) (*management.RemovePersonalAccessTokenResponse, error) {
+	m.orgIDs = append(m.orgIDs, orgID(ctx))
+	m.revoked = append(m.revoked, req.GetTokenId())
+	return &management.RemovePersonalAccessTokenResponse{}, nil
+}
+
+func orgID(ctx context.Context) string {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	if values := md.Get("x-zitadel-orgid"); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func TestCreate(t *testing.T) {
+	mgmt := new(managementService)
+	expiration := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	token, err := Create(context.Background(), &testClient{management: mgmt}, "org", "machine", expiration)
+	require.NoError(t, err)
+	assert.Equal(t, &Token{ID: "id", UserID: "machine", Token: "secret"}, token)
+	assert.Equal(t, "machine", mgmt.add.GetUserId())
+	assert.Equal(t, expiration, mgmt.add.GetExpirationDate().AsTime())
+	assert.Equal(t, []string{"org"}, mgmt.orgIDs)
+
+	source, err := token.Authentication()(context.Background(), "")
+	require.NoError(t, err)
+	oauthToken, err := source.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "secret", oauthToken.AccessToken)
+
+	_, err = Create(context.Background(), &testClient{management: mgmt}, "", "machine", time.Time{})
+	require.NoError(t, err)
+	assert.Nil(t, mgmt.add.GetExpirationDate())
+}
+
+func TestRevokeExpiring(t *testing.T) {
+	now := time.Now()
+	mgmt := &managementService{
+		tokens: []*user.PersonalAccessToken{
+			{Id: "expired", ExpirationDate: timestamppb.New(now.Add(-time.Hour))},
+			{Id: "expiring", ExpirationDate: timestamppb.New(now.Add(time.Hour))},
+			{Id: "valid", ExpirationDate: timestamppb.New(now.Add(48 * time.Hour))},
+			{Id: "no expiration"},
+		},
+	}
+	revoked, err := RevokeExpiring(context.Background(), &testClient{management: mgmt}, "org", "machine", now.Add(24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"expired", "expiring"}, revoked)
+	assert.Equal(t, revoked, mgmt.revoked)
+}
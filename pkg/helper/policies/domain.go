@@ -0,0 +1,110 @@
+package policies
+
+import (
+	"context"
+	"errors"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/admin"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/policy"
+)
+
+var (
+	ErrOrgIDRequired = errors.New("organization id is required")
+)
+
+// DomainPolicy defines how the domains of organizations are used and verified.
+type DomainPolicy struct {
+	// UserLoginMustBeDomain suffixes the usernames with the primary domain of the organization.
+	UserLoginMustBeDomain bool
+	// ValidateOrgDomains requires the domains of organizations to be verified (DNS or HTTP challenge).
+	ValidateOrgDomains bool
+	// SMTPSenderAddressMatchesInstanceDomain requires the sender address of the SMTP configuration
+	// to match one of the domains of the instance.
+	SMTPSenderAddressMatchesInstanceDomain bool
+	// IsDefault is set if an organization has no own policy and therefore uses the default of the instance.
+	// It's ignored on updates.
+	IsDefault bool
+}
+
+// GetDomainPolicy returns the (effective) domain policy of the level.
+//
+// Unlike the other policies, the domain policy of an organization can only be read, but not changed
+// by the organization itself. Reading it with an explicit orgID uses the Admin API.
+func GetDomainPolicy(ctx context.Context, c Client, level Level) (*DomainPolicy, error) {
+	if level.IsInstance() {
+		resp, err := c.AdminService().GetDomainPolicy(ctx, &admin.GetDomainPolicyRequest{})
+		if err != nil {
+			return nil, err
+		}
+		return domainPolicyFromProto(resp.GetPolicy()), nil
+	}
+	if level.OrgID() == "" {
+		resp, err := c.ManagementService().GetDomainPolicy(ctx, &management.GetDomainPolicyRequest{})
+		if err != nil {
+			return nil, err
+		}
+		return domainPolicyFromProto(resp.GetPolicy()), nil
+	}
+	resp, err := c.AdminService().GetCustomDomainPolicy(ctx, &admin.GetCustomDomainPolicyRequest{OrgId: level.OrgID()})
+	if err != nil {
+		return nil, err
+	}
+	return domainPolicyFromProto(resp.GetPolicy()), nil
+}
+
+// SetDomainPolicy updates the domain policy of the level using the Admin API.
+// For an organization without own policy, the override is created. As the override is managed on the instance,
+// the orgID must be provided explicitly, otherwise [ErrOrgIDRequired] is returned.
+func SetDomainPolicy(ctx context.Context, c Client, level Level, p *DomainPolicy) error {
+	if level.IsInstance() {
+		_, err := c.AdminService().UpdateDomainPolicy(ctx, &admin.UpdateDomainPolicyRequest{
+			UserLoginMustBeDomain:                  p.UserLoginMustBeDomain,
+			ValidateOrgDomains:                     p.ValidateOrgDomains,
+			SmtpSenderAddressMatchesInstanceDomain: p.SMTPSenderAddressMatchesInstanceDomain,
+		})
+		return err
+	}
+	if level.OrgID() == "" {
+		return ErrOrgIDRequired
+	}
+	current, err := GetDomainPolicy(ctx, c, level)
+	if err != nil {
+		return err
+	}
+	if current.IsDefault {
+		_, err = c.AdminService().AddCustomDomainPolicy(ctx, &admin.AddCustomDomainPolicyRequest{
+			OrgId:                                  level.OrgID(),
+			UserLoginMustBeDomain:                  p.UserLoginMustBeDomain,
+			ValidateOrgDomains:                     p.ValidateOrgDomains,
+			SmtpSenderAddressMatchesInstanceDomain: p.SMTPSenderAddressMatchesInstanceDomain,
+		})
+		return err
+	}
+	_, err = c.AdminService().UpdateCustomDomainPolicy(ctx, &admin.UpdateCustomDomainPolicyRequest{
+		OrgId:                                  level.OrgID(),
+		UserLoginMustBeDomain:                  p.UserLoginMustBeDomain,
+		ValidateOrgDomains:                     p.ValidateOrgDomains,
+		SmtpSenderAddressMatchesInstanceDomain: p.SMTPSenderAddressMatchesInstanceDomain,
+	})
+	return err
+}
+
+// ResetDomainPolicy removes the domain policy of the organization, so the instance default is used again.
+// The orgID is required, otherwise [ErrOrgIDRequired] is returned.
+func ResetDomainPolicy(ctx context.Context, c Client, orgID string) error {
+	if orgID == "" {
+		return ErrOrgIDRequired
+	}
+	_, err := c.AdminService().ResetCustomDomainPolicyToDefault(ctx, &admin.ResetCustomDomainPolicyToDefaultRequest{OrgId: orgID})
+	return err
+}
+
+func domainPolicyFromProto(p *policy.DomainPolicy) *DomainPolicy {
+	return &DomainPolicy{
+		UserLoginMustBeDomain:                  p.GetUserLoginMustBeDomain(),
+		ValidateOrgDomains:                     p.GetValidateOrgDomains(),
+		SMTPSenderAddressMatchesInstanceDomain: p.GetSmtpSenderAddressMatchesInstanceDomain(),
+		IsDefault:                              p.GetIsDefault(),
+	}
+}
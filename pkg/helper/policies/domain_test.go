@@ -0,0 +1,77 @@
+package policies
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/admin"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/policy"
+)
+
+func TestSetDomainPolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		level     Level
+		isDefault bool
+		wantCalls []string
+		wantErr   error
+	}{
+		{
+			name:    "organization of authorized user, error",
+			level:   Org(""),
+			wantErr: ErrOrgIDRequired,
+		},
+		{
+			name:      "instance, updated",
+			level:     Instance(),
+			wantCalls: []string{"UpdateDomainPolicy"},
+		},
+		{
+			name:      "organization inherits default, override created",
+			level:     Org("orgID"),
+			isDefault: true,
+			wantCalls: []string{"GetCustomDomainPolicy:orgID", "AddCustomDomainPolicy:orgID"},
+		},
+		{
+			name:      "organization override, updated",
+			level:     Org("orgID"),
+			wantCalls: []string{"GetCustomDomainPolicy:orgID", "UpdateCustomDomainPolicy:orgID"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &domainAdminService{adminService: &adminService{}, isDefault: tt.isDefault}
+			err := SetDomainPolicy(context.Background(), &testClient{admin: service}, tt.level, &DomainPolicy{ValidateOrgDomains: true})
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantCalls, service.calls)
+		})
+	}
+}
+
+type domainAdminService struct {
+	*adminService
+	isDefault bool
+}
+
+func (s *domainAdminService) UpdateDomainPolicy(context.Context, *admin.UpdateDomainPolicyRequest, ...grpc.CallOption) (*admin.UpdateDomainPolicyResponse, error) {
+	s.calls = append(s.calls, "UpdateDomainPolicy")
+	return &admin.UpdateDomainPolicyResponse{}, nil
+}
+
+func (s *domainAdminService) GetCustomDomainPolicy(_ context.Context, req *admin.GetCustomDomainPolicyRequest, _ ...grpc.CallOption) (*admin.GetCustomDomainPolicyResponse, error) {
+	s.calls = append(s.calls, "GetCustomDomainPolicy:"+req.GetOrgId())
+	return &admin.GetCustomDomainPolicyResponse{Policy: &policy.DomainPolicy{IsDefault: s.isDefault}}, nil
+}
+
+func (s *domainAdminService) AddCustomDomainPolicy(_ context.Context, req *admin.AddCustomDomainPolicyRequest, _ ...grpc.CallOption) (*admin.AddCustomDomainPolicyResponse, error) {
+	s.calls = append(s.calls, "AddCustomDomainPolicy:"+req.GetOrgId())
+	return &admin.AddCustomDomainPolicyResponse{}, nil
+}
+
+func (s *domainAdminService) UpdateCustomDomainPolicy(_ context.Context, req *admin.UpdateCustomDomainPolicyRequest, _ ...grpc.CallOption) (*admin.UpdateCustomDomainPolicyResponse, error) {
+	s.calls = append(s.calls, "UpdateCustomDomainPolicy:"+req.GetOrgId())
+	return &admin.UpdateCustomDomainPolicyResponse{}, nil
+}
@@ -0,0 +1,77 @@
+package policies
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/admin"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/policy"
+)
+
+// LockoutPolicy defines after how many failed attempts a user gets locked.
+// A value of 0 disables the lockout for the respective check.
+type LockoutPolicy struct {
+	MaxPasswordAttempts uint32
+	MaxOTPAttempts      uint32
+	// IsDefault is set if an organization has no own policy and therefore uses the default of the instance.
+	// It's ignored on updates.
+	IsDefault bool
+}
+
+// GetLockoutPolicy returns the (effective) lockout policy of the level.
+func GetLockoutPolicy(ctx context.Context, c Client, level Level) (*LockoutPolicy, error) {
+	if level.IsInstance() {
+		resp, err := c.AdminService().GetLockoutPolicy(ctx, &admin.GetLockoutPolicyRequest{})
+		if err != nil {
+			return nil, err
+		}
+		return lockoutPolicyFromProto(resp.GetPolicy()), nil
+	}
+	resp, err := c.ManagementService().GetLockoutPolicy(level.context(ctx), &management.GetLockoutPolicyRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return lockoutPolicyFromProto(resp.GetPolicy()), nil
+}
+
+// SetLockoutPolicy updates the lockout policy of the level.
+// For an organization without own policy, the override is created.
+func SetLockoutPolicy(ctx context.Context, c Client, level Level, p *LockoutPolicy) error {
+	if level.IsInstance() {
+		_, err := c.AdminService().UpdateLockoutPolicy(ctx, &admin.UpdateLockoutPolicyRequest{
+			MaxPasswordAttempts: p.MaxPasswordAttempts,
+			MaxOtpAttempts:      p.MaxOTPAttempts,
+		})
+		return err
+	}
+	current, err := GetLockoutPolicy(ctx, c, level)
+	if err != nil {
+		return err
+	}
+	if current.IsDefault {
+		_, err = c.ManagementService().AddCustomLockoutPolicy(level.context(ctx), &management.AddCustomLockoutPolicyRequest{
+			MaxPasswordAttempts: p.MaxPasswordAttempts,
+			MaxOtpAttempts:      p.MaxOTPAttempts,
+		})
+		return err
+	}
+	_, err = c.ManagementService().UpdateCustomLockoutPolicy(level.context(ctx), &management.UpdateCustomLockoutPolicyRequest{
+		MaxPasswordAttempts: p.MaxPasswordAttempts,
+		MaxOtpAttempts:      p.MaxOTPAttempts,
+	})
+	return err
+}
+
+// ResetLockoutPolicy removes the lockout policy of the organization, so the instance default is used again.
+func ResetLockoutPolicy(ctx context.Context, c Client, orgID string) error {
+	_, err := c.ManagementService().ResetLockoutPolicyToDefault(Org(orgID).context(ctx), &management.ResetLockoutPolicyToDefaultRequest{})
+	return err
+}
+
+func lockoutPolicyFromProto(p *policy.LockoutPolicy) *LockoutPolicy {
+	return &LockoutPolicy{
+		MaxPasswordAttempts: uint32(p.GetMaxPasswordAttempts()),
+		MaxOTPAttempts:      uint32(p.GetMaxOtpAttempts()),
+		IsDefault:           p.GetIsDefault(),
+	}
+}
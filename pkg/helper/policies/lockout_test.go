@@ -0,0 +1,41 @@
+package policies
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/policy"
+)
+
+func TestGetLockoutPolicy(t *testing.T) {
+	service := &lockoutManagementService{managementService: &managementService{}}
+	got, err := GetLockoutPolicy(context.Background(), &testClient{management: service}, Org("orgID"))
+	require.NoError(t, err)
+	assert.Equal(t, &LockoutPolicy{
+		MaxPasswordAttempts: 5,
+		MaxOTPAttempts:      3,
+		IsDefault:           true,
+	}, got)
+	assert.Equal(t, []string{"orgID"}, service.orgIDs)
+}
+
+type lockoutManagementService struct {
+	*managementService
+	orgIDs []string
+}
+
+func (s *lockoutManagementService) GetLockoutPolicy(ctx context.Context, _ *management.GetLockoutPolicyRequest, _ ...grpc.CallOption) (*management.GetLockoutPolicyResponse, error) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	s.orgIDs = md.Get("x-zitadel-orgid")
+	return &management.GetLockoutPolicyResponse{Policy: &policy.LockoutPolicy{
+		MaxPasswordAttempts: 5,
+		MaxOtpAttempts:      3,
+		IsDefault:           true,
+	}}, nil
+}
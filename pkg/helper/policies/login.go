@@ -0,0 +1,318 @@
+package policies
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/admin"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/idp"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/policy"
+)
+
+// SecondFactor is a factor which can be used in addition to the password.
+type SecondFactor = policy.SecondFactorType
+
+const (
+	SecondFactorOTP      = policy.SecondFactorType_SECOND_FACTOR_TYPE_OTP
+	SecondFactorU2F      = policy.SecondFactorType_SECOND_FACTOR_TYPE_U2F
+	SecondFactorOTPEmail = policy.SecondFactorType_SECOND_FACTOR_TYPE_OTP_EMAIL
+	SecondFactorOTPSMS   = policy.SecondFactorType_SECOND_FACTOR_TYPE_OTP_SMS
+)
+
+// MultiFactor is a factor which can be used instead of the password and second factor.
+type MultiFactor = policy.MultiFactorType
+
+const (
+	MultiFactorU2FWithVerification = policy.MultiFactorType_MULTI_FACTOR_TYPE_U2F_WITH_VERIFICATION
+)
+
+// IDPOwner defines whether an identity provider belongs to the instance or the organization.
+type IDPOwner = idp.IDPOwnerType
+
+const (
+	IDPOwnerInstance = idp.IDPOwnerType_IDP_OWNER_TYPE_SYSTEM
+	IDPOwnerOrg      = idp.IDPOwnerType_IDP_OWNER_TYPE_ORG
+)
+
+// IDPLink is an identity provider allowed in the login policy.
+type IDPLink struct {
+	ID string
+	// Owner is only required when adding the identity provider to the policy of an organization.
+	// It's not returned when reading the policy.
+	Owner IDPOwner
+}
+
+// LoginLifetimes define how long a check (of the respective factor) of a user is valid
+// before the user is prompted again.
+type LoginLifetimes struct {
+	PasswordCheck      time.Duration
+	ExternalLoginCheck time.Duration
+	MFAInitSkip        time.Duration
+	SecondFactorCheck  time.Duration
+	MultiFactorCheck   time.Duration
+}
+
+// LoginPolicy defines how users can authenticate and register.
+type LoginPolicy struct {
+	AllowUsernamePassword  bool
+	AllowRegister          bool
+	AllowExternalIDP       bool
+	AllowPasswordless      bool
+	AllowDomainDiscovery   bool
+	ForceMFA               bool
+	ForceMFALocalOnly      bool
+	HidePasswordReset      bool
+	IgnoreUnknownUsernames bool
+	DisableLoginWithEmail  bool
+	DisableLoginWithPhone  bool
+	DefaultRedirectURI     string
+	Lifetimes              LoginLifetimes
+	SecondFactors          []SecondFactor
+	MultiFactors           []MultiFactor
+	IDPs                   []IDPLink
+	// IsDefault is set if an organization has no own policy and therefore uses the default of the instance.
+	// It's ignored on updates.
+	IsDefault bool
+}
+
+// GetLoginPolicy returns the (effective) login policy of the level.
+func GetLoginPolicy(ctx context.Context, c Client, level Level) (*LoginPolicy, error) {
+	if level.IsInstance() {
+		resp, err := c.AdminService().GetLoginPolicy(ctx, &admin.GetLoginPolicyRequest{})
+		if err != nil {
+			return nil, err
+		}
+		return loginPolicyFromProto(resp.GetPolicy()), nil
+	}
+	resp, err := c.ManagementService().GetLoginPolicy(level.context(ctx), &management.GetLoginPolicyRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return loginPolicyFromProto(resp.GetPolicy()), nil
+}
+
+// SetLoginPolicy updates the login policy of the level including its factors and identity providers.
+// For an organization without own policy, the override is created.
+func SetLoginPolicy(ctx context.Context, c Client, level Level, p *LoginPolicy) error {
+	current, err := GetLoginPolicy(ctx, c, level)
+	if err != nil {
+		return err
+	}
+	if level.IsInstance() {
+		return setInstanceLoginPolicy(ctx, c.AdminService(), current, p)
+	}
+	ctx = level.context(ctx)
+	if current.IsDefault {
+		return addCustomLoginPolicy(ctx, c.ManagementService(), p)
+	}
+	return updateCustomLoginPolicy(ctx, c.ManagementService(), current, p)
+}
+
+// ResetLoginPolicy removes the login policy of the organization, so the instance default is used again.
+func ResetLoginPolicy(ctx context.Context, c Client, orgID string) error {
+	_, err := c.ManagementService().ResetLoginPolicyToDefault(Org(orgID).context(ctx), &management.ResetLoginPolicyToDefaultRequest{})
+	return err
+}
+
+func setInstanceLoginPolicy(ctx context.Context, c admin.AdminServiceClient, current, p *LoginPolicy) error {
+	_, err := c.UpdateLoginPolicy(ctx, &admin.UpdateLoginPolicyRequest{
+		AllowUsernamePassword:      p.AllowUsernamePassword,
+		AllowRegister:              p.AllowRegister,
+		AllowExternalIdp:           p.AllowExternalIDP,
+		ForceMfa:                   p.ForceMFA,
+		PasswordlessType:           passwordlessType(p.AllowPasswordless),
+		HidePasswordReset:          p.HidePasswordReset,
+		IgnoreUnknownUsernames:     p.IgnoreUnknownUsernames,
+		DefaultRedirectUri:         p.DefaultRedirectURI,
+		PasswordCheckLifetime:      durationpb.New(p.Lifetimes.PasswordCheck),
+		ExternalLoginCheckLifetime: durationpb.New(p.Lifetimes.ExternalLoginCheck),
+		MfaInitSkipLifetime:        durationpb.New(p.Lifetimes.MFAInitSkip),
+		SecondFactorCheckLifetime:  durationpb.New(p.Lifetimes.SecondFactorCheck),
+		MultiFactorCheckLifetime:   durationpb.New(p.Lifetimes.MultiFactorCheck),
+		AllowDomainDiscovery:       p.AllowDomainDiscovery,
+		DisableLoginWithEmail:      p.DisableLoginWithEmail,
+		DisableLoginWithPhone:      p.DisableLoginWithPhone,
+		ForceMfaLocalOnly:          p.ForceMFALocalOnly,
+	})
+	if err != nil {
+		return err
+	}
+	addSecond, removeSecond := diff(current.SecondFactors, p.SecondFactors)
+	for _, factor := range addSecond {
+		if _, err = c.AddSecondFactorToLoginPolicy(ctx, &admin.AddSecondFactorToLoginPolicyRequest{Type: factor}); err != nil {
+			return fmt.Errorf("unable to add second factor `%s`: %w", factor, err)
+		}
+	}
+	for _, factor := range removeSecond {
+		if _, err = c.RemoveSecondFactorFromLoginPolicy(ctx, &admin.RemoveSecondFactorFromLoginPolicyRequest{Type: factor}); err != nil {
+			return fmt.Errorf("unable to remove second factor `%s`: %w", factor, err)
+		}
+	}
+	addMulti, removeMulti := diff(current.MultiFactors, p.MultiFactors)
+	for _, factor := range addMulti {
+		if _, err = c.AddMultiFactorToLoginPolicy(ctx, &admin.AddMultiFactorToLoginPolicyRequest{Type: factor}); err != nil {
+			return fmt.Errorf("unable to add multi factor `%s`: %w", factor, err)
+		}
+	}
+	for _, factor := range removeMulti {
+		if _, err = c.RemoveMultiFactorFromLoginPolicy(ctx, &admin.RemoveMultiFactorFromLoginPolicyRequest{Type: factor}); err != nil {
+			return fmt.Errorf("unable to remove multi factor `%s`: %w", factor, err)
+		}
+	}
+	addIDPs, removeIDPs := diff(idpIDs(current.IDPs), idpIDs(p.IDPs))
+	for _, id := range addIDPs {
+		if _, err = c.AddIDPToLoginPolicy(ctx, &admin.AddIDPToLoginPolicyRequest{IdpId: id}); err != nil {
+			return fmt.Errorf("unable to add identity provider `%s`: %w", id, err)
+		}
+	}
+	for _, id := range removeIDPs {
+		if _, err = c.RemoveIDPFromLoginPolicy(ctx, &admin.RemoveIDPFromLoginPolicyRequest{IdpId: id}); err != nil {
+			return fmt.Errorf("unable to remove identity provider `%s`: %w", id, err)
+		}
+	}
+	return nil
+}
+
+func addCustomLoginPolicy(ctx context.Context, c management.ManagementServiceClient, p *LoginPolicy) error {
+	idps := make([]*management.AddCustomLoginPolicyRequest_IDP, len(p.IDPs))
+	for i, link := range p.IDPs {
+		idps[i] = &management.AddCustomLoginPolicyRequest_IDP{IdpId: link.ID, OwnerType: link.Owner}
+	}
+	_, err := c.AddCustomLoginPolicy(ctx, &management.AddCustomLoginPolicyRequest{
+		AllowUsernamePassword:      p.AllowUsernamePassword,
+		AllowRegister:              p.AllowRegister,
+		AllowExternalIdp:           p.AllowExternalIDP,
+		ForceMfa:                   p.ForceMFA,
+		PasswordlessType:           passwordlessType(p.AllowPasswordless),
+		HidePasswordReset:          p.HidePasswordReset,
+		IgnoreUnknownUsernames:     p.IgnoreUnknownUsernames,
+		DefaultRedirectUri:         p.DefaultRedirectURI,
+		PasswordCheckLifetime:      durationpb.New(p.Lifetimes.PasswordCheck),
+		ExternalLoginCheckLifetime: durationpb.New(p.Lifetimes.ExternalLoginCheck),
+		MfaInitSkipLifetime:        durationpb.New(p.Lifetimes.MFAInitSkip),
+		SecondFactorCheckLifetime:  durationpb.New(p.Lifetimes.SecondFactorCheck),
+		MultiFactorCheckLifetime:   durationpb.New(p.Lifetimes.MultiFactorCheck),
+		SecondFactors:              p.SecondFactors,
+		MultiFactors:               p.MultiFactors,
+		Idps:                       idps,
+		AllowDomainDiscovery:       p.AllowDomainDiscovery,
+		DisableLoginWithEmail:      p.DisableLoginWithEmail,
+		DisableLoginWithPhone:      p.DisableLoginWithPhone,
+		ForceMfaLocalOnly:          p.ForceMFALocalOnly,
+	})
+	return err
+}
+
+func updateCustomLoginPolicy(ctx context.Context, c management.ManagementServiceClient, current, p *LoginPolicy) error {
+	_, err := c.UpdateCustomLoginPolicy(ctx, &management.UpdateCustomLoginPolicyRequest{
+		AllowUsernamePassword:      p.AllowUsernamePassword,
+		AllowRegister:              p.AllowRegister,
+		AllowExternalIdp:           p.AllowExternalIDP,
+		ForceMfa:                   p.ForceMFA,
+		PasswordlessType:           passwordlessType(p.AllowPasswordless),
+		HidePasswordReset:          p.HidePasswordReset,
+		IgnoreUnknownUsernames:     p.IgnoreUnknownUsernames,
+		DefaultRedirectUri:         p.DefaultRedirectURI,
+		PasswordCheckLifetime:      durationpb.New(p.Lifetimes.PasswordCheck),
+		ExternalLoginCheckLifetime: durationpb.New(p.Lifetimes.ExternalLoginCheck),
+		MfaInitSkipLifetime:        durationpb.New(p.Lifetimes.MFAInitSkip),
+		SecondFactorCheckLifetime:  durationpb.New(p.Lifetimes.SecondFactorCheck),
+		MultiFactorCheckLifetime:   durationpb.New(p.Lifetimes.MultiFactorCheck),
+		AllowDomainDiscovery:       p.AllowDomainDiscovery,
+		DisableLoginWithEmail:      p.DisableLoginWithEmail,
+		DisableLoginWithPhone:      p.DisableLoginWithPhone,
+		ForceMfaLocalOnly:          p.ForceMFALocalOnly,
+	})
+	if err != nil {
+		return err
+	}
+	addSecond, removeSecond := diff(current.SecondFactors, p.SecondFactors)
+	for _, factor := range addSecond {
+		if _, err = c.AddSecondFactorToLoginPolicy(ctx, &management.AddSecondFactorToLoginPolicyRequest{Type: factor}); err != nil {
+			return fmt.Errorf("unable to add second factor `%s`: %w", factor, err)
+		}
+	}
+	for _, factor := range removeSecond {
+		if _, err = c.RemoveSecondFactorFromLoginPolicy(ctx, &management.RemoveSecondFactorFromLoginPolicyRequest{Type: factor}); err != nil {
+			return fmt.Errorf("unable to remove second factor `%s`: %w", factor, err)
+		}
+	}
+	addMulti, removeMulti := diff(current.MultiFactors, p.MultiFactors)
+	for _, factor := range addMulti {
+		if _, err = c.AddMultiFactorToLoginPolicy(ctx, &management.AddMultiFactorToLoginPolicyRequest{Type: factor}); err != nil {
+			return fmt.Errorf("unable to add multi factor `%s`: %w", factor, err)
+		}
+	}
+	for _, factor := range removeMulti {
+		if _, err = c.RemoveMultiFactorFromLoginPolicy(ctx, &management.RemoveMultiFactorFromLoginPolicyRequest{Type: factor}); err != nil {
+			return fmt.Errorf("unable to remove multi factor `%s`: %w", factor, err)
+		}
+	}
+	owners := make(map[string]IDPOwner, len(p.IDPs))
+	for _, link := range p.IDPs {
+		owners[link.ID] = link.Owner
+	}
+	addIDPs, removeIDPs := diff(idpIDs(current.IDPs), idpIDs(p.IDPs))
+	for _, id := range addIDPs {
+		if _, err = c.AddIDPToLoginPolicy(ctx, &management.AddIDPToLoginPolicyRequest{IdpId: id, OwnerType: owners[id]}); err != nil {
+			return fmt.Errorf("unable to add identity provider `%s`: %w", id, err)
+		}
+	}
+	for _, id := range removeIDPs {
+		if _, err = c.RemoveIDPFromLoginPolicy(ctx, &management.RemoveIDPFromLoginPolicyRequest{IdpId: id}); err != nil {
+			return fmt.Errorf("unable to remove identity provider `%s`: %w", id, err)
+		}
+	}
+	return nil
+}
+
+func loginPolicyFromProto(p *policy.LoginPolicy) *LoginPolicy {
+	idps := make([]IDPLink, len(p.GetIdps()))
+	for i, link := range p.GetIdps() {
+		idps[i] = IDPLink{ID: link.GetIdpId()}
+	}
+	return &LoginPolicy{
+		AllowUsernamePassword:  p.GetAllowUsernamePassword(),
+		AllowRegister:          p.GetAllowRegister(),
+		AllowExternalIDP:       p.GetAllowExternalIdp(),
+		AllowPasswordless:      p.GetPasswordlessType() == policy.PasswordlessType_PASSWORDLESS_TYPE_ALLOWED,
+		AllowDomainDiscovery:   p.GetAllowDomainDiscovery(),
+		ForceMFA:               p.GetForceMfa(),
+		ForceMFALocalOnly:      p.GetForceMfaLocalOnly(),
+		HidePasswordReset:      p.GetHidePasswordReset(),
+		IgnoreUnknownUsernames: p.GetIgnoreUnknownUsernames(),
+		DisableLoginWithEmail:  p.GetDisableLoginWithEmail(),
+		DisableLoginWithPhone:  p.GetDisableLoginWithPhone(),
+		DefaultRedirectURI:     p.GetDefaultRedirectUri(),
+		Lifetimes: LoginLifetimes{
+			PasswordCheck:      p.GetPasswordCheckLifetime().AsDuration(),
+			ExternalLoginCheck: p.GetExternalLoginCheckLifetime().AsDuration(),
+			MFAInitSkip:        p.GetMfaInitSkipLifetime().AsDuration(),
+			SecondFactorCheck:  p.GetSecondFactorCheckLifetime().AsDuration(),
+			MultiFactorCheck:   p.GetMultiFactorCheckLifetime().AsDuration(),
+		},
+		SecondFactors: p.GetSecondFactors(),
+		MultiFactors:  p.GetMultiFactors(),
+		IDPs:          idps,
+		IsDefault:     p.GetIsDefault(),
+	}
+}
+
+func passwordlessType(allowed bool) policy.PasswordlessType {
+	if allowed {
+		return policy.PasswordlessType_PASSWORDLESS_TYPE_ALLOWED
+	}
+	return policy.PasswordlessType_PASSWORDLESS_TYPE_NOT_ALLOWED
+}
+
+func idpIDs(links []IDPLink) []string {
+	ids := make([]string, len(links))
+	for i, link := range links {
+		ids[i] = link.ID
+	}
+	return ids
+}
@@ -0,0 +1,140 @@
+package policies
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/admin"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/idp"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/policy"
+)
+
+func TestSetLoginPolicy(t *testing.T) {
+	desired := &LoginPolicy{
+		AllowUsernamePassword: true,
+		SecondFactors:         []SecondFactor{SecondFactorOTP, SecondFactorU2F},
+		IDPs:                  []IDPLink{{ID: "idp2", Owner: IDPOwnerOrg}},
+	}
+	tests := []struct {
+		name      string
+		level     Level
+		current   *policy.LoginPolicy
+		wantAdmin []string
+		wantMgmt  []string
+	}{
+		{
+			name:  "instance, factors and idps changed",
+			level: Instance(),
+			current: &policy.LoginPolicy{
+				SecondFactors: []policy.SecondFactorType{SecondFactorOTP, SecondFactorOTPSMS},
+				Idps:          []*idp.IDPLoginPolicyLink{{IdpId: "idp1"}},
+			},
+			wantAdmin: []string{
+				"GetLoginPolicy",
+				"UpdateLoginPolicy",
+				"AddSecondFactorToLoginPolicy:SECOND_FACTOR_TYPE_U2F",
+				"RemoveSecondFactorFromLoginPolicy:SECOND_FACTOR_TYPE_OTP_SMS",
+				"AddIDPToLoginPolicy:idp2",
+				"RemoveIDPFromLoginPolicy:idp1",
+			},
+		},
+		{
+			name:  "organization inherits default, override created",
+			level: Org("orgID"),
+			current: &policy.LoginPolicy{
+				SecondFactors: []policy.SecondFactorType{SecondFactorOTP},
+				IsDefault:     true,
+			},
+			wantMgmt: []string{
+				"GetLoginPolicy",
+				"AddCustomLoginPolicy:[SECOND_FACTOR_TYPE_OTP SECOND_FACTOR_TYPE_U2F]",
+			},
+		},
+		{
+			name:  "organization override, updated",
+			level: Org("orgID"),
+			current: &policy.LoginPolicy{
+				SecondFactors: []policy.SecondFactorType{SecondFactorOTP, SecondFactorU2F},
+				Idps:          []*idp.IDPLoginPolicyLink{{IdpId: "idp2"}},
+			},
+			wantMgmt: []string{
+				"GetLoginPolicy",
+				"UpdateCustomLoginPolicy",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adminSvc := &loginAdminService{adminService: &adminService{}, policy: tt.current}
+			mgmtSvc := &loginManagementService{managementService: &managementService{}, policy: tt.current}
+			err := SetLoginPolicy(context.Background(), &testClient{admin: adminSvc, management: mgmtSvc}, tt.level, desired)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantAdmin, adminSvc.calls)
+			assert.Equal(t, tt.wantMgmt, mgmtSvc.calls)
+		})
+	}
+}
+
+type loginAdminService struct {
+	*adminService
+	policy *policy.LoginPolicy
+}
+
+func (s *loginAdminService) GetLoginPolicy(context.Context, *admin.GetLoginPolicyRequest, ...grpc.CallOption) (*admin.GetLoginPolicyResponse, error) {
+	s.calls = append(s.calls, "GetLoginPolicy")
+	return &admin.GetLoginPolicyResponse{Policy: s.policy}, nil
+}
+
+func (s *loginAdminService) UpdateLoginPolicy(context.Context, *admin.UpdateLoginPolicyRequest, ...grpc.CallOption) (*admin.UpdateLoginPolicyResponse, error) {
+	s.calls = append(s.calls, "UpdateLoginPolicy")
+	return &admin.UpdateLoginPolicyResponse{}, nil
+}
+
+func (s *loginAdminService) AddSecondFactorToLoginPolicy(_ context.Context, req *admin.AddSecondFactorToLoginPolicyRequest, _ ...grpc.CallOption) (*admin.AddSecondFactorToLoginPolicyResponse, error) {
+	s.calls = append(s.calls, "AddSecondFactorToLoginPolicy:"+req.GetType().String())
+	return &admin.AddSecondFactorToLoginPolicyResponse{}, nil
+}
+
+func (s *loginAdminService) RemoveSecondFactorFromLoginPolicy(_ context.Context, req *admin.RemoveSecondFactorFromLoginPolicyRequest, _ ...grpc.CallOption) (*admin.RemoveSecondFactorFromLoginPolicyResponse, error) {
+	s.calls = append(s.calls, "RemoveSecondFactorFromLoginPolicy:"+req.GetType().String())
+	return &admin.RemoveSecondFactorFromLoginPolicyResponse{}, nil
+}
+
+func (s *loginAdminService) AddIDPToLoginPolicy(_ context.Context, req *admin.AddIDPToLoginPolicyRequest, _ ...grpc.CallOption) (*admin.AddIDPToLoginPolicyResponse, error) {
+	s.calls = append(s.calls, "AddIDPToLoginPolicy:"+req.GetIdpId())
+	return &admin.AddIDPToLoginPolicyResponse{}, nil
+}
+
+func (s *loginAdminService) RemoveIDPFromLoginPolicy(_ context.Context, req *admin.RemoveIDPFromLoginPolicyRequest, _ ...grpc.CallOption) (*admin.RemoveIDPFromLoginPolicyResponse, error) {
+	s.calls = append(s.calls, "RemoveIDPFromLoginPolicy:"+req.GetIdpId())
+	return &admin.RemoveIDPFromLoginPolicyResponse{}, nil
+}
+
+type loginManagementService struct {
+	*managementService
+	policy *policy.LoginPolicy
+}
+
+func (s *loginManagementService) GetLoginPolicy(context.Context, *management.GetLoginPolicyRequest, ...grpc.CallOption) (*management.GetLoginPolicyResponse, error) {
+	s.calls = append(s.calls, "GetLoginPolicy")
+	return &management.GetLoginPolicyResponse{Policy: s.policy}, nil
+}
+
+func (s *loginManagementService) AddCustomLoginPolicy(_ context.Context, req *management.AddCustomLoginPolicyRequest, _ ...grpc.CallOption) (*management.AddCustomLoginPolicyResponse, error) {
+	factors := make([]string, len(req.GetSecondFactors()))
+	for i, factor := range req.GetSecondFactors() {
+		factors[i] = factor.String()
+	}
+	s.calls = append(s.calls, fmt.Sprintf("AddCustomLoginPolicy:%v", factors))
+	return &management.AddCustomLoginPolicyResponse{}, nil
+}
+
+func (s *loginManagementService) UpdateCustomLoginPolicy(context.Context, *management.UpdateCustomLoginPolicyRequest, ...grpc.CallOption) (*management.UpdateCustomLoginPolicyResponse, error) {
+	s.calls = append(s.calls, "UpdateCustomLoginPolicy")
+	return &management.UpdateCustomLoginPolicyResponse{}, nil
+}
@@ -0,0 +1,82 @@
+package policies
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/admin"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/policy"
+)
+
+// NotificationPolicy defines which optional notifications are sent to the users.
+type NotificationPolicy struct {
+	// PasswordChange sends a notification to the user after the password was changed.
+	PasswordChange bool
+	// IsDefault is set if an organization has no own policy and therefore uses the default of the instance.
+	// It's ignored on updates.
+	IsDefault bool
+}
+
+// GetNotificationPolicy returns the (effective) notification policy of the level.
+func GetNotificationPolicy(ctx context.Context, c Client, level Level) (*NotificationPolicy, error) {
+	if level.IsInstance() {
+		resp, err := c.AdminService().GetNotificationPolicy(ctx, &admin.GetNotificationPolicyRequest{})
+		if err != nil {
+			return nil, err
+		}
+		return notificationPolicyFromProto(resp.GetPolicy()), nil
+	}
+	resp, err := c.ManagementService().GetNotificationPolicy(level.context(ctx), &management.GetNotificationPolicyRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return notificationPolicyFromProto(resp.GetPolicy()), nil
+}
+
+// SetNotificationPolicy updates the notification policy of the level.
+// For an organization without own policy, the override is created. Instances set up before the notification
+// policy was introduced might not have a default yet, in which case it's created as well.
+func SetNotificationPolicy(ctx context.Context, c Client, level Level, p *NotificationPolicy) error {
+	if level.IsInstance() {
+		_, err := c.AdminService().UpdateNotificationPolicy(ctx, &admin.UpdateNotificationPolicyRequest{
+			PasswordChange: p.PasswordChange,
+		})
+		if status.Code(err) != codes.NotFound {
+			return err
+		}
+		_, err = c.AdminService().AddNotificationPolicy(ctx, &admin.AddNotificationPolicyRequest{
+			PasswordChange: p.PasswordChange,
+		})
+		return err
+	}
+	current, err := GetNotificationPolicy(ctx, c, level)
+	if err != nil {
+		return err
+	}
+	if current.IsDefault {
+		_, err = c.ManagementService().AddCustomNotificationPolicy(level.context(ctx), &management.AddCustomNotificationPolicyRequest{
+			PasswordChange: p.PasswordChange,
+		})
+		return err
+	}
+	_, err = c.ManagementService().UpdateCustomNotificationPolicy(level.context(ctx), &management.UpdateCustomNotificationPolicyRequest{
+		PasswordChange: p.PasswordChange,
+	})
+	return err
+}
+
+// ResetNotificationPolicy removes the notification policy of the organization, so the instance default is used again.
+func ResetNotificationPolicy(ctx context.Context, c Client, orgID string) error {
+	_, err := c.ManagementService().ResetNotificationPolicyToDefault(Org(orgID).context(ctx), &management.ResetNotificationPolicyToDefaultRequest{})
+	return err
+}
+
+func notificationPolicyFromProto(p *policy.NotificationPolicy) *NotificationPolicy {
+	return &NotificationPolicy{
+		PasswordChange: p.GetPasswordChange(),
+		IsDefault:      p.GetIsDefault(),
+	}
+}
@@ -0,0 +1,61 @@
+package policies
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/admin"
+)
+
+func TestSetNotificationPolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		updateErr error
+		wantCalls []string
+		wantErr   bool
+	}{
+		{
+			name:      "updated",
+			wantCalls: []string{"UpdateNotificationPolicy"},
+		},
+		{
+			name:      "not found, added",
+			updateErr: status.Error(codes.NotFound, "not found"),
+			wantCalls: []string{"UpdateNotificationPolicy", "AddNotificationPolicy"},
+		},
+		{
+			name:      "other error, returned",
+			updateErr: status.Error(codes.PermissionDenied, "denied"),
+			wantCalls: []string{"UpdateNotificationPolicy"},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &notificationAdminService{adminService: &adminService{}, updateErr: tt.updateErr}
+			err := SetNotificationPolicy(context.Background(), &testClient{admin: service}, Instance(), &NotificationPolicy{PasswordChange: true})
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantCalls, service.calls)
+		})
+	}
+}
+
+type notificationAdminService struct {
+	*adminService
+	updateErr error
+}
+
+func (s *notificationAdminService) UpdateNotificationPolicy(context.Context, *admin.UpdateNotificationPolicyRequest, ...grpc.CallOption) (*admin.UpdateNotificationPolicyResponse, error) {
+	s.calls = append(s.calls, "UpdateNotificationPolicy")
+	return &admin.UpdateNotificationPolicyResponse{}, s.updateErr
+}
+
+func (s *notificationAdminService) AddNotificationPolicy(context.Context, *admin.AddNotificationPolicyRequest, ...grpc.CallOption) (*admin.AddNotificationPolicyResponse, error) {
+	s.calls = append(s.calls, "AddNotificationPolicy")
+	return &admin.AddNotificationPolicyResponse{}, nil
+}
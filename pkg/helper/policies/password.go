@@ -0,0 +1,130 @@
+package policies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/admin"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/policy"
+)
+
+const (
+	// PasswordMaxMinLength is the highest minimum length accepted by ZITADEL (limit of bcrypt).
+	PasswordMaxMinLength = 72
+)
+
+var (
+	ErrInvalidPasswordMinLength = errors.New("invalid password min length")
+	ErrMissingPasswordPolicy    = errors.New("password complexity policy is required")
+)
+
+// PasswordComplexityPolicy defines the requirements for passwords of users.
+type PasswordComplexityPolicy struct {
+	MinLength    uint64
+	HasUppercase bool
+	HasLowercase bool
+	HasNumber    bool
+	HasSymbol    bool
+	// IsDefault is set if an organization has no own policy and therefore uses the default of the instance.
+	// It's ignored on updates.
+	IsDefault bool
+}
+
+// Validate checks the policy before calling the API, so misconfigurations are detected early.
+// The MinLength needs to be between 1 and [PasswordMaxMinLength], as ZITADEL requires.
+// Additionally, this helper requires it to be at least the number of required character classes,
+// since no password could satisfy the policy otherwise. ZITADEL itself does not check this.
+func (p *PasswordComplexityPolicy) Validate() error {
+	if p == nil {
+		return ErrMissingPasswordPolicy
+	}
+	if p.MinLength == 0 || p.MinLength > PasswordMaxMinLength {
+		return fmt.Errorf("%w: %d is not within [1, %d]", ErrInvalidPasswordMinLength, p.MinLength, PasswordMaxMinLength)
+	}
+	var required uint64
+	for _, has := range []bool{p.HasUppercase, p.HasLowercase, p.HasNumber, p.HasSymbol} {
+		if has {
+			required++
+		}
+	}
+	if p.MinLength < required {
+		return fmt.Errorf("%w: %d is lower than the %d required character classes", ErrInvalidPasswordMinLength, p.MinLength, required)
+	}
+	return nil
+}
+
+// GetPasswordComplexityPolicy returns the (effective) password complexity policy of the level.
+func GetPasswordComplexityPolicy(ctx context.Context, c Client, level Level) (*PasswordComplexityPolicy, error) {
+	if level.IsInstance() {
+		resp, err := c.AdminService().GetPasswordComplexityPolicy(ctx, &admin.GetPasswordComplexityPolicyRequest{})
+		if err != nil {
+			return nil, err
+		}
+		return passwordComplexityPolicyFromProto(resp.GetPolicy()), nil
+	}
+	resp, err := c.ManagementService().GetPasswordComplexityPolicy(level.context(ctx), &management.GetPasswordComplexityPolicyRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return passwordComplexityPolicyFromProto(resp.GetPolicy()), nil
+}
+
+// SetPasswordComplexityPolicy validates and updates the password complexity policy of the level.
+// For an organization without own policy, the override is created.
+func SetPasswordComplexityPolicy(ctx context.Context, c Client, level Level, p *PasswordComplexityPolicy) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	if level.IsInstance() {
+		_, err := c.AdminService().UpdatePasswordComplexityPolicy(ctx, &admin.UpdatePasswordComplexityPolicyRequest{
+			MinLength:    uint32(p.MinLength),
+			HasUppercase: p.HasUppercase,
+			HasLowercase: p.HasLowercase,
+			HasNumber:    p.HasNumber,
+			HasSymbol:    p.HasSymbol,
+		})
+		return err
+	}
+	current, err := GetPasswordComplexityPolicy(ctx, c, level)
+	if err != nil {
+		return err
+	}
+	if current.IsDefault {
+		_, err = c.ManagementService().AddCustomPasswordComplexityPolicy(level.context(ctx), &management.AddCustomPasswordComplexityPolicyRequest{
+			MinLength:    p.MinLength,
+			HasUppercase: p.HasUppercase,
+			HasLowercase: p.HasLowercase,
+			HasNumber:    p.HasNumber,
+			HasSymbol:    p.HasSymbol,
+		})
+		return err
+	}
+	_, err = c.ManagementService().UpdateCustomPasswordComplexityPolicy(level.context(ctx), &management.UpdateCustomPasswordComplexityPolicyRequest{
+		MinLength:    p.MinLength,
+		HasUppercase: p.HasUppercase,
+		HasLowercase: p.HasLowercase,
+		HasNumber:    p.HasNumber,
+		HasSymbol:    p.HasSymbol,
+	})
+	return err
+}
+
+// ResetPasswordComplexityPolicy removes the password complexity policy of the organization,
+// so the instance default is used again.
+func ResetPasswordComplexityPolicy(ctx context.Context, c Client, orgID string) error {
+	_, err := c.ManagementService().ResetPasswordComplexityPolicyToDefault(Org(orgID).context(ctx), &management.ResetPasswordComplexityPolicyToDefaultRequest{})
+	return err
+}
+
+func passwordComplexityPolicyFromProto(p *policy.PasswordComplexityPolicy) *PasswordComplexityPolicy {
+	return &PasswordComplexityPolicy{
+		MinLength:    p.GetMinLength(),
+		HasUppercase: p.GetHasUppercase(),
+		HasLowercase: p.GetHasLowercase(),
+		HasNumber:    p.GetHasNumber(),
+		HasSymbol:    p.GetHasSymbol(),
+		IsDefault:    p.GetIsDefault(),
+	}
+}
@@ -0,0 +1,105 @@
+package policies
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/policy"
+)
+
+func TestPasswordComplexityPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  *PasswordComplexityPolicy
+		wantErr error
+	}{
+		{
+			name:    "nil policy, error",
+			wantErr: ErrMissingPasswordPolicy,
+		},
+		{
+			name:    "zero min length, error",
+			policy:  &PasswordComplexityPolicy{},
+			wantErr: ErrInvalidPasswordMinLength,
+		},
+		{
+			name:    "min length too high, error",
+			policy:  &PasswordComplexityPolicy{MinLength: 73},
+			wantErr: ErrInvalidPasswordMinLength,
+		},
+		{
+			name: "min length lower than required classes, error",
+			policy: &PasswordComplexityPolicy{
+				MinLength:    3,
+				HasUppercase: true,
+				HasLowercase: true,
+				HasNumber:    true,
+				HasSymbol:    true,
+			},
+			wantErr: ErrInvalidPasswordMinLength,
+		},
+		{
+			name: "valid",
+			policy: &PasswordComplexityPolicy{
+				MinLength:    12,
+				HasUppercase: true,
+				HasNumber:    true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.policy.Validate(), tt.wantErr)
+		})
+	}
+}
+
+func TestSetPasswordComplexityPolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		isDefault bool
+		wantCalls []string
+	}{
+		{
+			name:      "organization inherits default, override created",
+			isDefault: true,
+			wantCalls: []string{"GetPasswordComplexityPolicy", "AddCustomPasswordComplexityPolicy"},
+		},
+		{
+			name:      "organization override, updated",
+			wantCalls: []string{"GetPasswordComplexityPolicy", "UpdateCustomPasswordComplexityPolicy"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &passwordManagementService{managementService: &managementService{}, isDefault: tt.isDefault}
+			err := SetPasswordComplexityPolicy(context.Background(), &testClient{management: service}, Org("orgID"), &PasswordComplexityPolicy{MinLength: 8})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCalls, service.calls)
+		})
+	}
+}
+
+type passwordManagementService struct {
+	*managementService
+	isDefault bool
+}
+
+func (s *passwordManagementService) GetPasswordComplexityPolicy(context.Context, *management.GetPasswordComplexityPolicyRequest, ...grpc.CallOption) (*management.GetPasswordComplexityPolicyResponse, error) {
+	s.calls = append(s.calls, "GetPasswordComplexityPolicy")
+	return &management.GetPasswordComplexityPolicyResponse{Policy: &policy.PasswordComplexityPolicy{IsDefault: s.isDefault}}, nil
+}
+
+func (s *passwordManagementService) AddCustomPasswordComplexityPolicy(context.Context, *management.AddCustomPasswordComplexityPolicyRequest, ...grpc.CallOption) (*management.AddCustomPasswordComplexityPolicyResponse, error) {
+	s.calls = append(s.calls, "AddCustomPasswordComplexityPolicy")
+	return &management.AddCustomPasswordComplexityPolicyResponse{}, nil
+}
+
+func (s *passwordManagementService) UpdateCustomPasswordComplexityPolicy(context.Context, *management.UpdateCustomPasswordComplexityPolicyRequest, ...grpc.CallOption) (*management.UpdateCustomPasswordComplexityPolicyResponse, error) {
+	s.calls = append(s.calls, "UpdateCustomPasswordComplexityPolicy")
+	return &management.UpdateCustomPasswordComplexityPolicyResponse{}, nil
+}
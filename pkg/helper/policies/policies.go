@@ -0,0 +1,81 @@
+// Package policies provides typed helpers for reading and updating the policies (settings) of ZITADEL.
+//
+// Every policy is defined as default on the instance and can be overridden by an organization.
+// The functions therefore take a [Level] to choose between the instance default ([Instance])
+// and the organization override ([Org]). Reading an organization's policy returns the effective policy,
+// which is the instance default unless the organization has its own (see IsDefault of the respective policy).
+// Updating an organization's policy creates the override if there's none yet. Use the respective Reset function
+// to remove the override again.
+package policies
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/admin"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/org"
+)
+
+// Client is the part of the [client.Client] used by this package.
+type Client interface {
+	AdminService() admin.AdminServiceClient
+	ManagementService() management.ManagementServiceClient
+}
+
+// Level selects the instance default or the organization override of a policy.
+type Level struct {
+	instance bool
+	orgID    string
+}
+
+// Instance selects the default policy of the instance.
+func Instance() Level {
+	return Level{instance: true}
+}
+
+// Org selects the policy of the organization. If orgID is empty, the organization of the authorized user is used.
+func Org(orgID string) Level {
+	return Level{orgID: orgID}
+}
+
+// IsInstance returns true if the level selects the instance default.
+func (l Level) IsInstance() bool {
+	return l.instance
+}
+
+// OrgID returns the id of the selected organization. It's empty for the instance
+// or if the organization of the authorized user is selected.
+func (l Level) OrgID() string {
+	return l.orgID
+}
+
+func (l Level) context(ctx context.Context) context.Context {
+	if l.instance {
+		return ctx
+	}
+	return org.Context(ctx, l.orgID)
+}
+
+// diff computes the values to be added and removed to get from the existing to the desired values.
+func diff[T comparable](existing, desired []T) (add, remove []T) {
+	current := make(map[T]struct{}, len(existing))
+	for _, v := range existing {
+		current[v] = struct{}{}
+	}
+	wanted := make(map[T]struct{}, len(desired))
+	for _, v := range desired {
+		if _, ok := wanted[v]; ok {
+			continue
+		}
+		wanted[v] = struct{}{}
+		if _, ok := current[v]; !ok {
+			add = append(add, v)
+		}
+	}
+	for _, v := range existing {
+		if _, ok := wanted[v]; !ok {
+			remove = append(remove, v)
+		}
+	}
+	return add, remove
+}
@@ -0,0 +1,73 @@
+package policies
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/admin"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+)
+
+func Test_diff(t *testing.T) {
+	type args struct {
+		existing []string
+		desired  []string
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantAdd    []string
+		wantRemove []string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "unchanged, different order",
+			args: args{
+				existing: []string{"a", "b"},
+				desired:  []string{"b", "a"},
+			},
+		},
+		{
+			name: "add and remove, duplicates ignored",
+			args: args{
+				existing: []string{"a", "b"},
+				desired:  []string{"b", "c", "c"},
+			},
+			wantAdd:    []string{"c"},
+			wantRemove: []string{"a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			add, remove := diff(tt.args.existing, tt.args.desired)
+			assert.Equal(t, tt.wantAdd, add)
+			assert.Equal(t, tt.wantRemove, remove)
+		})
+	}
+}
+
+type testClient struct {
+	admin      admin.AdminServiceClient
+	management management.ManagementServiceClient
+}
+
+func (c *testClient) AdminService() admin.AdminServiceClient {
+	return c.admin
+}
+
+func (c *testClient) ManagementService() management.ManagementServiceClient {
+	return c.management
+}
+
+type adminService struct {
+	admin.AdminServiceClient
+	calls []string
+}
+
+type managementService struct {
+	management.ManagementServiceClient
+	calls []string
+}
@@ -0,0 +1,119 @@
+package policies
+
+import (
+	"context"
+	"strings"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/admin"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/policy"
+)
+
+const (
+	// LanguagePlaceholder can be used in the links of the [PrivacyPolicy] and will be replaced by the language
+	// of the user in the login UI, e.g. https://example.com/{{.Lang}}/tos for language specific documents.
+	LanguagePlaceholder = "{{.Lang}}"
+)
+
+// PrivacyPolicy defines the legal and support links shown in the login UI and emails.
+// Use the [LanguagePlaceholder] in the links to serve language specific documents.
+type PrivacyPolicy struct {
+	TOSLink        string
+	PrivacyLink    string
+	HelpLink       string
+	SupportEmail   string
+	DocsLink       string
+	CustomLink     string
+	CustomLinkText string
+	// IsDefault is set if an organization has no own policy and therefore uses the default of the instance.
+	// It's ignored on updates.
+	IsDefault bool
+}
+
+// ForLanguage returns a copy of the policy with the [LanguagePlaceholder] of all links
+// replaced by the provided language, e.g. to render the links in your own application.
+func (p *PrivacyPolicy) ForLanguage(lang string) *PrivacyPolicy {
+	localized := *p
+	for _, link := range []*string{&localized.TOSLink, &localized.PrivacyLink, &localized.HelpLink, &localized.DocsLink, &localized.CustomLink} {
+		*link = strings.ReplaceAll(*link, LanguagePlaceholder, lang)
+	}
+	return &localized
+}
+
+// GetPrivacyPolicy returns the (effective) privacy policy of the level.
+func GetPrivacyPolicy(ctx context.Context, c Client, level Level) (*PrivacyPolicy, error) {
+	if level.IsInstance() {
+		resp, err := c.AdminService().GetPrivacyPolicy(ctx, &admin.GetPrivacyPolicyRequest{})
+		if err != nil {
+			return nil, err
+		}
+		return privacyPolicyFromProto(resp.GetPolicy()), nil
+	}
+	resp, err := c.ManagementService().GetPrivacyPolicy(level.context(ctx), &management.GetPrivacyPolicyRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return privacyPolicyFromProto(resp.GetPolicy()), nil
+}
+
+// SetPrivacyPolicy updates the privacy policy of the level.
+// For an organization without own policy, the override is created.
+func SetPrivacyPolicy(ctx context.Context, c Client, level Level, p *PrivacyPolicy) error {
+	if level.IsInstance() {
+		_, err := c.AdminService().UpdatePrivacyPolicy(ctx, &admin.UpdatePrivacyPolicyRequest{
+			TosLink:        p.TOSLink,
+			PrivacyLink:    p.PrivacyLink,
+			HelpLink:       p.HelpLink,
+			SupportEmail:   p.SupportEmail,
+			DocsLink:       p.DocsLink,
+			CustomLink:     p.CustomLink,
+			CustomLinkText: p.CustomLinkText,
+		})
+		return err
+	}
+	current, err := GetPrivacyPolicy(ctx, c, level)
+	if err != nil {
+		return err
+	}
+	if current.IsDefault {
+		_, err = c.ManagementService().AddCustomPrivacyPolicy(level.context(ctx), &management.AddCustomPrivacyPolicyRequest{
+			TosLink:        p.TOSLink,
+			PrivacyLink:    p.PrivacyLink,
+			HelpLink:       p.HelpLink,
+			SupportEmail:   p.SupportEmail,
+			DocsLink:       p.DocsLink,
+			CustomLink:     p.CustomLink,
+			CustomLinkText: p.CustomLinkText,
+		})
+		return err
+	}
+	_, err = c.ManagementService().UpdateCustomPrivacyPolicy(level.context(ctx), &management.UpdateCustomPrivacyPolicyRequest{
+		TosLink:        p.TOSLink,
+		PrivacyLink:    p.PrivacyLink,
+		HelpLink:       p.HelpLink,
+		SupportEmail:   p.SupportEmail,
+		DocsLink:       p.DocsLink,
+		CustomLink:     p.CustomLink,
+		CustomLinkText: p.CustomLinkText,
+	})
+	return err
+}
+
+// ResetPrivacyPolicy removes the privacy policy of the organization, so the instance default is used again.
+func ResetPrivacyPolicy(ctx context.Context, c Client, orgID string) error {
+	_, err := c.ManagementService().ResetPrivacyPolicyToDefault(Org(orgID).context(ctx), &management.ResetPrivacyPolicyToDefaultRequest{})
+	return err
+}
+
+func privacyPolicyFromProto(p *policy.PrivacyPolicy) *PrivacyPolicy {
+	return &PrivacyPolicy{
+		TOSLink:        p.GetTosLink(),
+		PrivacyLink:    p.GetPrivacyLink(),
+		HelpLink:       p.GetHelpLink(),
+		SupportEmail:   p.GetSupportEmail(),
+		DocsLink:       p.GetDocsLink(),
+		CustomLink:     p.GetCustomLink(),
+		CustomLinkText: p.GetCustomLinkText(),
+		IsDefault:      p.GetIsDefault(),
+	}
+}
@@ -0,0 +1,26 @@
+package policies
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrivacyPolicy_ForLanguage(t *testing.T) {
+	p := &PrivacyPolicy{
+		TOSLink:        "https://example.com/{{.Lang}}/tos",
+		PrivacyLink:    "https://example.com/privacy?lang={{.Lang}}",
+		HelpLink:       "https://example.com/help",
+		SupportEmail:   "support@example.com",
+		CustomLinkText: "{{.Lang}}",
+	}
+	got := p.ForLanguage("de")
+	assert.Equal(t, &PrivacyPolicy{
+		TOSLink:        "https://example.com/de/tos",
+		PrivacyLink:    "https://example.com/privacy?lang=de",
+		HelpLink:       "https://example.com/help",
+		SupportEmail:   "support@example.com",
+		CustomLinkText: "{{.Lang}}",
+	}, got)
+	assert.Equal(t, "https://example.com/{{.Lang}}/tos", p.TOSLink, "original must not be modified")
+}
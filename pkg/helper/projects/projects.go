@@ -0,0 +1,160 @@
+// Package projects provides typed helpers for the management of projects.
+//
+// All functions take an optional orgID. If it's empty, the call is executed in the organization
+// of the authorized user.
+package projects
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	object "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/project"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/org"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/query"
+)
+
+// Client is the part of the [client.Client] used by this package.
+type Client interface {
+	ManagementService() management.ManagementServiceClient
+}
+
+// PrivateLabeling defines which branding (private labeling) is used in the Login UI for the project.
+type PrivateLabeling = project.PrivateLabelingSetting
+
+const (
+	// PrivateLabelingUnspecified uses the branding of the instance or the organization of the login context.
+	PrivateLabelingUnspecified = project.PrivateLabelingSetting_PRIVATE_LABELING_SETTING_UNSPECIFIED
+	// PrivateLabelingEnforceProjectOwner enforces the branding of the organization owning the project.
+	PrivateLabelingEnforceProjectOwner = project.PrivateLabelingSetting_PRIVATE_LABELING_SETTING_ENFORCE_PROJECT_RESOURCE_OWNER_POLICY
+	// PrivateLabelingAllowUserOwner uses the branding of the project owner, but switches to the
+	// one of the user's organization as soon as the user is identified.
+	PrivateLabelingAllowUserOwner = project.PrivateLabelingSetting_PRIVATE_LABELING_SETTING_ALLOW_LOGIN_USER_RESOURCE_OWNER_POLICY
+)
+
+// Settings are the configurable behaviours of a project.
+type Settings struct {
+	// RoleAssertion adds the roles of the user to the tokens and userinfo.
+	RoleAssertion bool
+	// RoleCheck only allows users to authenticate if they're granted at least one role of the project.
+	RoleCheck bool
+	// HasProjectCheck only allows users to authenticate if their organization is the owner
+	// or has a grant of the project.
+	HasProjectCheck bool
+	// PrivateLabeling defines which branding is used in the Login UI.
+	PrivateLabeling PrivateLabeling
+}
+
+// SettingsOf returns the [Settings] of an existing project.
+func SettingsOf(p *project.Project) Settings {
+	return Settings{
+		RoleAssertion:   p.GetProjectRoleAssertion(),
+		RoleCheck:       p.GetProjectRoleCheck(),
+		HasProjectCheck: p.GetHasProjectCheck(),
+		PrivateLabeling: p.GetPrivateLabelingSetting(),
+	}
+}
+
+// Create creates a new project with the provided settings and returns its id.
+func Create(ctx context.Context, c Client, orgID, name string, settings Settings) (string, error) {
+	resp, err := c.ManagementService().AddProject(org.Context(ctx, orgID), &management.AddProjectRequest{
+		Name:                   name,
+		ProjectRoleAssertion:   settings.RoleAssertion,
+		ProjectRoleCheck:       settings.RoleCheck,
+		HasProjectCheck:        settings.HasProjectCheck,
+		PrivateLabelingSetting: settings.PrivateLabeling,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.GetId(), nil
+}
+
+// Update changes the name and settings of the project.
+func Update(ctx context.Context, c Client, orgID, projectID, name string, settings Settings) error {
+	_, err := c.ManagementService().UpdateProject(org.Context(ctx, orgID), &management.UpdateProjectRequest{
+		Id:                     projectID,
+		Name:                   name,
+		ProjectRoleAssertion:   settings.RoleAssertion,
+		ProjectRoleCheck:       settings.RoleCheck,
+		HasProjectCheck:        settings.HasProjectCheck,
+		PrivateLabelingSetting: settings.PrivateLabeling,
+	})
+	return err
+}
+
+// Get returns the project by its id.
+func Get(ctx context.Context, c Client, orgID, projectID string) (*project.Project, error) {
+	resp, err := c.ManagementService().GetProjectByID(org.Context(ctx, orgID), &management.GetProjectByIDRequest{Id: projectID})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetProject(), nil
+}
+
+// Deactivate deactivates the project. Users will not be able to authenticate on its applications.
+func Deactivate(ctx context.Context, c Client, orgID, projectID string) error {
+	_, err := c.ManagementService().DeactivateProject(org.Context(ctx, orgID), &management.DeactivateProjectRequest{Id: projectID})
+	return err
+}
+
+// Reactivate reactivates a previously deactivated project.
+func Reactivate(ctx context.Context, c Client, orgID, projectID string) error {
+	_, err := c.ManagementService().ReactivateProject(org.Context(ctx, orgID), &management.ReactivateProjectRequest{Id: projectID})
+	return err
+}
+
+// Remove removes the project including its applications, roles and grants.
+func Remove(ctx context.Context, c Client, orgID, projectID string) error {
+	_, err := c.ManagementService().RemoveProject(org.Context(ctx, orgID), &management.RemoveProjectRequest{Id: projectID})
+	return err
+}
+
+// Filter restricts the projects returned by [List].
+type Filter func() *project.ProjectQuery
+
+// WithName filters the projects by their exact name.
+func WithName(name string) Filter {
+	return WithNameMethod(name, object.TextQueryMethod_TEXT_QUERY_METHOD_EQUALS)
+}
+
+// WithNameMethod filters the projects by their name with the provided text comparison method.
+func WithNameMethod(name string, method object.TextQueryMethod) Filter {
+	return func() *project.ProjectQuery {
+		return &project.ProjectQuery{Query: &project.ProjectQuery_NameQuery{NameQuery: &project.ProjectNameQuery{Name: name, Method: method}}}
+	}
+}
+
+// WithResourceOwner filters the projects by the organization owning them.
+func WithResourceOwner(orgID string) Filter {
+	return func() *project.ProjectQuery {
+		return &project.ProjectQuery{Query: &project.ProjectQuery_ProjectResourceOwnerQuery{ProjectResourceOwnerQuery: &project.ProjectResourceOwnerQuery{ResourceOwner: orgID}}}
+	}
+}
+
+// List returns all projects of the organization matching all the provided filters.
+func List(ctx context.Context, c Client, orgID string, filters ...Filter) ([]*project.Project, error) {
+	queries := make([]*project.ProjectQuery, len(filters))
+	for i, filter := range filters {
+		queries[i] = filter()
+	}
+	return query.All(org.Context(ctx, orgID), func(ctx context.Context, offset uint64, limit uint32) ([]*project.Project, uint64, error) {
+		resp, err := c.ManagementService().ListProjects(ctx, &management.ListProjectsRequest{
+			Query:   &object.ListQuery{Offset: offset, Limit: limit, Asc: true},
+			Queries: queries,
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		return resp.GetResult(), resp.GetDetails().GetTotalResult(), nil
+	})
+}
+
+// FindByName returns the project with the provided (exact) name or nil if there is none.
+func FindByName(ctx context.Context, c Client, orgID, name string) (*project.Project, error) {
+	result, err := List(ctx, c, orgID, WithName(name))
+	if err != nil || len(result) == 0 {
+		return nil, err
+	}
+	return result[0], nil
+}
@@ -0,0 +1,120 @@
+package projects
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	object "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/project"
+)
+
+func TestCreate(t *testing.T) {
+	tests := []struct {
+		name      string
+		orgID     string
+		settings  Settings
+		wantReq   *management.AddProjectRequest
+		wantOrgID []string
+	}{
+		{
+			name:  "own organization",
+			orgID: "",
+			settings: Settings{
+				RoleAssertion: true,
+			},
+			wantReq: &management.AddProjectRequest{
+				Name:                 "project",
+				ProjectRoleAssertion: true,
+			},
+		},
+		{
+			name:  "other organization",
+			orgID: "orgID",
+			settings: Settings{
+				RoleCheck:       true,
+				HasProjectCheck: true,
+				PrivateLabeling: PrivateLabelingEnforceProjectOwner,
+			},
+			wantReq: &management.AddProjectRequest{
+				Name:                   "project",
+				ProjectRoleCheck:       true,
+				HasProjectCheck:        true,
+				PrivateLabelingSetting: PrivateLabelingEnforceProjectOwner,
+			},
+			wantOrgID: []string{"orgID"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &managementService{}
+			id, err := Create(context.Background(), &testClient{service}, tt.orgID, "project", tt.settings)
+			assert.NoError(t, err)
+			assert.Equal(t, "projectID", id)
+			assert.Equal(t, tt.wantReq, service.addReq)
+			assert.Equal(t, tt.wantOrgID, service.orgID)
+		})
+	}
+}
+
+func TestFindByName(t *testing.T) {
+	tests := []struct {
+		name     string
+		projects []*project.Project
+		want     *project.Project
+	}{
+		{
+			name: "not found",
+		},
+		{
+			name:     "found",
+			projects: []*project.Project{{Id: "projectID", Name: "project"}},
+			want:     &project.Project{Id: "projectID", Name: "project"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &managementService{projects: tt.projects}
+			got, err := FindByName(context.Background(), &testClient{service}, "", "project")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, "project", service.listReq.GetQueries()[0].GetNameQuery().GetName())
+		})
+	}
+}
+
+type testClient struct {
+	management management.ManagementServiceClient
+}
+
+func (c *testClient) ManagementService() management.ManagementServiceClient {
+	return c.management
+}
+
+type managementService struct {
+	management.ManagementServiceClient
+	projects []*project.Project
+	addReq   *management.AddProjectRequest
+	listReq  *management.ListProjectsRequest
+	orgID    []string
+}
+
+func (s *managementService) AddProject(ctx context.Context, req *management.AddProjectRequest, _ ...grpc.CallOption) (*management.AddProjectResponse, error) {
+	s.addReq = req
+	md, _ := metadata.FromOutgoingContext(ctx)
+	s.orgID = md.Get(client.OrgHeader)
+	return &management.AddProjectResponse{Id: "projectID"}, nil
+}
+
+func (s *managementService) ListProjects(_ context.Context, req *management.ListProjectsRequest, _ ...grpc.CallOption) (*management.ListProjectsResponse, error) {
+	s.listReq = req
+	return &management.ListProjectsResponse{
+		Details: &object.ListDetails{TotalResult: uint64(len(s.projects))},
+		Result:  s.projects,
+	}, nil
+}
@@ -0,0 +1,225 @@
+// Package roles provides typed helpers for the management of project roles,
+// including a [Sync] to converge the roles of a project to a desired state defined in code.
+//
+// All functions take an optional orgID. If it's empty, the call is executed in the organization
+// of the authorized user.
+package roles
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	object "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/project"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/org"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/query"
+)
+
+var (
+	ErrDuplicateRole = errors.New("duplicate role key")
+)
+
+// Client is the part of the [client.Client] used by this package.
+type Client interface {
+	ManagementService() management.ManagementServiceClient
+}
+
+// Role is a role of a project. The Key is used in the tokens and checks,
+// the DisplayName and Group are used for presentation in the Console.
+type Role struct {
+	Key         string
+	DisplayName string
+	Group       string
+}
+
+// Add adds a new role to the project.
+func Add(ctx context.Context, c Client, orgID, projectID string, role Role) error {
+	_, err := c.ManagementService().AddProjectRole(org.Context(ctx, orgID), &management.AddProjectRoleRequest{
+		ProjectId:   projectID,
+		RoleKey:     role.Key,
+		DisplayName: role.DisplayName,
+		Group:       role.Group,
+	})
+	return err
+}
+
+// AddBulk adds multiple new roles to the project in a single call.
+func AddBulk(ctx context.Context, c Client, orgID, projectID string, roles ...Role) error {
+	if len(roles) == 0 {
+		return nil
+	}
+	req := &management.BulkAddProjectRolesRequest{
+		ProjectId: projectID,
+		Roles:     make([]*management.BulkAddProjectRolesRequest_Role, len(roles)),
+	}
+	for i, role := range roles {
+		req.Roles[i] = &management.BulkAddProjectRolesRequest_Role{
+			Key:         role.Key,
+			DisplayName: role.DisplayName,
+			Group:       role.Group,
+		}
+	}
+	_, err := c.ManagementService().BulkAddProjectRoles(org.Context(ctx, orgID), req)
+	return err
+}
+
+// Update changes the display name and group of an existing role (identified by its key).
+func Update(ctx context.Context, c Client, orgID, projectID string, role Role) error {
+	_, err := c.ManagementService().UpdateProjectRole(org.Context(ctx, orgID), &management.UpdateProjectRoleRequest{
+		ProjectId:   projectID,
+		RoleKey:     role.Key,
+		DisplayName: role.DisplayName,
+		Group:       role.Group,
+	})
+	return err
+}
+
+// Remove removes the role from the project. Any grant of the role will be removed as well.
+func Remove(ctx context.Context, c Client, orgID, projectID, key string) error {
+	_, err := c.ManagementService().RemoveProjectRole(org.Context(ctx, orgID), &management.RemoveProjectRoleRequest{
+		ProjectId: projectID,
+		RoleKey:   key,
+	})
+	return err
+}
+
+// List returns all roles of the project.
+func List(ctx context.Context, c Client, orgID, projectID string) ([]Role, error) {
+	roles, err := query.All(org.Context(ctx, orgID), func(ctx context.Context, offset uint64, limit uint32) ([]*project.Role, uint64, error) {
+		resp, err := c.ManagementService().ListProjectRoles(ctx, &management.ListProjectRolesRequest{
+			ProjectId: projectID,
+			Query:     &object.ListQuery{Offset: offset, Limit: limit, Asc: true},
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		return resp.GetResult(), resp.GetDetails().GetTotalResult(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Role, len(roles))
+	for i, role := range roles {
+		result[i] = Role{
+			Key:         role.GetKey(),
+			DisplayName: role.GetDisplayName(),
+			Group:       role.GetGroup(),
+		}
+	}
+	return result, nil
+}
+
+// SyncResult contains the keys of the roles changed by [Sync].
+type SyncResult struct {
+	Added   []string
+	Updated []string
+	Removed []string
+}
+
+// SyncOption allows customization of the [Sync].
+type SyncOption func(*syncOptions)
+
+type syncOptions struct {
+	keepUnknown bool
+	dryRun      bool
+}
+
+// WithKeepUnknown will not remove existing roles which are not part of the desired roles.
+func WithKeepUnknown() SyncOption {
+	return func(o *syncOptions) {
+		o.keepUnknown = true
+	}
+}
+
+// WithDryRun will only compute the changes without applying them.
+func WithDryRun() SyncOption {
+	return func(o *syncOptions) {
+		o.dryRun = true
+	}
+}
+
+// Sync converges the roles of the project to the desired roles by adding missing roles,
+// updating changed display names and groups and removing roles not desired (unless [WithKeepUnknown] is used).
+func Sync(ctx context.Context, c Client, orgID, projectID string, desired []Role, options ...SyncOption) (*SyncResult, error) {
+	opts := new(syncOptions)
+	for _, option := range options {
+		option(opts)
+	}
+	existing, err := List(ctx, c, orgID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	add, update, remove, err := diff(existing, desired)
+	if err != nil {
+		return nil, err
+	}
+	if opts.keepUnknown {
+		remove = nil
+	}
+	result := &SyncResult{
+		Added:   keys(add),
+		Updated: keys(update),
+		Removed: remove,
+	}
+	if opts.dryRun {
+		return result, nil
+	}
+	if err = AddBulk(ctx, c, orgID, projectID, add...); err != nil {
+		return nil, fmt.Errorf("unable to add roles: %w", err)
+	}
+	for _, role := range update {
+		if err = Update(ctx, c, orgID, projectID, role); err != nil {
+			return nil, fmt.Errorf("unable to update role `%s`: %w", role.Key, err)
+		}
+	}
+	for _, key := range remove {
+		if err = Remove(ctx, c, orgID, projectID, key); err != nil {
+			return nil, fmt.Errorf("unable to remove role `%s`: %w", key, err)
+		}
+	}
+	return result, nil
+}
+
+// diff computes the roles to be added, updated and removed (keys) to get from the existing to the desired roles.
+func diff(existing, desired []Role) (add, update []Role, remove []string, err error) {
+	current := make(map[string]Role, len(existing))
+	for _, role := range existing {
+		current[role.Key] = role
+	}
+	wanted := make(map[string]struct{}, len(desired))
+	for _, role := range desired {
+		if _, ok := wanted[role.Key]; ok {
+			return nil, nil, nil, fmt.Errorf("%w: `%s`", ErrDuplicateRole, role.Key)
+		}
+		wanted[role.Key] = struct{}{}
+		existingRole, ok := current[role.Key]
+		if !ok {
+			add = append(add, role)
+			continue
+		}
+		if existingRole != role {
+			update = append(update, role)
+		}
+	}
+	for key := range current {
+		if _, ok := wanted[key]; !ok {
+			remove = append(remove, key)
+		}
+	}
+	sort.Strings(remove)
+	return add, update, remove, nil
+}
+
+func keys(roles []Role) []string {
+	if len(roles) == 0 {
+		return nil
+	}
+	k := make([]string, len(roles))
+	for i, role := range roles {
+		k[i] = role.Key
+	}
+	return k
+}
@@ -0,0 +1,65 @@
+package roles
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_diff(t *testing.T) {
+	type args struct {
+		existing []Role
+		desired  []Role
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantAdd    []Role
+		wantUpdate []Role
+		wantRemove []string
+		wantErr    error
+	}{
+		{
+			name: "duplicate, error",
+			args: args{
+				desired: []Role{{Key: "admin"}, {Key: "admin"}},
+			},
+			wantErr: ErrDuplicateRole,
+		},
+		{
+			name: "unchanged",
+			args: args{
+				existing: []Role{{Key: "admin", DisplayName: "Admin"}},
+				desired:  []Role{{Key: "admin", DisplayName: "Admin"}},
+			},
+		},
+		{
+			name: "add, update and remove",
+			args: args{
+				existing: []Role{
+					{Key: "admin", DisplayName: "Admin"},
+					{Key: "viewer", DisplayName: "Viewer"},
+					{Key: "billing", DisplayName: "Billing"},
+					{Key: "auditor", DisplayName: "Auditor"},
+				},
+				desired: []Role{
+					{Key: "admin", DisplayName: "Administrator", Group: "management"},
+					{Key: "viewer", DisplayName: "Viewer"},
+					{Key: "editor", DisplayName: "Editor"},
+				},
+			},
+			wantAdd:    []Role{{Key: "editor", DisplayName: "Editor"}},
+			wantUpdate: []Role{{Key: "admin", DisplayName: "Administrator", Group: "management"}},
+			wantRemove: []string{"auditor", "billing"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			add, update, remove, err := diff(tt.args.existing, tt.args.desired)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantAdd, add)
+			assert.Equal(t, tt.wantUpdate, update)
+			assert.Equal(t, tt.wantRemove, remove)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package texts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/admin"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/text"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/internal/org"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/policies"
+)
+
+// LoginTexts are the custom texts of the login UI in a single language.
+// The keys are in the form of `{screen}.{text}` using the field names of the API,
+// e.g. `select_account_text.title` or `login_text.login_button_text`.
+type LoginTexts map[string]string
+
+// merge returns a copy of the texts with the desired texts applied and whether any text changed.
+func (t LoginTexts) merge(desired LoginTexts) (LoginTexts, bool) {
+	merged := make(LoginTexts, len(t)+len(desired))
+	for key, value := range t {
+		merged[key] = value
+	}
+	changed := false
+	for key, value := range desired {
+		if merged[key] != value {
+			merged[key] = value
+			changed = true
+		}
+	}
+	return merged, changed
+}
+
+// GetLoginTexts returns the custom login texts of the level in the language.
+// Texts which are not customized are not returned.
+func GetLoginTexts(ctx context.Context, c Client, level policies.Level, lang string) (LoginTexts, error) {
+	var custom *text.LoginCustomText
+	if level.IsInstance() {
+		resp, err := c.AdminService().GetCustomLoginTexts(ctx, &admin.GetCustomLoginTextsRequest{Language: lang})
+		if err != nil {
+			return nil, err
+		}
+		custom = resp.GetCustomText()
+	} else {
+		resp, err := c.ManagementService().GetCustomLoginTexts(org.Context(ctx, level.OrgID()), &management.GetCustomLoginTextsRequest{Language: lang})
+		if err != nil {
+			return nil, err
+		}
+		custom = resp.GetCustomText()
+	}
+	return flatten(custom), nil
+}
+
+// SetLoginTexts replaces the custom login texts of the level in the language.
+// Texts not provided will be reset to the default. Use [Apply] to only change specific texts.
+func SetLoginTexts(ctx context.Context, c Client, level policies.Level, lang string, texts LoginTexts) error {
+	if level.IsInstance() {
+		req := &admin.SetCustomLoginTextsRequest{Language: lang}
+		if err := unflatten(texts, req); err != nil {
+			return err
+		}
+		_, err := c.AdminService().SetCustomLoginText(ctx, req)
+		return err
+	}
+	req := &management.SetCustomLoginTextsRequest{Language: lang}
+	if err := unflatten(texts, req); err != nil {
+		return err
+	}
+	_, err := c.ManagementService().SetCustomLoginText(org.Context(ctx, level.OrgID()), req)
+	return err
+}
+
+// ResetLoginTexts removes all custom login texts of the level in the language.
+func ResetLoginTexts(ctx context.Context, c Client, level policies.Level, lang string) error {
+	if level.IsInstance() {
+		_, err := c.AdminService().ResetCustomLoginTextToDefault(ctx, &admin.ResetCustomLoginTextsToDefaultRequest{Language: lang})
+		return err
+	}
+	_, err := c.ManagementService().ResetCustomLoginTextToDefault(org.Context(ctx, level.OrgID()), &management.ResetCustomLoginTextsToDefaultRequest{Language: lang})
+	return err
+}
+
+// flatten converts the (non-empty) texts of all screens into the `{screen}.{text}` form.
+func flatten(custom *text.LoginCustomText) LoginTexts {
+	texts := make(LoginTexts)
+	if custom == nil {
+		return texts
+	}
+	custom.ProtoReflect().Range(func(screenField protoreflect.FieldDescriptor, screen protoreflect.Value) bool {
+		if screenField.Kind() != protoreflect.MessageKind || !strings.HasSuffix(string(screenField.Name()), "_text") {
+			return true
+		}
+		screen.Message().Range(func(textField protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+			if textField.Kind() == protoreflect.StringKind {
+				texts[string(screenField.Name())+"."+string(textField.Name())] = value.String()
+			}
+			return true
+		})
+		return true
+	})
+	return texts
+}
+
+// unflatten sets the texts in the `{screen}.{text}` form on the request.
+func unflatten(texts LoginTexts, req proto.Message) error {
+	msg := req.ProtoReflect()
+	for key, value := range texts {
+		screenName, textName, ok := strings.Cut(key, ".")
+		if !ok {
+			return fmt.Errorf("%w: `%s`", ErrUnknownKey, key)
+		}
+		screenField := msg.Descriptor().Fields().ByName(protoreflect.Name(screenName))
+		if screenField == nil || screenField.Kind() != protoreflect.MessageKind {
+			return fmt.Errorf("%w: `%s`", ErrUnknownKey, key)
+		}
+		screen := msg.Mutable(screenField).Message()
+		textField := screen.Descriptor().Fields().ByName(protoreflect.Name(textName))
+		if textField == nil || textField.Kind() != protoreflect.StringKind {
+			return fmt.Errorf("%w: `%s`", ErrUnknownKey, key)
+		}
+		screen.Set(textField, protoreflect.ValueOfString(value))
+	}
+	return nil
+}
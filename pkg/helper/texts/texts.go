@@ -0,0 +1,109 @@
+// Package texts provides typed helpers for the custom texts of the login UI and the messages (email / SMS)
+// of the instance and organizations per language, including an [Apply] to push a whole translation catalog
+// with only the necessary calls.
+//
+// The level (instance default or organization override) is selected by [policies.Instance] and [policies.Org].
+package texts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/admin"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/policies"
+)
+
+var (
+	ErrUnknownKey = errors.New("unknown text key")
+)
+
+// Client is the part of the [client.Client] used by this package.
+type Client interface {
+	AdminService() admin.AdminServiceClient
+	ManagementService() management.ManagementServiceClient
+}
+
+// Catalog contains the desired custom texts per language (e.g. "de").
+type Catalog struct {
+	Login    map[string]LoginTexts
+	Messages map[string]map[MessageType]MessageText
+}
+
+// ApplyResult contains the texts changed by [Apply] in the form of `login/{language}`, resp. `{messageType}/{language}`.
+type ApplyResult struct {
+	Changed []string
+}
+
+// ApplyOption allows customization of the [Apply].
+type ApplyOption func(*applyOptions)
+
+type applyOptions struct {
+	dryRun bool
+}
+
+// WithDryRun will only compute the changes without applying them.
+func WithDryRun() ApplyOption {
+	return func(o *applyOptions) {
+		o.dryRun = true
+	}
+}
+
+// Apply merges the catalog into the existing custom texts of the level. Only texts (keys, resp. fields)
+// present in the catalog are changed and only languages and messages with changes result in an update.
+func Apply(ctx context.Context, c Client, level policies.Level, catalog *Catalog, options ...ApplyOption) (*ApplyResult, error) {
+	opts := new(applyOptions)
+	for _, option := range options {
+		option(opts)
+	}
+	result := new(ApplyResult)
+	for _, lang := range sortedKeys(catalog.Login) {
+		current, err := GetLoginTexts(ctx, c, level, lang)
+		if err != nil {
+			return nil, err
+		}
+		merged, changed := current.merge(catalog.Login[lang])
+		if !changed {
+			continue
+		}
+		result.Changed = append(result.Changed, "login/"+lang)
+		if opts.dryRun {
+			continue
+		}
+		if err = SetLoginTexts(ctx, c, level, lang, merged); err != nil {
+			return nil, fmt.Errorf("unable to set login texts `%s`: %w", lang, err)
+		}
+	}
+	for _, lang := range sortedKeys(catalog.Messages) {
+		messages := catalog.Messages[lang]
+		for _, messageType := range sortedKeys(messages) {
+			current, err := GetMessageText(ctx, c, level, messageType, lang)
+			if err != nil {
+				return nil, err
+			}
+			merged, changed := current.merge(messages[messageType])
+			if !changed {
+				continue
+			}
+			result.Changed = append(result.Changed, string(messageType)+"/"+lang)
+			if opts.dryRun {
+				continue
+			}
+			if err = SetMessageText(ctx, c, level, messageType, lang, merged); err != nil {
+				return nil, fmt.Errorf("unable to set %s message text `%s`: %w", messageType, lang, err)
+			}
+		}
+	}
+	return result, nil
+}
+
+func sortedKeys[K ~string, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
@@ -0,0 +1,166 @@
+package texts
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/admin"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/text"
+	"github.com/zitadel/zitadel-go/v3/pkg/helper/policies"
+)
+
+type testClient struct {
+	admin      admin.AdminServiceClient
+	management management.ManagementServiceClient
+}
+
+func (c *testClient) AdminService() admin.AdminServiceClient {
+	return c.admin
+}
+
+func (c *testClient) ManagementService() management.ManagementServiceClient {
+	return c.management
+}
+
+type managementService struct {
+	management.ManagementServiceClient
+	loginText   *text.LoginCustomText
+	initMessage *text.MessageCustomText
+	calls       []string
+	setLogin    *management.SetCustomLoginTextsRequest
+	setInit     *management.SetCustomInitMessageTextRequest
+}
+
+func (m *managementService) GetCustomLoginTexts(context.Context, *management.GetCustomLoginTextsRequest, ...grpc.CallOption) (*management.GetCustomLoginTextsResponse, error) {
+	return &management.GetCustomLoginTextsResponse{CustomText: m.loginText}, nil
+}
+
+func (m *managementService) SetCustomLoginText(_ context.Context, req *management.SetCustomLoginTextsRequest, _ ...grpc.CallOption) (*management.SetCustomLoginTextsResponse, error) {
+	m.calls = append(m.calls, "SetCustomLoginText")
+	m.setLogin = req
+	return &management.SetCustomLoginTextsResponse{}, nil
+}
+
+func (m *managementService) GetCustomInitMessageText(context.Context, *management.GetCustomInitMessageTextRequest, ...grpc.CallOption) (*management.GetCustomInitMessageTextResponse, error) {
+	return &management.GetCustomInitMessageTextResponse{CustomText: m.initMessage}, nil
+}
+
+func (m *managementService) SetCustomInitMessageText(_ context.Context, req *management.SetCustomInitMessageTextRequest, _ ...grpc.CallOption) (*management.SetCustomInitMessageTextResponse, error) {
+	m.calls = append(m.calls, "SetCustomInitMessageText")
+	m.setInit = req
+	return &management.SetCustomInitMessageTextResponse{}, nil
+}
+
+func Test_flatten_unflatten(t *testing.T) {
+	texts := LoginTexts{
+		"select_account_text.title": "Konto wählen",
+		"login_text.title":          "Anmelden",
+	}
+	req := new(management.SetCustomLoginTextsRequest)
+	require.NoError(t, unflatten(texts, req))
+	assert.Equal(t, "Konto wählen", req.GetSelectAccountText().GetTitle())
+	assert.Equal(t, "Anmelden", req.GetLoginText().GetTitle())
+
+	got := flatten(&text.LoginCustomText{
+		SelectAccountText: req.GetSelectAccountText(),
+		LoginText:         req.GetLoginText(),
+	})
+	assert.Equal(t, texts, got)
+}
+
+func Test_unflatten_unknownKey(t *testing.T) {
+	for _, key := range []string{"title", "unknown_text.title", "login_text.unknown", "details.sequence"} {
+		t.Run(key, func(t *testing.T) {
+			err := unflatten(LoginTexts{key: "value"}, new(admin.SetCustomLoginTextsRequest))
+			assert.ErrorIs(t, err, ErrUnknownKey)
+		})
+	}
+}
+
+func TestMessageType_methods(t *testing.T) {
+	adminType := reflect.TypeOf((*admin.AdminServiceClient)(nil)).Elem()
+	managementType := reflect.TypeOf((*management.ManagementServiceClient)(nil)).Elem()
+	for messageType, orgSet := range orgSetMethods {
+		t.Run(string(messageType), func(t *testing.T) {
+			for _, method := range []string{
+				"GetCustom" + string(messageType) + "MessageText",
+				"SetDefault" + string(messageType) + "MessageText",
+				"ResetCustom" + string(messageType) + "MessageTextToDefault",
+			} {
+				_, ok := adminType.MethodByName(method)
+				assert.True(t, ok, "admin: %s", method)
+			}
+			for _, method := range []string{
+				"GetCustom" + string(messageType) + "MessageText",
+				orgSet,
+				"ResetCustom" + string(messageType) + "MessageTextToDefault",
+			} {
+				_, ok := managementType.MethodByName(method)
+				assert.True(t, ok, "management: %s", method)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	catalog := &Catalog{
+		Login: map[string]LoginTexts{
+			"de": {"login_text.title": "Anmelden"},
+		},
+		Messages: map[string]map[MessageType]MessageText{
+			"de": {MessageInit: {Subject: "Willkommen"}},
+		},
+	}
+	tests := []struct {
+		name        string
+		loginText   *text.LoginCustomText
+		initMessage *text.MessageCustomText
+		options     []ApplyOption
+		wantChanged []string
+		wantCalls   []string
+	}{
+		{
+			name:        "unchanged",
+			loginText:   &text.LoginCustomText{LoginText: &text.LoginScreenText{Title: "Anmelden"}},
+			initMessage: &text.MessageCustomText{Subject: "Willkommen"},
+		},
+		{
+			name:        "changed, dry run",
+			initMessage: &text.MessageCustomText{Subject: "Hallo"},
+			options:     []ApplyOption{WithDryRun()},
+			wantChanged: []string{"login/de", "Init/de"},
+		},
+		{
+			name:        "changed, existing texts kept",
+			loginText:   &text.LoginCustomText{SelectAccountText: &text.SelectAccountScreenText{Title: "Konto wählen"}},
+			initMessage: &text.MessageCustomText{Subject: "Hallo", Greeting: "Hallo {{.DisplayName}}"},
+			wantChanged: []string{"login/de", "Init/de"},
+			wantCalls:   []string{"SetCustomLoginText", "SetCustomInitMessageText"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgmt := &managementService{loginText: tt.loginText, initMessage: tt.initMessage}
+			result, err := Apply(context.Background(), &testClient{management: mgmt}, policies.Org("org"), catalog, tt.options...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantChanged, result.Changed)
+			assert.Equal(t, tt.wantCalls, mgmt.calls)
+			if mgmt.setLogin != nil {
+				assert.Equal(t, "de", mgmt.setLogin.GetLanguage())
+				assert.Equal(t, "Anmelden", mgmt.setLogin.GetLoginText().GetTitle())
+				assert.Equal(t, "Konto wählen", mgmt.setLogin.GetSelectAccountText().GetTitle())
+			}
+			if mgmt.setInit != nil {
+				assert.Equal(t, "de", mgmt.setInit.GetLanguage())
+				assert.Equal(t, "Willkommen", mgmt.setInit.GetSubject())
+				assert.Equal(t, "Hallo {{.DisplayName}}", mgmt.setInit.GetGreeting())
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	user "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user/v2"
+)
+
+var (
+	ErrNoOTPMethod = errors.New("authentication method is not an OTP method")
+)
+
+// AuthMethod is a type of authentication method a user has set up.
+type AuthMethod = user.AuthenticationMethodType
+
+const (
+	AuthMethodPassword = user.AuthenticationMethodType_AUTHENTICATION_METHOD_TYPE_PASSWORD
+	AuthMethodPasskey  = user.AuthenticationMethodType_AUTHENTICATION_METHOD_TYPE_PASSKEY
+	AuthMethodIDP      = user.AuthenticationMethodType_AUTHENTICATION_METHOD_TYPE_IDP
+	AuthMethodTOTP     = user.AuthenticationMethodType_AUTHENTICATION_METHOD_TYPE_TOTP
+	AuthMethodU2F      = user.AuthenticationMethodType_AUTHENTICATION_METHOD_TYPE_U2F
+	AuthMethodOTPSMS   = user.AuthenticationMethodType_AUTHENTICATION_METHOD_TYPE_OTP_SMS
+	AuthMethodOTPEmail = user.AuthenticationMethodType_AUTHENTICATION_METHOD_TYPE_OTP_EMAIL
+)
+
+// ListAuthMethods returns the types of all authentication methods the user has set up.
+func ListAuthMethods(ctx context.Context, c Client, userID string) ([]AuthMethod, error) {
+	resp, err := c.UserServiceV2().ListAuthenticationMethodTypes(ctx, &user.ListAuthenticationMethodTypesRequest{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetAuthMethodTypes(), nil
+}
+
+// ListOTP returns the OTP methods (TOTP, OTP SMS and OTP Email) the user has set up.
+func ListOTP(ctx context.Context, c Client, userID string) ([]AuthMethod, error) {
+	methods, err := ListAuthMethods(ctx, c, userID)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(methods, func(method AuthMethod) bool { return !isOTP(method) }), nil
+}
+
+// RemoveOTP removes the OTP method from the user, e.g. after the loss of the device.
+func RemoveOTP(ctx context.Context, c Client, userID string, method AuthMethod) error {
+	var err error
+	switch method {
+	case AuthMethodTOTP:
+		_, err = c.UserServiceV2().RemoveTOTP(ctx, &user.RemoveTOTPRequest{UserId: userID})
+	case AuthMethodOTPSMS:
+		_, err = c.UserServiceV2().RemoveOTPSMS(ctx, &user.RemoveOTPSMSRequest{UserId: userID})
+	case AuthMethodOTPEmail:
+		_, err = c.UserServiceV2().RemoveOTPEmail(ctx, &user.RemoveOTPEmailRequest{UserId: userID})
+	default:
+		return fmt.Errorf("%w: `%s`", ErrNoOTPMethod, method)
+	}
+	return err
+}
+
+// TOTPRegistration is the pending registration of a new TOTP authenticator app.
+// The URI (e.g. as QR code) or the Secret needs to be added to the app and the generated code
+// verified with [VerifyTOTP] to finish the registration.
+type TOTPRegistration struct {
+	URI    string
+	Secret string
+}
+
+// ReenrollOTP removes the OTP method from the user (if set up) and enrolls it again:
+//   - for TOTP, a new registration is started and returned, which needs to be finished with [VerifyTOTP]
+//   - for OTP SMS and OTP Email, the method is directly added for the verified phone, resp. email, and nil is returned
+func ReenrollOTP(ctx context.Context, c Client, userID string, method AuthMethod) (*TOTPRegistration, error) {
+	if !isOTP(method) {
+		return nil, fmt.Errorf("%w: `%s`", ErrNoOTPMethod, method)
+	}
+	existing, err := ListOTP(ctx, c, userID)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(existing, method) {
+		if err = RemoveOTP(ctx, c, userID, method); err != nil {
+			return nil, err
+		}
+	}
+	switch method {
+	case AuthMethodTOTP:
+		resp, err := c.UserServiceV2().RegisterTOTP(ctx, &user.RegisterTOTPRequest{UserId: userID})
+		if err != nil {
+			return nil, err
+		}
+		return &TOTPRegistration{URI: resp.GetUri(), Secret: resp.GetSecret()}, nil
+	case AuthMethodOTPSMS:
+		_, err = c.UserServiceV2().AddOTPSMS(ctx, &user.AddOTPSMSRequest{UserId: userID})
+	default:
+		_, err = c.UserServiceV2().AddOTPEmail(ctx, &user.AddOTPEmailRequest{UserId: userID})
+	}
+	return nil, err
+}
+
+// VerifyTOTP finishes the registration started by [ReenrollOTP] with a code generated by the authenticator app.
+func VerifyTOTP(ctx context.Context, c Client, userID, code string) error {
+	_, err := c.UserServiceV2().VerifyTOTPRegistration(ctx, &user.VerifyTOTPRegistrationRequest{
+		UserId: userID,
+		Code:   code,
+	})
+	return err
+}
+
+func isOTP(method AuthMethod) bool {
+	return method == AuthMethodTOTP || method == AuthMethodOTPSMS || method == AuthMethodOTPEmail
+}
@@ -0,0 +1,61 @@
+package users
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListOTP(t *testing.T) {
+	users := &userService{methods: []AuthMethod{AuthMethodPassword, AuthMethodTOTP, AuthMethodPasskey, AuthMethodOTPEmail}}
+	methods, err := ListOTP(context.Background(), &testClient{user: users}, "user")
+	require.NoError(t, err)
+	assert.Equal(t, []AuthMethod{AuthMethodTOTP, AuthMethodOTPEmail}, methods)
+}
+
+func TestReenrollOTP(t *testing.T) {
+	tests := []struct {
+		name      string
+		methods   []AuthMethod
+		method    AuthMethod
+		want      *TOTPRegistration
+		wantErr   error
+		wantCalls []string
+	}{
+		{
+			name:    "no otp method",
+			method:  AuthMethodPasskey,
+			wantErr: ErrNoOTPMethod,
+		},
+		{
+			name:      "totp, existing removed",
+			methods:   []AuthMethod{AuthMethodPassword, AuthMethodTOTP},
+			method:    AuthMethodTOTP,
+			want:      &TOTPRegistration{URI: "otpauth://totp/ZITADEL:user?secret=SECRET", Secret: "SECRET"},
+			wantCalls: []string{"RemoveTOTP", "RegisterTOTP"},
+		},
+		{
+			name:      "sms, existing removed",
+			methods:   []AuthMethod{AuthMethodOTPSMS},
+			method:    AuthMethodOTPSMS,
+			wantCalls: []string{"RemoveOTPSMS", "AddOTPSMS"},
+		},
+		{
+			name:      "email, not yet set up",
+			methods:   []AuthMethod{AuthMethodPassword},
+			method:    AuthMethodOTPEmail,
+			wantCalls: []string{"AddOTPEmail"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := &userService{methods: tt.methods}
+			got, err := ReenrollOTP(context.Background(), &testClient{user: users}, "user", tt.method)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantCalls, users.calls)
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package users
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	userV1 "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user"
+	user "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user/v2"
+)
+
+// AuthenticatorType is the type of a WebAuthn authenticator of a user.
+type AuthenticatorType string
+
+const (
+	// AuthenticatorPasskey is used for passwordless authentication.
+	AuthenticatorPasskey AuthenticatorType = "passkey"
+	// AuthenticatorU2F is used as second factor (security key).
+	AuthenticatorU2F AuthenticatorType = "u2f"
+)
+
+// Authenticator is a WebAuthn authenticator (passkey or U2F security key) of a user.
+type Authenticator struct {
+	Type AuthenticatorType
+	ID   string
+	Name string
+	// Ready is false for authenticators with an unfinished registration.
+	Ready bool
+}
+
+// ListPasskeys returns the passkeys of the user.
+func ListPasskeys(ctx context.Context, c Client, userID string) ([]*Authenticator, error) {
+	resp, err := c.UserServiceV2().ListPasskeys(ctx, &user.ListPasskeysRequest{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*Authenticator, len(resp.GetResult()))
+	for i, passkey := range resp.GetResult() {
+		result[i] = &Authenticator{
+			Type:  AuthenticatorPasskey,
+			ID:    passkey.GetId(),
+			Name:  passkey.GetName(),
+			Ready: passkey.GetState() == user.AuthFactorState_AUTH_FACTOR_STATE_READY,
+		}
+	}
+	return result, nil
+}
+
+// ListU2F returns the U2F security keys of the user.
+func ListU2F(ctx context.Context, c Client, userID string) ([]*Authenticator, error) {
+	resp, err := c.ManagementService().ListHumanAuthFactors(ctx, &management.ListHumanAuthFactorsRequest{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	var result []*Authenticator
+	for _, factor := range resp.GetResult() {
+		u2f := factor.GetU2F()
+		if u2f == nil {
+			continue
+		}
+		result = append(result, &Authenticator{
+			Type:  AuthenticatorU2F,
+			ID:    u2f.GetId(),
+			Name:  u2f.GetName(),
+			Ready: factor.GetState() == userV1.AuthFactorState_AUTH_FACTOR_STATE_READY,
+		})
+	}
+	return result, nil
+}
+
+// ListAuthenticators returns the passkeys and U2F security keys of the user.
+func ListAuthenticators(ctx context.Context, c Client, userID string) ([]*Authenticator, error) {
+	passkeys, err := ListPasskeys(ctx, c, userID)
+	if err != nil {
+		return nil, err
+	}
+	u2f, err := ListU2F(ctx, c, userID)
+	if err != nil {
+		return nil, err
+	}
+	return append(passkeys, u2f...), nil
+}
+
+// RemoveAuthenticator removes the passkey or U2F security key from the user.
+func RemoveAuthenticator(ctx context.Context, c Client, userID string, authenticator *Authenticator) error {
+	switch authenticator.Type {
+	case AuthenticatorPasskey:
+		_, err := c.UserServiceV2().RemovePasskey(ctx, &user.RemovePasskeyRequest{
+			UserId:    userID,
+			PasskeyId: authenticator.ID,
+		})
+		return err
+	case AuthenticatorU2F:
+		_, err := c.UserServiceV2().RemoveU2F(ctx, &user.RemoveU2FRequest{
+			UserId: userID,
+			U2FId:  authenticator.ID,
+		})
+		return err
+	default:
+		return fmt.Errorf("unknown authenticator type `%s`", authenticator.Type)
+	}
+}
+
+// PasskeyRegistrationCode allows a user to register a passkey without being authenticated,
+// e.g. in a custom UI by passing it to the RegisterPasskey call of the user service.
+type PasskeyRegistrationCode struct {
+	ID   string
+	Code string
+}
+
+// CreatePasskeyRegistrationCode creates a code to register a passkey for the user and returns it
+// instead of sending it, e.g. to show it in a helpdesk UI.
+func CreatePasskeyRegistrationCode(ctx context.Context, c Client, userID string) (*PasskeyRegistrationCode, error) {
+	resp, err := c.UserServiceV2().CreatePasskeyRegistrationLink(ctx, &user.CreatePasskeyRegistrationLinkRequest{
+		UserId: userID,
+		Medium: &user.CreatePasskeyRegistrationLinkRequest_ReturnCode{
+			ReturnCode: &user.ReturnPasskeyRegistrationCode{},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &PasskeyRegistrationCode{
+		ID:   resp.GetCode().GetId(),
+		Code: resp.GetCode().GetCode(),
+	}, nil
+}
+
+// SendPasskeyRegistrationLink lets ZITADEL send an email to the user with a link to register a passkey.
+// The urlTemplate (placeholders: UserID, OrgID, CodeID and Code) points to a custom registration page.
+// If it's empty, the registration page of the ZITADEL login is used.
+func SendPasskeyRegistrationLink(ctx context.Context, c Client, userID, urlTemplate string) error {
+	link := new(user.SendPasskeyRegistrationLink)
+	if urlTemplate != "" {
+		link.UrlTemplate = &urlTemplate
+	}
+	_, err := c.UserServiceV2().CreatePasskeyRegistrationLink(ctx, &user.CreatePasskeyRegistrationLinkRequest{
+		UserId: userID,
+		Medium: &user.CreatePasskeyRegistrationLinkRequest_SendLink{
+			SendLink: link,
+		},
+	})
+	return err
+}
+
+// ResetPasskeys removes all passkeys of the user (including unfinished registrations)
+// and sends a link to register a new one (see [SendPasskeyRegistrationLink]).
+// It returns the removed passkeys.
+func ResetPasskeys(ctx context.Context, c Client, userID, urlTemplate string) ([]*Authenticator, error) {
+	passkeys, err := ListPasskeys(ctx, c, userID)
+	if err != nil {
+		return nil, err
+	}
+	for i, passkey := range passkeys {
+		if err = RemoveAuthenticator(ctx, c, userID, passkey); err != nil {
+			return passkeys[:i], fmt.Errorf("unable to remove passkey `%s`: %w", passkey.ID, err)
+		}
+	}
+	if err = SendPasskeyRegistrationLink(ctx, c, userID, urlTemplate); err != nil {
+		return passkeys, fmt.Errorf("unable to send passkey registration link: %w", err)
+	}
+	return passkeys, nil
+}
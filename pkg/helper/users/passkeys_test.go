@@ -0,0 +1,64 @@
+package users
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	userV1 "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user"
+	user "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user/v2"
+)
+
+func TestListAuthenticators(t *testing.T) {
+	c := &testClient{
+		user: &userService{
+			passkeys: []*user.Passkey{
+				{Id: "p1", Name: "MacBook", State: user.AuthFactorState_AUTH_FACTOR_STATE_READY},
+				{Id: "p2", State: user.AuthFactorState_AUTH_FACTOR_STATE_NOT_READY},
+			},
+		},
+		management: &managementService{
+			factors: []*userV1.AuthFactor{
+				{State: userV1.AuthFactorState_AUTH_FACTOR_STATE_READY, Type: &userV1.AuthFactor_Otp{Otp: &userV1.AuthFactorOTP{}}},
+				{State: userV1.AuthFactorState_AUTH_FACTOR_STATE_READY, Type: &userV1.AuthFactor_U2F{U2F: &userV1.AuthFactorU2F{Id: "u1", Name: "YubiKey"}}},
+			},
+		},
+	}
+	authenticators, err := ListAuthenticators(context.Background(), c, "user")
+	require.NoError(t, err)
+	assert.Equal(t, []*Authenticator{
+		{Type: AuthenticatorPasskey, ID: "p1", Name: "MacBook", Ready: true},
+		{Type: AuthenticatorPasskey, ID: "p2"},
+		{Type: AuthenticatorU2F, ID: "u1", Name: "YubiKey", Ready: true},
+	}, authenticators)
+
+	for _, authenticator := range authenticators {
+		require.NoError(t, RemoveAuthenticator(context.Background(), c, "user", authenticator))
+	}
+	assert.Equal(t, []string{"RemovePasskey p1", "RemovePasskey p2", "RemoveU2F u1"}, c.user.(*userService).calls)
+	assert.Error(t, RemoveAuthenticator(context.Background(), c, "user", &Authenticator{Type: "unknown"}))
+}
+
+func TestResetPasskeys(t *testing.T) {
+	users := &userService{
+		passkeys: []*user.Passkey{
+			{Id: "p1", State: user.AuthFactorState_AUTH_FACTOR_STATE_READY},
+			{Id: "p2", State: user.AuthFactorState_AUTH_FACTOR_STATE_NOT_READY},
+		},
+	}
+	removed, err := ResetPasskeys(context.Background(), &testClient{user: users}, "user", "https://example.com/passkey?code={{.Code}}")
+	require.NoError(t, err)
+	assert.Len(t, removed, 2)
+	assert.Equal(t, []string{"RemovePasskey p1", "RemovePasskey p2", "CreatePasskeyRegistrationLink"}, users.calls)
+	assert.Equal(t, "https://example.com/passkey?code={{.Code}}", users.link.GetSendLink().GetUrlTemplate())
+}
+
+func TestCreatePasskeyRegistrationCode(t *testing.T) {
+	users := new(userService)
+	code, err := CreatePasskeyRegistrationCode(context.Background(), &testClient{user: users}, "user")
+	require.NoError(t, err)
+	assert.Equal(t, &PasskeyRegistrationCode{ID: "codeID", Code: "code"}, code)
+	assert.NotNil(t, users.link.GetReturnCode())
+}
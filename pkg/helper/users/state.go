@@ -0,0 +1,108 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	user "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user/v2"
+)
+
+var (
+	ErrInvalidStateTransition = errors.New("invalid user state transition")
+)
+
+// State is the lifecycle state of a user.
+type State = user.UserState
+
+const (
+	StateActive   = user.UserState_USER_STATE_ACTIVE
+	StateInactive = user.UserState_USER_STATE_INACTIVE
+	StateDeleted  = user.UserState_USER_STATE_DELETED
+	StateLocked   = user.UserState_USER_STATE_LOCKED
+	// StateInitial is the state of a user, who has not yet set up any authentication method.
+	StateInitial = user.UserState_USER_STATE_INITIAL
+)
+
+// StateTransitionError is returned if the action is not allowed in the current state of the user.
+// It matches [ErrInvalidStateTransition] with [errors.Is].
+type StateTransitionError struct {
+	UserID string
+	Action string
+	State  State
+}
+
+func (e *StateTransitionError) Error() string {
+	return fmt.Sprintf("%s: cannot %s user `%s` in state %s", ErrInvalidStateTransition, e.Action, e.UserID, e.State)
+}
+
+func (e *StateTransitionError) Is(target error) bool {
+	return target == ErrInvalidStateTransition
+}
+
+// GetState returns the current state of the user.
+func GetState(ctx context.Context, c Client, userID string) (State, error) {
+	resp, err := c.UserServiceV2().GetUserByID(ctx, &user.GetUserByIDRequest{UserId: userID})
+	if err != nil {
+		return user.UserState_USER_STATE_UNSPECIFIED, err
+	}
+	return resp.GetUser().GetState(), nil
+}
+
+// Deactivate deactivates an active or locked user. Deactivated users cannot log in, but are kept with all their data.
+func Deactivate(ctx context.Context, c Client, userID string) error {
+	if err := checkTransition(ctx, c, userID, "deactivate", StateActive, StateLocked); err != nil {
+		return err
+	}
+	_, err := c.UserServiceV2().DeactivateUser(ctx, &user.DeactivateUserRequest{UserId: userID})
+	return err
+}
+
+// Reactivate reactivates a previously deactivated user.
+func Reactivate(ctx context.Context, c Client, userID string) error {
+	if err := checkTransition(ctx, c, userID, "reactivate", StateInactive); err != nil {
+		return err
+	}
+	_, err := c.UserServiceV2().ReactivateUser(ctx, &user.ReactivateUserRequest{UserId: userID})
+	return err
+}
+
+// Lock locks an active or initial user. Locked users cannot log in, e.g. after too many failed attempts.
+func Lock(ctx context.Context, c Client, userID string) error {
+	if err := checkTransition(ctx, c, userID, "lock", StateActive, StateInitial); err != nil {
+		return err
+	}
+	_, err := c.UserServiceV2().LockUser(ctx, &user.LockUserRequest{UserId: userID})
+	return err
+}
+
+// Unlock unlocks a locked user.
+func Unlock(ctx context.Context, c Client, userID string) error {
+	if err := checkTransition(ctx, c, userID, "unlock", StateLocked); err != nil {
+		return err
+	}
+	_, err := c.UserServiceV2().UnlockUser(ctx, &user.UnlockUserRequest{UserId: userID})
+	return err
+}
+
+// Delete deletes the user in any state. This cannot be undone.
+func Delete(ctx context.Context, c Client, userID string) error {
+	if err := checkTransition(ctx, c, userID, "delete", StateActive, StateInactive, StateLocked, StateInitial); err != nil {
+		return err
+	}
+	_, err := c.UserServiceV2().DeleteUser(ctx, &user.DeleteUserRequest{UserId: userID})
+	return err
+}
+
+// checkTransition returns a [StateTransitionError] if the current state of the user is none of the allowed states.
+func checkTransition(ctx context.Context, c Client, userID, action string, allowed ...State) error {
+	state, err := GetState(ctx, c, userID)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(allowed, state) {
+		return &StateTransitionError{UserID: userID, Action: action, State: state}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package users
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateTransitions(t *testing.T) {
+	type transition func(ctx context.Context, c Client, userID string) error
+	tests := []struct {
+		name       string
+		state      State
+		transition transition
+		wantErr    string
+		wantCalls  []string
+	}{
+		{
+			name:       "deactivate active",
+			state:      StateActive,
+			transition: Deactivate,
+			wantCalls:  []string{"DeactivateUser"},
+		},
+		{
+			name:       "deactivate initial",
+			state:      StateInitial,
+			transition: Deactivate,
+			wantErr:    "invalid user state transition: cannot deactivate user `user` in state USER_STATE_INITIAL",
+		},
+		{
+			name:       "reactivate inactive",
+			state:      StateInactive,
+			transition: Reactivate,
+			wantCalls:  []string{"ReactivateUser"},
+		},
+		{
+			name:       "reactivate active",
+			state:      StateActive,
+			transition: Reactivate,
+			wantErr:    "invalid user state transition: cannot reactivate user `user` in state USER_STATE_ACTIVE",
+		},
+		{
+			name:       "lock initial",
+			state:      StateInitial,
+			transition: Lock,
+			wantCalls:  []string{"LockUser"},
+		},
+		{
+			name:       "lock locked",
+			state:      StateLocked,
+			transition: Lock,
+			wantErr:    "invalid user state transition: cannot lock user `user` in state USER_STATE_LOCKED",
+		},
+		{
+			name:       "unlock locked",
+			state:      StateLocked,
+			transition: Unlock,
+			wantCalls:  []string{"UnlockUser"},
+		},
+		{
+			name:       "delete inactive",
+			state:      StateInactive,
+			transition: Delete,
+			wantCalls:  []string{"DeleteUser"},
+		},
+		{
+			name:       "delete deleted",
+			state:      StateDeleted,
+			transition: Delete,
+			wantErr:    "invalid user state transition: cannot delete user `user` in state USER_STATE_DELETED",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := &userService{state: tt.state}
+			err := tt.transition(context.Background(), &testClient{user: users}, "user")
+			if tt.wantErr != "" {
+				assert.ErrorIs(t, err, ErrInvalidStateTransition)
+				assert.EqualError(t, err, tt.wantErr)
+				var transitionErr *StateTransitionError
+				if assert.ErrorAs(t, err, &transitionErr) {
+					assert.Equal(t, tt.state, transitionErr.State)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCalls, users.calls)
+		})
+	}
+}
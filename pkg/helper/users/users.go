@@ -0,0 +1,21 @@
+// Package users provides typed helpers for the administration of (human) users,
+// such as their lifecycle state, the verification of their email address and phone number and managing their authenticators,
+// e.g. for a helpdesk resetting the passkeys of a user.
+//
+// The functions use the user service (v2) where possible. The few calls of the Management API
+// are executed in the organization of the authorized user. For users of another organization,
+// set the organization of the call using [middleware.SetOrgID].
+//
+// [middleware.SetOrgID]: https://pkg.go.dev/github.com/zitadel/zitadel-go/v3/pkg/client/middleware#SetOrgID
+package users
+
+import (
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	user "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user/v2"
+)
+
+// Client is the part of the [client.Client] used by this package.
+type Client interface {
+	UserServiceV2() user.UserServiceClient
+	ManagementService() management.ManagementServiceClient
+}
@@ -0,0 +1,145 @@
+package users
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	userV1 "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user"
+	user "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user/v2"
+)
+
+type testClient struct {
+	user       user.UserServiceClient
+	management management.ManagementServiceClient
+}
+
+func (c *testClient) UserServiceV2() user.UserServiceClient {
+	return c.user
+}
+
+func (c *testClient) ManagementService() management.ManagementServiceClient {
+	return c.management
+}
+
+type userService struct {
+	user.UserServiceClient
+	passkeys []*user.Passkey
+	methods  []user.AuthenticationMethodType
+	calls    []string
+	link     *user.CreatePasskeyRegistrationLinkRequest
+	setEmail *user.SetEmailRequest
+	setPhone *user.SetPhoneRequest
+	state    user.UserState
+}
+
+func (s *userService) GetUserByID(context.Context, *user.GetUserByIDRequest, ...grpc.CallOption) (*user.GetUserByIDResponse, error) {
+	return &user.GetUserByIDResponse{User: &user.User{State: s.state}}, nil
+}
+
+func (s *userService) DeactivateUser(context.Context, *user.DeactivateUserRequest, ...grpc.CallOption) (*user.DeactivateUserResponse, error) {
+	s.calls = append(s.calls, "DeactivateUser")
+	return &user.DeactivateUserResponse{}, nil
+}
+
+func (s *userService) ReactivateUser(context.Context, *user.ReactivateUserRequest, ...grpc.CallOption) (*user.ReactivateUserResponse, error) {
+	s.calls = append(s.calls, "ReactivateUser")
+	return &user.ReactivateUserResponse{}, nil
+}
+
+func (s *userService) LockUser(context.Context, *user.LockUserRequest, ...grpc.CallOption) (*user.LockUserResponse, error) {
+	s.calls = append(s.calls, "LockUser")
+	return &user.LockUserResponse{}, nil
+}
+
+func (s *userService) UnlockUser(context.Context, *user.UnlockUserRequest, ...grpc.CallOption) (*user.UnlockUserResponse, error) {
+	s.calls = append(s.calls, "UnlockUser")
+	return &user.UnlockUserResponse{}, nil
+}
+
+func (s *userService) DeleteUser(context.Context, *user.DeleteUserRequest, ...grpc.CallOption) (*user.DeleteUserResponse, error) {
+	s.calls = append(s.calls, "DeleteUser")
+	return &user.DeleteUserResponse{}, nil
+}
+
+func (s *userService) SetEmail(_ context.Context, req *user.SetEmailRequest, _ ...grpc.CallOption) (*user.SetEmailResponse, error) {
+	s.setEmail = req
+	resp := new(user.SetEmailResponse)
+	if req.GetReturnCode() != nil {
+		code := "emailCode"
+		resp.VerificationCode = &code
+	}
+	return resp, nil
+}
+
+func (s *userService) SetPhone(_ context.Context, req *user.SetPhoneRequest, _ ...grpc.CallOption) (*user.SetPhoneResponse, error) {
+	s.setPhone = req
+	resp := new(user.SetPhoneResponse)
+	if req.GetReturnCode() != nil {
+		code := "phoneCode"
+		resp.VerificationCode = &code
+	}
+	return resp, nil
+}
+
+func (s *userService) ListAuthenticationMethodTypes(context.Context, *user.ListAuthenticationMethodTypesRequest, ...grpc.CallOption) (*user.ListAuthenticationMethodTypesResponse, error) {
+	return &user.ListAuthenticationMethodTypesResponse{AuthMethodTypes: s.methods}, nil
+}
+
+func (s *userService) RemoveTOTP(context.Context, *user.RemoveTOTPRequest, ...grpc.CallOption) (*user.RemoveTOTPResponse, error) {
+	s.calls = append(s.calls, "RemoveTOTP")
+	return &user.RemoveTOTPResponse{}, nil
+}
+
+func (s *userService) RegisterTOTP(context.Context, *user.RegisterTOTPRequest, ...grpc.CallOption) (*user.RegisterTOTPResponse, error) {
+	s.calls = append(s.calls, "RegisterTOTP")
+	return &user.RegisterTOTPResponse{Uri: "otpauth://totp/ZITADEL:user?secret=SECRET", Secret: "SECRET"}, nil
+}
+
+func (s *userService) RemoveOTPSMS(context.Context, *user.RemoveOTPSMSRequest, ...grpc.CallOption) (*user.RemoveOTPSMSResponse, error) {
+	s.calls = append(s.calls, "RemoveOTPSMS")
+	return &user.RemoveOTPSMSResponse{}, nil
+}
+
+func (s *userService) AddOTPSMS(context.Context, *user.AddOTPSMSRequest, ...grpc.CallOption) (*user.AddOTPSMSResponse, error) {
+	s.calls = append(s.calls, "AddOTPSMS")
+	return &user.AddOTPSMSResponse{}, nil
+}
+
+func (s *userService) AddOTPEmail(context.Context, *user.AddOTPEmailRequest, ...grpc.CallOption) (*user.AddOTPEmailResponse, error) {
+	s.calls = append(s.calls, "AddOTPEmail")
+	return &user.AddOTPEmailResponse{}, nil
+}
+
+func (s *userService) ListPasskeys(context.Context, *user.ListPasskeysRequest, ...grpc.CallOption) (*user.ListPasskeysResponse, error) {
+	return &user.ListPasskeysResponse{Result: s.passkeys}, nil
+}
+
+func (s *userService) RemovePasskey(_ context.Context, req *user.RemovePasskeyRequest, _ ...grpc.CallOption) (*user.RemovePasskeyResponse, error) {
+	s.calls = append(s.calls, "RemovePasskey "+req.GetPasskeyId())
+	return &user.RemovePasskeyResponse{}, nil
+}
+
+func (s *userService) RemoveU2F(_ context.Context, req *user.RemoveU2FRequest, _ ...grpc.CallOption) (*user.RemoveU2FResponse, error) {
+	s.calls = append(s.calls, "RemoveU2F "+req.GetU2FId())
+	return &user.RemoveU2FResponse{}, nil
+}
+
+func (s *userService) CreatePasskeyRegistrationLink(_ context.Context, req *user.CreatePasskeyRegistrationLinkRequest, _ ...grpc.CallOption) (*user.CreatePasskeyRegistrationLinkResponse, error) {
+	s.calls = append(s.calls, "CreatePasskeyRegistrationLink")
+	s.link = req
+	if req.GetReturnCode() != nil {
+		return &user.CreatePasskeyRegistrationLinkResponse{Code: &user.PasskeyRegistrationCode{Id: "codeID", Code: "code"}}, nil
+	}
+	return &user.CreatePasskeyRegistrationLinkResponse{}, nil
+}
+
+type managementService struct {
+	management.ManagementServiceClient
+	factors []*userV1.AuthFactor
+}
+
+func (s *managementService) ListHumanAuthFactors(context.Context, *management.ListHumanAuthFactorsRequest, ...grpc.CallOption) (*management.ListHumanAuthFactorsResponse, error) {
+	return &management.ListHumanAuthFactorsResponse{Result: s.factors}, nil
+}
@@ -0,0 +1,159 @@
+package users
+
+import (
+	"context"
+	"errors"
+
+	user "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user/v2"
+)
+
+var (
+	ErrVerifiedNotAllowed = errors.New("a resent code cannot be marked as verified")
+)
+
+type verificationMode int
+
+const (
+	verificationSend verificationMode = iota
+	verificationReturn
+	verificationVerified
+)
+
+// Verification defines how an email address or phone number is verified.
+// The zero value lets ZITADEL send the code (same as [SendCode]).
+type Verification struct {
+	mode        verificationMode
+	urlTemplate string
+}
+
+// SendCode lets ZITADEL send the verification code to the user (by email, resp. SMS).
+// Emails will link to the verification page of the ZITADEL login.
+func SendCode() Verification {
+	return Verification{mode: verificationSend}
+}
+
+// SendCodeWithURL lets ZITADEL send the verification code by email linking to a custom verification page.
+// The urlTemplate can use the placeholders UserID, OrgID and Code, e.g. `https://example.com/verify?user={{.UserID}}&code={{.Code}}`.
+// It's ignored for phone numbers.
+func SendCodeWithURL(urlTemplate string) Verification {
+	return Verification{mode: verificationSend, urlTemplate: urlTemplate}
+}
+
+// ReturnCode returns the verification code instead of sending it, e.g. to deliver it by other means.
+func ReturnCode() Verification {
+	return Verification{mode: verificationReturn}
+}
+
+// Verified marks the email address or phone number as verified without any code, e.g. if it was verified
+// by another system. It's not allowed for resending a code.
+func Verified() Verification {
+	return Verification{mode: verificationVerified}
+}
+
+func (v Verification) sendEmailCode() *user.SendEmailVerificationCode {
+	code := new(user.SendEmailVerificationCode)
+	if v.urlTemplate != "" {
+		code.UrlTemplate = &v.urlTemplate
+	}
+	return code
+}
+
+// SetEmail changes the email address of the user and verifies it as defined by the verification.
+// If the code is returned ([ReturnCode]), it's returned, otherwise the returned code is empty.
+func SetEmail(ctx context.Context, c Client, userID, email string, verification Verification) (string, error) {
+	req := &user.SetEmailRequest{UserId: userID, Email: email}
+	switch verification.mode {
+	case verificationReturn:
+		req.Verification = &user.SetEmailRequest_ReturnCode{ReturnCode: &user.ReturnEmailVerificationCode{}}
+	case verificationVerified:
+		req.Verification = &user.SetEmailRequest_IsVerified{IsVerified: true}
+	default:
+		req.Verification = &user.SetEmailRequest_SendCode{SendCode: verification.sendEmailCode()}
+	}
+	resp, err := c.UserServiceV2().SetEmail(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	return resp.GetVerificationCode(), nil
+}
+
+// ResendEmailCode creates a new verification code for the (unverified) email address of the user.
+// If the code is returned ([ReturnCode]), it's returned, otherwise the returned code is empty.
+func ResendEmailCode(ctx context.Context, c Client, userID string, verification Verification) (string, error) {
+	req := &user.ResendEmailCodeRequest{UserId: userID}
+	switch verification.mode {
+	case verificationReturn:
+		req.Verification = &user.ResendEmailCodeRequest_ReturnCode{ReturnCode: &user.ReturnEmailVerificationCode{}}
+	case verificationVerified:
+		return "", ErrVerifiedNotAllowed
+	default:
+		req.Verification = &user.ResendEmailCodeRequest_SendCode{SendCode: verification.sendEmailCode()}
+	}
+	resp, err := c.UserServiceV2().ResendEmailCode(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	return resp.GetVerificationCode(), nil
+}
+
+// VerifyEmail verifies the email address of the user with the code sent to (or returned for) it.
+func VerifyEmail(ctx context.Context, c Client, userID, code string) error {
+	_, err := c.UserServiceV2().VerifyEmail(ctx, &user.VerifyEmailRequest{
+		UserId:           userID,
+		VerificationCode: code,
+	})
+	return err
+}
+
+// SetPhone changes the phone number of the user and verifies it as defined by the verification.
+// If the code is returned ([ReturnCode]), it's returned, otherwise the returned code is empty.
+func SetPhone(ctx context.Context, c Client, userID, phone string, verification Verification) (string, error) {
+	req := &user.SetPhoneRequest{UserId: userID, Phone: phone}
+	switch verification.mode {
+	case verificationReturn:
+		req.Verification = &user.SetPhoneRequest_ReturnCode{ReturnCode: &user.ReturnPhoneVerificationCode{}}
+	case verificationVerified:
+		req.Verification = &user.SetPhoneRequest_IsVerified{IsVerified: true}
+	default:
+		req.Verification = &user.SetPhoneRequest_SendCode{SendCode: &user.SendPhoneVerificationCode{}}
+	}
+	resp, err := c.UserServiceV2().SetPhone(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	return resp.GetVerificationCode(), nil
+}
+
+// ResendPhoneCode creates a new verification code for the (unverified) phone number of the user.
+// If the code is returned ([ReturnCode]), it's returned, otherwise the returned code is empty.
+func ResendPhoneCode(ctx context.Context, c Client, userID string, verification Verification) (string, error) {
+	req := &user.ResendPhoneCodeRequest{UserId: userID}
+	switch verification.mode {
+	case verificationReturn:
+		req.Verification = &user.ResendPhoneCodeRequest_ReturnCode{ReturnCode: &user.ReturnPhoneVerificationCode{}}
+	case verificationVerified:
+		return "", ErrVerifiedNotAllowed
+	default:
+		req.Verification = &user.ResendPhoneCodeRequest_SendCode{SendCode: &user.SendPhoneVerificationCode{}}
+	}
+	resp, err := c.UserServiceV2().ResendPhoneCode(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	return resp.GetVerificationCode(), nil
+}
+
+// VerifyPhone verifies the phone number of the user with the code sent to (or returned for) it.
+func VerifyPhone(ctx context.Context, c Client, userID, code string) error {
+	_, err := c.UserServiceV2().VerifyPhone(ctx, &user.VerifyPhoneRequest{
+		UserId:           userID,
+		VerificationCode: code,
+	})
+	return err
+}
+
+// RemovePhone removes the phone number of the user. Any OTP SMS set up for it is removed as well.
+func RemovePhone(ctx context.Context, c Client, userID string) error {
+	_, err := c.UserServiceV2().RemovePhone(ctx, &user.RemovePhoneRequest{UserId: userID})
+	return err
+}
@@ -0,0 +1,73 @@
+package users
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetEmail(t *testing.T) {
+	tests := []struct {
+		name            string
+		verification    Verification
+		wantCode        string
+		wantSend        bool
+		wantURLTemplate string
+		wantVerified    bool
+	}{
+		{
+			name:     "zero value sends code",
+			wantSend: true,
+		},
+		{
+			name:            "send code with url",
+			verification:    SendCodeWithURL("https://example.com/verify?code={{.Code}}"),
+			wantSend:        true,
+			wantURLTemplate: "https://example.com/verify?code={{.Code}}",
+		},
+		{
+			name:         "return code",
+			verification: ReturnCode(),
+			wantCode:     "emailCode",
+		},
+		{
+			name:         "verified",
+			verification: Verified(),
+			wantVerified: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := new(userService)
+			code, err := SetEmail(context.Background(), &testClient{user: users}, "user", "user@example.com", tt.verification)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCode, code)
+			assert.Equal(t, "user@example.com", users.setEmail.GetEmail())
+			assert.Equal(t, tt.wantSend, users.setEmail.GetSendCode() != nil)
+			assert.Equal(t, tt.wantURLTemplate, users.setEmail.GetSendCode().GetUrlTemplate())
+			assert.Equal(t, tt.wantVerified, users.setEmail.GetIsVerified())
+		})
+	}
+}
+
+func TestSetPhone(t *testing.T) {
+	users := new(userService)
+	code, err := SetPhone(context.Background(), &testClient{user: users}, "user", "+41791234567", ReturnCode())
+	require.NoError(t, err)
+	assert.Equal(t, "phoneCode", code)
+	assert.NotNil(t, users.setPhone.GetReturnCode())
+
+	code, err = SetPhone(context.Background(), &testClient{user: users}, "user", "+41791234567", SendCodeWithURL("ignored"))
+	require.NoError(t, err)
+	assert.Empty(t, code)
+	assert.NotNil(t, users.setPhone.GetSendCode())
+}
+
+func TestResendCode_verified(t *testing.T) {
+	_, err := ResendEmailCode(context.Background(), &testClient{user: new(userService)}, "user", Verified())
+	assert.ErrorIs(t, err, ErrVerifiedNotAllowed)
+	_, err = ResendPhoneCode(context.Background(), &testClient{user: new(userService)}, "user", Verified())
+	assert.ErrorIs(t, err, ErrVerifiedNotAllowed)
+}
@@ -18,10 +18,14 @@ func New[T authorization.Ctx](authorizer *authorization.Authorizer[T]) *Intercep
 	}
 }
 
+// RequireAuthorization will check the token of the authorization header and provide the authorization context.
+// The method, host, path and query of the request are passed as attributes to a [authorization.PolicyEvaluator]
+// ([authorization.WithPolicy]), the method and path as resource to an [authorization.AuditHook].
+// The path is cleaned of dot segments and duplicate slashes, as for matching a [Route].
 func (i *Interceptor[T]) RequireAuthorization(options ...authorization.CheckOption) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-			ctx, err := i.authorizer.CheckAuthorization(req.Context(), req.Header.Get(authorization.HeaderName), options...)
+			ctx, err := i.authorizer.CheckAuthorization(checkContext(req), req.Header.Get(authorization.HeaderName), options...)
 			if err != nil {
 				if errors.Is(err, &authorization.UnauthorizedErr{}) {
 					http.Error(w, err.Error(), http.StatusUnauthorized)
@@ -36,6 +40,39 @@ func (i *Interceptor[T]) RequireAuthorization(options ...authorization.CheckOpti
 	}
 }
 
+// CheckAuthorization will check the token of the authorization header (if any) and provide the authorization context.
+// Unlike [Interceptor.RequireAuthorization] it will not reject requests without or with an invalid token,
+// e.g. for GraphQL endpoints, where the requirements are checked per field.
+func (i *Interceptor[T]) CheckAuthorization() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			token := req.Header.Get(authorization.HeaderName)
+			if token == "" {
+				next.ServeHTTP(w, req)
+				return
+			}
+			ctx, err := i.authorizer.CheckAuthorization(checkContext(req), token)
+			if err == nil {
+				req = req.WithContext(authorization.WithAuthContext(req.Context(), ctx))
+			}
+			next.ServeHTTP(w, req)
+		})
+	}
+}
+
 func (i *Interceptor[T]) Context(ctx context.Context) T {
 	return authorization.Context[T](ctx)
 }
+
+// checkContext provides the attributes of the request for the policy and the resource for the audit hook.
+// The path is cleaned the same way as for matching the [Route], so e.g. `/public/../admin` is checked as `/admin`.
+func checkContext(req *http.Request) context.Context {
+	p := cleanPath(req.URL.Path)
+	ctx := authorization.WithResource(req.Context(), req.Method+" "+p)
+	return authorization.WithPolicyAttributes(ctx, map[string]any{
+		"method": req.Method,
+		"host":   req.Host,
+		"path":   p,
+		"query":  req.URL.Query(),
+	})
+}
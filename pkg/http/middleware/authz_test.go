@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authorization"
+	"github.com/zitadel/zitadel-go/v3/pkg/zitadel"
+)
+
+func TestInterceptor_CheckAuthorization(t *testing.T) {
+	authZ, err := authorization.New(context.Background(), zitadel.New("zitadel.example.com"),
+		func(context.Context, *zitadel.Zitadel) (authorization.Verifier[*testCtx], error) {
+			return tokenVerifier{}, nil
+		})
+	require.NoError(t, err)
+	interceptor := New(authZ)
+
+	tests := []struct {
+		name           string
+		token          string
+		wantAuthorized bool
+	}{
+		{
+			name: "no token",
+		},
+		{
+			name:  "invalid token",
+			token: "Bearer invalid",
+		},
+		{
+			name:           "valid token",
+			token:          "Bearer valid",
+			wantAuthorized: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var authorized bool
+			handler := interceptor.CheckAuthorization()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				authorized = interceptor.Context(r.Context()).IsAuthorized()
+			}))
+			req := httptest.NewRequest(http.MethodPost, "/query", nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", tt.token)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tt.wantAuthorized, authorized)
+		})
+	}
+}
+
+func TestInterceptor_RequireAuthorization_cleanPath(t *testing.T) {
+	var resource string
+	var attributes map[string]any
+	authZ, err := authorization.New(context.Background(), zitadel.New("zitadel.example.com"),
+		func(context.Context, *zitadel.Zitadel) (authorization.Verifier[*testCtx], error) {
+			return tokenVerifier{}, nil
+		},
+		authorization.WithAuditHook[*testCtx](authorization.AuditFunc(func(_ context.Context, decision *authorization.Decision) {
+			resource = decision.Resource
+		})),
+	)
+	require.NoError(t, err)
+	policy := authorization.PolicyFunc(func(_ context.Context, input *authorization.PolicyInput) (bool, error) {
+		attributes = input.Attributes
+		return input.Attributes["path"] != "/admin", nil
+	})
+	handler := New(authZ).RequireAuthorization(authorization.WithPolicy(policy))(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	req := httptest.NewRequest(http.MethodGet, "/public/..//admin", nil)
+	req.Header.Set("Authorization", "Bearer valid")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Equal(t, "/admin", attributes["path"])
+	assert.Equal(t, "GET /admin", resource)
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authorization"
+)
+
+// PropagateIdentity wraps the base [http.RoundTripper] (or the [http.DefaultTransport] if nil) to forward
+// the [authorization.Identity] of the authorized caller of the request context as headers, e.g.:
+//
+//	client := &http.Client{Transport: middleware.PropagateIdentity(nil)}
+//	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://orders.internal/orders", nil)
+//
+// The headers are informational only, downstream services must still authorize the requests themselves.
+func PropagateIdentity(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &identityTransport{base: base}
+}
+
+type identityTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip implements [http.RoundTripper].
+func (t *identityTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	headers := authorization.CallerIdentity(req.Context()).Headers()
+	if len(headers) == 0 {
+		return t.base.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authorization"
+)
+
+type orgCtx struct {
+	testCtx
+}
+
+func (o *orgCtx) OrgID() string     { return "org" }
+func (o *orgCtx) ProjectID() string { return "project" }
+
+func TestPropagateIdentity(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		want    http.Header
+		wantNil bool
+	}{
+		{
+			name:    "unauthorized",
+			ctx:     context.Background(),
+			wantNil: true,
+		},
+		{
+			name: "user only",
+			ctx:  authorization.WithAuthContext(context.Background(), &testCtx{}),
+			want: http.Header{"X-Zitadel-User-Id": {"userID"}},
+		},
+		{
+			name: "user, org and project",
+			ctx:  authorization.WithAuthContext(context.Background(), &orgCtx{}),
+			want: http.Header{
+				"X-Zitadel-User-Id":     {"userID"},
+				"X-Zitadel-User-Org-Id": {"org"},
+				"X-Zitadel-Project-Id":  {"project"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got http.Header
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = http.Header{}
+				for _, key := range []string{authorization.HeaderUserID, authorization.HeaderOrgID, authorization.HeaderProjectID} {
+					if value := r.Header.Get(key); value != "" {
+						got.Set(key, value)
+					}
+				}
+			}))
+			defer server.Close()
+
+			req, err := http.NewRequestWithContext(tt.ctx, http.MethodGet, server.URL, nil)
+			require.NoError(t, err)
+			resp, err := (&http.Client{Transport: PropagateIdentity(nil)}).Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+			if tt.wantNil {
+				assert.Empty(t, got)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
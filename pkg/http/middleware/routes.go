@@ -0,0 +1,210 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authorization"
+)
+
+var (
+	ErrInvalidRoute  = errors.New("invalid route")
+	ErrUnmappedRoute = errors.New("route is not mapped to any authorization requirement")
+)
+
+// Route maps HTTP requests by their method and path to the authorization requirements.
+// Routes can be defined in Go or loaded from a configuration file (JSON or YAML), e.g.:
+//
+//	routes:
+//	  - path: /healthz
+//	    public: true
+//	  - method: GET
+//	    path: /api/documents/**
+//	    anyRoles: [reader, editor]
+//	  - method: POST
+//	    path: /api/documents/*
+//	    roles: [editor]
+//	    scopes: [documents:write]
+type Route struct {
+	// Method of the request, e.g. `GET`. An empty method or `*` matches any method.
+	Method string `json:"method,omitempty" yaml:"method,omitempty"`
+	// Path pattern of the request, e.g. `/api/documents/*`.
+	// A `*` segment matches exactly one path segment, a trailing `**` segment any number of remaining segments.
+	Path string `json:"path" yaml:"path"`
+	// Public routes do not require any authorization.
+	Public bool `json:"public,omitempty" yaml:"public,omitempty"`
+	// Roles must all be granted to the authorized user ([authorization.WithRole]).
+	Roles []string `json:"roles,omitempty" yaml:"roles,omitempty"`
+	// AnyRoles requires at least one of the roles to be granted to the authorized user ([authorization.WithAnyRole]).
+	AnyRoles []string `json:"anyRoles,omitempty" yaml:"anyRoles,omitempty"`
+	// Scopes must all be granted to the token ([authorization.WithScope]).
+	Scopes []string `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+}
+
+type compiledRoute struct {
+	method   string
+	segments []string
+	public   bool
+	checks   []authorization.CheckOption
+}
+
+// RoutesOption allows customization of the [Interceptor.RequireRoutes] middleware.
+type RoutesOption func(*routesOptions)
+
+type routesOptions struct {
+	denyUnmapped bool
+}
+
+// WithDenyByDefault rejects all requests not matching any route with [http.StatusForbidden].
+// This prevents accidentally exposed endpoints, e.g. after adding a new handler.
+// Use [ValidateRoutes] to detect unmapped endpoints on startup.
+func WithDenyByDefault() RoutesOption {
+	return func(o *routesOptions) {
+		o.denyUnmapped = true
+	}
+}
+
+// RequireRoutes compiles the routes into a single middleware, instead of wiring [Interceptor.RequireAuthorization]
+// with the requirements for every handler. The first route matching the request is applied.
+// By default, requests not matching any route are passed to the next handler without authorization,
+// see [WithDenyByDefault] to change that.
+// An [ErrInvalidRoute] is returned if a route cannot be compiled, e.g. because of an invalid path pattern.
+func (i *Interceptor[T]) RequireRoutes(routes []Route, opts ...RoutesOption) (func(next http.Handler) http.Handler, error) {
+	o := new(routesOptions)
+	for _, opt := range opts {
+		opt(o)
+	}
+	compiled, err := compileRoutes(routes)
+	if err != nil {
+		return nil, err
+	}
+	return func(next http.Handler) http.Handler {
+		handlers := make([]http.Handler, len(compiled))
+		for j, route := range compiled {
+			handlers[j] = next
+			if !route.public {
+				handlers[j] = i.RequireAuthorization(route.checks...)(next)
+			}
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			for j, route := range compiled {
+				if route.matches(req.Method, req.URL.Path) {
+					handlers[j].ServeHTTP(w, req)
+					return
+				}
+			}
+			if o.denyUnmapped {
+				http.Error(w, ErrUnmappedRoute.Error(), http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, req)
+		})
+	}, nil
+}
+
+// ValidateRoutes returns an [ErrUnmappedRoute] listing all endpoints not matching any of the routes, e.g.:
+//
+//	err := middleware.ValidateRoutes(routes, "GET /api/documents/{id}", "POST /api/documents", "/healthz")
+//
+// An endpoint consists of an optional method and the path. Path parameters (e.g. `{id}`) are matched by `*` and `**`.
+// An endpoint without method must be matched by a route for any method.
+// An [ErrInvalidRoute] is returned if a route cannot be compiled.
+func ValidateRoutes(routes []Route, endpoints ...string) error {
+	compiled, err := compileRoutes(routes)
+	if err != nil {
+		return err
+	}
+	var unmapped []string
+	for _, endpoint := range endpoints {
+		method, path, ok := strings.Cut(endpoint, " ")
+		if !ok {
+			method, path = "", endpoint
+		}
+		method = strings.ToUpper(method)
+		if !slices.ContainsFunc(compiled, func(route *compiledRoute) bool {
+			return (route.method == "" || method != "") && route.matches(method, strings.TrimSpace(path))
+		}) {
+			unmapped = append(unmapped, endpoint)
+		}
+	}
+	if len(unmapped) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: `%s`", ErrUnmappedRoute, strings.Join(unmapped, "`, `"))
+}
+
+func compileRoutes(routes []Route) ([]*compiledRoute, error) {
+	compiled := make([]*compiledRoute, len(routes))
+	for j, route := range routes {
+		c, err := compileRoute(route)
+		if err != nil {
+			return nil, fmt.Errorf("%w: `%s %s`: %w", ErrInvalidRoute, route.Method, route.Path, err)
+		}
+		compiled[j] = c
+	}
+	return compiled, nil
+}
+
+func compileRoute(route Route) (*compiledRoute, error) {
+	if !strings.HasPrefix(route.Path, "/") {
+		return nil, errors.New("path must start with `/`")
+	}
+	if route.Public && (len(route.Roles) > 0 || len(route.AnyRoles) > 0 || len(route.Scopes) > 0) {
+		return nil, errors.New("public route must not have any requirements")
+	}
+	segments := splitPath(route.Path)
+	for j, segment := range segments {
+		if segment == "**" && j != len(segments)-1 {
+			return nil, errors.New("`**` is only allowed as last segment")
+		}
+	}
+	c := &compiledRoute{
+		method:   strings.ToUpper(route.Method),
+		segments: segments,
+		public:   route.Public,
+	}
+	if c.method == "*" {
+		c.method = ""
+	}
+	for _, role := range route.Roles {
+		c.checks = append(c.checks, authorization.WithRole(role))
+	}
+	if len(route.AnyRoles) > 0 {
+		c.checks = append(c.checks, authorization.WithAnyRole(route.AnyRoles...))
+	}
+	for _, scope := range route.Scopes {
+		c.checks = append(c.checks, authorization.WithScope(scope))
+	}
+	return c, nil
+}
+
+// matches reports whether the route matches the method and path. The path is cleaned first,
+// so dot segments and duplicate slashes (e.g. `/public/../admin`) cannot bypass a stricter route.
+func (c *compiledRoute) matches(method, p string) bool {
+	if c.method != "" && c.method != method {
+		return false
+	}
+	segments := splitPath(cleanPath(p))
+	for j, segment := range c.segments {
+		if segment == "**" {
+			return true
+		}
+		if j >= len(segments) || (segment != "*" && segment != segments[j]) {
+			return false
+		}
+	}
+	return len(segments) == len(c.segments)
+}
+
+// cleanPath returns the path without dot segments and duplicate slashes, as it's matched against the routes.
+func cleanPath(p string) string {
+	return path.Clean("/" + p)
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
@@ -0,0 +1,213 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authorization"
+	"github.com/zitadel/zitadel-go/v3/pkg/zitadel"
+)
+
+func TestInterceptor_RequireRoutes(t *testing.T) {
+	authZ, err := authorization.New(context.Background(), zitadel.New("zitadel.example.com"),
+		func(context.Context, *zitadel.Zitadel) (authorization.Verifier[*testCtx], error) {
+			return tokenVerifier{}, nil
+		})
+	require.NoError(t, err)
+	mw, err := New(authZ).RequireRoutes([]Route{
+		{Path: "/healthz", Public: true},
+		{Method: http.MethodGet, Path: "/api/documents/**"},
+		{Method: http.MethodPost, Path: "/api/documents/*", Roles: []string{"admin"}},
+		{Method: "*", Path: "/api/admin/*/settings", AnyRoles: []string{"owner", "admin"}},
+		{Path: "/api/scoped", Scopes: []string{"documents:write"}},
+	})
+	require.NoError(t, err)
+	handler := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	tests := []struct {
+		name     string
+		method   string
+		target   string
+		token    string
+		wantCode int
+	}{
+		{
+			name:     "public",
+			method:   http.MethodGet,
+			target:   "/healthz",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "unmapped",
+			method:   http.MethodGet,
+			target:   "/other",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "wildcard, unauthorized",
+			method:   http.MethodGet,
+			target:   "/api/documents/1/versions",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "wildcard, authorized",
+			method:   http.MethodGet,
+			target:   "/api/documents/1/versions",
+			token:    "Bearer valid",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "role, missing",
+			method:   http.MethodPost,
+			target:   "/api/documents/1",
+			token:    "Bearer valid",
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "role, granted",
+			method:   http.MethodPost,
+			target:   "/api/documents/1",
+			token:    "Bearer admin",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "segment wildcard, any role granted",
+			method:   http.MethodPut,
+			target:   "/api/admin/org1/settings",
+			token:    "Bearer admin",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "segment wildcard, any role missing",
+			method:   http.MethodPut,
+			target:   "/api/admin/org1/settings",
+			token:    "Bearer valid",
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "scope missing",
+			method:   http.MethodGet,
+			target:   "/api/scoped",
+			token:    "Bearer admin",
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "dot segments",
+			method:   http.MethodPost,
+			target:   "/healthz/../api/documents/1",
+			token:    "Bearer valid",
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "current dir segment",
+			method:   http.MethodPut,
+			target:   "/api/./admin/org1/settings",
+			token:    "Bearer valid",
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "duplicate slashes",
+			method:   http.MethodPost,
+			target:   "/api//documents/1",
+			token:    "Bearer valid",
+			wantCode: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", tt.token)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			assert.Equal(t, tt.wantCode, rec.Code)
+		})
+	}
+}
+
+func TestInterceptor_RequireRoutes_denyByDefault(t *testing.T) {
+	authZ, err := authorization.New(context.Background(), zitadel.New("zitadel.example.com"),
+		func(context.Context, *zitadel.Zitadel) (authorization.Verifier[*testCtx], error) {
+			return tokenVerifier{}, nil
+		})
+	require.NoError(t, err)
+	mw, err := New(authZ).RequireRoutes([]Route{{Path: "/healthz", Public: true}}, WithDenyByDefault())
+	require.NoError(t, err)
+	handler := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/other", nil)
+	req.Header.Set("Authorization", "Bearer admin")
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestValidateRoutes(t *testing.T) {
+	routes := []Route{
+		{Path: "/healthz", Public: true},
+		{Method: http.MethodGet, Path: "/api/documents/*"},
+		{Path: "/api/admin/**", Roles: []string{"admin"}},
+	}
+	tests := []struct {
+		name      string
+		endpoints []string
+		wantErr   string
+	}{
+		{
+			name:      "all mapped",
+			endpoints: []string{"/healthz", "GET /api/documents/{id}", "POST /api/admin/users/{id}", "/api/admin"},
+		},
+		{
+			name:      "unmapped",
+			endpoints: []string{"GET /api/documents/{id}", "POST /api/documents/{id}", "/api/documents/{id}", "/metrics"},
+			wantErr:   "`POST /api/documents/{id}`, `/api/documents/{id}`, `/metrics`",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRoutes(routes, tt.endpoints...)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrUnmappedRoute)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestInterceptor_RequireRoutes_invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		route Route
+	}{
+		{
+			name:  "relative path",
+			route: Route{Path: "api"},
+		},
+		{
+			name:  "public with requirements",
+			route: Route{Path: "/api", Public: true, Roles: []string{"admin"}},
+		},
+		{
+			name:  "double wildcard not last",
+			route: Route{Path: "/api/**/documents"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New[*testCtx](nil).RequireRoutes([]Route{tt.route})
+			assert.ErrorIs(t, err, ErrInvalidRoute)
+		})
+	}
+}
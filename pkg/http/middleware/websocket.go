@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authorization"
+)
+
+const (
+	// WebSocketProtocolPrefix is the prefix of the subprotocol in the `Sec-WebSocket-Protocol` header containing the token,
+	// e.g. `new WebSocket(url, ["chat", "bearer." + token])` in the browser, which cannot set an Authorization header.
+	WebSocketProtocolPrefix = "bearer."
+	// WebSocketProtocol replaces the token if it was the only subprotocol of the request. The client offered a subprotocol,
+	// so the handshake only succeeds if the server selects one, e.g. with `websocket.Upgrader{Subprotocols: []string{middleware.WebSocketProtocol}}`.
+	// Clients offering another subprotocol besides the token (e.g. `chat`) need the server to select that one instead.
+	WebSocketProtocol = "bearer"
+	// WebSocketQueryParam is the query parameter containing the token, e.g. `wss://example.com/ws?access_token=...`.
+	WebSocketQueryParam = "access_token"
+)
+
+// RequireWebSocketAuthorization works like [Interceptor.RequireAuthorization] for WebSocket upgrade requests.
+// Since browsers cannot set an Authorization header on WebSocket connections, the token is also accepted
+// as subprotocol with the [WebSocketProtocolPrefix] or as [WebSocketQueryParam].
+// The token is removed from the request, so the upgrader of the next handler only negotiates the remaining subprotocols
+// and it does not leak into logs. If the token was the only subprotocol, it's replaced by the [WebSocketProtocol],
+// which the upgrader needs to select. The authorization context is provided before the connection is hijacked.
+//
+// Requests which are no WebSocket upgrades are only authorized by the Authorization header.
+func (i *Interceptor[T]) RequireWebSocketAuthorization(options ...authorization.CheckOption) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			token := req.Header.Get(authorization.HeaderName)
+			if isWebSocketUpgrade(req) {
+				req = req.Clone(req.Context())
+				if wsToken := webSocketToken(req); token == "" && wsToken != "" {
+					token = oidc.BearerToken + " " + wsToken
+				}
+			}
+			ctx, err := i.authorizer.CheckAuthorization(checkContext(req), token, options...)
+			if err != nil {
+				if errors.Is(err, &authorization.UnauthorizedErr{}) {
+					http.Error(w, err.Error(), http.StatusUnauthorized)
+					return
+				}
+				http.Error(w, err.Error(), http.StatusForbidden)
+				return
+			}
+			req = req.WithContext(authorization.WithAuthContext(req.Context(), ctx))
+			next.ServeHTTP(w, req)
+		})
+	}
+}
+
+func isWebSocketUpgrade(req *http.Request) bool {
+	return headerContainsToken(req.Header, "Connection", "upgrade") &&
+		headerContainsToken(req.Header, "Upgrade", "websocket")
+}
+
+func headerContainsToken(header http.Header, name, token string) bool {
+	for _, value := range header.Values(name) {
+		for _, v := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(v), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// webSocketToken returns the token of the subprotocol or query parameter and removes both from the request.
+func webSocketToken(req *http.Request) (token string) {
+	var protocols []string
+	for _, value := range req.Header.Values("Sec-WebSocket-Protocol") {
+		for _, protocol := range strings.Split(value, ",") {
+			protocol = strings.TrimSpace(protocol)
+			if t, ok := strings.CutPrefix(protocol, WebSocketProtocolPrefix); ok {
+				token = t
+				continue
+			}
+			if protocol != "" {
+				protocols = append(protocols, protocol)
+			}
+		}
+	}
+	req.Header.Del("Sec-WebSocket-Protocol")
+	if token != "" && len(protocols) == 0 {
+		protocols = []string{WebSocketProtocol}
+	}
+	if len(protocols) > 0 {
+		req.Header.Set("Sec-WebSocket-Protocol", strings.Join(protocols, ", "))
+	}
+	query := req.URL.Query()
+	if token == "" {
+		token = query.Get(WebSocketQueryParam)
+	}
+	if query.Has(WebSocketQueryParam) {
+		query.Del(WebSocketQueryParam)
+		req.URL.RawQuery = query.Encode()
+		req.RequestURI = req.URL.RequestURI()
+	}
+	return token
+}
@@ -0,0 +1,168 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authorization"
+	"github.com/zitadel/zitadel-go/v3/pkg/zitadel"
+)
+
+type testCtx struct {
+	token string
+	role  string
+}
+
+func (t *testCtx) IsAuthorized() bool                              { return t != nil }
+func (t *testCtx) UserID() string                                  { return "userID" }
+func (t *testCtx) IsGrantedRole(role string) bool                  { return role == t.role }
+func (t *testCtx) IsGrantedRoleInOrganization(string, string) bool { return false }
+func (t *testCtx) SetToken(token string)                           { t.token = token }
+func (t *testCtx) GetToken() string                                { return t.token }
+
+type tokenVerifier struct{}
+
+func (tokenVerifier) CheckAuthorization(_ context.Context, token string) (*testCtx, error) {
+	switch token {
+	case "Bearer valid":
+		return &testCtx{}, nil
+	case "Bearer admin":
+		return &testCtx{role: "admin"}, nil
+	}
+	return nil, errors.New("invalid token")
+}
+
+func TestInterceptor_RequireWebSocketAuthorization(t *testing.T) {
+	authZ, err := authorization.New(context.Background(), zitadel.New("zitadel.example.com"),
+		func(context.Context, *zitadel.Zitadel) (authorization.Verifier[*testCtx], error) {
+			return tokenVerifier{}, nil
+		})
+	require.NoError(t, err)
+	mw := New(authZ).RequireWebSocketAuthorization()
+
+	tests := []struct {
+		name          string
+		target        string
+		header        http.Header
+		wantCode      int
+		wantProtocols string
+		wantURI       string
+	}{
+		{
+			name:     "authorization header",
+			target:   "/ws",
+			header:   http.Header{"Authorization": {"Bearer valid"}},
+			wantCode: http.StatusOK,
+			wantURI:  "/ws",
+		},
+		{
+			name:   "subprotocol",
+			target: "/ws",
+			header: http.Header{
+				"Connection":             {"keep-alive, Upgrade"},
+				"Upgrade":                {"websocket"},
+				"Sec-Websocket-Protocol": {"chat, bearer.valid"},
+			},
+			wantCode:      http.StatusOK,
+			wantProtocols: "chat",
+			wantURI:       "/ws",
+		},
+		{
+			name:   "single subprotocol",
+			target: "/ws",
+			header: http.Header{
+				"Connection":             {"Upgrade"},
+				"Upgrade":                {"websocket"},
+				"Sec-Websocket-Protocol": {"bearer.valid"},
+			},
+			wantCode:      http.StatusOK,
+			wantProtocols: WebSocketProtocol,
+			wantURI:       "/ws",
+		},
+		{
+			name:   "query param",
+			target: "/ws?room=1&access_token=valid",
+			header: http.Header{
+				"Connection": {"Upgrade"},
+				"Upgrade":    {"websocket"},
+			},
+			wantCode: http.StatusOK,
+			wantURI:  "/ws?room=1",
+		},
+		{
+			name:   "invalid subprotocol token",
+			target: "/ws",
+			header: http.Header{
+				"Connection":             {"Upgrade"},
+				"Upgrade":                {"websocket"},
+				"Sec-Websocket-Protocol": {"bearer.invalid"},
+			},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "query param without upgrade",
+			target:   "/ws?access_token=valid",
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotReq *http.Request
+			handler := mw(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				gotReq = req
+			}))
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			for key, values := range tt.header {
+				req.Header[key] = values
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			assert.True(t, authorization.IsAuthorized(gotReq.Context()))
+			assert.Equal(t, tt.wantProtocols, gotReq.Header.Get("Sec-WebSocket-Protocol"))
+			assert.Equal(t, tt.wantURI, gotReq.RequestURI)
+		})
+	}
+}
+
+func TestInterceptor_RequireWebSocketAuthorization_policyAttributes(t *testing.T) {
+	authZ, err := authorization.New(context.Background(), zitadel.New("zitadel.example.com"),
+		func(context.Context, *zitadel.Zitadel) (authorization.Verifier[*testCtx], error) {
+			return tokenVerifier{}, nil
+		})
+	require.NoError(t, err)
+	var attributes map[string]any
+	policy := authorization.PolicyFunc(func(_ context.Context, input *authorization.PolicyInput) (bool, error) {
+		attributes = input.Attributes
+		return input.Attributes["path"] == "/ws", nil
+	})
+	handler := New(authZ).RequireWebSocketAuthorization(authorization.WithPolicy(policy))(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	for target, wantCode := range map[string]int{
+		"/ws?room=1&access_token=valid":    http.StatusOK,
+		"/admin?room=1&access_token=valid": http.StatusForbidden,
+		// the path is cleaned, as for matching the routes
+		"/admin/../ws?room=1&access_token=valid":  http.StatusOK,
+		"//ws/../admin?room=1&access_token=valid": http.StatusForbidden,
+	} {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set("Connection", "Upgrade")
+		req.Header.Set("Upgrade", "websocket")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		assert.Equal(t, wantCode, w.Code, target)
+		assert.Equal(t, http.MethodGet, attributes["method"])
+		assert.Equal(t, "example.com", attributes["host"])
+		assert.Equal(t, url.Values{"room": {"1"}}, attributes["query"])
+	}
+}
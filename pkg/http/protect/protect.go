@@ -0,0 +1,104 @@
+// Package protect wires the authentication and authorization middlewares to a router (e.g. [http.ServeMux])
+// with requirements per route pattern, instead of wrapping each handler manually:
+//
+//	handler := protect.Routes(mux).
+//		Require("/admin/*", authZ.RequireAuthorization(authorization.WithRole("admin"))).
+//		Require("/*", authN.RequireAuthentication()).
+//		Public("/healthz", "/auth/*")
+//	http.ListenAndServe(":8089", handler)
+package protect
+
+import (
+	"net/http"
+	"path"
+	"strings"
+)
+
+// Middleware is a requirement of a route, e.g. the [authentication.Interceptor.RequireAuthentication]
+// or [middleware.Interceptor.RequireAuthorization].
+type Middleware = func(next http.Handler) http.Handler
+
+// Router serves the requests with the handler after applying the middlewares of the route pattern matching the request.
+type Router struct {
+	handler http.Handler
+	rules   []rule
+}
+
+type rule struct {
+	pattern     string
+	middlewares []Middleware
+}
+
+// Routes creates a [Router] for the handler (e.g. an [http.ServeMux]).
+// Requests not matching any pattern are passed to the handler without any requirement.
+func Routes(handler http.Handler) *Router {
+	return &Router{
+		handler: handler,
+	}
+}
+
+// Require applies the middlewares (in the provided order) to all requests matching the pattern.
+//
+// A pattern is either a path (`/healthz`), a path ending with a wildcard matching all subpaths (`/admin/*` matches
+// `/admin`, `/admin/` and `/admin/users/1`) or a pattern with wildcards as in [path.Match] (`/orgs/*/settings`).
+// If multiple patterns match a request, the longest one wins, so more specific routes can override their parent.
+func (r *Router) Require(pattern string, middlewares ...Middleware) *Router {
+	r.rules = append(r.rules, rule{pattern: pattern, middlewares: middlewares})
+	return r
+}
+
+// Public allows all requests matching any of the patterns without any requirement,
+// e.g. for health checks or static files inside of a protected route.
+func (r *Router) Public(patterns ...string) *Router {
+	for _, pattern := range patterns {
+		r.rules = append(r.rules, rule{pattern: pattern})
+	}
+	return r
+}
+
+// ServeHTTP implements the [http.Handler] interface.
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var handler http.Handler = r.handler
+	if match := r.match(req.URL.Path); match != nil {
+		for i := len(match.middlewares) - 1; i >= 0; i-- {
+			handler = match.middlewares[i](handler)
+		}
+	}
+	handler.ServeHTTP(w, req)
+}
+
+// match returns the rule with the longest pattern matching the (cleaned) path, so `/public/../admin`
+// cannot be used to bypass the requirements of `/admin`.
+func (r *Router) match(p string) *rule {
+	p = cleanPath(p)
+	var match *rule
+	for i, rule := range r.rules {
+		if !matches(rule.pattern, p) {
+			continue
+		}
+		if match == nil || len(rule.pattern) > len(match.pattern) {
+			match = &r.rules[i]
+		}
+	}
+	return match
+}
+
+func matches(pattern, p string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "/*"); ok {
+		return p == prefix || strings.HasPrefix(p, prefix+"/") || prefix == ""
+	}
+	ok, err := path.Match(pattern, p)
+	return err == nil && ok
+}
+
+func cleanPath(p string) string {
+	if p == "" || p[0] != '/' {
+		p = "/" + p
+	}
+	cleaned := path.Clean(p)
+	// keep the trailing slash, as the ServeMux would
+	if strings.HasSuffix(p, "/") && cleaned != "/" {
+		cleaned += "/"
+	}
+	return cleaned
+}
@@ -0,0 +1,71 @@
+package protect
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func deny(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+	})
+}
+
+func header(value string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Add("X-Middleware", value)
+			next.ServeHTTP(w, req)
+		})
+	}
+}
+
+func TestRouter_ServeHTTP(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	router := Routes(ok).
+		Require("/*", header("authn")).
+		Require("/admin/*", header("authn"), deny).
+		Require("/orgs/*/settings", deny).
+		Public("/healthz", "/admin/public/*")
+
+	tests := []struct {
+		name           string
+		path           string
+		wantCode       int
+		wantMiddleware []string
+	}{
+		{"root", "/", http.StatusOK, []string{"authn"}},
+		{"fallback", "/profile", http.StatusOK, []string{"authn"}},
+		{"public", "/healthz", http.StatusOK, nil},
+		{"prefix without slash", "/admin", http.StatusForbidden, []string{"authn"}},
+		{"prefix subpath", "/admin/users/1", http.StatusForbidden, []string{"authn"}},
+		{"more specific public", "/admin/public/logo.png", http.StatusOK, nil},
+		{"dot segments", "/admin/public/../users", http.StatusForbidden, []string{"authn"}},
+		{"glob", "/orgs/123/settings", http.StatusForbidden, nil},
+		{"glob no match", "/orgs/123/projects", http.StatusOK, []string{"authn"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.URL.Path = tt.path
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tt.wantCode, w.Code)
+			assert.Equal(t, tt.wantMiddleware, w.Header().Values("X-Middleware"))
+		})
+	}
+}
+
+func TestRoutes_unmatched(t *testing.T) {
+	router := Routes(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})).Require("/admin/*", deny)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/other", nil))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
@@ -0,0 +1,68 @@
+// Package zitadelecho provides the authentication and authorization middlewares
+// of the [authentication.Interceptor] and [middleware.Interceptor] as [echo.MiddlewareFunc].
+//
+// The package is a separate module, so the root module does not depend on Echo.
+package zitadelecho
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authentication"
+	"github.com/zitadel/zitadel-go/v3/pkg/authorization"
+	"github.com/zitadel/zitadel-go/v3/pkg/http/middleware"
+)
+
+// Middleware converts a net/http middleware into an [echo.MiddlewareFunc].
+// If the middleware calls the next handler, the (possibly extended) request is set on the [echo.Context]
+// before calling the next [echo.HandlerFunc], otherwise (e.g. on a redirect to the Login UI) the response is left as written.
+func Middleware(mw func(next http.Handler) http.Handler) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) (err error) {
+			mw(http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+				c.SetRequest(req)
+				err = next(c)
+			})).ServeHTTP(c.Response(), c.Request())
+			return err
+		}
+	}
+}
+
+// RequireAuthentication is the [authentication.Interceptor.RequireAuthentication] as [echo.MiddlewareFunc].
+func RequireAuthentication[T authentication.Ctx](interceptor *authentication.Interceptor[T]) echo.MiddlewareFunc {
+	return Middleware(interceptor.RequireAuthentication())
+}
+
+// CheckAuthentication is the [authentication.Interceptor.CheckAuthentication] as [echo.MiddlewareFunc].
+func CheckAuthentication[T authentication.Ctx](interceptor *authentication.Interceptor[T]) echo.MiddlewareFunc {
+	return Middleware(interceptor.CheckAuthentication())
+}
+
+// RequireAuthorization is the [middleware.Interceptor.RequireAuthorization] as [echo.MiddlewareFunc].
+func RequireAuthorization[T authorization.Ctx](interceptor *middleware.Interceptor[T], options ...authorization.CheckOption) echo.MiddlewareFunc {
+	return Middleware(interceptor.RequireAuthorization(options...))
+}
+
+// Authenticator serves the endpoints of the [authentication.Authenticator] (login, callback, logout, ...),
+// e.g. `e.Any("/auth/*", zitadelecho.Authenticator(authN))`.
+func Authenticator[T authentication.Ctx](authenticator *authentication.Authenticator[T]) echo.HandlerFunc {
+	return echo.WrapHandler(authenticator)
+}
+
+// UserFromContext returns the authenticated user provided by the [RequireAuthentication]
+// or [CheckAuthentication] middleware. It returns the zero value if the user is not authenticated.
+func UserFromContext[T authentication.Ctx](c echo.Context) T {
+	return authentication.Context[T](c.Request().Context())
+}
+
+// IsAuthenticated returns if the user of the request is authenticated.
+func IsAuthenticated(c echo.Context) bool {
+	return authentication.IsAuthenticated(c.Request().Context())
+}
+
+// AuthorizationFromContext returns the authorization provided by the [RequireAuthorization] middleware.
+// It returns the zero value if the request is not authorized.
+func AuthorizationFromContext[T authorization.Ctx](c echo.Context) T {
+	return authorization.Context[T](c.Request().Context())
+}
@@ -0,0 +1,57 @@
+package zitadelecho
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type ctxKey struct{}
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name      string
+		mw        func(next http.Handler) http.Handler
+		wantCode  int
+		wantValue any
+	}{
+		{
+			"next called, context passed",
+			func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+					next.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), ctxKey{}, "user")))
+				})
+			},
+			http.StatusOK,
+			"user",
+		},
+		{
+			"next not called",
+			func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+					http.Redirect(w, req, "/auth/login", http.StatusFound)
+				})
+			},
+			http.StatusFound,
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var value any
+			e := echo.New()
+			e.GET("/", func(c echo.Context) error {
+				value = c.Request().Context().Value(ctxKey{})
+				return c.NoContent(http.StatusOK)
+			}, Middleware(tt.mw))
+			w := httptest.NewRecorder()
+			e.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+			assert.Equal(t, tt.wantCode, w.Code)
+			assert.Equal(t, tt.wantValue, value)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+// Package zitadelfiber provides the authentication and authorization middlewares
+// of the [authentication.Interceptor] and [middleware.Interceptor] as [fiber.Handler].
+//
+// Fiber is based on fasthttp, so the request is converted into a [http.Request] for the middleware
+// and the context provided by it is stored as user context of the [fiber.Ctx] ([fiber.Ctx.UserContext]).
+//
+// The package is a separate module, so the root module does not depend on Fiber.
+package zitadelfiber
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authentication"
+	"github.com/zitadel/zitadel-go/v3/pkg/authorization"
+	"github.com/zitadel/zitadel-go/v3/pkg/http/middleware"
+)
+
+// Middleware converts a net/http middleware into a [fiber.Handler].
+// If the middleware calls the next handler, the context of the (possibly extended) request is set as user context
+// and the next handler of the chain is called, otherwise (e.g. on a redirect to the Login UI) the response is left as written.
+func Middleware(mw func(next http.Handler) http.Handler) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		req, err := adaptor.ConvertRequest(c, true)
+		if err != nil {
+			return err
+		}
+		req = req.WithContext(c.UserContext())
+		w := &responseWriter{c: c, header: make(http.Header)}
+		var next bool
+		mw(http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+			next = true
+			c.SetUserContext(req.Context())
+		})).ServeHTTP(w, req)
+		// headers (e.g. cookies of a refreshed session) might have been set without writing a response
+		w.writeHeader()
+		if next {
+			return c.Next()
+		}
+		return nil
+	}
+}
+
+// RequireAuthentication is the [authentication.Interceptor.RequireAuthentication] as [fiber.Handler].
+func RequireAuthentication[T authentication.Ctx](interceptor *authentication.Interceptor[T]) fiber.Handler {
+	return Middleware(interceptor.RequireAuthentication())
+}
+
+// CheckAuthentication is the [authentication.Interceptor.CheckAuthentication] as [fiber.Handler].
+func CheckAuthentication[T authentication.Ctx](interceptor *authentication.Interceptor[T]) fiber.Handler {
+	return Middleware(interceptor.CheckAuthentication())
+}
+
+// RequireAuthorization is the [middleware.Interceptor.RequireAuthorization] as [fiber.Handler].
+func RequireAuthorization[T authorization.Ctx](interceptor *middleware.Interceptor[T], options ...authorization.CheckOption) fiber.Handler {
+	return Middleware(interceptor.RequireAuthorization(options...))
+}
+
+// Authenticator serves the endpoints of the [authentication.Authenticator] (login, callback, logout, ...),
+// e.g. `app.All("/auth/*", zitadelfiber.Authenticator(authN))`.
+func Authenticator[T authentication.Ctx](authenticator *authentication.Authenticator[T]) fiber.Handler {
+	return adaptor.HTTPHandler(authenticator)
+}
+
+// UserFromContext returns the authenticated user provided by the [RequireAuthentication]
+// or [CheckAuthentication] handler. It returns the zero value if the user is not authenticated.
+func UserFromContext[T authentication.Ctx](c *fiber.Ctx) T {
+	return authentication.Context[T](c.UserContext())
+}
+
+// IsAuthenticated returns if the user of the request is authenticated.
+func IsAuthenticated(c *fiber.Ctx) bool {
+	return authentication.IsAuthenticated(c.UserContext())
+}
+
+// AuthorizationFromContext returns the authorization provided by the [RequireAuthorization] handler.
+// It returns the zero value if the request is not authorized.
+func AuthorizationFromContext[T authorization.Ctx](c *fiber.Ctx) T {
+	return authorization.Context[T](c.UserContext())
+}
+
+// responseWriter writes the response of the net/http middleware to the [fiber.Ctx].
+type responseWriter struct {
+	c           *fiber.Ctx
+	header      http.Header
+	wroteHeader bool
+}
+
+func (w *responseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *responseWriter) WriteHeader(statusCode int) {
+	if w.wroteHeader {
+		return
+	}
+	w.c.Status(statusCode)
+	w.writeHeader()
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.c.Write(b)
+}
+
+// writeHeader copies the headers to the fasthttp response, at most once.
+func (w *responseWriter) writeHeader() {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	for key, values := range w.header {
+		for _, value := range values {
+			w.c.Response().Header.Add(key, value)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package zitadelfiber
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ctxKey struct{}
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name         string
+		mw           func(next http.Handler) http.Handler
+		wantCode     int
+		wantValue    any
+		wantLocation string
+		wantCookie   bool
+	}{
+		{
+			"next called, context passed",
+			func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+					http.SetCookie(w, &http.Cookie{Name: "session", Value: "refreshed"})
+					next.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), ctxKey{}, "user")))
+				})
+			},
+			http.StatusOK,
+			"user",
+			"",
+			true,
+		},
+		{
+			"next not called",
+			func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+					http.Redirect(w, req, "/auth/login", http.StatusFound)
+				})
+			},
+			http.StatusFound,
+			nil,
+			"/auth/login",
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var value any
+			app := fiber.New()
+			app.Get("/", Middleware(tt.mw), func(c *fiber.Ctx) error {
+				value = c.UserContext().Value(ctxKey{})
+				return c.SendStatus(http.StatusOK)
+			})
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCode, resp.StatusCode)
+			assert.Equal(t, tt.wantValue, value)
+			assert.Equal(t, tt.wantLocation, resp.Header.Get("Location"))
+			assert.Equal(t, tt.wantCookie, len(resp.Cookies()) > 0)
+		})
+	}
+}
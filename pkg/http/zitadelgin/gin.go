@@ -0,0 +1,70 @@
+// Package zitadelgin provides the authentication and authorization middlewares
+// of the [authentication.Interceptor] and [middleware.Interceptor] as [gin.HandlerFunc].
+//
+// The package is a separate module, so the root module does not depend on Gin.
+package zitadelgin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authentication"
+	"github.com/zitadel/zitadel-go/v3/pkg/authorization"
+	"github.com/zitadel/zitadel-go/v3/pkg/http/middleware"
+)
+
+// Middleware converts a net/http middleware into a [gin.HandlerFunc].
+// If the middleware calls the next handler, the (possibly extended) request is set on the [gin.Context]
+// and the remaining handlers of the chain are executed, otherwise (e.g. on a redirect to the Login UI) the chain is aborted.
+func Middleware(mw func(next http.Handler) http.Handler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var next bool
+		mw(http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+			next = true
+			c.Request = req
+			c.Next()
+		})).ServeHTTP(c.Writer, c.Request)
+		if !next {
+			c.Abort()
+		}
+	}
+}
+
+// RequireAuthentication is the [authentication.Interceptor.RequireAuthentication] as [gin.HandlerFunc].
+func RequireAuthentication[T authentication.Ctx](interceptor *authentication.Interceptor[T]) gin.HandlerFunc {
+	return Middleware(interceptor.RequireAuthentication())
+}
+
+// CheckAuthentication is the [authentication.Interceptor.CheckAuthentication] as [gin.HandlerFunc].
+func CheckAuthentication[T authentication.Ctx](interceptor *authentication.Interceptor[T]) gin.HandlerFunc {
+	return Middleware(interceptor.CheckAuthentication())
+}
+
+// RequireAuthorization is the [middleware.Interceptor.RequireAuthorization] as [gin.HandlerFunc].
+func RequireAuthorization[T authorization.Ctx](interceptor *middleware.Interceptor[T], options ...authorization.CheckOption) gin.HandlerFunc {
+	return Middleware(interceptor.RequireAuthorization(options...))
+}
+
+// Authenticator serves the endpoints of the [authentication.Authenticator] (login, callback, logout, ...),
+// e.g. `router.Any("/auth/*path", zitadelgin.Authenticator(authN))`.
+func Authenticator[T authentication.Ctx](authenticator *authentication.Authenticator[T]) gin.HandlerFunc {
+	return gin.WrapH(authenticator)
+}
+
+// UserFromContext returns the authenticated user provided by the [RequireAuthentication]
+// or [CheckAuthentication] handler. It returns the zero value if the user is not authenticated.
+func UserFromContext[T authentication.Ctx](c *gin.Context) T {
+	return authentication.Context[T](c.Request.Context())
+}
+
+// IsAuthenticated returns if the user of the request is authenticated.
+func IsAuthenticated(c *gin.Context) bool {
+	return authentication.IsAuthenticated(c.Request.Context())
+}
+
+// AuthorizationFromContext returns the authorization provided by the [RequireAuthorization] handler.
+// It returns the zero value if the request is not authorized.
+func AuthorizationFromContext[T authorization.Ctx](c *gin.Context) T {
+	return authorization.Context[T](c.Request.Context())
+}
@@ -0,0 +1,64 @@
+package zitadelgin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type ctxKey struct{}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name      string
+		mw        func(next http.Handler) http.Handler
+		wantCode  int
+		wantBody  string
+		wantValue any
+	}{
+		{
+			"next called, context passed",
+			func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+					next.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), ctxKey{}, "user")))
+				})
+			},
+			http.StatusOK,
+			"user",
+			"user",
+		},
+		{
+			"next not called, chain aborted",
+			func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+					http.Redirect(w, req, "/auth/login", http.StatusFound)
+				})
+			},
+			http.StatusFound,
+			"",
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var value any
+			router := gin.New()
+			router.GET("/", Middleware(tt.mw), func(c *gin.Context) {
+				value = c.Request.Context().Value(ctxKey{})
+				c.String(http.StatusOK, "%v", value)
+			})
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+			assert.Equal(t, tt.wantCode, w.Code)
+			assert.Equal(t, tt.wantValue, value)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// Package zitadelgqlgen provides the `@zitadelAuth` directive for gqlgen, to require the authorization
+// (and roles) of the caller per field instead of per HTTP endpoint:
+//
+//	directive @zitadelAuth(roles: [String!]) on FIELD_DEFINITION
+//
+//	type Query {
+//		products: [Product!]!
+//		orders: [Order!]! @zitadelAuth
+//		users: [User!]! @zitadelAuth(roles: ["admin"])
+//	}
+//
+// The directive is registered in the generated config of gqlgen:
+//
+//	cfg := generated.Config{Resolvers: &resolver{}}
+//	cfg.Directives.ZitadelAuth = zitadelgqlgen.Auth
+//	srv := handler.NewDefaultServer(generated.NewExecutableSchema(cfg))
+//	http.Handle("/query", mw.CheckAuthorization()(srv))
+//
+// The authorization context is provided by the CheckAuthorization of the http middleware.Interceptor, which,
+// unlike its RequireAuthorization, does not reject requests to public fields.
+//
+// The package is a separate module, so the root module does not depend on gqlgen.
+package zitadelgqlgen
+
+import (
+	"context"
+	"errors"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authorization"
+)
+
+// Schema is the definition of the directive to be added to the GraphQL schema.
+const Schema = `directive @zitadelAuth(roles: [String!]) on FIELD_DEFINITION`
+
+const (
+	// CodeUnauthenticated is the `code` extension of the error, if the caller is not authorized.
+	CodeUnauthenticated = "UNAUTHENTICATED"
+	// CodeForbidden is the `code` extension of the error, if a role is not granted to the caller.
+	CodeForbidden = "FORBIDDEN"
+)
+
+// Auth implements the `@zitadelAuth(roles: [String!])` directive. It requires the caller to be authorized
+// and to be granted all the roles (if any) using [authorization.Require].
+// Otherwise, the field resolves to an error with the [CodeUnauthenticated], resp. [CodeForbidden] `code` extension.
+func Auth(ctx context.Context, _ interface{}, next graphql.Resolver, roles []string) (interface{}, error) {
+	reqs := make([]authorization.Requirement, len(roles))
+	for i, role := range roles {
+		reqs[i] = authorization.Role(role)
+	}
+	if err := authorization.Require(ctx, reqs...); err != nil {
+		return nil, toError(ctx, err)
+	}
+	return next(ctx)
+}
+
+func toError(ctx context.Context, err error) error {
+	code := CodeForbidden
+	if errors.Is(err, &authorization.UnauthorizedErr{}) {
+		code = CodeUnauthenticated
+	}
+	return &gqlerror.Error{
+		Err:        err,
+		Message:    err.Error(),
+		Path:       graphql.GetPath(ctx),
+		Extensions: map[string]interface{}{"code": code},
+	}
+}
@@ -0,0 +1,69 @@
+package zitadelgqlgen
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authorization"
+)
+
+type testCtx struct {
+	role string
+}
+
+func (t *testCtx) IsAuthorized() bool                              { return t != nil }
+func (t *testCtx) UserID() string                                  { return "userID" }
+func (t *testCtx) IsGrantedRole(role string) bool                  { return role == t.role }
+func (t *testCtx) IsGrantedRoleInOrganization(string, string) bool { return false }
+func (t *testCtx) SetToken(string)                                 {}
+func (t *testCtx) GetToken() string                                { return "" }
+
+func TestAuth(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		roles    []string
+		wantCode string
+	}{
+		{
+			name:     "unauthorized",
+			ctx:      context.Background(),
+			wantCode: CodeUnauthenticated,
+		},
+		{
+			name: "authorized",
+			ctx:  authorization.WithAuthContext(context.Background(), &testCtx{}),
+		},
+		{
+			name:     "missing role",
+			ctx:      authorization.WithAuthContext(context.Background(), &testCtx{}),
+			roles:    []string{"admin"},
+			wantCode: CodeForbidden,
+		},
+		{
+			name:  "granted role",
+			ctx:   authorization.WithAuthContext(context.Background(), &testCtx{role: "admin"}),
+			roles: []string{"admin"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := Auth(tt.ctx, nil, func(context.Context) (interface{}, error) {
+				return "resolved", nil
+			}, tt.roles)
+			if tt.wantCode == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, "resolved", res)
+				return
+			}
+			var gqlErr *gqlerror.Error
+			assert.True(t, errors.As(err, &gqlErr))
+			assert.Equal(t, tt.wantCode, gqlErr.Extensions["code"])
+			assert.Nil(t, res)
+		})
+	}
+}
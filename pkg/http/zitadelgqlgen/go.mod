@@ -0,0 +1,23 @@
+module github.com/zitadel/zitadel-go/pkg/http/zitadelgqlgen
+
+go 1.21
+
+require (
+	github.com/99designs/gqlgen v0.17.49
+	github.com/stretchr/testify v1.9.0
+	github.com/vektah/gqlparser/v2 v2.5.16
+	github.com/zitadel/zitadel-go/v3 v3.0.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/sosodev/duration v1.3.1 // indirect
+	golang.org/x/exp v0.0.0-20240719175910-8a7402abbf56 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// The root module is replaced to develop both together. Dependents ignore the replacement and use the required
+// version, which is set to the released root version when this module is tagged (see build/release-modules.sh).
+replace github.com/zitadel/zitadel-go/v3 => ../../..
@@ -0,0 +1,52 @@
+package notification
+
+// Message is the payload of a notification ZITADEL wants to be delivered by the HTTP provider,
+// e.g. the code to verify an email address or a phone number.
+type Message struct {
+	ContextInfo  ContextInfo    `json:"contextInfo"`
+	TemplateData TemplateData   `json:"templateData"`
+	Args         map[string]any `json:"args,omitempty"`
+}
+
+// ContextInfo describes the notification and its recipient.
+// Depending on the channel, either the RecipientEmailAddress or the RecipientPhoneNumber is set.
+type ContextInfo struct {
+	// EventType is the type of the event triggering the notification, e.g. `user.human.phone.code.added`.
+	EventType             string        `json:"eventType"`
+	Provider              *ProviderInfo `json:"provider,omitempty"`
+	RecipientEmailAddress string        `json:"recipientEmailAddress,omitempty"`
+	RecipientPhoneNumber  string        `json:"recipientPhoneNumber,omitempty"`
+}
+
+// ProviderInfo identifies the HTTP provider (as configured in ZITADEL) calling the endpoint.
+type ProviderInfo struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+}
+
+// TemplateData contains the texts of the message template, already translated into the language of the user,
+// and the branding of the organization. SMS only use the Text.
+type TemplateData struct {
+	Title           string `json:"title,omitempty"`
+	PreHeader       string `json:"preHeader,omitempty"`
+	Subject         string `json:"subject,omitempty"`
+	Greeting        string `json:"greeting,omitempty"`
+	Text            string `json:"text,omitempty"`
+	URL             string `json:"url,omitempty"`
+	ButtonText      string `json:"buttonText,omitempty"`
+	PrimaryColor    string `json:"primaryColor,omitempty"`
+	BackgroundColor string `json:"backgroundColor,omitempty"`
+	FontColor       string `json:"fontColor,omitempty"`
+	LogoURL         string `json:"logoUrl,omitempty"`
+	FontURL         string `json:"fontUrl,omitempty"`
+	FontFamily      string `json:"fontFamily,omitempty"`
+	IncludeFooter   bool   `json:"includeFooter,omitempty"`
+	FooterText      string `json:"footerText,omitempty"`
+}
+
+// Code returns the (one-time) code of the message, e.g. to be sent in a custom template.
+// It's empty for messages without a code or if ZITADEL lets an external provider generate the code.
+func (m *Message) Code() string {
+	code, _ := m.Args["code"].(string)
+	return code
+}
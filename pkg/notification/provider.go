@@ -0,0 +1,133 @@
+// Package notification provides the HTTP handlers to implement the endpoints of the email and SMS HTTP providers
+// of ZITADEL, so notifications can be delivered through a custom pipeline:
+//
+//	provider, err := notification.NewProvider(signingKey)
+//	http.Handle("/notifications/email", provider.Email(func(ctx context.Context, m *notification.Message) error {
+//		return mailer.Send(ctx, m.ContextInfo.RecipientEmailAddress, m.TemplateData.Subject, m.TemplateData.Text)
+//	}))
+//	http.Handle("/notifications/sms", provider.SMS(func(ctx context.Context, m *notification.Message) error {
+//		return gateway.Send(ctx, m.ContextInfo.RecipientPhoneNumber, m.TemplateData.Text)
+//	}))
+//
+// The signature of every call is verified with the signing key of the provider (see the actions package),
+// before the typed [Message] is passed to the handler function.
+//
+// For the configuration of the providers see the helper/notification package.
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/actions"
+)
+
+var (
+	// ErrRejected can be wrapped by the handler functions for messages which cannot be delivered at all,
+	// e.g. an invalid recipient, so it's answered with 400 Bad Request instead of 500 Internal Server Error.
+	ErrRejected = errors.New("notification rejected")
+
+	ErrMissingRecipient = errors.New("missing recipient")
+)
+
+// Handler delivers the message. ZITADEL considers the notification as sent, if no error is returned.
+type Handler func(ctx context.Context, m *Message) error
+
+// Provider provides the handlers for the endpoints of the HTTP providers.
+type Provider struct {
+	signingKey string
+	tolerance  time.Duration
+	unsigned   bool
+}
+
+// Option allows customization of the [Provider].
+type Option func(*Provider)
+
+// WithTolerance allows a maximum age of the signature other than the [actions.DefaultTolerance].
+func WithTolerance(tolerance time.Duration) Option {
+	return func(p *Provider) {
+		p.tolerance = tolerance
+	}
+}
+
+// WithoutSignatureVerification accepts unsigned calls, e.g. of ZITADEL versions not signing the calls
+// of the HTTP providers. The endpoints must then be protected otherwise, e.g. by the network,
+// as anyone reaching them can deliver messages through the pipeline.
+func WithoutSignatureVerification() Option {
+	return func(p *Provider) {
+		p.unsigned = true
+	}
+}
+
+// NewProvider creates a [Provider] verifying the calls with the signing key of the HTTP provider.
+// The signing key is required, unless [WithoutSignatureVerification] is used, otherwise an [actions.ErrMissingSigningKey] is returned.
+func NewProvider(signingKey string, opts ...Option) (*Provider, error) {
+	p := &Provider{
+		signingKey: signingKey,
+		tolerance:  actions.DefaultTolerance,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	if p.signingKey == "" && !p.unsigned {
+		return nil, actions.ErrMissingSigningKey
+	}
+	return p, nil
+}
+
+// Email creates the handler for the endpoint of the email HTTP provider.
+// Messages without a RecipientEmailAddress are rejected.
+func (p *Provider) Email(handle Handler) http.Handler {
+	return p.handler(func(m *Message) bool { return m.ContextInfo.RecipientEmailAddress != "" }, handle)
+}
+
+// SMS creates the handler for the endpoint of the SMS HTTP provider.
+// Messages without a RecipientPhoneNumber are rejected.
+func (p *Provider) SMS(handle Handler) http.Handler {
+	return p.handler(func(m *Message) bool { return m.ContextInfo.RecipientPhoneNumber != "" }, handle)
+}
+
+// handler verifies the signature and decodes the message. The responses are:
+//   - 200 OK, if the handler delivered the message
+//   - 400 Bad Request, if the message is invalid or the handler returned an error wrapping [ErrRejected]
+//   - 401 Unauthorized, if the signature is invalid
+//   - 500 Internal Server Error for any other error of the handler
+//
+// ZITADEL treats every status other than 2xx as a failed delivery.
+func (p *Provider) handler(hasRecipient func(*Message) bool, handle Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, ok := p.readPayload(w, r)
+		if !ok {
+			return
+		}
+		m := new(Message)
+		if err := json.Unmarshal(payload, m); err != nil {
+			http.Error(w, "failed to decode payload: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !hasRecipient(m) {
+			http.Error(w, ErrMissingRecipient.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := handle(r.Context(), m); err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, ErrRejected) {
+				status = http.StatusBadRequest
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// readPayload reads the payload and verifies its signature, unless [WithoutSignatureVerification] is used.
+func (p *Provider) readPayload(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	if p.unsigned {
+		return actions.ReadPayload(w, r)
+	}
+	return actions.ReadSignedPayload(w, r, p.signingKey, p.tolerance)
+}
@@ -0,0 +1,120 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/actions"
+)
+
+const smsPayload = `{
+	"contextInfo":{"eventType":"user.human.phone.code.added","provider":{"id":"1","description":"gateway"},"recipientPhoneNumber":"+41791234567"},
+	"templateData":{"text":"Your code is 123456"},
+	"args":{"code":"123456"}
+}`
+
+func call(t *testing.T, handler http.Handler, payload string, signingKey string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload))
+	if signingKey != "" {
+		req.Header.Set(actions.SigningHeader, actions.ComputeSignature(time.Now(), []byte(payload), signingKey))
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestProvider_SMS(t *testing.T) {
+	var delivered *Message
+	provider, err := NewProvider("key")
+	require.NoError(t, err)
+	handler := provider.SMS(func(_ context.Context, m *Message) error {
+		switch m.ContextInfo.RecipientPhoneNumber {
+		case "+0":
+			return fmt.Errorf("%w: invalid number", ErrRejected)
+		case "+1":
+			return errors.New("gateway unavailable")
+		}
+		delivered = m
+		return nil
+	})
+	tests := []struct {
+		name       string
+		payload    string
+		signingKey string
+		wantStatus int
+	}{
+		{
+			name:       "delivered",
+			payload:    smsPayload,
+			signingKey: "key",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid signature",
+			payload:    smsPayload,
+			signingKey: "wrong",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "missing recipient",
+			payload:    `{"contextInfo":{"recipientEmailAddress":"alice@example.com"},"templateData":{"text":"text"}}`,
+			signingKey: "key",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "rejected",
+			payload:    `{"contextInfo":{"recipientPhoneNumber":"+0"},"templateData":{"text":"text"}}`,
+			signingKey: "key",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "failed",
+			payload:    `{"contextInfo":{"recipientPhoneNumber":"+1"},"templateData":{"text":"text"}}`,
+			signingKey: "key",
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := call(t, handler, tt.payload, tt.signingKey)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+	assert.Equal(t, "gateway", delivered.ContextInfo.Provider.Description)
+	assert.Equal(t, "Your code is 123456", delivered.TemplateData.Text)
+	assert.Equal(t, "123456", delivered.Code())
+}
+
+func TestProvider_Email(t *testing.T) {
+	var delivered *Message
+	provider, err := NewProvider("", WithoutSignatureVerification())
+	require.NoError(t, err)
+	handler := provider.Email(func(_ context.Context, m *Message) error {
+		delivered = m
+		return nil
+	})
+
+	rec := call(t, handler, `{"contextInfo":{"eventType":"user.human.initialization.code.added","recipientEmailAddress":"alice@example.com"},"templateData":{"subject":"Welcome","url":"https://example.com/init"}}`, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "alice@example.com", delivered.ContextInfo.RecipientEmailAddress)
+	assert.Equal(t, "Welcome", delivered.TemplateData.Subject)
+	assert.Empty(t, delivered.Code())
+
+	rec = call(t, handler, smsPayload, "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "sms must not be accepted by the email endpoint")
+}
+
+func TestNewProvider(t *testing.T) {
+	_, err := NewProvider("")
+	assert.ErrorIs(t, err, actions.ErrMissingSigningKey)
+}
@@ -0,0 +1,261 @@
+// Package scim provides a client for the SCIM 2.0 endpoint of ZITADEL, e.g. to provision the users from an HR system,
+// and an embeddable SCIM 2.0 [Server] provisioning the users of an identity provider to ZITADEL:
+//
+//	api, err := client.New(ctx, zitadel.New("your-domain.zitadel.cloud"), client.WithAuth(client.DefaultServiceUserAuthentication(keyPath)))
+//	scimClient := scim.NewClient(api, orgID)
+//	user, err := scimClient.CreateUser(ctx, &scim.User{
+//		UserName: "alice",
+//		Name:     &scim.Name{GivenName: "Alice", FamilyName: "Smith"},
+//		Emails:   []scim.Attribute{{Value: "alice@example.com", Primary: true}},
+//	})
+//
+// The requests are authorized the same way as the gRPC calls of the client.
+package scim
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ContentType is the media type of SCIM requests and responses.
+const ContentType = "application/scim+json"
+
+// API is the part of the [client.Client] used by this package.
+type API interface {
+	Origin() string
+	HTTPClient() *http.Client
+}
+
+// Client calls the SCIM 2.0 endpoint of an organization.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// ClientOption allows customization of the [Client].
+type ClientOption func(*Client)
+
+// WithBaseURL allows a SCIM endpoint other than `{origin}/scim/v2/{orgID}`, e.g. of another service provider.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// NewClient creates a [Client] for the SCIM endpoint of the organization.
+func NewClient(api API, orgID string, opts ...ClientOption) *Client {
+	c := &Client{
+		baseURL:    api.Origin() + "/scim/v2/" + url.PathEscape(orgID),
+		httpClient: api.HTTPClient(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// ListOptions are the query parameters of a list request.
+type ListOptions struct {
+	// Filter, e.g. `userName eq "alice"`, see [Eq].
+	Filter string
+	// StartIndex is the 1-based index of the first result.
+	StartIndex int
+	// Count is the maximum number of results.
+	Count     int
+	SortBy    string
+	SortOrder SortOrder
+	// Attributes limits the returned attributes.
+	Attributes []string
+}
+
+// SortOrder of the list results.
+type SortOrder string
+
+const (
+	Ascending  SortOrder = "ascending"
+	Descending SortOrder = "descending"
+)
+
+func (o *ListOptions) query() url.Values {
+	query := url.Values{}
+	if o == nil {
+		return query
+	}
+	if o.Filter != "" {
+		query.Set("filter", o.Filter)
+	}
+	if o.StartIndex > 0 {
+		query.Set("startIndex", strconv.Itoa(o.StartIndex))
+	}
+	if o.Count > 0 {
+		query.Set("count", strconv.Itoa(o.Count))
+	}
+	if o.SortBy != "" {
+		query.Set("sortBy", o.SortBy)
+	}
+	if o.SortOrder != "" {
+		query.Set("sortOrder", string(o.SortOrder))
+	}
+	if len(o.Attributes) > 0 {
+		query.Set("attributes", strings.Join(o.Attributes, ","))
+	}
+	return query
+}
+
+// Eq creates an equality filter of the attribute, e.g. `userName eq "alice"`.
+func Eq(attribute, value string) string {
+	quoted, _ := json.Marshal(value)
+	return attribute + " eq " + string(quoted)
+}
+
+// CreateUser creates the user and returns it as stored by the service provider.
+func (c *Client) CreateUser(ctx context.Context, user *User) (*User, error) {
+	user.Schemas = withSchema(user.Schemas, UserSchema)
+	return do[User](ctx, c, http.MethodPost, "/Users", nil, user)
+}
+
+// GetUser returns the user by its id.
+func (c *Client) GetUser(ctx context.Context, id string) (*User, error) {
+	return do[User](ctx, c, http.MethodGet, "/Users/"+url.PathEscape(id), nil, nil)
+}
+
+// ReplaceUser replaces all attributes of the user.
+func (c *Client) ReplaceUser(ctx context.Context, user *User) (*User, error) {
+	user.Schemas = withSchema(user.Schemas, UserSchema)
+	return do[User](ctx, c, http.MethodPut, "/Users/"+url.PathEscape(user.ID), nil, user)
+}
+
+// PatchUser modifies the attributes of the user, e.g. to deactivate it:
+//
+//	user, err := c.PatchUser(ctx, id, scim.PatchOperation{Op: scim.PatchReplace, Path: "active", Value: false})
+//
+// Some service providers respond without the modified user, in which case nil is returned.
+func (c *Client) PatchUser(ctx context.Context, id string, operations ...PatchOperation) (*User, error) {
+	return do[User](ctx, c, http.MethodPatch, "/Users/"+url.PathEscape(id), nil, newPatchOp(operations))
+}
+
+// DeleteUser deletes the user.
+func (c *Client) DeleteUser(ctx context.Context, id string) error {
+	_, err := do[User](ctx, c, http.MethodDelete, "/Users/"+url.PathEscape(id), nil, nil)
+	return err
+}
+
+// ListUsers returns a page of the users matching the options.
+func (c *Client) ListUsers(ctx context.Context, opts *ListOptions) (*ListResponse[*User], error) {
+	return do[ListResponse[*User]](ctx, c, http.MethodGet, "/Users", opts.query(), nil)
+}
+
+// CreateGroup creates the group and returns it as stored by the service provider.
+// Note that ZITADEL does not provide groups (yet), these methods are meant for other service providers.
+func (c *Client) CreateGroup(ctx context.Context, group *Group) (*Group, error) {
+	group.Schemas = withSchema(group.Schemas, GroupSchema)
+	return do[Group](ctx, c, http.MethodPost, "/Groups", nil, group)
+}
+
+// GetGroup returns the group by its id.
+func (c *Client) GetGroup(ctx context.Context, id string) (*Group, error) {
+	return do[Group](ctx, c, http.MethodGet, "/Groups/"+url.PathEscape(id), nil, nil)
+}
+
+// ReplaceGroup replaces all attributes of the group.
+func (c *Client) ReplaceGroup(ctx context.Context, group *Group) (*Group, error) {
+	group.Schemas = withSchema(group.Schemas, GroupSchema)
+	return do[Group](ctx, c, http.MethodPut, "/Groups/"+url.PathEscape(group.ID), nil, group)
+}
+
+// PatchGroup modifies the attributes of the group, e.g. to add a member:
+//
+//	group, err := c.PatchGroup(ctx, id, scim.PatchOperation{Op: scim.PatchAdd, Path: "members", Value: []scim.Attribute{{Value: userID}}})
+func (c *Client) PatchGroup(ctx context.Context, id string, operations ...PatchOperation) (*Group, error) {
+	return do[Group](ctx, c, http.MethodPatch, "/Groups/"+url.PathEscape(id), nil, newPatchOp(operations))
+}
+
+// DeleteGroup deletes the group.
+func (c *Client) DeleteGroup(ctx context.Context, id string) error {
+	_, err := do[Group](ctx, c, http.MethodDelete, "/Groups/"+url.PathEscape(id), nil, nil)
+	return err
+}
+
+// ListGroups returns a page of the groups matching the options.
+func (c *Client) ListGroups(ctx context.Context, opts *ListOptions) (*ListResponse[*Group], error) {
+	return do[ListResponse[*Group]](ctx, c, http.MethodGet, "/Groups", opts.query(), nil)
+}
+
+// Bulk executes all operations of the request at once. Failed operations do not fail the request,
+// their status is part of the [BulkResponse].
+func (c *Client) Bulk(ctx context.Context, req *BulkRequest) (*BulkResponse, error) {
+	req.Schemas = withSchema(req.Schemas, BulkRequestMessage)
+	return do[BulkResponse](ctx, c, http.MethodPost, "/Bulk", nil, req)
+}
+
+func newPatchOp(operations []PatchOperation) *PatchOp {
+	return &PatchOp{
+		Schemas:    []string{PatchOpMessage},
+		Operations: operations,
+	}
+}
+
+func withSchema(schemas []string, schema string) []string {
+	for _, s := range schemas {
+		if s == schema {
+			return schemas
+		}
+	}
+	return append([]string{schema}, schemas...)
+}
+
+// do sends the request and decodes the response into T.
+// A response without content (e.g. of a DELETE request) results in nil.
+func do[T any](ctx context.Context, c *Client, method, path string, query url.Values, body any) (*T, error) {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("encode scim request: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", ContentType)
+	if body != nil {
+		req.Header.Set("Content-Type", ContentType)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read scim response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		scimErr := new(Error)
+		if err := json.Unmarshal(data, scimErr); err != nil || scimErr.Status == 0 {
+			scimErr = NewError(resp.StatusCode, "", strings.TrimSpace(string(data)))
+		}
+		return nil, scimErr
+	}
+	if resp.StatusCode == http.StatusNoContent || len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil
+	}
+	result := new(T)
+	if err := json.Unmarshal(data, result); err != nil {
+		return nil, fmt.Errorf("decode scim response: %w", err)
+	}
+	return result, nil
+}
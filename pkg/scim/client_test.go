@@ -0,0 +1,148 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/client"
+)
+
+var _ API = (*client.Client)(nil)
+
+type testAPI struct {
+	origin string
+}
+
+func (a *testAPI) Origin() string {
+	return a.origin
+}
+
+func (a *testAPI) HTTPClient() *http.Client {
+	return http.DefaultClient
+}
+
+type recordedRequest struct {
+	method      string
+	uri         string
+	contentType string
+	body        map[string]any
+}
+
+func newTestServer(t *testing.T, status int, response string) (*Client, *recordedRequest) {
+	t.Helper()
+	recorded := new(recordedRequest)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		recorded.method = r.Method
+		recorded.uri = r.URL.RequestURI()
+		recorded.contentType = r.Header.Get("Content-Type")
+		data, _ := io.ReadAll(r.Body)
+		if len(data) > 0 {
+			require.NoError(t, json.Unmarshal(data, &recorded.body))
+		}
+		w.Header().Set("Content-Type", ContentType)
+		w.WriteHeader(status)
+		w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	return NewClient(&testAPI{origin: server.URL}, "org"), recorded
+}
+
+func TestClient_CreateUser(t *testing.T) {
+	c, recorded := newTestServer(t, http.StatusCreated, `{"schemas":["`+UserSchema+`"],"id":"1","userName":"alice","emails":[{"value":"alice@example.com"}]}`)
+	user, err := c.CreateUser(context.Background(), &User{
+		UserName: "alice",
+		Emails:   []Attribute{{Value: "alice@example.com"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "1", user.ID)
+	assert.Equal(t, "alice@example.com", user.PrimaryEmail())
+	assert.Equal(t, http.MethodPost, recorded.method)
+	assert.Equal(t, "/scim/v2/org/Users", recorded.uri)
+	assert.Equal(t, ContentType, recorded.contentType)
+	assert.Equal(t, []any{UserSchema}, recorded.body["schemas"])
+	assert.Equal(t, "alice", recorded.body["userName"])
+}
+
+func TestClient_ListUsers(t *testing.T) {
+	c, recorded := newTestServer(t, http.StatusOK, `{"schemas":["`+ListResponseMessage+`"],"totalResults":2,"startIndex":1,"itemsPerPage":1,"Resources":[{"id":"1","userName":"alice"}]}`)
+	list, err := c.ListUsers(context.Background(), &ListOptions{Filter: Eq("userName", `al"ice`), Count: 1, StartIndex: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 2, list.TotalResults)
+	require.Len(t, list.Resources, 1)
+	assert.Equal(t, "alice", list.Resources[0].UserName)
+	assert.Equal(t, "/scim/v2/org/Users?count=1&filter=userName+eq+%22al%5C%22ice%22&startIndex=1", recorded.uri)
+}
+
+func TestClient_PatchUser(t *testing.T) {
+	c, recorded := newTestServer(t, http.StatusNoContent, "")
+	user, err := c.PatchUser(context.Background(), "1", PatchOperation{Op: PatchReplace, Path: "active", Value: false})
+	require.NoError(t, err)
+	assert.Nil(t, user)
+	assert.Equal(t, http.MethodPatch, recorded.method)
+	assert.Equal(t, "/scim/v2/org/Users/1", recorded.uri)
+	assert.Equal(t, map[string]any{
+		"schemas":    []any{PatchOpMessage},
+		"Operations": []any{map[string]any{"op": "replace", "path": "active", "value": false}},
+	}, recorded.body)
+}
+
+func TestClient_Bulk(t *testing.T) {
+	c, recorded := newTestServer(t, http.StatusOK, `{"schemas":["`+BulkResponseMessage+`"],"Operations":[{"method":"POST","bulkId":"alice","location":"/Users/1","status":"201"}]}`)
+	resp, err := c.Bulk(context.Background(), &BulkRequest{
+		Operations: []BulkOperation{{Method: http.MethodPost, BulkID: "alice", Path: "/Users", Data: &User{Schemas: []string{UserSchema}, UserName: "alice"}}},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Operations, 1)
+	assert.Equal(t, "201", resp.Operations[0].Status)
+	assert.Equal(t, "/scim/v2/org/Bulk", recorded.uri)
+	assert.Equal(t, []any{BulkRequestMessage}, recorded.body["schemas"])
+}
+
+func TestClient_errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		response string
+		want     error
+		wantType string
+	}{
+		{
+			name:     "not found",
+			status:   http.StatusNotFound,
+			response: `{"schemas":["` + ErrorMessage + `"],"status":"404","detail":"user not found"}`,
+			want:     ErrNotFound,
+		},
+		{
+			name:     "conflict with numeric status",
+			status:   http.StatusConflict,
+			response: `{"schemas":["` + ErrorMessage + `"],"status":409,"scimType":"uniqueness"}`,
+			want:     ErrConflict,
+			wantType: "uniqueness",
+		},
+		{
+			name:     "no scim error",
+			status:   http.StatusBadRequest,
+			response: `bad request`,
+			want:     ErrInvalidValue,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := newTestServer(t, tt.status, tt.response)
+			_, err := c.GetUser(context.Background(), "1")
+			assert.ErrorIs(t, err, tt.want)
+			var scimErr *Error
+			require.True(t, errors.As(err, &scimErr))
+			assert.Equal(t, Status(tt.status), scimErr.Status)
+			assert.Equal(t, tt.wantType, scimErr.ScimType)
+		})
+	}
+}
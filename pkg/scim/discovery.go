@@ -0,0 +1,158 @@
+package scim
+
+// Schemas of the discovery resources (RFC 7643, section 8.7).
+const (
+	ServiceProviderConfigSchema = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	ResourceTypeSchema          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	SchemaSchema                = "urn:ietf:params:scim:schemas:core:2.0:Schema"
+)
+
+type supported struct {
+	Supported bool `json:"supported"`
+}
+
+type filterSupported struct {
+	Supported  bool `json:"supported"`
+	MaxResults int  `json:"maxResults"`
+}
+
+type bulkSupported struct {
+	Supported      bool `json:"supported"`
+	MaxOperations  int  `json:"maxOperations"`
+	MaxPayloadSize int  `json:"maxPayloadSize"`
+}
+
+type authenticationScheme struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type serviceProviderConfig struct {
+	Schemas               []string               `json:"schemas"`
+	Patch                 supported              `json:"patch"`
+	Bulk                  bulkSupported          `json:"bulk"`
+	Filter                filterSupported        `json:"filter"`
+	ChangePassword        supported              `json:"changePassword"`
+	Sort                  supported              `json:"sort"`
+	ETag                  supported              `json:"etag"`
+	AuthenticationSchemes []authenticationScheme `json:"authenticationSchemes"`
+	Meta                  *Meta                  `json:"meta"`
+}
+
+func (s *Server) serviceProviderConfig() *serviceProviderConfig {
+	return &serviceProviderConfig{
+		Schemas:        []string{ServiceProviderConfigSchema},
+		Patch:          supported{Supported: true},
+		Filter:         filterSupported{Supported: true, MaxResults: s.maxResults},
+		ChangePassword: supported{Supported: true},
+		AuthenticationSchemes: []authenticationScheme{{
+			Type:        "oauthbearertoken",
+			Name:        "OAuth Bearer Token",
+			Description: "Authentication with an access token issued by ZITADEL",
+		}},
+		Meta: &Meta{ResourceType: "ServiceProviderConfig", Location: s.baseURL + "/ServiceProviderConfig"},
+	}
+}
+
+type resourceType struct {
+	Schemas     []string `json:"schemas"`
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Endpoint    string   `json:"endpoint"`
+	Description string   `json:"description"`
+	Schema      string   `json:"schema"`
+	Meta        *Meta    `json:"meta"`
+}
+
+func (s *Server) userResourceType() *resourceType {
+	return &resourceType{
+		Schemas:     []string{ResourceTypeSchema},
+		ID:          "User",
+		Name:        "User",
+		Endpoint:    "/Users",
+		Description: "Human user of the ZITADEL organization",
+		Schema:      UserSchema,
+		Meta:        &Meta{ResourceType: "ResourceType", Location: s.baseURL + "/ResourceTypes/User"},
+	}
+}
+
+type schema struct {
+	Schemas     []string          `json:"schemas"`
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Attributes  []schemaAttribute `json:"attributes"`
+	Meta        *Meta             `json:"meta"`
+}
+
+type schemaAttribute struct {
+	Name          string            `json:"name"`
+	Type          string            `json:"type"`
+	MultiValued   bool              `json:"multiValued"`
+	Required      bool              `json:"required"`
+	CaseExact     bool              `json:"caseExact"`
+	Mutability    string            `json:"mutability"`
+	Returned      string            `json:"returned"`
+	Uniqueness    string            `json:"uniqueness"`
+	SubAttributes []schemaAttribute `json:"subAttributes,omitempty"`
+}
+
+func stringAttribute(name string) schemaAttribute {
+	return schemaAttribute{Name: name, Type: "string", Mutability: "readWrite", Returned: "default", Uniqueness: "none"}
+}
+
+func multiValuedAttribute(name string) schemaAttribute {
+	return schemaAttribute{
+		Name:        name,
+		Type:        "complex",
+		MultiValued: true,
+		Mutability:  "readWrite",
+		Returned:    "default",
+		Uniqueness:  "none",
+		SubAttributes: []schemaAttribute{
+			stringAttribute("value"),
+			stringAttribute("type"),
+			{Name: "primary", Type: "boolean", Mutability: "readWrite", Returned: "default", Uniqueness: "none"},
+		},
+	}
+}
+
+// userSchema describes the attributes of the core user schema supported by the [Server].
+func (s *Server) userSchema() *schema {
+	userName := stringAttribute("userName")
+	userName.Required = true
+	userName.Uniqueness = "server"
+	password := stringAttribute("password")
+	password.Mutability = "writeOnly"
+	password.Returned = "never"
+	return &schema{
+		Schemas:     []string{SchemaSchema},
+		ID:          UserSchema,
+		Name:        "User",
+		Description: "User Account",
+		Attributes: []schemaAttribute{
+			userName,
+			{
+				Name:       "name",
+				Type:       "complex",
+				Mutability: "readWrite",
+				Returned:   "default",
+				Uniqueness: "none",
+				SubAttributes: []schemaAttribute{
+					stringAttribute("formatted"),
+					stringAttribute("givenName"),
+					stringAttribute("familyName"),
+				},
+			},
+			stringAttribute("displayName"),
+			stringAttribute("nickName"),
+			stringAttribute("preferredLanguage"),
+			{Name: "active", Type: "boolean", Mutability: "readWrite", Returned: "default", Uniqueness: "none"},
+			password,
+			multiValuedAttribute("emails"),
+			multiValuedAttribute("phoneNumbers"),
+		},
+		Meta: &Meta{ResourceType: "Schema", Location: s.baseURL + "/Schemas/" + UserSchema},
+	}
+}
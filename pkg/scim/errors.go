@@ -0,0 +1,85 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+var (
+	ErrNotFound     = errors.New("scim resource not found")
+	ErrConflict     = errors.New("scim resource already exists")
+	ErrInvalidValue = errors.New("invalid scim value")
+)
+
+// Error is the error response of a SCIM service provider. Use [errors.Is] with [ErrNotFound], [ErrConflict]
+// and [ErrInvalidValue] for the common cases, or [errors.As] for the details.
+type Error struct {
+	Schemas []string `json:"schemas"`
+	// Status is the HTTP status code, e.g. `404`.
+	Status Status `json:"status"`
+	// ScimType is the detail error keyword, e.g. `uniqueness` or `invalidFilter`.
+	ScimType string `json:"scimType,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+}
+
+// NewError creates an [Error] for the status code.
+func NewError(status int, scimType, detail string) *Error {
+	return &Error{
+		Schemas:  []string{ErrorMessage},
+		Status:   Status(status),
+		ScimType: scimType,
+		Detail:   detail,
+	}
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("scim error %d", e.Status)
+	if e.ScimType != "" {
+		msg += " (" + e.ScimType + ")"
+	}
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	return msg
+}
+
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.Status == http.StatusNotFound
+	case ErrConflict:
+		return e.Status == http.StatusConflict
+	case ErrInvalidValue:
+		return e.Status == http.StatusBadRequest
+	}
+	return false
+}
+
+// Status is an HTTP status code. RFC 7644 defines it as string,
+// but it's accepted as number as well, since some service providers send it as such.
+type Status int
+
+func (s Status) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strconv.Itoa(int(s)))
+}
+
+func (s *Status) UnmarshalJSON(data []byte) error {
+	var code int
+	if err := json.Unmarshal(data, &code); err == nil {
+		*s = Status(code)
+		return nil
+	}
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	code, err := strconv.Atoi(str)
+	if err != nil {
+		return fmt.Errorf("invalid status `%s`: %w", str, err)
+	}
+	*s = Status(code)
+	return nil
+}
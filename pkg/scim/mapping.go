@@ -0,0 +1,362 @@
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	object "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object/v2"
+	user "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user/v2"
+)
+
+func (s *Server) addHumanUserRequest(u *User) *user.AddHumanUserRequest {
+	req := &user.AddHumanUserRequest{
+		Username:     &u.UserName,
+		Organization: &object.Organization{Org: &object.Organization_OrgId{OrgId: s.orgID}},
+		Profile:      humanProfile(u),
+		// ZITADEL requires an email address, the userName is used if none is provisioned
+		Email: &user.SetHumanEmail{Email: u.UserName, Verification: &user.SetHumanEmail_IsVerified{IsVerified: true}},
+	}
+	if email := u.PrimaryEmail(); email != "" {
+		req.Email.Email = email
+	}
+	if phone := u.PrimaryPhoneNumber(); phone != "" {
+		req.Phone = &user.SetHumanPhone{Phone: phone, Verification: &user.SetHumanPhone_IsVerified{IsVerified: true}}
+	}
+	if u.Password != "" {
+		req.PasswordType = &user.AddHumanUserRequest_Password{Password: &user.Password{Password: u.Password}}
+	}
+	return req
+}
+
+// humanProfile maps the names of the user. Since ZITADEL requires a given and a family name,
+// the display name, resp. the userName is used for missing ones.
+func humanProfile(u *User) *user.SetHumanProfile {
+	fallback := u.DisplayName
+	if fallback == "" {
+		fallback = u.UserName
+	}
+	profile := &user.SetHumanProfile{
+		GivenName:  fallback,
+		FamilyName: fallback,
+	}
+	if u.Name != nil && u.Name.GivenName != "" {
+		profile.GivenName = u.Name.GivenName
+	}
+	if u.Name != nil && u.Name.FamilyName != "" {
+		profile.FamilyName = u.Name.FamilyName
+	}
+	if u.DisplayName != "" {
+		profile.DisplayName = &u.DisplayName
+	}
+	if u.NickName != "" {
+		profile.NickName = &u.NickName
+	}
+	if u.PreferredLanguage != "" {
+		profile.PreferredLanguage = &u.PreferredLanguage
+	}
+	return profile
+}
+
+func profileEqual(a, b *user.SetHumanProfile) bool {
+	return proto.Equal(a, b)
+}
+
+func (s *Server) toSCIM(u *user.User) *User {
+	human := u.GetHuman()
+	profile := human.GetProfile()
+	active := u.GetState() == user.UserState_USER_STATE_ACTIVE || u.GetState() == user.UserState_USER_STATE_INITIAL
+	scimUser := &User{
+		Schemas:  []string{UserSchema},
+		ID:       u.GetUserId(),
+		UserName: u.GetUsername(),
+		Name: &Name{
+			Formatted:  profile.GetDisplayName(),
+			GivenName:  profile.GetGivenName(),
+			FamilyName: profile.GetFamilyName(),
+		},
+		DisplayName:       profile.GetDisplayName(),
+		NickName:          profile.GetNickName(),
+		PreferredLanguage: profile.GetPreferredLanguage(),
+		Active:            &active,
+		Meta: &Meta{
+			ResourceType: "User",
+			Location:     s.baseURL + "/Users/" + u.GetUserId(),
+			Version:      `W/"` + strconv.FormatUint(u.GetDetails().GetSequence(), 10) + `"`,
+		},
+	}
+	if changed := u.GetDetails().GetChangeDate(); changed != nil {
+		lastModified := changed.AsTime()
+		scimUser.Meta.LastModified = &lastModified
+	}
+	if email := human.GetEmail().GetEmail(); email != "" {
+		scimUser.Emails = []Attribute{{Value: email, Type: "work", Primary: true}}
+	}
+	if phone := human.GetPhone().GetPhone(); phone != "" {
+		scimUser.PhoneNumbers = []Attribute{{Value: phone, Type: "work", Primary: true}}
+	}
+	return scimUser
+}
+
+var filterExpression = regexp.MustCompile(`^([A-Za-z][\w.]*)\s+(?i:eq)\s+("(?:[^"\\]|\\.)*")\s*`)
+
+var filterConjunction = regexp.MustCompile(`^(?i:and)\s+`)
+
+// parseFilter maps a filter of equality expressions joined by `and`, e.g. `userName eq "alice"`,
+// to the search queries of ZITADEL. Other filters result in an `invalidFilter` error.
+func parseFilter(filter string) ([]*user.SearchQuery, error) {
+	var queries []*user.SearchQuery
+	rest := strings.TrimSpace(filter)
+	for rest != "" {
+		match := filterExpression.FindStringSubmatch(rest)
+		if match == nil {
+			return nil, NewError(http.StatusBadRequest, "invalidFilter", "unsupported filter `"+filter+"`")
+		}
+		var value string
+		if err := json.Unmarshal([]byte(match[2]), &value); err != nil {
+			return nil, NewError(http.StatusBadRequest, "invalidFilter", "invalid value in filter `"+filter+"`")
+		}
+		query, err := searchQuery(match[1], value)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, query)
+		rest = rest[len(match[0]):]
+		if rest != "" {
+			conjunction := filterConjunction.FindString(rest)
+			if conjunction == "" {
+				return nil, NewError(http.StatusBadRequest, "invalidFilter", "unsupported filter `"+filter+"`")
+			}
+			rest = rest[len(conjunction):]
+		}
+	}
+	return queries, nil
+}
+
+func searchQuery(attribute, value string) (*user.SearchQuery, error) {
+	method := object.TextQueryMethod_TEXT_QUERY_METHOD_EQUALS_IGNORE_CASE
+	switch strings.ToLower(attribute) {
+	case "id":
+		return &user.SearchQuery{Query: &user.SearchQuery_InUserIdsQuery{InUserIdsQuery: &user.InUserIDQuery{UserIds: []string{value}}}}, nil
+	case "username":
+		return &user.SearchQuery{Query: &user.SearchQuery_UserNameQuery{UserNameQuery: &user.UserNameQuery{UserName: value, Method: method}}}, nil
+	case "emails", "emails.value":
+		return &user.SearchQuery{Query: &user.SearchQuery_EmailQuery{EmailQuery: &user.EmailQuery{EmailAddress: value, Method: method}}}, nil
+	case "displayname":
+		return &user.SearchQuery{Query: &user.SearchQuery_DisplayNameQuery{DisplayNameQuery: &user.DisplayNameQuery{DisplayName: value, Method: method}}}, nil
+	case "name.givenname":
+		return &user.SearchQuery{Query: &user.SearchQuery_FirstNameQuery{FirstNameQuery: &user.FirstNameQuery{FirstName: value, Method: method}}}, nil
+	case "name.familyname":
+		return &user.SearchQuery{Query: &user.SearchQuery_LastNameQuery{LastNameQuery: &user.LastNameQuery{LastName: value, Method: method}}}, nil
+	default:
+		return nil, NewError(http.StatusBadRequest, "invalidFilter", "unsupported filter attribute `"+attribute+"`")
+	}
+}
+
+// applyPatch applies the operations to a copy of the user. The attribute names are case-insensitive,
+// values of the `active` attribute are accepted as string as well (e.g. `"False"` sent by Microsoft Entra ID).
+func applyPatch(current *User, operations []PatchOperation) (*User, error) {
+	data, err := json.Marshal(current)
+	if err != nil {
+		return nil, err
+	}
+	attributes := make(map[string]any)
+	if err = json.Unmarshal(data, &attributes); err != nil {
+		return nil, err
+	}
+	for _, operation := range operations {
+		if err = applyOperation(attributes, operation); err != nil {
+			return nil, err
+		}
+	}
+	if key := findKey(attributes, "active"); key != "" {
+		if active, ok := attributes[key].(string); ok {
+			attributes[key], err = strconv.ParseBool(active)
+			if err != nil {
+				return nil, NewError(http.StatusBadRequest, "invalidValue", "invalid value of active: "+active)
+			}
+		}
+	}
+	if data, err = json.Marshal(attributes); err != nil {
+		return nil, err
+	}
+	patched := new(User)
+	if err = json.Unmarshal(data, patched); err != nil {
+		return nil, NewError(http.StatusBadRequest, "invalidValue", err.Error())
+	}
+	return patched, nil
+}
+
+func applyOperation(attributes map[string]any, operation PatchOperation) error {
+	op := strings.ToLower(operation.Op)
+	if op != PatchAdd && op != PatchRemove && op != PatchReplace {
+		return NewError(http.StatusBadRequest, "invalidSyntax", "invalid operation `"+operation.Op+"`")
+	}
+	if operation.Path != "" {
+		return applyPath(attributes, op, operation.Path, operation.Value)
+	}
+	if op == PatchRemove {
+		return NewError(http.StatusBadRequest, "noTarget", "remove operation requires a path")
+	}
+	values, ok := operation.Value.(map[string]any)
+	if !ok {
+		return NewError(http.StatusBadRequest, "invalidValue", "operation without path requires an object value")
+	}
+	for path, value := range values {
+		if err := applyPath(attributes, op, path, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var pathExpression = regexp.MustCompile(`^([A-Za-z][\w$-]*)(?:\[\s*([A-Za-z][\w.]*)\s+(?i:eq)\s+("(?:[^"\\]|\\.)*"|true|false)\s*\])?(?:\.([A-Za-z][\w$-]*))?$`)
+
+// applyPath applies the operation to the attribute of the path, which is either
+// an attribute (`active`), a sub-attribute (`name.givenName`) or a filtered multi-valued attribute
+// (`emails[type eq "work"].value`), optionally prefixed by the URN of the (extension) schema.
+func applyPath(attributes map[string]any, op, path string, value any) error {
+	if rest, ok := cutPrefixFold(path, EnterpriseUserSchema+":"); ok {
+		extension := subAttributes(attributes, EnterpriseUserSchema, op != PatchRemove)
+		if extension == nil {
+			return nil
+		}
+		return applyPath(extension, op, rest, value)
+	}
+	if rest, ok := cutPrefixFold(path, UserSchema+":"); ok {
+		path = rest
+	}
+	if strings.EqualFold(path, EnterpriseUserSchema) {
+		return applyValue(attributes, op, EnterpriseUserSchema, value)
+	}
+	match := pathExpression.FindStringSubmatch(path)
+	if match == nil {
+		return NewError(http.StatusBadRequest, "invalidPath", "unsupported path `"+path+"`")
+	}
+	attribute, filterAttribute, filterValue, sub := match[1], match[2], match[3], match[4]
+	if filterAttribute == "" {
+		if sub == "" {
+			return applyValue(attributes, op, attribute, value)
+		}
+		container := subAttributes(attributes, attribute, op != PatchRemove)
+		if container == nil {
+			return nil
+		}
+		return applyValue(container, op, sub, value)
+	}
+	var expected any
+	if err := json.Unmarshal([]byte(filterValue), &expected); err != nil {
+		return NewError(http.StatusBadRequest, "invalidPath", "invalid filter value in path `"+path+"`")
+	}
+	key := findKey(attributes, attribute)
+	if key == "" {
+		key = attribute
+	}
+	elements, _ := attributes[key].([]any)
+	matched := false
+	remaining := elements[:0:0]
+	for _, element := range elements {
+		e, ok := element.(map[string]any)
+		if !ok || fmt.Sprint(e[findKey(e, filterAttribute)]) != fmt.Sprint(expected) {
+			remaining = append(remaining, element)
+			continue
+		}
+		matched = true
+		switch {
+		case op == PatchRemove && sub == "":
+			continue
+		case sub == "":
+			if v, ok := value.(map[string]any); ok {
+				for k, val := range v {
+					e[k] = val
+				}
+			}
+		default:
+			if err := applyValue(e, op, sub, value); err != nil {
+				return err
+			}
+		}
+		remaining = append(remaining, e)
+	}
+	if !matched && op != PatchRemove {
+		e := map[string]any{filterAttribute: expected}
+		if sub == "" {
+			if v, ok := value.(map[string]any); ok {
+				for k, val := range v {
+					e[k] = val
+				}
+			}
+		} else {
+			e[sub] = value
+		}
+		remaining = append(remaining, e)
+	}
+	attributes[key] = remaining
+	return nil
+}
+
+func applyValue(attributes map[string]any, op, attribute string, value any) error {
+	key := findKey(attributes, attribute)
+	switch op {
+	case PatchRemove:
+		if key != "" {
+			delete(attributes, key)
+		}
+		return nil
+	case PatchAdd:
+		if key != "" {
+			if existing, ok := attributes[key].([]any); ok {
+				if values, ok := value.([]any); ok {
+					attributes[key] = append(existing, values...)
+					return nil
+				}
+			}
+		}
+	}
+	if key == "" {
+		key = attribute
+	}
+	attributes[key] = value
+	return nil
+}
+
+// subAttributes returns the complex attribute, which is created if missing and create is set.
+func subAttributes(attributes map[string]any, attribute string, create bool) map[string]any {
+	key := findKey(attributes, attribute)
+	if key != "" {
+		if container, ok := attributes[key].(map[string]any); ok {
+			return container
+		}
+	}
+	if !create {
+		return nil
+	}
+	if key == "" {
+		key = attribute
+	}
+	container := make(map[string]any)
+	attributes[key] = container
+	return container
+}
+
+// findKey returns the key of the attribute matching the name case-insensitively or an empty string.
+func findKey(attributes map[string]any, name string) string {
+	for key := range attributes {
+		if strings.EqualFold(key, name) {
+			return key
+		}
+	}
+	return ""
+}
+
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) < len(prefix) || !strings.EqualFold(s[:len(prefix)], prefix) {
+		return s, false
+	}
+	return s[len(prefix):], true
+}
@@ -0,0 +1,182 @@
+package scim
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Schemas and messages of SCIM 2.0 (RFC 7643 and RFC 7644).
+const (
+	UserSchema           = "urn:ietf:params:scim:schemas:core:2.0:User"
+	GroupSchema          = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	EnterpriseUserSchema = "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"
+	ListResponseMessage  = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	PatchOpMessage       = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	BulkRequestMessage   = "urn:ietf:params:scim:api:messages:2.0:BulkRequest"
+	BulkResponseMessage  = "urn:ietf:params:scim:api:messages:2.0:BulkResponse"
+	ErrorMessage         = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+// User is a resource of the core user schema, optionally with the enterprise user extension.
+type User struct {
+	Schemas           []string        `json:"schemas"`
+	ID                string          `json:"id,omitempty"`
+	ExternalID        string          `json:"externalId,omitempty"`
+	UserName          string          `json:"userName"`
+	Name              *Name           `json:"name,omitempty"`
+	DisplayName       string          `json:"displayName,omitempty"`
+	NickName          string          `json:"nickName,omitempty"`
+	ProfileURL        string          `json:"profileUrl,omitempty"`
+	Title             string          `json:"title,omitempty"`
+	UserType          string          `json:"userType,omitempty"`
+	PreferredLanguage string          `json:"preferredLanguage,omitempty"`
+	Locale            string          `json:"locale,omitempty"`
+	Timezone          string          `json:"timezone,omitempty"`
+	Active            *bool           `json:"active,omitempty"`
+	Password          string          `json:"password,omitempty"`
+	Emails            []Attribute     `json:"emails,omitempty"`
+	PhoneNumbers      []Attribute     `json:"phoneNumbers,omitempty"`
+	Groups            []Attribute     `json:"groups,omitempty"`
+	Roles             []Attribute     `json:"roles,omitempty"`
+	Enterprise        *EnterpriseUser `json:"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User,omitempty"`
+	Meta              *Meta           `json:"meta,omitempty"`
+}
+
+// Name is the name of a [User].
+type Name struct {
+	Formatted       string `json:"formatted,omitempty"`
+	FamilyName      string `json:"familyName,omitempty"`
+	GivenName       string `json:"givenName,omitempty"`
+	MiddleName      string `json:"middleName,omitempty"`
+	HonorificPrefix string `json:"honorificPrefix,omitempty"`
+	HonorificSuffix string `json:"honorificSuffix,omitempty"`
+}
+
+// EnterpriseUser is the enterprise extension of a [User].
+type EnterpriseUser struct {
+	EmployeeNumber string     `json:"employeeNumber,omitempty"`
+	CostCenter     string     `json:"costCenter,omitempty"`
+	Organization   string     `json:"organization,omitempty"`
+	Division       string     `json:"division,omitempty"`
+	Department     string     `json:"department,omitempty"`
+	Manager        *Attribute `json:"manager,omitempty"`
+}
+
+// Attribute is a value of a multi-valued attribute, e.g. an email of a [User] or a member of a [Group].
+type Attribute struct {
+	Value   string `json:"value,omitempty"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+// PrimaryEmail returns the primary email of the user, resp. the first one if none is marked as primary.
+func (u *User) PrimaryEmail() string {
+	return primary(u.Emails)
+}
+
+// PrimaryPhoneNumber returns the primary phone number of the user, resp. the first one if none is marked as primary.
+func (u *User) PrimaryPhoneNumber() string {
+	return primary(u.PhoneNumbers)
+}
+
+func primary(attributes []Attribute) string {
+	for _, attribute := range attributes {
+		if attribute.Primary {
+			return attribute.Value
+		}
+	}
+	if len(attributes) > 0 {
+		return attributes[0].Value
+	}
+	return ""
+}
+
+// Group is a resource of the core group schema.
+type Group struct {
+	Schemas     []string    `json:"schemas"`
+	ID          string      `json:"id,omitempty"`
+	ExternalID  string      `json:"externalId,omitempty"`
+	DisplayName string      `json:"displayName"`
+	Members     []Attribute `json:"members,omitempty"`
+	Meta        *Meta       `json:"meta,omitempty"`
+}
+
+// Meta contains the metadata of a resource set by the service provider.
+type Meta struct {
+	ResourceType string     `json:"resourceType,omitempty"`
+	Created      *time.Time `json:"created,omitempty"`
+	LastModified *time.Time `json:"lastModified,omitempty"`
+	Location     string     `json:"location,omitempty"`
+	Version      string     `json:"version,omitempty"`
+}
+
+// ListResponse is a page of the resources matching a query.
+type ListResponse[T any] struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex,omitempty"`
+	ItemsPerPage int      `json:"itemsPerPage,omitempty"`
+	Resources    []T      `json:"Resources"`
+}
+
+// PatchOp is the body of a PATCH request.
+type PatchOp struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+// PatchOperation is a single modification of a PATCH request, e.g.:
+//
+//	scim.PatchOperation{Op: scim.PatchReplace, Path: "active", Value: false}
+type PatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path,omitempty"`
+	Value any    `json:"value,omitempty"`
+}
+
+// Operations of a [PatchOperation].
+const (
+	PatchAdd     = "add"
+	PatchRemove  = "remove"
+	PatchReplace = "replace"
+)
+
+// BulkRequest contains multiple operations executed by a single request.
+type BulkRequest struct {
+	Schemas []string `json:"schemas"`
+	// FailOnErrors is the number of errors after which the remaining operations are not executed.
+	// Zero executes all operations.
+	FailOnErrors int             `json:"failOnErrors,omitempty"`
+	Operations   []BulkOperation `json:"Operations"`
+}
+
+// BulkOperation is a single operation of a [BulkRequest], e.g. the creation of a [User]:
+//
+//	scim.BulkOperation{Method: http.MethodPost, BulkID: "alice", Path: "/Users", Data: user}
+type BulkOperation struct {
+	Method string `json:"method"`
+	// BulkID allows referencing a resource created by another operation of the same request as `bulkId:{id}`.
+	BulkID  string `json:"bulkId,omitempty"`
+	Version string `json:"version,omitempty"`
+	Path    string `json:"path"`
+	Data    any    `json:"data,omitempty"`
+}
+
+// BulkResponse contains the results of the operations of a [BulkRequest].
+type BulkResponse struct {
+	Schemas    []string              `json:"schemas"`
+	Operations []BulkOperationResult `json:"Operations"`
+}
+
+// BulkOperationResult is the result of a single [BulkOperation].
+type BulkOperationResult struct {
+	Method   string `json:"method"`
+	BulkID   string `json:"bulkId,omitempty"`
+	Version  string `json:"version,omitempty"`
+	Location string `json:"location,omitempty"`
+	// Status is the HTTP status code of the operation, e.g. `201`.
+	Status   string          `json:"status"`
+	Response json.RawMessage `json:"response,omitempty"`
+}
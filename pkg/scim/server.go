@@ -0,0 +1,373 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	object "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object/v2"
+	user "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user/v2"
+)
+
+// maxRequestSize limits the size of the body read from the request.
+const maxRequestSize = 1 << 20
+
+// UserService is the part of the [client.Client] used by the [Server].
+type UserService interface {
+	UserServiceV2() user.UserServiceClient
+}
+
+// Server is an embeddable SCIM 2.0 service provider, which maps the provisioning requests
+// of an identity provider (e.g. Okta or Microsoft Entra ID) to the (human) users of a ZITADEL organization:
+//
+//	server := scim.NewServer(api, orgID, scim.WithServerBaseURL("https://app.example.com/scim/v2"))
+//	http.Handle("/scim/v2/", http.StripPrefix("/scim/v2", mw.RequireAuthorization()(server)))
+//
+// It serves the `Users` resources and the discovery endpoints (`ServiceProviderConfig`, `ResourceTypes` and `Schemas`).
+// The server does not authorize the requests itself, use e.g. the authorization middleware of the http/middleware package.
+//
+// Emails and phone numbers provisioned by the identity provider are considered verified.
+// The `externalId` is not stored, identity providers must match the users by their `userName`.
+type Server struct {
+	users      UserService
+	orgID      string
+	baseURL    string
+	maxResults int
+}
+
+// ServerOption allows customization of the [Server].
+type ServerOption func(*Server)
+
+// WithServerBaseURL sets the URL the server is reachable at, used for the `location` of the resources.
+func WithServerBaseURL(baseURL string) ServerOption {
+	return func(s *Server) {
+		s.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithMaxResults sets the maximum number of resources returned by a list request (default 100).
+func WithMaxResults(maxResults int) ServerOption {
+	return func(s *Server) {
+		s.maxResults = maxResults
+	}
+}
+
+// NewServer creates a [Server] provisioning the users of the organization.
+func NewServer(users UserService, orgID string, opts ...ServerOption) *Server {
+	s := &Server{
+		users:      users,
+		orgID:      orgID,
+		maxResults: 100,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// ServeHTTP implements [http.Handler].
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	resource, id, _ := strings.Cut(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case resource == "ServiceProviderConfig" && id == "":
+		s.serveDiscovery(w, r, s.serviceProviderConfig())
+	case resource == "ResourceTypes" && id == "":
+		s.serveDiscovery(w, r, newListResponse([]any{s.userResourceType()}, 1, 1))
+	case resource == "ResourceTypes" && id == "User":
+		s.serveDiscovery(w, r, s.userResourceType())
+	case resource == "Schemas" && id == "":
+		s.serveDiscovery(w, r, newListResponse([]any{s.userSchema()}, 1, 1))
+	case resource == "Schemas" && id == UserSchema:
+		s.serveDiscovery(w, r, s.userSchema())
+	case resource == "Users" && id == "":
+		switch r.Method {
+		case http.MethodGet:
+			s.listUsers(w, r)
+		case http.MethodPost:
+			s.createUser(w, r)
+		default:
+			writeError(w, NewError(http.StatusMethodNotAllowed, "", "method not allowed"))
+		}
+	case resource == "Users":
+		switch r.Method {
+		case http.MethodGet:
+			s.getUser(w, r, id)
+		case http.MethodPut:
+			s.replaceUser(w, r, id)
+		case http.MethodPatch:
+			s.patchUser(w, r, id)
+		case http.MethodDelete:
+			s.deleteUser(w, r, id)
+		default:
+			writeError(w, NewError(http.StatusMethodNotAllowed, "", "method not allowed"))
+		}
+	default:
+		writeError(w, NewError(http.StatusNotFound, "", "unknown endpoint "+r.URL.Path))
+	}
+}
+
+func (s *Server) serveDiscovery(w http.ResponseWriter, r *http.Request, v any) {
+	if r.Method != http.MethodGet {
+		writeError(w, NewError(http.StatusMethodNotAllowed, "", "method not allowed"))
+		return
+	}
+	writeJSON(w, http.StatusOK, v)
+}
+
+func (s *Server) createUser(w http.ResponseWriter, r *http.Request) {
+	scimUser := new(User)
+	if err := readJSON(r, scimUser); err != nil {
+		writeError(w, err)
+		return
+	}
+	if scimUser.UserName == "" {
+		writeError(w, NewError(http.StatusBadRequest, "invalidValue", "userName is required"))
+		return
+	}
+	created, err := s.users.UserServiceV2().AddHumanUser(r.Context(), s.addHumanUserRequest(scimUser))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if scimUser.Active != nil && !*scimUser.Active {
+		if _, err = s.users.UserServiceV2().DeactivateUser(r.Context(), &user.DeactivateUserRequest{UserId: created.GetUserId()}); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+	s.writeUser(w, r.Context(), created.GetUserId(), http.StatusCreated)
+}
+
+func (s *Server) getUser(w http.ResponseWriter, r *http.Request, id string) {
+	s.writeUser(w, r.Context(), id, http.StatusOK)
+}
+
+func (s *Server) replaceUser(w http.ResponseWriter, r *http.Request, id string) {
+	replacement := new(User)
+	if err := readJSON(r, replacement); err != nil {
+		writeError(w, err)
+		return
+	}
+	current, err := s.user(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err = s.updateUser(r.Context(), current, replacement); err != nil {
+		writeError(w, err)
+		return
+	}
+	s.writeUser(w, r.Context(), id, http.StatusOK)
+}
+
+func (s *Server) patchUser(w http.ResponseWriter, r *http.Request, id string) {
+	patch := new(PatchOp)
+	if err := readJSON(r, patch); err != nil {
+		writeError(w, err)
+		return
+	}
+	current, err := s.user(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	patched, err := applyPatch(current, patch.Operations)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err = s.updateUser(r.Context(), current, patched); err != nil {
+		writeError(w, err)
+		return
+	}
+	s.writeUser(w, r.Context(), id, http.StatusOK)
+}
+
+func (s *Server) deleteUser(w http.ResponseWriter, r *http.Request, id string) {
+	if _, err := s.user(r.Context(), id); err != nil {
+		writeError(w, err)
+		return
+	}
+	if _, err := s.users.UserServiceV2().DeleteUser(r.Context(), &user.DeleteUserRequest{UserId: id}); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listUsers(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	queries, err := parseFilter(query.Get("filter"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	startIndex, count := 1, s.maxResults
+	if v := query.Get("startIndex"); v != "" {
+		if startIndex, err = strconv.Atoi(v); err != nil {
+			writeError(w, NewError(http.StatusBadRequest, "invalidValue", "invalid startIndex"))
+			return
+		}
+		startIndex = max(startIndex, 1)
+	}
+	if v := query.Get("count"); v != "" {
+		if count, err = strconv.Atoi(v); err != nil {
+			writeError(w, NewError(http.StatusBadRequest, "invalidValue", "invalid count"))
+			return
+		}
+		count = min(max(count, 0), s.maxResults)
+	}
+	queries = append(queries,
+		&user.SearchQuery{Query: &user.SearchQuery_OrganizationIdQuery{OrganizationIdQuery: &user.OrganizationIdQuery{OrganizationId: s.orgID}}},
+		&user.SearchQuery{Query: &user.SearchQuery_TypeQuery{TypeQuery: &user.TypeQuery{Type: user.Type_TYPE_HUMAN}}},
+	)
+	resp, err := s.users.UserServiceV2().ListUsers(r.Context(), &user.ListUsersRequest{
+		// a count of 0 only requests the total number of results, but ZITADEL would apply its default limit
+		Query:   &object.ListQuery{Offset: uint64(startIndex - 1), Limit: uint32(max(count, 1)), Asc: true},
+		Queries: []*user.SearchQuery{{Query: &user.SearchQuery_AndQuery{AndQuery: &user.AndQuery{Queries: queries}}}},
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var resources []any
+	for _, u := range resp.GetResult()[:min(count, len(resp.GetResult()))] {
+		resources = append(resources, s.toSCIM(u))
+	}
+	writeJSON(w, http.StatusOK, newListResponse(resources, int(resp.GetDetails().GetTotalResult()), startIndex))
+}
+
+// user returns the human user of the organization, other users are reported as not found.
+func (s *Server) user(ctx context.Context, id string) (*User, error) {
+	resp, err := s.users.UserServiceV2().GetUserByID(ctx, &user.GetUserByIDRequest{UserId: id})
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetUser().GetHuman() == nil || resp.GetUser().GetDetails().GetResourceOwner() != s.orgID {
+		return nil, NewError(http.StatusNotFound, "", "user "+id+" not found")
+	}
+	return s.toSCIM(resp.GetUser()), nil
+}
+
+func (s *Server) writeUser(w http.ResponseWriter, ctx context.Context, id string, status int) {
+	scimUser, err := s.user(ctx, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if scimUser.Meta != nil {
+		w.Header().Set("Location", scimUser.Meta.Location)
+	}
+	writeJSON(w, status, scimUser)
+}
+
+// updateUser updates the attributes of the user changed between current and updated.
+func (s *Server) updateUser(ctx context.Context, current, updated *User) error {
+	if updated.UserName == "" {
+		return NewError(http.StatusBadRequest, "invalidValue", "userName is required")
+	}
+	req := &user.UpdateHumanUserRequest{UserId: current.ID}
+	changed := false
+	if updated.UserName != current.UserName {
+		req.Username = &updated.UserName
+		changed = true
+	}
+	if profile := humanProfile(updated); !profileEqual(profile, humanProfile(current)) {
+		req.Profile = profile
+		changed = true
+	}
+	if email := updated.PrimaryEmail(); email != "" && email != current.PrimaryEmail() {
+		req.Email = &user.SetHumanEmail{Email: email, Verification: &user.SetHumanEmail_IsVerified{IsVerified: true}}
+		changed = true
+	}
+	if phone := updated.PrimaryPhoneNumber(); phone != "" && phone != current.PrimaryPhoneNumber() {
+		req.Phone = &user.SetHumanPhone{Phone: phone, Verification: &user.SetHumanPhone_IsVerified{IsVerified: true}}
+		changed = true
+	}
+	if updated.Password != "" {
+		req.Password = &user.SetPassword{PasswordType: &user.SetPassword_Password{Password: &user.Password{Password: updated.Password}}}
+		changed = true
+	}
+	if changed {
+		if _, err := s.users.UserServiceV2().UpdateHumanUser(ctx, req); err != nil {
+			return err
+		}
+	}
+	if updated.Active == nil || *updated.Active == *current.Active {
+		return nil
+	}
+	var err error
+	if *updated.Active {
+		_, err = s.users.UserServiceV2().ReactivateUser(ctx, &user.ReactivateUserRequest{UserId: current.ID})
+	} else {
+		_, err = s.users.UserServiceV2().DeactivateUser(ctx, &user.DeactivateUserRequest{UserId: current.ID})
+	}
+	return err
+}
+
+func readJSON(r *http.Request, v any) error {
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxRequestSize))
+	if err != nil {
+		return NewError(http.StatusBadRequest, "invalidSyntax", "failed to read request: "+err.Error())
+	}
+	if err = json.Unmarshal(data, v); err != nil {
+		return NewError(http.StatusBadRequest, "invalidSyntax", err.Error())
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes the error as SCIM error response, gRPC errors of ZITADEL are mapped to the corresponding status.
+func writeError(w http.ResponseWriter, err error) {
+	var scimErr *Error
+	if !errors.As(err, &scimErr) {
+		scimErr = NewError(http.StatusInternalServerError, "", err.Error())
+		if s, ok := status.FromError(err); ok {
+			scimErr = grpcError(s)
+		}
+	}
+	writeJSON(w, int(scimErr.Status), scimErr)
+}
+
+func grpcError(s *status.Status) *Error {
+	switch s.Code() {
+	case codes.NotFound:
+		return NewError(http.StatusNotFound, "", s.Message())
+	case codes.AlreadyExists:
+		return NewError(http.StatusConflict, "uniqueness", s.Message())
+	case codes.InvalidArgument, codes.FailedPrecondition:
+		return NewError(http.StatusBadRequest, "invalidValue", s.Message())
+	case codes.Unauthenticated:
+		return NewError(http.StatusUnauthorized, "", s.Message())
+	case codes.PermissionDenied:
+		return NewError(http.StatusForbidden, "", s.Message())
+	default:
+		return NewError(http.StatusInternalServerError, "", fmt.Sprintf("%s: %s", s.Code(), s.Message()))
+	}
+}
+
+func newListResponse(resources []any, total, startIndex int) *ListResponse[any] {
+	if resources == nil {
+		resources = []any{}
+	}
+	return &ListResponse[any]{
+		Schemas:      []string{ListResponseMessage},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
@@ -0,0 +1,315 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	object "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object/v2"
+	user "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user/v2"
+)
+
+func TestServer_discovery(t *testing.T) {
+	server := NewServer(&testUserService{}, "org", WithServerBaseURL("https://app.example.com/scim/v2/"))
+	tests := []struct {
+		path       string
+		wantSchema string
+	}{
+		{path: "/ServiceProviderConfig", wantSchema: ServiceProviderConfigSchema},
+		{path: "/ResourceTypes", wantSchema: ListResponseMessage},
+		{path: "/ResourceTypes/User", wantSchema: ResourceTypeSchema},
+		{path: "/Schemas", wantSchema: ListResponseMessage},
+		{path: "/Schemas/" + UserSchema, wantSchema: SchemaSchema},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := serve(server, http.MethodGet, tt.path, "")
+			require.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, ContentType, rec.Header().Get("Content-Type"))
+			var body struct {
+				Schemas []string `json:"schemas"`
+			}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, []string{tt.wantSchema}, body.Schemas)
+		})
+	}
+	assert.Equal(t, http.StatusNotFound, serve(server, http.MethodGet, "/Groups", "").Code)
+}
+
+func TestServer_createUser(t *testing.T) {
+	service := newTestUserService()
+	server := NewServer(service, "org", WithServerBaseURL("https://app.example.com/scim/v2"))
+
+	rec := serve(server, http.MethodPost, "/Users", `{
+		"schemas":["`+UserSchema+`"],
+		"userName":"alice",
+		"name":{"givenName":"Alice","familyName":"Smith"},
+		"emails":[{"value":"alice@example.com","type":"work","primary":true}],
+		"active":false
+	}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	assert.Equal(t, "https://app.example.com/scim/v2/Users/1", rec.Header().Get("Location"))
+	assert.JSONEq(t, `{
+		"schemas":["`+UserSchema+`"],
+		"id":"1",
+		"userName":"alice",
+		"name":{"givenName":"Alice","familyName":"Smith"},
+		"active":false,
+		"emails":[{"value":"alice@example.com","type":"work","primary":true}],
+		"meta":{"resourceType":"User","location":"https://app.example.com/scim/v2/Users/1","version":"W/\"0\""}
+	}`, rec.Body.String())
+
+	added := service.added[0]
+	assert.Equal(t, "org", added.GetOrganization().GetOrgId())
+	assert.True(t, added.GetEmail().GetIsVerified())
+
+	rec = serve(server, http.MethodPost, "/Users", `{"userName":"alice"}`)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"scimType":"uniqueness"`)
+}
+
+func TestServer_patchUser(t *testing.T) {
+	service := newTestUserService()
+	service.addUser("1", "org", "alice", "alice@example.com")
+	server := NewServer(service, "org")
+
+	rec := serve(server, http.MethodPatch, "/Users/1", `{
+		"schemas":["`+PatchOpMessage+`"],
+		"Operations":[
+			{"op":"Replace","path":"active","value":"False"},
+			{"op":"replace","path":"emails[type eq \"work\"].value","value":"alicia@example.com"},
+			{"op":"replace","value":{"name.givenName":"Alicia"}}
+		]
+	}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.Len(t, service.updated, 1)
+	assert.Equal(t, "alicia@example.com", service.updated[0].GetEmail().GetEmail())
+	assert.Equal(t, "Alicia", service.updated[0].GetProfile().GetGivenName())
+	assert.Nil(t, service.updated[0].Username)
+	assert.Equal(t, user.UserState_USER_STATE_INACTIVE, service.users["1"].GetState())
+
+	rec = serve(server, http.MethodPatch, "/Users/1", `{"Operations":[{"op":"move","path":"active"}]}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestServer_otherOrganization(t *testing.T) {
+	service := newTestUserService()
+	service.addUser("1", "other", "alice", "alice@example.com")
+	server := NewServer(service, "org")
+
+	assert.Equal(t, http.StatusNotFound, serve(server, http.MethodGet, "/Users/1", "").Code)
+	assert.Equal(t, http.StatusNotFound, serve(server, http.MethodDelete, "/Users/1", "").Code)
+	assert.Contains(t, service.users, "1")
+}
+
+func TestServer_deleteUser(t *testing.T) {
+	service := newTestUserService()
+	service.addUser("1", "org", "alice", "alice@example.com")
+	server := NewServer(service, "org")
+
+	assert.Equal(t, http.StatusNoContent, serve(server, http.MethodDelete, "/Users/1", "").Code)
+	assert.NotContains(t, service.users, "1")
+	assert.Equal(t, http.StatusNotFound, serve(server, http.MethodGet, "/Users/1", "").Code)
+}
+
+func TestServer_listUsers(t *testing.T) {
+	service := newTestUserService()
+	service.addUser("1", "org", "alice", "alice@example.com")
+	server := NewServer(service, "org")
+
+	rec := serve(server, http.MethodGet, `/Users?filter=userName+eq+"alice"&count=10`, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	list := new(ListResponse[*User])
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), list))
+	assert.Equal(t, 1, list.TotalResults)
+	require.Len(t, list.Resources, 1)
+	assert.Equal(t, "alice", list.Resources[0].UserName)
+	queries := service.listed.GetQueries()[0].GetAndQuery().GetQueries()
+	assert.Equal(t, "alice", queries[0].GetUserNameQuery().GetUserName())
+	assert.Equal(t, "org", queries[1].GetOrganizationIdQuery().GetOrganizationId())
+
+	rec = serve(server, http.MethodGet, `/Users?filter=userName+co+"ali"`, "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"scimType":"invalidFilter"`)
+}
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		filter  string
+		want    int
+		wantErr bool
+	}{
+		{filter: ``, want: 0},
+		{filter: `userName eq "alice"`, want: 1},
+		{filter: `userName Eq "a and b" AND emails.value eq "alice@example.com"`, want: 2},
+		{filter: `externalId eq "1"`, wantErr: true},
+		{filter: `userName eq "alice" or userName eq "bob"`, wantErr: true},
+		{filter: `userName pr`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			got, err := parseFilter(tt.filter)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidValue)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, got, tt.want)
+		})
+	}
+}
+
+func TestApplyPatch(t *testing.T) {
+	active := true
+	current := &User{
+		UserName: "alice",
+		Name:     &Name{GivenName: "Alice", FamilyName: "Smith"},
+		Active:   &active,
+		Emails:   []Attribute{{Value: "alice@example.com", Type: "work", Primary: true}},
+	}
+	tests := []struct {
+		name       string
+		operations []PatchOperation
+		want       func(u *User)
+	}{
+		{
+			name:       "replace sub-attribute",
+			operations: []PatchOperation{{Op: "replace", Path: "name.familyName", Value: "Jones"}},
+			want:       func(u *User) { u.Name.FamilyName = "Jones" },
+		},
+		{
+			name:       "add enterprise attribute",
+			operations: []PatchOperation{{Op: "add", Path: EnterpriseUserSchema + ":department", Value: "Sales"}},
+			want:       func(u *User) { u.Enterprise = &EnterpriseUser{Department: "Sales"} },
+		},
+		{
+			name:       "add filtered element",
+			operations: []PatchOperation{{Op: "add", Path: `emails[type eq "home"].value`, Value: "alice@home.example"}},
+			want: func(u *User) {
+				u.Emails = append(u.Emails, Attribute{Type: "home", Value: "alice@home.example"})
+			},
+		},
+		{
+			name:       "remove filtered element",
+			operations: []PatchOperation{{Op: "remove", Path: `emails[type eq "work"]`}},
+			want:       func(u *User) { u.Emails = []Attribute{} },
+		},
+		{
+			name:       "replace without path",
+			operations: []PatchOperation{{Op: "replace", Value: map[string]any{"ACTIVE": false, "displayName": "Ali"}}},
+			want: func(u *User) {
+				inactive := false
+				u.Active = &inactive
+				u.DisplayName = "Ali"
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := applyPatch(current, tt.operations)
+			require.NoError(t, err)
+			want, err := applyPatch(current, nil)
+			require.NoError(t, err)
+			tt.want(want)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func serve(handler http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, strings.ReplaceAll(target, `"`, "%22"), strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+type testUserService struct {
+	user.UserServiceClient
+	users   map[string]*user.User
+	added   []*user.AddHumanUserRequest
+	updated []*user.UpdateHumanUserRequest
+	listed  *user.ListUsersRequest
+}
+
+func newTestUserService() *testUserService {
+	return &testUserService{users: make(map[string]*user.User)}
+}
+
+func (s *testUserService) UserServiceV2() user.UserServiceClient {
+	return s
+}
+
+func (s *testUserService) addUser(id, orgID, username, email string) *user.User {
+	u := &user.User{
+		UserId:   id,
+		Username: username,
+		State:    user.UserState_USER_STATE_ACTIVE,
+		Details:  &object.Details{ResourceOwner: orgID},
+		Type: &user.User_Human{Human: &user.HumanUser{
+			Profile: &user.HumanProfile{},
+			Email:   &user.HumanEmail{Email: email, IsVerified: true},
+		}},
+	}
+	s.users[id] = u
+	return u
+}
+
+func (s *testUserService) AddHumanUser(_ context.Context, req *user.AddHumanUserRequest, _ ...grpc.CallOption) (*user.AddHumanUserResponse, error) {
+	for _, u := range s.users {
+		if u.GetUsername() == req.GetUsername() {
+			return nil, status.Error(codes.AlreadyExists, "user already exists")
+		}
+	}
+	s.added = append(s.added, req)
+	id := "1"
+	u := s.addUser(id, req.GetOrganization().GetOrgId(), req.GetUsername(), req.GetEmail().GetEmail())
+	u.GetHuman().Profile = &user.HumanProfile{
+		GivenName:  req.GetProfile().GetGivenName(),
+		FamilyName: req.GetProfile().GetFamilyName(),
+	}
+	return &user.AddHumanUserResponse{UserId: id}, nil
+}
+
+func (s *testUserService) GetUserByID(_ context.Context, req *user.GetUserByIDRequest, _ ...grpc.CallOption) (*user.GetUserByIDResponse, error) {
+	u, ok := s.users[req.GetUserId()]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	return &user.GetUserByIDResponse{User: u}, nil
+}
+
+func (s *testUserService) UpdateHumanUser(_ context.Context, req *user.UpdateHumanUserRequest, _ ...grpc.CallOption) (*user.UpdateHumanUserResponse, error) {
+	s.updated = append(s.updated, req)
+	return &user.UpdateHumanUserResponse{}, nil
+}
+
+func (s *testUserService) DeactivateUser(_ context.Context, req *user.DeactivateUserRequest, _ ...grpc.CallOption) (*user.DeactivateUserResponse, error) {
+	s.users[req.GetUserId()].State = user.UserState_USER_STATE_INACTIVE
+	return &user.DeactivateUserResponse{}, nil
+}
+
+func (s *testUserService) DeleteUser(_ context.Context, req *user.DeleteUserRequest, _ ...grpc.CallOption) (*user.DeleteUserResponse, error) {
+	delete(s.users, req.GetUserId())
+	return &user.DeleteUserResponse{}, nil
+}
+
+func (s *testUserService) ListUsers(_ context.Context, req *user.ListUsersRequest, _ ...grpc.CallOption) (*user.ListUsersResponse, error) {
+	s.listed = req
+	result := make([]*user.User, 0, len(s.users))
+	for _, u := range s.users {
+		result = append(result, u)
+	}
+	return &user.ListUsersResponse{
+		Details: &object.ListDetails{TotalResult: uint64(len(result))},
+		Result:  result,
+	}, nil
+}